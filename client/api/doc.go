@@ -0,0 +1,13 @@
+// Package api is the relayer API layer generated from an OpenAPI spec of the
+// relayer's endpoints. It defines the paths of the endpoints and their request
+// and response types; the hand-written api_types package and the clients layer
+// ergonomics on top of it.
+//
+// The spec in openapi.json is a hand-maintained excerpt, not a copy of a spec
+// published by the relayer: its operations and schemas are transcribed from
+// the relayer's routes and API types. To adopt a new endpoint, add its
+// operation and schemas to openapi.json, checked against the relayer's
+// source, and regenerate with `go generate ./client/api`
+package api
+
+//go:generate go run ../../internal/apigen/cmd/apigen -spec openapi.json -out api.gen.go -package api
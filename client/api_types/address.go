@@ -0,0 +1,179 @@
+package api_types //nolint:revive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrInvalidAddress is returned for address options that are not valid,
+// non-zero hex addresses
+var ErrInvalidAddress = errors.New("invalid address")
+
+// NormalizeAddress validates a hex address and returns its EIP-55 checksummed
+// form. Addresses in mixed case must carry a valid checksum, while all
+// lowercase or all uppercase addresses carry none. The zero address is
+// rejected, since funds sent to it are lost
+func NormalizeAddress(address string) (string, error) {
+	if !common.IsHexAddress(address) {
+		return "", fmt.Errorf("%w: %q is not a 20 byte hex address", ErrInvalidAddress, address)
+	}
+
+	parsed := common.HexToAddress(address)
+	if parsed == (common.Address{}) {
+		return "", fmt.Errorf("%w: %q is the zero address", ErrInvalidAddress, address)
+	}
+
+	digits := address[len(address)-2*common.AddressLength:]
+	mixedCase := strings.ToLower(digits) != digits && strings.ToUpper(digits) != digits
+	if mixedCase && digits != parsed.Hex()[2:] {
+		return "", fmt.Errorf("%w: %q fails its EIP-55 checksum", ErrInvalidAddress, address)
+	}
+
+	return parsed.Hex(), nil
+}
+
+// Address is a hex-encoded Ethereum address. Addresses compare and marshal in
+// their canonical form: lowercase, 0x-prefixed hex, regardless of the case
+// they were given in
+type Address string
+
+// NewAddress validates a hex address, see NormalizeAddress, and returns it in
+// canonical form
+func NewAddress(address string) (Address, error) {
+	if _, err := NormalizeAddress(address); err != nil {
+		return "", err
+	}
+	return Address(address).Canonical(), nil
+}
+
+// AddressFromCommon converts a go-ethereum address
+func AddressFromCommon(address common.Address) Address {
+	return Address(strings.ToLower(address.Hex()))
+}
+
+// Canonical returns the address in lowercase, 0x-prefixed form. Strings that
+// are not 20 byte hex addresses are only lowercased
+func (a Address) Canonical() Address {
+	return Address(canonicalHex(string(a)))
+}
+
+// Common converts the address to a go-ethereum address
+func (a Address) Common() common.Address {
+	return common.HexToAddress(string(a))
+}
+
+// Checksummed returns the address in EIP-55 checksummed form
+func (a Address) Checksummed() string {
+	return a.Common().Hex()
+}
+
+// Equal returns whether two addresses are equal, ignoring case
+func (a Address) Equal(other Address) bool {
+	return a.Canonical() == other.Canonical()
+}
+
+// Validate checks that the address is a valid, non-zero hex address
+func (a Address) Validate() error {
+	_, err := NormalizeAddress(string(a))
+	return err
+}
+
+// String returns the address as given
+func (a Address) String() string {
+	return string(a)
+}
+
+// MarshalJSON encodes the address in canonical form
+func (a Address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(a.Canonical()))
+}
+
+// UnmarshalJSON decodes an address, storing it in canonical form
+func (a *Address) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*a = Address(raw).Canonical()
+	return nil
+}
+
+// Mint is the hex-encoded address of a token. Mints compare and marshal in
+// their canonical form, see Address
+type Mint string
+
+// NewMint validates a token address, see NormalizeAddress, and returns it in
+// canonical form
+func NewMint(mint string) (Mint, error) {
+	address, err := NewAddress(mint)
+	return Mint(address), err
+}
+
+// MintFromCommon converts a go-ethereum address
+func MintFromCommon(address common.Address) Mint {
+	return Mint(AddressFromCommon(address))
+}
+
+// Address returns the token's address
+func (m Mint) Address() Address {
+	return Address(m)
+}
+
+// Canonical returns the mint in lowercase, 0x-prefixed form
+func (m Mint) Canonical() Mint {
+	return Mint(canonicalHex(string(m)))
+}
+
+// Common converts the mint to a go-ethereum address
+func (m Mint) Common() common.Address {
+	return common.HexToAddress(string(m))
+}
+
+// Equal returns whether two mints are equal, ignoring case
+func (m Mint) Equal(other Mint) bool {
+	return m.Canonical() == other.Canonical()
+}
+
+// IsNative returns whether the mint is the native asset sentinel
+func (m Mint) IsNative() bool {
+	return IsNativeAsset(string(m))
+}
+
+// Validate checks that the mint is a valid, non-zero hex address
+func (m Mint) Validate() error {
+	return m.Address().Validate()
+}
+
+// String returns the mint as given
+func (m Mint) String() string {
+	return string(m)
+}
+
+// MarshalJSON encodes the mint in canonical form
+func (m Mint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(m.Canonical()))
+}
+
+// UnmarshalJSON decodes a mint, storing it in canonical form
+func (m *Mint) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*m = Mint(raw).Canonical()
+	return nil
+}
+
+// canonicalHex lowercases a hex string, adding the 0x prefix to a 20 byte
+// address given without one
+func canonicalHex(value string) string {
+	lower := strings.ToLower(value)
+	if common.IsHexAddress(lower) && !strings.HasPrefix(lower, "0x") {
+		return "0x" + lower
+	}
+	return lower
+}
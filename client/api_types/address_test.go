@@ -0,0 +1,76 @@
+package api_types //nolint:revive
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeAddress(t *testing.T) {
+	const checksummed = "0xC5fE800A3D92112473e4E811296F194DA7b26BA7"
+
+	// Single-case addresses carry no checksum and are checksummed
+	for _, address := range []string{
+		checksummed,
+		strings.ToLower(checksummed),
+		"0x" + strings.ToUpper(checksummed[2:]),
+		checksummed[2:],
+	} {
+		normalized, err := NormalizeAddress(address)
+		assert.NoError(t, err, address)
+		assert.Equal(t, checksummed, normalized)
+	}
+
+	// A mixed-case address with a wrong checksum is rejected
+	badChecksum := "0xc5FE800A3D92112473e4E811296F194DA7b26BA7"
+	for _, address := range []string{
+		badChecksum,
+		"0x0000000000000000000000000000000000000000",
+		"0x1234",
+		"not an address",
+	} {
+		_, err := NormalizeAddress(address)
+		assert.True(t, errors.Is(err, ErrInvalidAddress), address)
+	}
+}
+
+func TestMintCanonicalForm(t *testing.T) {
+	const checksummed = "0xaf88d065e77c8cC2239327C5EDb3A432268e5831"
+	mint := Mint(checksummed)
+
+	// Mints compare and marshal in lowercase regardless of input case
+	assert.True(t, mint.Equal(Mint(strings.ToLower(checksummed))))
+	assert.Equal(t, Mint(strings.ToLower(checksummed)), mint.Canonical())
+	encoded, err := json.Marshal(mint)
+	assert.NoError(t, err)
+	assert.Equal(t, `"`+strings.ToLower(checksummed)+`"`, string(encoded))
+
+	// API types keep mints as strings, exposing them as Mints
+	transfer := ApiExternalAssetTransfer{Mint: checksummed, Amount: NewAmount(1)}
+	assert.Equal(t, mint, transfer.Token())
+	assert.True(t, transfer.Token().Equal(MintFromCommon(mint.Common())))
+
+	// Conversions to and from go-ethereum addresses
+	assert.Equal(t, common.HexToAddress(checksummed), mint.Common())
+	assert.Equal(t, mint.Canonical(), MintFromCommon(mint.Common()))
+	assert.Equal(t, checksummed, mint.Address().Checksummed())
+
+	// Validation is explicit
+	_, err = NewMint("0x1")
+	assert.True(t, errors.Is(err, ErrInvalidAddress))
+	validated, err := NewMint(checksummed)
+	assert.NoError(t, err)
+	assert.Equal(t, mint.Canonical(), validated)
+}
+
+func TestAddressCanonicalForm(t *testing.T) {
+	address := Address("C5fE800A3D92112473e4E811296F194DA7b26BA7")
+	assert.Equal(t, Address("0xc5fe800a3d92112473e4e811296f194da7b26ba7"), address.Canonical())
+	assert.True(t, address.Equal(AddressFromCommon(address.Common())))
+	assert.NoError(t, address.Validate())
+	assert.Error(t, Address("0x0000000000000000000000000000000000000000").Validate())
+}
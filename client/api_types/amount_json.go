@@ -0,0 +1,85 @@
+package api_types //nolint:revive
+
+import (
+	"math/big"
+	"math/bits"
+	"strconv"
+)
+
+// Amounts are encoded as JSON numbers on every quote, bundle and wallet, so
+// their encoding is on the hot path of batch quoting. Token amounts routinely
+// exceed an int64, e.g. 10 WETH is 1e19 wei, so amounts stay big integers;
+// instead, amounts that fit in 128 bits, which covers every amount the
+// relayer settles, are formatted and parsed without big integer arithmetic
+
+// maxFastParseDigits is the most digits parsed without big integer
+// arithmetic, the most that always fit in an int64
+const maxFastParseDigits = 18
+
+// pow19 is the largest power of ten that fits in a uint64
+const pow19 = 1e19
+
+// appendAmount appends the decimal form of an amount to the buffer
+func appendAmount(dst []byte, a *big.Int) []byte {
+	words := a.Bits()
+	if bits.UintSize != 64 || len(words) > 2 {
+		return a.Append(dst, 10 /* base */)
+	}
+
+	if a.Sign() < 0 {
+		dst = append(dst, '-')
+	}
+	var hi, lo uint64
+	if len(words) > 0 {
+		lo = uint64(words[0])
+	}
+	if len(words) > 1 {
+		hi = uint64(words[1])
+	}
+	return appendUint128(dst, hi, lo)
+}
+
+// appendUint128 appends the decimal form of the 128-bit integer hi<<64 | lo
+func appendUint128(dst []byte, hi, lo uint64) []byte {
+	if hi == 0 {
+		return strconv.AppendUint(dst, lo, 10 /* base */)
+	}
+
+	// Split off the low 19 digits, which fit in a uint64, and format the rest
+	quoHi, rem := hi/pow19, hi%pow19
+	quoLo, low := bits.Div64(rem, lo, pow19)
+	dst = appendUint128(dst, quoHi, quoLo)
+
+	var digits [19]byte
+	for i := len(digits) - 1; i >= 0; i-- {
+		digits[i] = byte('0' + low%10)
+		low /= 10
+	}
+	return append(dst, digits[:]...)
+}
+
+// parseSmallAmount parses a decimal integer of at most maxFastParseDigits
+// digits, with an optional sign. It reports false for any other input, which
+// is left to big.Int
+func parseSmallAmount(b []byte) (int64, bool) {
+	neg := false
+	if len(b) > 0 && (b[0] == '-' || b[0] == '+') {
+		neg = b[0] == '-'
+		b = b[1:]
+	}
+	if len(b) == 0 || len(b) > maxFastParseDigits {
+		return 0, false
+	}
+
+	var n int64
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int64(c-'0')
+	}
+	if neg {
+		n = -n
+	}
+	return n, true
+}
@@ -4,8 +4,18 @@ package api_types //nolint:revive
 import (
 	"errors"
 	"math/big"
+	"strings"
 )
 
+// NativeAssetAddr is the sentinel mint used to denote native ETH in external
+// orders. Native ETH is only supported as the base asset
+const NativeAssetAddr = "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"
+
+// IsNativeAsset returns whether the given mint is the native asset sentinel
+func IsNativeAsset(mint string) bool {
+	return strings.EqualFold(mint, NativeAssetAddr)
+}
+
 // ApiExternalOrder is an order from outside of the darkpool, generated by a client
 // requesting an external match
 type ApiExternalOrder struct { //nolint:revive
@@ -26,6 +36,16 @@ type ApiExternalOrder struct { //nolint:revive
 	MinFillSize Amount `json:"min_fill_size"`
 }
 
+// BaseToken returns the order's base mint as a Mint
+func (o *ApiExternalOrder) BaseToken() Mint {
+	return Mint(o.BaseMint)
+}
+
+// QuoteToken returns the order's quote mint as a Mint
+func (o *ApiExternalOrder) QuoteToken() Mint {
+	return Mint(o.QuoteMint)
+}
+
 // ApiExternalOrderBuilder helps construct ApiExternalOrder with validation
 type ApiExternalOrderBuilder struct { //nolint:revive
 	order ApiExternalOrder
@@ -89,6 +109,9 @@ func (b *ApiExternalOrderBuilder) Build() (*ApiExternalOrder, error) {
 	if b.order.QuoteMint == "" {
 		return nil, errors.New("quote mint is required")
 	}
+	if IsNativeAsset(b.order.QuoteMint) {
+		return nil, errors.New("native asset is only supported as the base mint")
+	}
 	if b.order.Side == "" {
 		return nil, errors.New("side is required")
 	}
@@ -104,6 +127,11 @@ type ApiExternalAssetTransfer struct { //nolint:revive
 	Amount Amount `json:"amount"`
 }
 
+// Token returns the transfer's mint as a Mint
+func (t *ApiExternalAssetTransfer) Token() Mint {
+	return Mint(t.Mint)
+}
+
 // ApiExternalQuote is a quote from the relayer for an external order
 type ApiExternalQuote struct { //nolint:revive
 	Order       ApiExternalOrder         `json:"order"`
@@ -141,10 +169,47 @@ type ApiExternalMatchResult struct { //nolint:revive
 	Direction   string `json:"direction"`
 }
 
+// BaseToken returns the match's base mint as a Mint
+func (r *ApiExternalMatchResult) BaseToken() Mint {
+	return Mint(r.BaseMint)
+}
+
+// QuoteToken returns the match's quote mint as a Mint
+func (r *ApiExternalMatchResult) QuoteToken() Mint {
+	return Mint(r.QuoteMint)
+}
+
+// Price returns the price at which the match executes, in units of the quote
+// token per unit of the base token
+func (r *ApiExternalMatchResult) Price() (Price, error) {
+	return NewPriceFromAmounts(r.QuoteAmount, r.BaseAmount)
+}
+
 // ApiSettlementTransaction is an EVM transaction parameterization for settling an external match
 type ApiSettlementTransaction struct { //nolint:revive
 	Type  string `json:"type"`
 	To    string `json:"to"`
 	Data  string `json:"data"`
 	Value string `json:"value"`
+	// Gas is the relayer's estimate of the transaction's gas, as a hex or
+	// decimal quantity; only set if gas estimation was requested
+	Gas string `json:"gas,omitempty"`
+}
+
+// ApiGasSponsorshipInfo describes the gas refund a sponsored match receives
+type ApiGasSponsorshipInfo struct { //nolint:revive
+	// RefundAmount is the amount refunded, in wei if refunded in native ETH
+	// and otherwise in the smallest denomination of the token received
+	RefundAmount Amount `json:"refund_amount"`
+	// RefundNativeEth is set if the refund is paid in native ETH rather than
+	// the token received
+	RefundNativeEth bool `json:"refund_native_eth"`
+	// RefundAddress is the address the refund is paid to, if not the sender
+	RefundAddress *string `json:"refund_address,omitempty"`
+}
+
+// ApiSignedGasSponsorshipInfo is a gas sponsorship signed by the auth server
+type ApiSignedGasSponsorshipInfo struct { //nolint:revive
+	GasSponsorshipInfo ApiGasSponsorshipInfo `json:"gas_sponsorship_info"`
+	Signature          string                `json:"signature"`
 }
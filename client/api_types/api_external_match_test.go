@@ -0,0 +1,29 @@
+package api_types //nolint:revive
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNativeAssetOrderValidation(t *testing.T) {
+	// Native ETH may be sold as the base asset, in any case
+	order, err := NewExternalOrderBuilder().
+		WithBaseMint(strings.ToLower(NativeAssetAddr)).
+		WithQuoteMint("0x2").
+		WithBaseAmount(NewAmount(100)).
+		WithSide("Sell").
+		Build()
+	assert.NoError(t, err)
+	assert.True(t, IsNativeAsset(order.BaseMint))
+
+	// But not used as the quote asset
+	_, err = NewExternalOrderBuilder().
+		WithBaseMint("0x1").
+		WithQuoteMint(NativeAssetAddr).
+		WithBaseAmount(NewAmount(100)).
+		WithSide("Buy").
+		Build()
+	assert.Error(t, err)
+}
@@ -1,9 +1,160 @@
 package api_types //nolint:revive
 
+import (
+	"encoding/json"
+
+	"github.com/renegade-fi/golang-sdk/client/api"
+)
+
 // ApiToken is a token available on the exchange
-type ApiToken struct { //nolint:revive
+type ApiToken = api.ApiToken //nolint:revive
+
+// ApiDepthSide is the liquidity resting on one side of a pair's order book
+type ApiDepthSide struct { //nolint:revive
+	// The total quantity of the base token on this side
+	TotalQuantity Amount `json:"total_quantity"`
+	// The total quantity, valued in USD; see TotalQuantityUsdDecimal for the
+	// exact value
+	TotalQuantityUsd float64 `json:"total_quantity_usd"` //nolint:revive
+	// totalQuantityUsd is the USD quantity exactly as decoded, nil if the side
+	// was not decoded from JSON
+	totalQuantityUsd *Decimal
+}
+
+// TotalQuantityUsdDecimal returns the side's USD quantity exactly as reported
+// by the relayer, or the exact value of TotalQuantityUsd if it was set since
+func (s *ApiDepthSide) TotalQuantityUsdDecimal() Decimal { //nolint:revive
+	return exactDecimal(s.TotalQuantityUsd, s.totalQuantityUsd)
+}
+
+// wireDepthSide is the JSON form of an ApiDepthSide
+type wireDepthSide struct {
+	TotalQuantity    Amount  `json:"total_quantity"`
+	TotalQuantityUsd Decimal `json:"total_quantity_usd"` //nolint:revive
+}
+
+// MarshalJSON encodes the side with its exact USD quantity
+func (s ApiDepthSide) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wireDepthSide{
+		TotalQuantity:    s.TotalQuantity,
+		TotalQuantityUsd: s.TotalQuantityUsdDecimal(),
+	})
+}
+
+// UnmarshalJSON decodes the side, keeping its exact USD quantity
+func (s *ApiDepthSide) UnmarshalJSON(data []byte) error {
+	var wire wireDepthSide
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	*s = ApiDepthSide{
+		TotalQuantity:    wire.TotalQuantity,
+		TotalQuantityUsd: wire.TotalQuantityUsd.Float64(),
+		totalQuantityUsd: &wire.TotalQuantityUsd,
+	}
+	return nil
+}
+
+// ApiPriceAndDepth is the price and order book depth of a token, quoted
+// against USDC
+type ApiPriceAndDepth struct { //nolint:revive
 	// The mint (erc20 address) of the token
 	Address string `json:"address"`
-	// The symbol of the token
-	Symbol string `json:"symbol"`
+	// The price of the token; see PriceDecimal for the exact value
+	Price float64 `json:"price"`
+	// The time the price was sampled, in milliseconds since the epoch
+	Timestamp uint64 `json:"timestamp"`
+	// The liquidity of buy orders
+	Buy ApiDepthSide `json:"buy"`
+	// The liquidity of sell orders
+	Sell ApiDepthSide `json:"sell"`
+	// price is the price exactly as decoded, nil if the depth was not decoded
+	// from JSON
+	price *Decimal
+}
+
+// Token returns the token's address as a Mint
+func (d *ApiPriceAndDepth) Token() Mint {
+	return Mint(d.Address)
+}
+
+// PriceDecimal returns the token's price exactly as reported by the relayer,
+// or the exact value of Price if it was set since
+func (d *ApiPriceAndDepth) PriceDecimal() Decimal {
+	return exactDecimal(d.Price, d.price)
+}
+
+// wirePriceAndDepth is the JSON form of an ApiPriceAndDepth
+type wirePriceAndDepth struct {
+	Address   string       `json:"address"`
+	Price     Decimal      `json:"price"`
+	Timestamp uint64       `json:"timestamp"`
+	Buy       ApiDepthSide `json:"buy"`
+	Sell      ApiDepthSide `json:"sell"`
+}
+
+// MarshalJSON encodes the depth with its exact price
+func (d ApiPriceAndDepth) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wirePriceAndDepth{
+		Address:   d.Address,
+		Price:     d.PriceDecimal(),
+		Timestamp: d.Timestamp,
+		Buy:       d.Buy,
+		Sell:      d.Sell,
+	})
+}
+
+// UnmarshalJSON decodes the depth, keeping its exact price
+func (d *ApiPriceAndDepth) UnmarshalJSON(data []byte) error {
+	var wire wirePriceAndDepth
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	*d = ApiPriceAndDepth{
+		Address:   wire.Address,
+		Price:     wire.Price.Float64(),
+		Timestamp: wire.Timestamp,
+		Buy:       wire.Buy,
+		Sell:      wire.Sell,
+		price:     &wire.Price,
+	}
+	return nil
+}
+
+// exactDecimal returns the decoded exact value of a float field, unless the
+// field no longer holds its float approximation, i.e. it was set since
+func exactDecimal(value float64, exact *Decimal) Decimal {
+	if exact != nil && exact.Float64() == value {
+		return *exact
+	}
+	return NewDecimalFromFloat(value)
+}
+
+// ApiTimestampedPrice is a price and the time it was sampled
+type ApiTimestampedPrice struct { //nolint:revive
+	// The price, exactly as reported by the server
+	Price Decimal `json:"price"`
+	// The time the price was sampled, in milliseconds since the epoch
+	Timestamp uint64 `json:"timestamp"`
+}
+
+// ApiMarketInfo describes a market of the v2 API, a base token quoted against
+// a quote token
+type ApiMarketInfo struct { //nolint:revive
+	// The base token of the market
+	Base ApiToken `json:"base"`
+	// The quote token of the market
+	Quote ApiToken `json:"quote"`
+	// The price of the base token, in units of the quote token
+	Price ApiTimestampedPrice `json:"price"`
+}
+
+// ApiMarketDepth is the price and order book depth of a market of the v2 API
+type ApiMarketDepth struct { //nolint:revive
+	// The market
+	Market ApiMarketInfo `json:"market"`
+	// The liquidity of buy orders
+	Buy ApiDepthSide `json:"buy"`
+	// The liquidity of sell orders
+	Sell ApiDepthSide `json:"sell"`
 }
@@ -15,6 +15,10 @@ import (
 // The number of u32 limbs in the serialized form of a secret share
 const secretShareLimbCount = 8 // 256 bits
 
+// The maximum length of a serialized amount: the 78 digits of the largest
+// 256-bit integer and a sign
+const maxAmountLength = 79
+
 // Amount is a big.Int marshalled and unmarshalled as a rust-compatible string
 type Amount big.Int
 
@@ -33,10 +37,15 @@ func (a *Amount) String() string {
 	return (*big.Int)(a).String()
 }
 
-// MarshalJSON marshals the amount to a JSON string
+// Clone returns a copy of the amount that shares no digits with it
+func (a *Amount) Clone() Amount {
+	return Amount(*new(big.Int).Set((*big.Int)(a)))
+}
+
+// MarshalJSON marshals the amount to a JSON number
 func (a Amount) MarshalJSON() ([]byte, error) {
-	s := a.String()
-	return []byte(s), nil
+	// Size the buffer for a 128-bit amount, so that it is allocated once
+	return appendAmount(make([]byte, 0, 40), (*big.Int)(&a)), nil
 }
 
 // SetString sets the amount from a string
@@ -49,8 +58,18 @@ func (a *Amount) SetString(s string, base int) error {
 	return nil
 }
 
-// UnmarshalJSON unmarshals the amount from a JSON string
+// UnmarshalJSON unmarshals the amount from a JSON string. Amounts longer than
+// a 256-bit integer are rejected before parsing
 func (a *Amount) UnmarshalJSON(b []byte) error {
+	if len(b) > maxAmountLength {
+		return fmt.Errorf("amount exceeds %d characters", maxAmountLength)
+	}
+	if n, ok := parseSmallAmount(b); ok {
+		// Set a fresh integer, as copies of the amount share its digits
+		*a = NewAmount(n)
+		return nil
+	}
+
 	s := string(b)
 	return a.SetString(s, 10)
 }
@@ -91,6 +110,25 @@ type TimestampedPrice struct {
 	Price     string `json:"price"`
 }
 
+// Decimal returns the exact value of the price
+func (p TimestampedPrice) Decimal() (Decimal, error) {
+	return ParseDecimal(p.Price)
+}
+
+// ToPrice returns the price as a Price
+func (p TimestampedPrice) ToPrice() (Price, error) {
+	return ParsePrice(p.Price)
+}
+
+// Float64 returns the price as the nearest float64
+func (p TimestampedPrice) Float64() (float64, error) {
+	price, err := p.Decimal()
+	if err != nil {
+		return 0, err
+	}
+	return price.Float64(), nil
+}
+
 // orderSideFromScalar converts a wallet.Scalar to an order side
 func orderSideFromScalar(s wallet.Scalar) (string, error) {
 	if s.IsZero() {
@@ -135,6 +173,16 @@ type ApiOrder struct { //nolint:revive
 	WorstCasePrice string `json:"worst_case_price"`
 }
 
+// BaseToken returns the order's base mint as a Mint
+func (a *ApiOrder) BaseToken() Mint {
+	return Mint(a.BaseMint)
+}
+
+// QuoteToken returns the order's quote mint as a Mint
+func (a *ApiOrder) QuoteToken() Mint {
+	return Mint(a.QuoteMint)
+}
+
 // FromOrder converts a wallet.Order to an ApiOrder
 func (a *ApiOrder) FromOrder(o *wallet.Order) (*ApiOrder, error) {
 	a.Id = o.Id
@@ -171,9 +219,73 @@ func (a *ApiOrder) ToOrder(o *wallet.Order) error {
 	}
 
 	o.Side = side
+
+	// Relayers that predate worst case prices omit the field
+	o.WorstCasePrice = wallet.FixedPoint{}
+	if a.WorstCasePrice != "" {
+		if _, err := o.WorstCasePrice.FromReprDecimalString(a.WorstCasePrice); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// The states of an order in the relayer
+const (
+	// OrderStateCreated is the state of an order that is not yet matchable
+	OrderStateCreated = "Created"
+	// OrderStateMatching is the state of an open order awaiting a match
+	OrderStateMatching = "Matching"
+	// OrderStateSettlingMatch is the state of an order whose match is settling
+	OrderStateSettlingMatch = "SettlingMatch"
+	// OrderStateFilled is the state of a fully filled order
+	OrderStateFilled = "Filled"
+	// OrderStateCancelled is the state of a cancelled order
+	OrderStateCancelled = "Cancelled"
+)
+
+// ApiPartialOrderFill is a single fill of an order
+type ApiPartialOrderFill struct { //nolint:revive
+	// The amount of the base asset filled
+	Amount Amount `json:"amount"`
+	// The price at which the fill executed
+	Price TimestampedPrice `json:"price"`
+}
+
+// ApiOrderMetadata is the relayer's record of an order's lifecycle
+type ApiOrderMetadata struct { //nolint:revive
+	// The id of the order
+	Id uuid.UUID `json:"id"` //nolint:revive
+	// The state of the order, one of the OrderState constants
+	State string `json:"state"`
+	// The fills of the order so far
+	Fills []ApiPartialOrderFill `json:"fills"`
+	// The time the order was created, in milliseconds since the epoch
+	Created uint64 `json:"created"`
+	// The order as it was placed
+	Data ApiOrder `json:"data"`
+}
+
+// FilledAmount returns the total amount of the base asset filled
+func (m *ApiOrderMetadata) FilledAmount() Amount {
+	filled := NewAmount(0)
+	for _, fill := range m.Fills {
+		filled = filled.Add(fill.Amount)
+	}
+	return filled
+}
+
+// IsOpen returns whether the order can still be matched
+func (m *ApiOrderMetadata) IsOpen() bool {
+	switch m.State {
+	case OrderStateCreated, OrderStateMatching, OrderStateSettlingMatch:
+		return true
+	default:
+		return false
+	}
+}
+
 // ApiBalance is a balance in a Renegade wallet
 type ApiBalance struct { //nolint:revive
 	// The mint (erc20 address) of the asset
@@ -186,6 +298,11 @@ type ApiBalance struct { //nolint:revive
 	ProtocolFeeBalance Amount `json:"protocol_fee_balance"`
 }
 
+// Token returns the balance's mint as a Mint
+func (a *ApiBalance) Token() Mint {
+	return Mint(a.Mint)
+}
+
 // FromBalance converts a wallet.Balance to an ApiBalance
 func (a *ApiBalance) FromBalance(b *wallet.Balance) error {
 	a.Mint = b.Mint.ToHexString()
@@ -392,22 +509,18 @@ func (a *ApiWallet) FromWallet(w *wallet.Wallet) (*ApiWallet, error) {
 
 	// Convert orders
 	a.Orders = make([]ApiOrder, len(w.Orders))
-	for _, order := range w.Orders {
-		var apiOrder ApiOrder
-		if _, err := apiOrder.FromOrder(&order); err != nil {
+	for i := range w.Orders {
+		if _, err := a.Orders[i].FromOrder(&w.Orders[i]); err != nil {
 			return nil, err
 		}
-		a.Orders = append(a.Orders, apiOrder)
 	}
 
 	// Convert balances
 	a.Balances = make([]ApiBalance, len(w.Balances))
-	for _, balance := range w.Balances {
-		var apiBalance ApiBalance
-		if err := apiBalance.FromBalance(&balance); err != nil {
+	for i := range w.Balances {
+		if err := a.Balances[i].FromBalance(&w.Balances[i]); err != nil {
 			return nil, err
 		}
-		a.Balances = append(a.Balances, apiBalance)
 	}
 
 	// Convert keychain, managing cluster, and match fee
@@ -423,8 +536,9 @@ func (a *ApiWallet) FromWallet(w *wallet.Wallet) (*ApiWallet, error) {
 		return nil, err
 	}
 
-	for _, share := range publicShares {
-		a.BlindedPublicShares = append(a.BlindedPublicShares, ScalarToUintLimbs(share))
+	a.BlindedPublicShares = make([][secretShareLimbCount]uint32, len(publicShares))
+	for i, share := range publicShares {
+		a.BlindedPublicShares[i] = ScalarToUintLimbs(share)
 	}
 
 	// Convert the private shares
@@ -433,8 +547,9 @@ func (a *ApiWallet) FromWallet(w *wallet.Wallet) (*ApiWallet, error) {
 		return nil, err
 	}
 
-	for _, share := range privateShares {
-		a.PrivateShares = append(a.PrivateShares, ScalarToUintLimbs(share))
+	a.PrivateShares = make([][secretShareLimbCount]uint32, len(privateShares))
+	for i, share := range privateShares {
+		a.PrivateShares[i] = ScalarToUintLimbs(share)
 	}
 
 	// Convert the blinder
@@ -0,0 +1,132 @@
+package api_types //nolint:revive
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	mrand "math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// randomScalar samples a scalar of the given number of bytes
+func randomScalar(r *mrand.Rand, nBytes int) wallet.Scalar {
+	b := make([]byte, nBytes)
+	r.Read(b)
+	return new(wallet.Scalar).FromBigInt(new(big.Int).SetBytes(b))
+}
+
+// randomWallet builds a wallet around the given keychain with a random number
+// of random orders and balances
+func randomWallet(t *testing.T, r *mrand.Rand, base *wallet.Wallet) *wallet.Wallet {
+	w := *base
+	w.Orders = make([]wallet.Order, r.Intn(wallet.MaxOrders+1))
+	for i := range w.Orders {
+		side := wallet.Buy
+		if r.Intn(2) == 1 {
+			side = wallet.Sell
+		}
+		order := wallet.NewOrderBuilder().
+			WithBaseMint(randomScalar(r, 20)).
+			WithQuoteMint(randomScalar(r, 20)).
+			WithSide(side).
+			WithAmount(randomScalar(r, 16)).
+			WithWorstCasePrice(wallet.FixedPoint{Repr: randomScalar(r, 31)}).
+			Build()
+		w.Orders[i] = order
+	}
+
+	w.Balances = make([]wallet.Balance, r.Intn(wallet.MaxBalances+1))
+	for i := range w.Balances {
+		w.Balances[i] = wallet.Balance{
+			Mint:               randomScalar(r, 20),
+			Amount:             randomScalar(r, 16),
+			RelayerFeeBalance:  randomScalar(r, 16),
+			ProtocolFeeBalance: randomScalar(r, 16),
+		}
+	}
+
+	w.MatchFee = wallet.FixedPoint{Repr: randomScalar(r, 8)}
+	w.Blinder = randomScalar(r, 31)
+	scalars, err := wallet.ToScalarsRecursive(&w.BlindedPublicShares)
+	assert.NoError(t, err)
+	for i := range scalars {
+		scalars[i] = randomScalar(r, 31)
+	}
+	assert.NoError(t, wallet.FromScalarsRecursive(&w.BlindedPublicShares, wallet.NewScalarIterator(scalars)))
+
+	return &w
+}
+
+// TestApiWalletRoundTripProperty checks that any wallet survives conversion to
+// an API wallet, JSON encoding, and conversion back unchanged
+func TestApiWalletRoundTripProperty(t *testing.T) {
+	key, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	assert.NoError(t, err)
+	base, err := wallet.NewEmptyWallet(key, 0 /* chainId */)
+	assert.NoError(t, err)
+
+	roundTrip := func(seed int64) bool {
+		original := randomWallet(t, mrand.New(mrand.NewSource(seed)), base)
+		apiWallet, err := new(ApiWallet).FromWallet(original)
+		if err != nil {
+			t.Logf("seed %d: failed to convert wallet: %v", seed, err)
+			return false
+		}
+
+		encoded, err := json.Marshal(apiWallet)
+		if err != nil {
+			return false
+		}
+		var decoded ApiWallet
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Logf("seed %d: failed to decode wallet: %v", seed, err)
+			return false
+		}
+
+		recovered, err := decoded.ToWallet()
+		if err != nil {
+			t.Logf("seed %d: failed to recover wallet: %v", seed, err)
+			return false
+		}
+		return reflect.DeepEqual(original, recovered)
+	}
+
+	assert.NoError(t, quick.Check(roundTrip, &quick.Config{MaxCount: 50}))
+}
+
+// TestApiWalletReuseProperty checks that converting into a previously used API
+// wallet leaves no trace of the earlier wallet
+func TestApiWalletReuseProperty(t *testing.T) {
+	key, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	assert.NoError(t, err)
+	base, err := wallet.NewEmptyWallet(key, 0 /* chainId */)
+	assert.NoError(t, err)
+
+	reuse := func(seed1, seed2 int64) bool {
+		first := randomWallet(t, mrand.New(mrand.NewSource(seed1)), base)
+		second := randomWallet(t, mrand.New(mrand.NewSource(seed2)), base)
+
+		var reused ApiWallet
+		if _, err := reused.FromWallet(first); err != nil {
+			return false
+		}
+		if _, err := reused.FromWallet(second); err != nil {
+			return false
+		}
+		fresh, err := new(ApiWallet).FromWallet(second)
+		if err != nil {
+			return false
+		}
+		return reflect.DeepEqual(fresh, &reused)
+	}
+
+	assert.NoError(t, quick.Check(reuse, &quick.Config{MaxCount: 20}))
+}
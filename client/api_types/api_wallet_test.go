@@ -3,6 +3,9 @@ package api_types //nolint:revive
 import (
 	"crypto/ecdsa"
 	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
@@ -28,3 +31,75 @@ func TestApiWalletConversion(t *testing.T) {
 	// Check that the recovered wallet is the same as the original wallet
 	assert.Equal(t, originalWallet, recoveredWallet)
 }
+
+func TestAmountUnmarshalJSONLengthBound(t *testing.T) {
+	var amount Amount
+	maxU256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	assert.NoError(t, amount.UnmarshalJSON([]byte(maxU256.String())))
+	assert.Error(t, amount.UnmarshalJSON([]byte(strings.Repeat("9", 1000))))
+}
+
+func TestAmountJSONMatchesBigInt(t *testing.T) {
+	pow := func(base, exp int64) *big.Int { return new(big.Int).Exp(big.NewInt(base), big.NewInt(exp), nil) }
+	minusOne := func(i *big.Int) *big.Int { return new(big.Int).Sub(i, big.NewInt(1)) }
+	values := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(-42),
+		big.NewInt(999_999_999_999_999_999),
+		minusOne(pow(2, 64)),
+		pow(2, 64),
+		pow(10, 19),
+		new(big.Int).Add(pow(10, 19), big.NewInt(5)),
+		new(big.Int).Add(pow(10, 38), big.NewInt(7)),
+		minusOne(pow(2, 128)),
+		new(big.Int).Neg(minusOne(pow(2, 128))),
+		pow(2, 128),
+		minusOne(pow(2, 256)),
+	}
+
+	for _, value := range values {
+		encoded, err := Amount(*value).MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, value.String(), string(encoded))
+
+		var decoded Amount
+		assert.NoError(t, decoded.UnmarshalJSON(encoded))
+		assert.Equal(t, 0, (*big.Int)(&decoded).Cmp(value), value.String())
+	}
+
+	// Decoding into a copy leaves the original untouched
+	original := NewAmount(12345)
+	shared := original
+	assert.NoError(t, shared.UnmarshalJSON([]byte("7")))
+	assert.Equal(t, "12345", original.String())
+	assert.Equal(t, "7", shared.String())
+
+	// Malformed input is rejected as before
+	var amount Amount
+	for _, input := range []string{"", "-", "+", "1.5", "1e3", `"12"`, "0x10", "null"} {
+		assert.Error(t, amount.UnmarshalJSON([]byte(input)), input)
+	}
+}
+
+func TestOrderMetadataUnmarshal(t *testing.T) {
+	raw := `{
+		"id": "6f4e2b1a-3c5d-4e7f-8a9b-0c1d2e3f4a5b",
+		"state": "Matching",
+		"fills": [
+			{"amount": 100, "price": {"timestamp": 1, "price": "2000.5"}},
+			{"amount": 250, "price": {"timestamp": 2, "price": "2001"}}
+		],
+		"created": 1700000000000,
+		"data": {"id": "6f4e2b1a-3c5d-4e7f-8a9b-0c1d2e3f4a5b", "amount": 1000, "side": "Buy"}
+	}`
+
+	var metadata ApiOrderMetadata
+	assert.NoError(t, json.Unmarshal([]byte(raw), &metadata))
+	assert.True(t, metadata.IsOpen())
+	filled := metadata.FilledAmount()
+	assert.Equal(t, "350", filled.String())
+	assert.Equal(t, metadata.Id, metadata.Data.Id)
+
+	metadata.State = OrderStateCancelled
+	assert.False(t, metadata.IsOpen())
+}
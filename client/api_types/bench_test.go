@@ -0,0 +1,241 @@
+package api_types //nolint:revive
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/google/uuid"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// benchmarkMatchResult is a match of a typical size, selling 1.5 WETH for
+// 4500 USDC
+func benchmarkMatchResult() ApiExternalMatchResult {
+	var baseAmount Amount
+	_ = baseAmount.SetString("1500000000000000000", 10)
+	return ApiExternalMatchResult{
+		QuoteMint:   "0xdf8d259c04020562717557f2b5a3cf28e92707d1",
+		BaseMint:    "0xc3414a7ef14aaaa9c4522dfc00a4e66e74e9c25a",
+		QuoteAmount: NewAmount(4_500_000_000),
+		BaseAmount:  baseAmount,
+		Direction:   "Sell",
+	}
+}
+
+// benchmarkSignedQuote is a signed quote of a typical size
+func benchmarkSignedQuote() ApiSignedQuote {
+	match := benchmarkMatchResult()
+	return ApiSignedQuote{
+		Quote: ApiExternalQuote{
+			Order: ApiExternalOrder{
+				QuoteMint:  match.QuoteMint,
+				BaseMint:   match.BaseMint,
+				BaseAmount: match.BaseAmount,
+				Side:       match.Direction,
+			},
+			MatchResult: match,
+			Fees:        ApiFee{RelayerFee: NewAmount(900_000), ProtocolFee: NewAmount(450_000)},
+			Send:        ApiExternalAssetTransfer{Mint: match.BaseMint, Amount: match.BaseAmount},
+			Receive:     ApiExternalAssetTransfer{Mint: match.QuoteMint, Amount: NewAmount(4_498_650_000)},
+			Price:       TimestampedPrice{Price: "3000.000000000000000", Timestamp: 1_700_000_000_000},
+			Timestamp:   1_700_000_000_000,
+		},
+		Signature: "0x" + strings.Repeat("ab", 65),
+	}
+}
+
+// benchmarkBundle is a match bundle with settlement calldata of a typical size
+func benchmarkBundle() ApiExternalMatchBundle {
+	quote := benchmarkSignedQuote().Quote
+	return ApiExternalMatchBundle{
+		MatchResult: quote.MatchResult,
+		Fees:        quote.Fees,
+		Receive:     quote.Receive,
+		Send:        quote.Send,
+		SettlementTx: ApiSettlementTransaction{
+			Type:  "eip1559",
+			To:    "0x9af58f1ff20ab22e819e40b57ffd784d115a9ef5",
+			Data:  "0x" + strings.Repeat("0123456789abcdef", 256),
+			Value: "0x0",
+		},
+	}
+}
+
+func BenchmarkSignedQuoteMarshal(b *testing.B) {
+	quote := benchmarkSignedQuote()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(&quote); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSignedQuoteUnmarshal(b *testing.B) {
+	quote := benchmarkSignedQuote()
+	data, err := json.Marshal(&quote)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		var decoded ApiSignedQuote
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBundleMarshal(b *testing.B) {
+	bundle := benchmarkBundle()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(&bundle); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBundleUnmarshal(b *testing.B) {
+	bundle := benchmarkBundle()
+	data, err := json.Marshal(&bundle)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		var decoded ApiExternalMatchBundle
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAmountMarshalJSON(b *testing.B) {
+	var large Amount
+	_ = large.SetString("1500000000000000000000", 10)
+	amounts := []struct {
+		name   string
+		amount Amount
+	}{
+		{"small", NewAmount(4_500_000_000)},
+		{"large", large},
+	}
+
+	for _, tc := range amounts {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := tc.amount.MarshalJSON(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAmountUnmarshalJSON(b *testing.B) {
+	amounts := []struct {
+		name string
+		data []byte
+	}{
+		{"small", []byte(`4500000000`)},
+		{"large", []byte(`1500000000000000000000`)},
+	}
+
+	for _, tc := range amounts {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var amount Amount
+				if err := amount.UnmarshalJSON(tc.data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMatchResultPrice(b *testing.B) {
+	match := benchmarkMatchResult()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		price, err := match.Price()
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = price.FromAtoms(18 /* baseDecimals */, 6 /* quoteDecimals */).Float64()
+	}
+}
+
+func BenchmarkPriceQuoteAmount(b *testing.B) {
+	price, err := ParsePrice("3000.123456")
+	if err != nil {
+		b.Fatal(err)
+	}
+	price = price.ToAtoms(18 /* baseDecimals */, 6 /* quoteDecimals */)
+	baseAmount := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = price.QuoteAmount(baseAmount, wallet.RoundFloor)
+	}
+}
+
+func BenchmarkOrderConversion(b *testing.B) {
+	order := wallet.NewOrderBuilder().
+		WithId(uuid.New()).
+		WithQuoteMintHex("0xdf8d259c04020562717557f2b5a3cf28e92707d1").
+		WithBaseMintHex("0xc3414a7ef14aaaa9c4522dfc00a4e66e74e9c25a").
+		WithSide(wallet.Sell).
+		WithAmountBigInt(big.NewInt(1_000_000)).
+		WithWorstCasePrice(wallet.FixedPointFromFloat(3000)).
+		Build()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		apiOrder, err := new(ApiOrder).FromOrder(&order)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var recovered wallet.Order
+		if err := apiOrder.ToOrder(&recovered); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWalletConversion(b *testing.B) {
+	key, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	w, err := wallet.NewEmptyWallet(key, 0 /* chainId */)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		apiWallet, err := new(ApiWallet).FromWallet(w)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := apiWallet.ToWallet(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
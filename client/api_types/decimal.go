@@ -0,0 +1,161 @@
+package api_types //nolint:revive
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxDecimalLength is the maximum length of a serialized decimal
+	maxDecimalLength = 128
+	// maxDecimalExponent bounds the exponent of a decimal in scientific
+	// notation, so that parsing cannot be made arbitrarily expensive
+	maxDecimalExponent = 100
+	// inexactDecimalDigits is the number of fractional digits printed for a
+	// decimal without a finite decimal expansion, e.g. one third
+	inexactDecimalDigits = 18
+)
+
+// Decimal is an exact decimal number, used for wire values that would lose
+// precision as a float64, e.g. prices. It unmarshals from a JSON number or
+// string and marshals as a JSON number carrying its exact digits. The zero
+// value is zero
+type Decimal struct {
+	// rat is the decimal's value, nil for zero. It is never mutated once set
+	rat *big.Rat
+}
+
+// ParseDecimal parses a decimal from its string form, in plain or scientific
+// notation
+func ParseDecimal(s string) (Decimal, error) {
+	if len(s) > maxDecimalLength {
+		return Decimal{}, fmt.Errorf("decimal exceeds %d characters", maxDecimalLength)
+	}
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exponent, err := strconv.Atoi(s[i+1:])
+		if err != nil || exponent > maxDecimalExponent || exponent < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("invalid decimal exponent: %s", s)
+		}
+	}
+	if strings.Contains(s, "/") {
+		return Decimal{}, fmt.Errorf("invalid decimal: %s", s)
+	}
+
+	rat, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal: %s", s)
+	}
+	return Decimal{rat: rat}, nil
+}
+
+// MustParseDecimal parses a decimal, panicking if it is invalid. For
+// constants
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// NewDecimalFromFloat creates a decimal with the exact value of a float64,
+// which must be finite
+func NewDecimalFromFloat(f float64) Decimal {
+	return Decimal{rat: new(big.Rat).SetFloat64(f)}
+}
+
+// NewDecimalFromRat creates a decimal with the value of a rational number
+func NewDecimalFromRat(r *big.Rat) Decimal {
+	return Decimal{rat: new(big.Rat).Set(r)}
+}
+
+// Rat returns the decimal's value as a rational number
+func (d Decimal) Rat() *big.Rat {
+	if d.rat == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Set(d.rat)
+}
+
+// Float64 returns the nearest float64 to the decimal's value
+func (d Decimal) Float64() float64 {
+	if d.rat == nil {
+		return 0
+	}
+	f, _ := d.rat.Float64()
+	return f
+}
+
+// Sign returns -1, 0, or 1 as the decimal is negative, zero, or positive
+func (d Decimal) Sign() int {
+	if d.rat == nil {
+		return 0
+	}
+	return d.rat.Sign()
+}
+
+// IsZero returns whether the decimal is zero
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp compares two decimals
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// String returns the decimal in plain notation, with every digit of its
+// value if its decimal expansion is finite
+func (d Decimal) String() string {
+	if d.rat == nil {
+		return "0"
+	}
+	if digits, ok := fractionalDigits(d.rat.Denom()); ok {
+		return d.rat.FloatString(digits)
+	}
+	return d.rat.FloatString(inexactDecimalDigits)
+}
+
+// MarshalJSON marshals the decimal as a JSON number
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON unmarshals the decimal from a JSON number or string
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(b, `"`)
+	parsed, err := ParseDecimal(string(b))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// fractionalDigits returns the number of fractional digits in the decimal
+// expansion of a fraction with the given denominator, if it is finite, i.e.
+// the denominator has no prime factors other than 2 and 5
+func fractionalDigits(denom *big.Int) (int, bool) {
+	twos := int(denom.TrailingZeroBits())
+	rest := new(big.Int).Rsh(denom, uint(twos))
+
+	fives := 0
+	five := big.NewInt(5)
+	quo, rem := new(big.Int), new(big.Int)
+	for {
+		quo.QuoRem(rest, five, rem)
+		if rem.Sign() != 0 {
+			break
+		}
+		rest.Set(quo)
+		fives++
+	}
+
+	if rest.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	return max(twos, fives), true
+}
@@ -0,0 +1,60 @@
+package api_types //nolint:revive
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecimalRoundTripsExactly(t *testing.T) {
+	// A price with more significant digits than a float64 holds
+	raw := `{"address":"0x1","price":3456.123456789012345678,"timestamp":1,` +
+		`"buy":{"total_quantity":1,"total_quantity_usd":"0.1"},"sell":{"total_quantity":2,"total_quantity_usd":0}}`
+
+	var depth ApiPriceAndDepth
+	assert.NoError(t, json.Unmarshal([]byte(raw), &depth))
+	assert.Equal(t, "3456.123456789012345678", depth.PriceDecimal().String())
+	assert.Equal(t, "0.1", depth.Buy.TotalQuantityUsdDecimal().String())
+	assert.InDelta(t, 3456.123456789012, depth.Price, 1e-9)
+	assert.Equal(t, 0.1, depth.Buy.TotalQuantityUsd)
+
+	encoded, err := json.Marshal(depth)
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"price":3456.123456789012345678`)
+	assert.Contains(t, string(encoded), `"total_quantity_usd":0.1`)
+
+	// Setting the float field replaces the decoded value
+	depth.Price = 2
+	assert.Equal(t, "2", depth.PriceDecimal().String())
+}
+
+func TestDecimalString(t *testing.T) {
+	assert.Equal(t, "0", Decimal{}.String())
+	assert.Equal(t, "42", MustParseDecimal("42.000").String())
+	assert.Equal(t, "0.00012", MustParseDecimal("1.2e-4").String())
+	assert.Equal(t, "0.5", NewDecimalFromFloat(0.5).String())
+	assert.Equal(t, "0.333333333333333333", NewDecimalFromRat(big.NewRat(1, 3)).String())
+
+	assert.Equal(t, -1, MustParseDecimal("0.1").Cmp(MustParseDecimal("0.10000000000000000001")))
+	assert.True(t, MustParseDecimal("-0").IsZero())
+}
+
+func TestParseDecimalRejectsInvalidInput(t *testing.T) {
+	for _, input := range []string{"", "abc", "1/3", "1e1000", "1e99999999999"} {
+		_, err := ParseDecimal(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestTimestampedPriceDecimal(t *testing.T) {
+	price := TimestampedPrice{Timestamp: 1, Price: "0.000123456789012345678"}
+	exact, err := price.Decimal()
+	assert.NoError(t, err)
+	assert.Equal(t, "0.000123456789012345678", exact.String())
+
+	f, err := price.Float64()
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.000123456789012345678, f, 1e-18)
+}
@@ -0,0 +1,107 @@
+package api_types //nolint:revive
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ExecutionEstimate is the expected execution of an order of a given size
+// against a token's order book depth
+type ExecutionEstimate struct {
+	// Size is the order size, in the base token's smallest denomination
+	Size Amount
+	// FilledSize is the part of the order the depth can fill
+	FilledSize Amount
+	// AveragePrice is the depth-weighted average price of the filled part, in
+	// USD per whole base token; zero if nothing fills
+	AveragePrice Price
+	// FillRatio is the fraction of the order that fills, between zero and one
+	FillRatio float64
+	// SlippageBps is the average price's deviation from the depth's price, in
+	// basis points; positive when the price is worse for the order
+	SlippageBps float64
+}
+
+// FullyFilled returns whether the depth fills the whole order
+func (e *ExecutionEstimate) FullyFilled() bool {
+	return e.FilledSize.Cmp(e.Size) == 0
+}
+
+// EstimateExecution estimates the execution of an order buying or selling the
+// given amount of the token, in its smallest denomination, against the depth.
+// A buy order fills against the sell side and a sell order against the buy
+// side.
+//
+// Renegade crosses orders at the midpoint price, so every fill the depth
+// supports executes at the depth's price: the estimate's slippage is zero
+// within the available depth, and an order larger than the depth is partially
+// filled rather than walked through worse prices
+func (d *ApiPriceAndDepth) EstimateExecution(side string, size Amount) (*ExecutionEstimate, error) {
+	available, err := d.availableDepth(side)
+	if err != nil {
+		return nil, err
+	}
+	if (*big.Int)(&size).Sign() < 0 {
+		return nil, fmt.Errorf("negative order size: %s", size.String())
+	}
+
+	estimate := &ExecutionEstimate{Size: size, FilledSize: size}
+	if size.Cmp(available) > 0 {
+		estimate.FilledSize = available
+	}
+	if estimate.FilledSize.IsZero() {
+		return estimate, nil
+	}
+
+	// Every filled unit executes at the midpoint, so the depth-weighted
+	// average is the depth's price
+	midpoint := NewPriceFromDecimal(d.PriceDecimal())
+	estimate.AveragePrice = midpoint
+
+	estimate.FillRatio, _ = new(big.Rat).SetFrac((*big.Int)(&estimate.FilledSize), (*big.Int)(&size)).Float64()
+	estimate.SlippageBps = slippageBps(side, estimate.AveragePrice, midpoint)
+	return estimate, nil
+}
+
+// SlippageCurve estimates the execution of orders of each of the given sizes,
+// e.g. for a pre-trade transparency report. See EstimateExecution
+func (d *ApiPriceAndDepth) SlippageCurve(side string, sizes []Amount) ([]ExecutionEstimate, error) {
+	curve := make([]ExecutionEstimate, 0, len(sizes))
+	for _, size := range sizes {
+		estimate, err := d.EstimateExecution(side, size)
+		if err != nil {
+			return nil, err
+		}
+		curve = append(curve, *estimate)
+	}
+	return curve, nil
+}
+
+// availableDepth returns the quantity of the base token an order on the given
+// side can fill against
+func (d *ApiPriceAndDepth) availableDepth(side string) (Amount, error) {
+	switch side {
+	case "Buy":
+		return d.Sell.TotalQuantity, nil
+	case "Sell":
+		return d.Buy.TotalQuantity, nil
+	default:
+		return Amount{}, fmt.Errorf("invalid order side: %s", side)
+	}
+}
+
+// slippageBps returns an average price's deviation from a reference price, in
+// basis points, signed so that a worse price for the order is positive
+func slippageBps(side string, average, reference Price) float64 {
+	if reference.IsZero() {
+		return 0
+	}
+	deviation := new(big.Rat).Sub(average.Rat(), reference.Rat())
+	deviation.Quo(deviation, reference.Rat())
+	deviation.Mul(deviation, big.NewRat(10_000, 1))
+	if side == "Sell" {
+		deviation.Neg(deviation)
+	}
+	bps, _ := deviation.Float64()
+	return bps
+}
@@ -0,0 +1,63 @@
+package api_types //nolint:revive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDepth() *ApiPriceAndDepth {
+	return &ApiPriceAndDepth{
+		Price: 2500.5,
+		Buy:   ApiDepthSide{TotalQuantity: NewAmount(400)},
+		Sell:  ApiDepthSide{TotalQuantity: NewAmount(1000)},
+	}
+}
+
+func TestEstimateExecution(t *testing.T) {
+	depth := testDepth()
+
+	// A buy within the sell side's depth fills in full at the midpoint
+	estimate, err := depth.EstimateExecution("Buy", NewAmount(600))
+	assert.NoError(t, err)
+	assert.True(t, estimate.FullyFilled())
+	assert.Equal(t, "2500.5", estimate.AveragePrice.String())
+	assert.Equal(t, 1.0, estimate.FillRatio)
+	assert.Equal(t, 0.0, estimate.SlippageBps)
+
+	// A sell larger than the buy side's depth partially fills
+	estimate, err = depth.EstimateExecution("Sell", NewAmount(800))
+	assert.NoError(t, err)
+	assert.False(t, estimate.FullyFilled())
+	assert.Equal(t, "400", estimate.FilledSize.String())
+	assert.Equal(t, 0.5, estimate.FillRatio)
+
+	// No depth fills nothing
+	depth.Buy.TotalQuantity = NewAmount(0)
+	estimate, err = depth.EstimateExecution("Sell", NewAmount(10))
+	assert.NoError(t, err)
+	assert.True(t, estimate.FilledSize.IsZero())
+	assert.True(t, estimate.AveragePrice.IsZero())
+	assert.Equal(t, 0.0, estimate.FillRatio)
+
+	_, err = depth.EstimateExecution("Hold", NewAmount(10))
+	assert.Error(t, err)
+	_, err = depth.EstimateExecution("Buy", NewAmount(-1))
+	assert.Error(t, err)
+}
+
+func TestSlippageCurve(t *testing.T) {
+	sizes := []Amount{NewAmount(500), NewAmount(1000), NewAmount(2000), NewAmount(4000)}
+	curve, err := testDepth().SlippageCurve("Buy", sizes)
+	assert.NoError(t, err)
+	assert.Len(t, curve, len(sizes))
+
+	ratios := make([]float64, len(curve))
+	for i, estimate := range curve {
+		ratios[i] = estimate.FillRatio
+	}
+	assert.Equal(t, []float64{1, 1, 0.5, 0.25}, ratios)
+
+	_, err = testDepth().SlippageCurve("Hold", sizes)
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package api_types //nolint:revive
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// FuzzAmountUnmarshalJSON checks that amount decoding never panics and that
+// decoded amounts round trip
+func FuzzAmountUnmarshalJSON(f *testing.F) {
+	f.Add([]byte("0"))
+	f.Add([]byte("-1"))
+	f.Add([]byte("115792089237316195423570985008687907853269984665640564039457584007913129639935"))
+	f.Add([]byte(`"12"`))
+	f.Add([]byte(strings.Repeat("9", 1000)))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var amount Amount
+		if err := amount.UnmarshalJSON(b); err != nil {
+			return
+		}
+
+		encoded, err := amount.MarshalJSON()
+		if err != nil {
+			t.Fatalf("failed to marshal decoded amount: %v", err)
+		}
+
+		var decoded Amount
+		if err := decoded.UnmarshalJSON(encoded); err != nil || decoded.Cmp(amount) != 0 {
+			t.Fatalf("amount %s did not round trip: %v", amount.String(), err)
+		}
+	})
+}
+
+// FuzzScalarLimbs checks that limb conversion never panics
+func FuzzScalarLimbs(f *testing.F) {
+	f.Add([]byte(`[1,2,3,4,5,6,7,8]`))
+	f.Add([]byte("[" + strings.TrimSuffix(strings.Repeat("4294967295,", 8), ",") + "]"))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var limbs ScalarLimbs
+		if err := json.Unmarshal(b, &limbs); err != nil {
+			return
+		}
+
+		ScalarToUintLimbs(ScalarFromUintLimbs(limbs))
+	})
+}
+
+// FuzzApiSignedQuote checks that decoding a signed quote never panics
+func FuzzApiSignedQuote(f *testing.F) {
+	quote, err := json.Marshal(ApiSignedQuote{
+		Quote: ApiExternalQuote{
+			Order: ApiExternalOrder{QuoteMint: "0x01", BaseMint: "0x02", Side: "Buy"},
+		},
+		Signature: "0xabcd",
+	})
+	if err != nil {
+		f.Fatalf("failed to encode seed quote: %v", err)
+	}
+
+	f.Add(quote)
+	f.Add([]byte(`{"quote":{"match_result":{"base_amount":-1}}}`))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var signedQuote ApiSignedQuote
+		//nolint:errcheck
+		json.Unmarshal(b, &signedQuote)
+	})
+}
+
+// FuzzApiWallet checks that decoding and converting a wallet never panics
+func FuzzApiWallet(f *testing.F) {
+	key, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	if err != nil {
+		f.Fatalf("failed to generate key: %v", err)
+	}
+	w, err := wallet.NewEmptyWallet(key, 0 /* chainId */)
+	if err != nil {
+		f.Fatalf("failed to create wallet: %v", err)
+	}
+	apiWallet, err := new(ApiWallet).FromWallet(w)
+	if err != nil {
+		f.Fatalf("failed to convert wallet: %v", err)
+	}
+	seed, err := json.Marshal(apiWallet)
+	if err != nil {
+		f.Fatalf("failed to encode seed wallet: %v", err)
+	}
+
+	f.Add(seed)
+	f.Add([]byte(`{"managing_cluster":"0x` + strings.Repeat("ff", 65) + `"}`))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var decoded ApiWallet
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			return
+		}
+
+		//nolint:errcheck
+		decoded.ToWallet()
+	})
+}
@@ -0,0 +1,61 @@
+package api_types //nolint:revive
+
+// The order presets build the external orders of common strategies with the
+// right combination of builder fields. Amounts are in the token's smallest
+// denomination, and orders accept partial fills; use the builder with
+// WithMinFillSize to bound them
+
+// NewMarketBuy builds an order buying the given amount of the base token
+func NewMarketBuy(baseMint, quoteMint string, baseAmount Amount) (*ApiExternalOrder, error) {
+	return NewExternalOrderBuilder().
+		WithBaseMint(baseMint).
+		WithQuoteMint(quoteMint).
+		WithBaseAmount(baseAmount).
+		WithSide("Buy").
+		Build()
+}
+
+// NewMarketSell builds an order selling the given amount of the base token
+func NewMarketSell(baseMint, quoteMint string, baseAmount Amount) (*ApiExternalOrder, error) {
+	return NewExternalOrderBuilder().
+		WithBaseMint(baseMint).
+		WithQuoteMint(quoteMint).
+		WithBaseAmount(baseAmount).
+		WithSide("Sell").
+		Build()
+}
+
+// NewMarketBuyQuoteDenominated builds an order spending the given amount of
+// the quote token on the base token, e.g. buying $100 of WETH
+func NewMarketBuyQuoteDenominated(baseMint, quoteMint string, quoteAmount Amount) (*ApiExternalOrder, error) {
+	return NewExternalOrderBuilder().
+		WithBaseMint(baseMint).
+		WithQuoteMint(quoteMint).
+		WithQuoteAmount(quoteAmount).
+		WithSide("Buy").
+		Build()
+}
+
+// NewMarketSellQuoteDenominated builds an order selling the base token for the
+// given amount of the quote token, e.g. selling $100 worth of WETH
+func NewMarketSellQuoteDenominated(baseMint, quoteMint string, quoteAmount Amount) (*ApiExternalOrder, error) {
+	return NewExternalOrderBuilder().
+		WithBaseMint(baseMint).
+		WithQuoteMint(quoteMint).
+		WithQuoteAmount(quoteAmount).
+		WithSide("Sell").
+		Build()
+}
+
+// NewExactOutputSell builds an order selling the base token to receive the
+// given amount of the quote token. The amount is sized before fees, which
+// the relayer deducts from the quote token received
+func NewExactOutputSell(baseMint, quoteMint string, amountOut Amount) (*ApiExternalOrder, error) {
+	return NewMarketSellQuoteDenominated(baseMint, quoteMint, amountOut)
+}
+
+// NewExactOutputBuy builds an order buying exactly the given amount of the
+// base token, before fees
+func NewExactOutputBuy(baseMint, quoteMint string, amountOut Amount) (*ApiExternalOrder, error) {
+	return NewMarketBuy(baseMint, quoteMint, amountOut)
+}
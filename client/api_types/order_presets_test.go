@@ -0,0 +1,46 @@
+package api_types //nolint:revive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderPresets(t *testing.T) {
+	amount := NewAmount(100)
+	cases := []struct {
+		build       func(string, string, Amount) (*ApiExternalOrder, error)
+		side        string
+		quoteSized  bool
+		description string
+	}{
+		{NewMarketBuy, "Buy", false, "market buy"},
+		{NewMarketSell, "Sell", false, "market sell"},
+		{NewMarketBuyQuoteDenominated, "Buy", true, "quote denominated buy"},
+		{NewMarketSellQuoteDenominated, "Sell", true, "quote denominated sell"},
+		{NewExactOutputSell, "Sell", true, "exact output sell"},
+		{NewExactOutputBuy, "Buy", false, "exact output buy"},
+	}
+
+	for _, tc := range cases {
+		order, err := tc.build("0x1", "0x2", amount)
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, "0x1", order.BaseMint, tc.description)
+		assert.Equal(t, "0x2", order.QuoteMint, tc.description)
+		assert.Equal(t, tc.side, order.Side, tc.description)
+		assert.True(t, order.MinFillSize.IsZero(), tc.description)
+		if tc.quoteSized {
+			assert.Equal(t, "100", order.QuoteAmount.String(), tc.description)
+			assert.True(t, order.BaseAmount.IsZero(), tc.description)
+		} else {
+			assert.Equal(t, "100", order.BaseAmount.String(), tc.description)
+			assert.True(t, order.QuoteAmount.IsZero(), tc.description)
+		}
+	}
+
+	// The presets validate like the builder
+	_, err := NewMarketBuy("0x1", NativeAssetAddr, amount)
+	assert.Error(t, err)
+	_, err = NewMarketSell("0x1", "0x2", NewAmount(0))
+	assert.Error(t, err)
+}
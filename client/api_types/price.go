@@ -0,0 +1,182 @@
+package api_types //nolint:revive
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// fixedPointPrecisionBits is the number of fractional bits of a
+// wallet.FixedPoint
+const fixedPointPrecisionBits = 63
+
+// ErrZeroPrice is returned when a price would be derived from a zero amount or
+// a zero price is inverted
+var ErrZeroPrice = errors.New("price is zero")
+
+// Price is an exact price, in units of a quote token per unit of a base token.
+// It is the common form of the prices the relayer reports as decimal strings,
+// the fixed point prices of wallet orders, and the prices implied by match
+// amounts, so that converting between them loses no precision. The zero value
+// is a zero price
+type Price struct {
+	// rat is the price's value, nil for zero. It is never mutated once set
+	rat *big.Rat
+}
+
+// ParsePrice parses a price from a decimal string, as reported in quotes
+func ParsePrice(s string) (Price, error) {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return Price{}, err
+	}
+	return NewPriceFromDecimal(d), nil
+}
+
+// NewPriceFromDecimal creates a price from a decimal
+func NewPriceFromDecimal(d Decimal) Price {
+	return Price{rat: d.Rat()}
+}
+
+// NewPriceFromFloat creates a price with the exact value of a float64, which
+// must be finite
+func NewPriceFromFloat(f float64) Price {
+	return Price{rat: new(big.Rat).SetFloat64(f)}
+}
+
+// NewPriceFromFixedPoint creates a price with the exact value of a fixed
+// point number, e.g. an order's worst case price
+func NewPriceFromFixedPoint(fp wallet.FixedPoint) Price {
+	denom := new(big.Int).Lsh(big.NewInt(1), fixedPointPrecisionBits)
+	return Price{rat: new(big.Rat).SetFrac(fp.Repr.ToBigInt(), denom)}
+}
+
+// NewPriceFromAmounts creates the price at which the given quote and base
+// amounts trade
+func NewPriceFromAmounts(quoteAmount, baseAmount Amount) (Price, error) {
+	if baseAmount.IsZero() {
+		return Price{}, ErrZeroPrice
+	}
+	return Price{rat: new(big.Rat).SetFrac((*big.Int)(&quoteAmount), (*big.Int)(&baseAmount))}, nil
+}
+
+// Rat returns the price as a rational number
+func (p Price) Rat() *big.Rat {
+	if p.rat == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Set(p.rat)
+}
+
+// Decimal returns the price as a decimal
+func (p Price) Decimal() Decimal {
+	return Decimal{rat: p.Rat()}
+}
+
+// Float64 returns the nearest float64 to the price
+func (p Price) Float64() float64 {
+	return p.Decimal().Float64()
+}
+
+// FixedPoint returns the price as a fixed point number, floored to its
+// precision as the relayer does. The price must be non-negative
+func (p Price) FixedPoint() wallet.FixedPoint {
+	r := p.Rat()
+	shifted := new(big.Int).Lsh(r.Num(), fixedPointPrecisionBits)
+	repr := new(big.Int).Quo(shifted, r.Denom())
+	return wallet.NewFixedPoint(new(wallet.Scalar).FromBigInt(repr))
+}
+
+// String returns the price in decimal notation, see Decimal.String
+func (p Price) String() string {
+	return p.Decimal().String()
+}
+
+// Sign returns -1, 0, or 1 as the price is negative, zero, or positive
+func (p Price) Sign() int {
+	return p.Decimal().Sign()
+}
+
+// IsZero returns whether the price is zero
+func (p Price) IsZero() bool {
+	return p.Sign() == 0
+}
+
+// Cmp compares two prices
+func (p Price) Cmp(other Price) int {
+	return p.Rat().Cmp(other.Rat())
+}
+
+// Mul returns the product of two prices, e.g. to chain a base/quote price
+// with a quote/USD price
+func (p Price) Mul(other Price) Price {
+	return Price{rat: new(big.Rat).Mul(p.Rat(), other.Rat())}
+}
+
+// Invert returns the price of the quote token in units of the base token
+func (p Price) Invert() (Price, error) {
+	if p.IsZero() {
+		return Price{}, ErrZeroPrice
+	}
+	return Price{rat: new(big.Rat).Inv(p.Rat())}, nil
+}
+
+// ToAtoms converts a price between whole tokens, e.g. a USD price, to a price
+// between the tokens' smallest denominations
+func (p Price) ToAtoms(baseDecimals, quoteDecimals uint8) Price {
+	return p.scaleDecimals(int(quoteDecimals) - int(baseDecimals))
+}
+
+// FromAtoms converts a price between the tokens' smallest denominations to a
+// price between whole tokens
+func (p Price) FromAtoms(baseDecimals, quoteDecimals uint8) Price {
+	return p.scaleDecimals(int(baseDecimals) - int(quoteDecimals))
+}
+
+// QuoteAmount returns the quote amount that trades for a base amount at the
+// price, rounded with the given mode. The price must be non-negative
+func (p Price) QuoteAmount(baseAmount *big.Int, mode wallet.RoundingMode) *big.Int {
+	r := p.Rat()
+	product := new(big.Int).Mul(baseAmount, r.Num())
+	return roundedQuo(product, r.Denom(), mode)
+}
+
+// BaseAmount returns the base amount that trades for a quote amount at the
+// price, rounded with the given mode. The price must be positive
+func (p Price) BaseAmount(quoteAmount *big.Int, mode wallet.RoundingMode) *big.Int {
+	r := p.Rat()
+	product := new(big.Int).Mul(quoteAmount, r.Denom())
+	return roundedQuo(product, r.Num(), mode)
+}
+
+// scaleDecimals multiplies the price by a power of ten
+func (p Price) scaleDecimals(exponent int) Price {
+	inverse := exponent < 0
+	if inverse {
+		exponent = -exponent
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+	factor := new(big.Rat).SetInt(scale)
+	if inverse {
+		factor.Inv(factor)
+	}
+	return Price{rat: factor.Mul(factor, p.Rat())}
+}
+
+// roundedQuo divides two non-negative integers, rounding with the given mode
+func roundedQuo(num, den *big.Int, mode wallet.RoundingMode) *big.Int {
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	switch mode {
+	case wallet.RoundCeil:
+		if rem.Sign() > 0 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	case wallet.RoundNearest:
+		if new(big.Int).Lsh(rem, 1).Cmp(den) >= 0 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	return quo
+}
@@ -0,0 +1,49 @@
+package api_types //nolint:revive
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+func TestPriceConversionsAreExact(t *testing.T) {
+	// A decimal price survives a round trip through its inverse
+	price, err := ParsePrice("3456.789")
+	assert.NoError(t, err)
+	inverse, err := price.Invert()
+	assert.NoError(t, err)
+	back, err := inverse.Invert()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, price.Cmp(back))
+	assert.Equal(t, "3456.789", back.String())
+
+	// Fixed point prices convert exactly in both directions
+	fp := wallet.FixedPointFromFloat(1.5)
+	assert.Equal(t, "1.5", NewPriceFromFixedPoint(fp).String())
+	assert.Equal(t, fp.ToReprDecimalString(), NewPriceFromFixedPoint(fp).FixedPoint().ToReprDecimalString())
+
+	// A WETH/USDC price of 2000 is 2000e6 / 1e18 in atoms
+	atoms := MustParseDecimal("2000")
+	atomPrice := NewPriceFromDecimal(atoms).ToAtoms(18, 6)
+	assert.Equal(t, "0.000000002", atomPrice.String())
+	assert.Equal(t, "2000", atomPrice.FromAtoms(18, 6).String())
+}
+
+func TestPriceFromAmounts(t *testing.T) {
+	match := ApiExternalMatchResult{QuoteAmount: NewAmount(1000), BaseAmount: NewAmount(3)}
+	price, err := match.Price()
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(1000, 3), price.Rat())
+
+	assert.Equal(t, big.NewInt(333), price.QuoteAmount(big.NewInt(1), wallet.RoundFloor))
+	assert.Equal(t, big.NewInt(334), price.QuoteAmount(big.NewInt(1), wallet.RoundCeil))
+	assert.Equal(t, big.NewInt(3), price.BaseAmount(big.NewInt(1000), wallet.RoundFloor))
+
+	_, err = NewPriceFromAmounts(NewAmount(1), NewAmount(0))
+	assert.ErrorIs(t, err, ErrZeroPrice)
+	_, err = Price{}.Invert()
+	assert.ErrorIs(t, err, ErrZeroPrice)
+}
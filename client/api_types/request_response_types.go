@@ -2,15 +2,28 @@ package api_types //nolint:revive
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
+
+	"github.com/renegade-fi/golang-sdk/client/api"
 )
 
 //nolint:revive
 const (
 	// --- Orderbook Endpoints --- //
 	// GetSupportedTokensPath is the path for the GetSupportedTokens action
-	GetSupportedTokensPath = "/v0/supported-tokens"
+	GetSupportedTokensPath = api.GetSupportedTokensPath
+	// GetDepthByMintPath is the path to fetch the order book depth of a token
+	GetDepthByMintPath = "/v0/order_book/depth/%s"
+	// GetDepthForAllPairsPath is the path to fetch the order book depth of
+	// every supported token
+	GetDepthForAllPairsPath = "/v0/order_book/depth"
+	// GetExternalMatchFeePath is the path to fetch the fee rates charged on
+	// external matches that trade the given token
+	GetExternalMatchFeePath = api.GetExternalMatchFeePath
 
 	// --- Wallet Endpoints --- //
 	// GetWalletPath is the path for the GetWallet action
@@ -28,6 +41,12 @@ const (
 	CreateOrderPath = "/v0/wallet/%s/orders"
 	// CancelOrderPath is the path for the CancelOrder action
 	CancelOrderPath = "/v0/wallet/%s/orders/%s/cancel"
+	// UpdateOrderPath is the path for the UpdateOrder action
+	UpdateOrderPath = "/v0/wallet/%s/orders/%s/update"
+	// OrderMetadataPath is the path to fetch the metadata of an order
+	OrderMetadataPath = "/v0/wallet/%s/orders/%s/metadata"
+	// OrderHistoryPath is the path to fetch the metadata of a wallet's orders
+	OrderHistoryPath = "/v0/wallet/%s/order-history"
 	// DepositPath is the path for the Deposit action
 	DepositPath = "/v0/wallet/%s/balances/deposit"
 	// WithdrawPath is the path for the Withdraw action
@@ -35,9 +54,22 @@ const (
 	// PayFeesPath is the path to enqueue tasks to pay wallet fees
 	PayFeesPath = "/v0/wallet/%s/pay-fees"
 	// TaskStatusPath is the path to fetch the status of a task
-	TaskStatusPath = "/v0/tasks/%s"
+	TaskStatusPath = api.TaskStatusPath
 	// TaskHistoryPath is the path to fetch the task history for a wallet
-	TaskHistoryPath = "/v0/wallet/%s/task-history"
+	TaskHistoryPath = api.TaskHistoryPath
+
+	// --- Admin Endpoints --- //
+	// AdminCreateMatchingPoolPath is the path to create a matching pool
+	AdminCreateMatchingPoolPath = "/v0/admin/matching_pools/%s"
+	// AdminDestroyMatchingPoolPath is the path to destroy a matching pool
+	AdminDestroyMatchingPoolPath = "/v0/admin/matching_pools/%s/destroy"
+	// AdminCreateOrderInMatchingPoolPath is the path to create an order in a
+	// matching pool
+	AdminCreateOrderInMatchingPoolPath = "/v0/admin/wallet/%s/order-in-pool"
+	// AdminAssignOrderPath is the path to move an order to a matching pool
+	AdminAssignOrderPath = "/v0/admin/orders/%s/assign-pool/%s"
+	// AdminOrderMatchingPoolPath is the path to fetch the matching pool of an order
+	AdminOrderMatchingPoolPath = "/v0/admin/orders/%s/matching-pool"
 
 	// --- External Match Endpoints --- //
 	// GetExternalMatchBundlePath is the path to fetch an external match bundle
@@ -46,6 +78,14 @@ const (
 	GetExternalMatchQuotePath = "/v0/matching-engine/quote"
 	// AssembleExternalQuotePath is the path to assemble a quote into a settlement transaction
 	AssembleExternalQuotePath = "/v0/matching-engine/assemble-external-match"
+
+	// --- v2 Endpoints --- //
+	// ExchangeMetadataPath is the path to fetch the exchange metadata, served
+	// only by servers that support the v2 API
+	ExchangeMetadataPath = "/v2/metadata/exchange"
+	// MarketDepthByMintPath is the path to fetch the price and depth of a
+	// token's market, served only by servers that support the v2 API
+	MarketDepthByMintPath = "/v2/markets/%s/depth"
 )
 
 // ScalarLimbs is an array of uint32 limbs
@@ -60,6 +100,31 @@ type WalletUpdateAuthorization struct {
 	NewRootKey *string `json:"new_root_key"`
 }
 
+// BuildGetDepthByMintPath builds the path for the GetDepthByMint action
+func BuildGetDepthByMintPath(mint string) string {
+	return fmt.Sprintf(GetDepthByMintPath, mint)
+}
+
+// BuildMarketDepthByMintPath builds the path for the MarketDepthByMint action
+func BuildMarketDepthByMintPath(mint string) string {
+	return fmt.Sprintf(MarketDepthByMintPath, mint)
+}
+
+// BuildGetExternalMatchFeePath builds the path for the GetExternalMatchFee action
+func BuildGetExternalMatchFeePath(mint string) string {
+	return fmt.Sprintf(GetExternalMatchFeePath, url.QueryEscape(mint))
+}
+
+// BuildExternalMatchQuotePath builds the path for the GetExternalMatchQuote
+// action, directing the quote's gas sponsorship refund to the given address
+// if set
+func BuildExternalMatchQuotePath(refundAddress *string) string {
+	if refundAddress == nil {
+		return GetExternalMatchQuotePath
+	}
+	return GetExternalMatchQuotePath + "?refund_address=" + url.QueryEscape(*refundAddress)
+}
+
 // BuildGetWalletPath builds the path for the GetWallet action
 func BuildGetWalletPath(walletID uuid.UUID) string {
 	return fmt.Sprintf(GetWalletPath, walletID)
@@ -85,6 +150,21 @@ func BuildCancelOrderPath(walletID uuid.UUID, orderID uuid.UUID) string {
 	return fmt.Sprintf(CancelOrderPath, walletID, orderID)
 }
 
+// BuildUpdateOrderPath builds the path for the UpdateOrder action
+func BuildUpdateOrderPath(walletID uuid.UUID, orderID uuid.UUID) string {
+	return fmt.Sprintf(UpdateOrderPath, walletID, orderID)
+}
+
+// BuildOrderMetadataPath builds the path for the OrderMetadata action
+func BuildOrderMetadataPath(walletID uuid.UUID, orderID uuid.UUID) string {
+	return fmt.Sprintf(OrderMetadataPath, walletID, orderID)
+}
+
+// BuildOrderHistoryPath builds the path for the OrderHistory action
+func BuildOrderHistoryPath(walletID uuid.UUID) string {
+	return fmt.Sprintf(OrderHistoryPath, walletID)
+}
+
 // BuildDepositPath builds the path for the Deposit action
 func BuildDepositPath(walletID uuid.UUID) string {
 	return fmt.Sprintf(DepositPath, walletID)
@@ -110,13 +190,89 @@ func BuildTaskHistoryPath(walletID uuid.UUID) string {
 	return fmt.Sprintf(TaskHistoryPath, walletID)
 }
 
+// TaskHistoryQuery holds the pagination and filter parameters of a TaskHistory
+// request. Zero values are omitted from the request
+type TaskHistoryQuery struct {
+	// Limit is the maximum number of tasks to return
+	Limit int
+	// Cursor is the opaque cursor of the page to return, as returned in the
+	// previous page's NextCursor
+	Cursor string
+	// TaskTypes restricts the tasks to the given task or wallet update types
+	TaskTypes []string
+	// States restricts the tasks to the given states
+	States []string
+}
+
+// BuildTaskHistoryQueryPath builds the path for a paginated, filtered
+// TaskHistory action
+func BuildTaskHistoryQueryPath(walletID uuid.UUID, query TaskHistoryQuery) string {
+	params := url.Values{}
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+	if query.Cursor != "" {
+		params.Set("cursor", query.Cursor)
+	}
+	for _, taskType := range query.TaskTypes {
+		params.Add("task_type", taskType)
+	}
+	for _, state := range query.States {
+		params.Add("state", state)
+	}
+
+	path := BuildTaskHistoryPath(walletID)
+	if len(params) == 0 {
+		return path
+	}
+	return path + "?" + params.Encode()
+}
+
+// BuildAdminCreateMatchingPoolPath builds the path for the AdminCreateMatchingPool action
+func BuildAdminCreateMatchingPoolPath(pool string) string {
+	return fmt.Sprintf(AdminCreateMatchingPoolPath, url.PathEscape(pool))
+}
+
+// BuildAdminDestroyMatchingPoolPath builds the path for the
+// AdminDestroyMatchingPool action
+func BuildAdminDestroyMatchingPoolPath(pool string) string {
+	return fmt.Sprintf(AdminDestroyMatchingPoolPath, url.PathEscape(pool))
+}
+
+// BuildAdminCreateOrderInMatchingPoolPath builds the path for the
+// AdminCreateOrderInMatchingPool action
+func BuildAdminCreateOrderInMatchingPoolPath(walletID uuid.UUID) string {
+	return fmt.Sprintf(AdminCreateOrderInMatchingPoolPath, walletID)
+}
+
+// BuildAdminAssignOrderPath builds the path for the AdminAssignOrder action
+func BuildAdminAssignOrderPath(orderID uuid.UUID, pool string) string {
+	return fmt.Sprintf(AdminAssignOrderPath, orderID, url.PathEscape(pool))
+}
+
+// BuildAdminOrderMatchingPoolPath builds the path for the AdminOrderMatchingPool action
+func BuildAdminOrderMatchingPoolPath(orderID uuid.UUID) string {
+	return fmt.Sprintf(AdminOrderMatchingPoolPath, orderID)
+}
+
 // -----------------------
 // | Orderbook Endpoints |
 // -----------------------
 
 // GetSupportedTokensResponse is the response body for the GetSupportedTokens request
-type GetSupportedTokensResponse struct {
-	Tokens []ApiToken `json:"tokens"`
+type GetSupportedTokensResponse = api.GetSupportedTokensResponse
+
+// GetExternalMatchFeeResponse is the response body for the GetExternalMatchFee request
+type GetExternalMatchFeeResponse = api.GetExternalMatchFeeResponse
+
+// GetDepthByMintResponse is the response body for the GetDepthByMint request
+type GetDepthByMintResponse struct {
+	Depth ApiPriceAndDepth `json:"depth"`
+}
+
+// GetDepthForAllPairsResponse is the response body for the GetDepthForAllPairs request
+type GetDepthForAllPairsResponse struct {
+	Pairs []ApiPriceAndDepth `json:"pairs"`
 }
 
 // --------------------
@@ -186,6 +342,31 @@ type CancelOrderResponse struct {
 	Order ApiOrder `json:"order"`
 }
 
+// UpdateOrderRequest is the request body for the UpdateOrder action
+type UpdateOrderRequest struct {
+	// Order is the order to replace the existing order with
+	Order ApiOrder `json:"order"`
+	WalletUpdateAuthorization
+}
+
+// UpdateOrderResponse is the response body for the UpdateOrder action
+type UpdateOrderResponse struct {
+	// TaskId is the ID of the task that was created to update the wallet
+	TaskId uuid.UUID `json:"task_id"` //nolint:revive
+}
+
+// GetOrderMetadataResponse is the response body for the OrderMetadata action
+type GetOrderMetadataResponse struct {
+	// Order is the metadata of the requested order
+	Order ApiOrderMetadata `json:"order"`
+}
+
+// GetOrderHistoryResponse is the response body for the OrderHistory action
+type GetOrderHistoryResponse struct {
+	// Orders is the metadata of the wallet's orders, most recent first
+	Orders []ApiOrderMetadata `json:"orders"`
+}
+
 // DepositRequest is the request body for the Deposit action
 type DepositRequest struct {
 	// FromAddr is the address to deposit from
@@ -264,12 +445,79 @@ type ApiHistoricalTask struct { //nolint:revive
 	State string `json:"state"`
 	// CreatedAt is the timestamp when the task was created
 	CreatedAt uint64 `json:"created_at"`
+	// TaskInfo describes the task
+	TaskInfo ApiHistoricalTaskInfo `json:"task_info"`
+}
+
+// ApiHistoricalTaskInfo describes a historical task
+type ApiHistoricalTaskInfo struct { //nolint:revive
+	// TaskType is the type of the task, e.g. "UpdateWallet" or "SettleMatch"
+	TaskType string `json:"task_type"`
+	// UpdateType is the type of a wallet update, e.g. "Deposit" or
+	// "PlaceOrder", empty for other tasks
+	UpdateType string `json:"update_type,omitempty"`
+
+	// --- Transfers and Fees --- //
+
+	// Mint is the token deposited, withdrawn, or paid as a fee
+	Mint string `json:"mint,omitempty"`
+	// Amount is the amount deposited, withdrawn, or paid as a fee
+	Amount *Amount `json:"amount,omitempty"`
+	// IsProtocol is set if a fee was paid to the protocol, rather than the
+	// relayer
+	IsProtocol bool `json:"is_protocol,omitempty"`
+
+	// --- Orders --- //
+
+	// Order is the order placed or cancelled
+	Order *ApiOrder `json:"order,omitempty"`
+
+	// --- Matches --- //
+
+	// Base is the mint of the base token of a settled match
+	Base string `json:"base,omitempty"`
+	// Quote is the mint of the quote token of a settled match
+	Quote string `json:"quote,omitempty"`
+	// IsSell is set if the wallet sold the base token in a settled match
+	IsSell bool `json:"is_sell,omitempty"`
+	// Volume is the amount of the base token traded in a settled match
+	Volume *Amount `json:"volume,omitempty"`
+}
+
+// HasType returns whether the task is of the given task or wallet update type,
+// compared case-insensitively
+func (t *ApiHistoricalTask) HasType(taskType string) bool {
+	return strings.EqualFold(t.TaskInfo.TaskType, taskType) ||
+		(t.TaskInfo.UpdateType != "" && strings.EqualFold(t.TaskInfo.UpdateType, taskType))
 }
 
 // TaskHistoryResponse is the response body for the TaskHistory endpoint
 type TaskHistoryResponse struct {
 	// Tasks is the list of tasks in the queue
 	Tasks []ApiHistoricalTask `json:"tasks"`
+	// NextCursor is the cursor of the next page of tasks, empty on the last
+	// page or if the relayer does not paginate the history
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// -------------------
+// | Admin Endpoints |
+// -------------------
+
+// AdminCreateOrderInMatchingPoolRequest is the request body for the
+// AdminCreateOrderInMatchingPool action. Its response is a CreateOrderResponse
+type AdminCreateOrderInMatchingPoolRequest struct {
+	Order ApiOrder `json:"order"`
+	WalletUpdateAuthorization
+	// MatchingPool is the name of the pool to create the order in
+	MatchingPool string `json:"matching_pool"`
+}
+
+// AdminOrderMatchingPoolResponse is the response body for the
+// AdminOrderMatchingPool action
+type AdminOrderMatchingPoolResponse struct {
+	// MatchingPool is the name of the pool the order is matched in
+	MatchingPool string `json:"matching_pool"`
 }
 
 // ----------------------------
@@ -298,6 +546,8 @@ type ExternalQuoteRequest struct {
 // ExternalQuoteResponse is the response body for the ExternalQuote action
 type ExternalQuoteResponse struct {
 	Quote ApiSignedQuote `json:"signed_quote"`
+	// GasSponsorshipInfo is the gas sponsorship granted to the quote, if any
+	GasSponsorshipInfo *ApiSignedGasSponsorshipInfo `json:"gas_sponsorship_info,omitempty"`
 }
 
 // AssembleExternalQuoteRequest is a request to assemble an external match quote
@@ -311,3 +561,25 @@ type AssembleExternalQuoteRequest struct {
 	// UpdatedOrder is the order to use for the assembly, if different from the quote
 	UpdatedOrder *ApiExternalOrder `json:"updated_order,omitempty"`
 }
+
+// MarketDepthByMintResponse is the response body for the MarketDepthByMint
+// action
+type MarketDepthByMintResponse struct {
+	MarketDepth ApiMarketDepth `json:"market_depth"`
+}
+
+// ExchangeMetadataResponse is the response body for the ExchangeMetadata action
+type ExchangeMetadataResponse struct {
+	// ChainId is the ID of the chain the exchange settles on
+	ChainId uint64 `json:"chain_id"` //nolint:revive
+	// SettlementContractAddress is the address of the darkpool contract that
+	// settles external matches
+	SettlementContractAddress string `json:"settlement_contract_address"`
+	// FeeRecipient is the address the protocol's fees are paid to, empty if
+	// the server does not report it
+	FeeRecipient string `json:"fee_recipient,omitempty"`
+	// GasSponsorAddress is the address of the gas sponsorship contract that
+	// sponsored settlement transactions are sent to, empty if the server does
+	// not report it
+	GasSponsorAddress string `json:"gas_sponsor_address,omitempty"`
+}
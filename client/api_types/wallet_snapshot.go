@@ -0,0 +1,94 @@
+package api_types //nolint:revive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// WalletSnapshotVersion is the version of the wallet snapshot schema written
+// by this SDK
+const WalletSnapshotVersion = 1
+
+// ErrUnsupportedSnapshotVersion is returned when reading a wallet snapshot
+// written in a schema version this SDK does not understand
+var ErrUnsupportedSnapshotVersion = errors.New("unsupported wallet snapshot version")
+
+// WalletSnapshot is a versioned, self-describing export of a wallet's full
+// state, suitable for backups, migrations between hosts, and offline analysis.
+//
+// The wallet is stored in the relayer's API schema, so a snapshot may be read
+// by any tooling that understands the relayer's wallet type
+type WalletSnapshot struct {
+	// The version of the snapshot schema
+	Version int `json:"version"`
+	// The time at which the snapshot was taken
+	ExportedAt time.Time `json:"exported_at"`
+	// Whether the wallet's private keys have been omitted from the snapshot
+	SecretsRedacted bool `json:"secrets_redacted"`
+	// The wallet's state
+	Wallet ApiWallet `json:"wallet"`
+}
+
+// NewWalletSnapshot takes a snapshot of the given wallet, omitting its private
+// keys unless includeSecrets is set
+func NewWalletSnapshot(w *wallet.Wallet, includeSecrets bool) (*WalletSnapshot, error) {
+	apiWallet, err := new(ApiWallet).FromWallet(w)
+	if err != nil {
+		return nil, err
+	}
+
+	if !includeSecrets {
+		apiWallet.KeyChain.PrivateKeys = ApiPrivateKeychain{}
+	}
+
+	return &WalletSnapshot{
+		Version:         WalletSnapshotVersion,
+		ExportedAt:      time.Now().UTC(),
+		SecretsRedacted: !includeSecrets,
+		Wallet:          *apiWallet,
+	}, nil
+}
+
+// ToWallet converts the snapshot back into a wallet. The private keychain of a
+// wallet restored from a redacted snapshot is zero
+func (s *WalletSnapshot) ToWallet() (*wallet.Wallet, error) {
+	apiWallet := s.Wallet
+	if s.SecretsRedacted {
+		if _, err := apiWallet.KeyChain.PrivateKeys.FromPrivateKeychain(&wallet.PrivateKeychain{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return apiWallet.ToWallet()
+}
+
+// WriteWalletSnapshot writes the snapshot to the given writer as JSON
+func WriteWalletSnapshot(w io.Writer, snapshot *WalletSnapshot) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(snapshot); err != nil {
+		return fmt.Errorf("failed to write wallet snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// ReadWalletSnapshot reads a snapshot written by WriteWalletSnapshot from the
+// given reader
+func ReadWalletSnapshot(r io.Reader) (*WalletSnapshot, error) {
+	var snapshot WalletSnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("failed to read wallet snapshot: %w", err)
+	}
+
+	if snapshot.Version != WalletSnapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, snapshot.Version)
+	}
+
+	return &snapshot, nil
+}
@@ -0,0 +1,67 @@
+package api_types //nolint:revive
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+func TestWalletSnapshotRoundTrip(t *testing.T) {
+	key, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	assert.NoError(t, err)
+	originalWallet, err := wallet.NewEmptyWallet(key, 0 /* chainId */)
+	assert.NoError(t, err)
+	order := wallet.NewOrderBuilder().
+		WithBaseMintHex("0x01").
+		WithQuoteMintHex("0x02").
+		WithSide(wallet.Sell).
+		WithAmountBigInt(big.NewInt(100)).
+		WithWorstCasePrice(wallet.FixedPointFromFloat(1.5)).
+		Build()
+	assert.NoError(t, originalWallet.NewOrder(order))
+
+	snapshot, err := NewWalletSnapshot(originalWallet, true /* includeSecrets */)
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	assert.NoError(t, WriteWalletSnapshot(&buf, snapshot))
+
+	readSnapshot, err := ReadWalletSnapshot(&buf)
+	assert.NoError(t, err)
+	recoveredWallet, err := readSnapshot.ToWallet()
+	assert.NoError(t, err)
+	assert.Equal(t, originalWallet, recoveredWallet)
+}
+
+func TestWalletSnapshotRedactsSecrets(t *testing.T) {
+	key, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	assert.NoError(t, err)
+	originalWallet, err := wallet.NewEmptyWallet(key, 0 /* chainId */)
+	assert.NoError(t, err)
+
+	snapshot, err := NewWalletSnapshot(originalWallet, false /* includeSecrets */)
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	assert.NoError(t, WriteWalletSnapshot(&buf, snapshot))
+	assert.NotContains(t, buf.String(), originalWallet.Keychain.PrivateKeys.SkMatch.ToHexString())
+
+	readSnapshot, err := ReadWalletSnapshot(&buf)
+	assert.NoError(t, err)
+	recoveredWallet, err := readSnapshot.ToWallet()
+	assert.NoError(t, err)
+	assert.Equal(t, wallet.PrivateKeychain{}, recoveredWallet.Keychain.PrivateKeys)
+	assert.Equal(t, originalWallet.Keychain.PublicKeys, recoveredWallet.Keychain.PublicKeys)
+	assert.Equal(t, originalWallet.BlindedPublicShares, recoveredWallet.BlindedPublicShares)
+}
+
+func TestWalletSnapshotRejectsUnknownVersion(t *testing.T) {
+	_, err := ReadWalletSnapshot(strings.NewReader(`{"version": 2, "wallet": {}}`))
+	assert.ErrorIs(t, err, ErrUnsupportedSnapshotVersion)
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// StatusError is returned when the server responds with a non-2xx status code
+type StatusError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Body is the raw response body
+	Body []byte
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d, body: %s", e.StatusCode, string(e.Body))
+}
+
+// maxPooledBufferSize is the largest response buffer returned to the pool, so
+// that one large response does not pin its memory for the client's lifetime
+const maxPooledBufferSize = 1 << 20
+
+// bodyBufferPool reuses the buffers response bodies are read into for
+// decoding
+var bodyBufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// DecodeJSON decodes a single JSON value from the given reader into the
+// provided interface. The body is read into a pooled buffer, so that decoding
+// many responses does not allocate a growing read buffer for each.
+//
+// This trades streaming for fewer allocations: the whole body is held in
+// memory while it is decoded, rather than decoded as it is read. The client
+// still bounds that memory, as bodies are read through the MaxResponseSize
+// limit. The body must hold exactly one JSON value; unlike a streaming
+// decode, trailing data after the value is an error
+func DecodeJSON(r io.Reader, response interface{}) error {
+	return decodePooled(r, response, false /* useNumber */)
+}
+
+// DecodeJSONNumbers decodes a single JSON value as DecodeJSON does, decoding
+// numbers into interface values as json.Number to preserve their precision
+func DecodeJSONNumbers(r io.Reader, response interface{}) error {
+	return decodePooled(r, response, true /* useNumber */)
+}
+
+// decodePooled reads a body into a pooled buffer and decodes it
+func decodePooled(r io.Reader, response interface{}, useNumber bool) error {
+	buf := bodyBufferPool.Get().(*bytes.Buffer) //nolint:forcetypeassert
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			buf.Reset()
+			bodyBufferPool.Put(buf)
+		}
+	}()
+
+	if _, err := buf.ReadFrom(r); err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var err error
+	if useNumber {
+		decoder := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+		decoder.UseNumber()
+		err = decoder.Decode(response)
+		// Reject trailing data as json.Unmarshal does
+		if _, tokenErr := decoder.Token(); err == nil && tokenErr != io.EOF {
+			err = errors.New("invalid character after top-level value")
+		}
+	} else {
+		err = json.Unmarshal(buf.Bytes(), response)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decode response body: %w", err)
+	}
+	return nil
+}
+
+// maxBytesReader wraps a reader, returning ErrResponseTooLarge once more than
+// `remaining` bytes have been read
+type maxBytesReader struct {
+	r         io.Reader
+	remaining int64
+	limited   bool
+}
+
+// newMaxBytesReader creates a reader limited to max bytes; a non-positive max
+// disables the limit
+func newMaxBytesReader(r io.Reader, max int64) *maxBytesReader {
+	return &maxBytesReader{r: r, remaining: max, limited: max > 0}
+}
+
+// Read implements io.Reader
+func (m *maxBytesReader) Read(p []byte) (int, error) {
+	if !m.limited {
+		return m.r.Read(p)
+	}
+
+	// Allow reading one byte past the limit so that we can detect overflow
+	if int64(len(p)) > m.remaining+1 {
+		p = p[:m.remaining+1]
+	}
+
+	n, err := m.r.Read(p)
+	if int64(n) > m.remaining {
+		n = int(m.remaining)
+		m.remaining = 0
+		return n, ErrResponseTooLarge
+	}
+
+	m.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the server when the circuit
+// breaker for a request's endpoint class is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitState is the state of a circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed allows requests through and counts consecutive failures
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects requests until the cooldown elapses
+	CircuitOpen
+	// CircuitHalfOpen allows a single probe request through; its outcome
+	// closes or re-opens the circuit
+	CircuitHalfOpen
+)
+
+// String returns the name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerOptions configures the circuit breaker of an HttpClient
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures after which an
+	// endpoint class's circuit opens
+	FailureThreshold int
+	// Cooldown is how long an open circuit rejects requests before allowing a
+	// half-open probe
+	Cooldown time.Duration
+	// OnStateChange, if set, is called whenever an endpoint class's circuit
+	// changes state. It is called synchronously and must not block
+	OnStateChange func(endpointClass string, from, to CircuitState)
+}
+
+// circuitBreaker tracks a circuit per endpoint class
+type circuitBreaker struct {
+	options CircuitBreakerOptions
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// circuit is the state of a single endpoint class's circuit
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	// probing is set while a half-open probe is in flight
+	probing bool
+}
+
+// newCircuitBreaker creates a circuit breaker with the given options
+func newCircuitBreaker(options CircuitBreakerOptions) *circuitBreaker {
+	return &circuitBreaker{
+		options:  options,
+		circuits: make(map[string]*circuit),
+	}
+}
+
+// allow returns whether a request to the endpoint class may proceed
+func (b *circuitBreaker) allow(class string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(class)
+	switch c.state {
+	case CircuitOpen:
+		if time.Since(c.openedAt) < b.options.Cooldown {
+			return false
+		}
+		b.transition(class, c, CircuitHalfOpen)
+		c.probing = true
+		return true
+	case CircuitHalfOpen:
+		if c.probing {
+			return false
+		}
+		c.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record records the outcome of a request to the endpoint class. Requests
+// canceled by the caller say nothing about the server's health and only
+// release the half-open probe slot
+func (b *circuitBreaker) record(class string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(class)
+	c.probing = false
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+
+	if !isCircuitFailure(err) {
+		c.failures = 0
+		if c.state != CircuitClosed {
+			b.transition(class, c, CircuitClosed)
+		}
+		return
+	}
+
+	c.failures++
+	if c.state == CircuitHalfOpen || c.failures >= b.options.FailureThreshold {
+		c.openedAt = time.Now()
+		if c.state != CircuitOpen {
+			b.transition(class, c, CircuitOpen)
+		}
+	}
+}
+
+// state returns the current state of the endpoint class's circuit
+func (b *circuitBreaker) state(class string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.circuit(class).state
+}
+
+// circuit returns the endpoint class's circuit, creating it if needed.
+// Must be called with the lock held
+func (b *circuitBreaker) circuit(class string) *circuit {
+	c, ok := b.circuits[class]
+	if !ok {
+		c = &circuit{state: CircuitClosed}
+		b.circuits[class] = c
+	}
+	return c
+}
+
+// transition moves a circuit to a new state and notifies the hook.
+// Must be called with the lock held
+func (b *circuitBreaker) transition(class string, c *circuit, to CircuitState) {
+	from := c.state
+	c.state = to
+	if b.options.OnStateChange != nil {
+		b.options.OnStateChange(class, from, to)
+	}
+}
+
+// CircuitState returns the state of the circuit breaker for the endpoint
+// class of the given path. Returns CircuitClosed if the breaker is disabled
+func (c *HttpClient) CircuitState(path string) CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	return c.breaker.state(EndpointClass(path))
+}
+
+// EndpointClass returns the circuit breaker endpoint class of a request path,
+// its first two segments without the query, e.g. `/v0/wallet` for
+// `/v0/wallet/{id}/orders`
+func EndpointClass(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if len(segments) > 2 {
+		segments = segments[:2]
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// isCircuitFailure returns whether a request's outcome indicates that the
+// server is unhealthy: the server could not be reached or it returned a 5xx.
+// Client errors do not count
+func isCircuitFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	// Transport failures are reported by the http client as url errors
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
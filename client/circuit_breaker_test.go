@@ -0,0 +1,89 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointClass(t *testing.T) {
+	assert.Equal(t, "/v0/wallet", EndpointClass("/v0/wallet/1234/orders"))
+	assert.Equal(t, "/v0/matching-engine", EndpointClass("/v0/matching-engine/quote"))
+	assert.Equal(t, "/v0/ping", EndpointClass("/v0/ping?x=1"))
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	var transitions []CircuitState
+	options := NewHttpClientOptions().
+		WithCircuitBreaker(2, 50*time.Millisecond).
+		WithCircuitStateHook(func(_ string, _, to CircuitState) {
+			transitions = append(transitions, to)
+		})
+	client := NewHttpClientWithOptions(server.URL, nil /* authKey */, options)
+
+	// Two consecutive failures open the circuit
+	var resp map[string]string
+	for i := 0; i < 2; i++ {
+		err := client.GetJSON("/v0/wallet", nil /* body */, &resp)
+		assert.Error(t, err)
+	}
+	assert.Equal(t, CircuitOpen, client.CircuitState("/v0/wallet/abc"))
+
+	// Requests fail fast while open, without reaching the server
+	err := client.GetJSON("/v0/wallet", nil /* body */, &resp)
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	assert.Equal(t, int32(2), requests.Load())
+
+	// Other endpoint classes are unaffected
+	assert.Equal(t, CircuitClosed, client.CircuitState("/v0/order_book"))
+
+	// After the cooldown, a successful probe closes the circuit
+	time.Sleep(60 * time.Millisecond)
+	healthy.Store(true)
+	err = client.GetJSON("/v0/wallet", nil /* body */, &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, CircuitClosed, client.CircuitState("/v0/wallet"))
+	assert.Equal(t, []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed}, transitions)
+}
+
+func TestCircuitStateHookOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	// The hook is called whether it is set before or after the breaker is enabled
+	for name, options := range map[string]func(hook func(string, CircuitState, CircuitState)) *HttpClientOptions{
+		"hook first": func(hook func(string, CircuitState, CircuitState)) *HttpClientOptions {
+			return NewHttpClientOptions().WithCircuitStateHook(hook).WithCircuitBreaker(1, time.Minute)
+		},
+		"breaker first": func(hook func(string, CircuitState, CircuitState)) *HttpClientOptions {
+			return NewHttpClientOptions().WithCircuitBreaker(1, time.Minute).WithCircuitStateHook(hook)
+		},
+	} {
+		var transitions []CircuitState
+		hook := func(_ string, _, to CircuitState) { transitions = append(transitions, to) }
+		client := NewHttpClientWithOptions(server.URL, nil /* authKey */, options(hook))
+
+		var resp map[string]string
+		assert.Error(t, client.GetJSON("/v0/wallet", nil /* body */, &resp))
+		assert.Equal(t, []CircuitState{CircuitOpen}, transitions, name)
+	}
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// clockSkewTolerance is the skew below which a measured offset is
+// indistinguishable from the one-second resolution of the `Date` header
+const clockSkewTolerance = 2 * time.Second
+
+// ErrClockSkew is returned when the local clock is too far from the server's
+// for request signatures to be accepted, and the skew cannot be compensated
+var ErrClockSkew = errors.New("clock skew between client and server")
+
+// ClockSkew returns the most recently measured offset of the server's clock
+// from the local clock, positive when the server is ahead. The offset is
+// measured from the `Date` header of each response; zero is returned before
+// any response has been received
+func (c *HttpClient) ClockSkew() time.Duration {
+	return time.Duration(c.clockOffset.Load())
+}
+
+// SyncClock measures the server's clock offset with a lightweight request,
+// so that the first signed request is already compensated
+func (c *HttpClient) SyncClock() (time.Duration, error) {
+	if err := c.beginRequest(); err != nil {
+		return 0, err
+	}
+	defer c.endRequest()
+
+	req, err := http.NewRequest(http.MethodHead, c.baseURL, nil /* body */)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create clock sync request: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sync clock: %w", err)
+	}
+	//nolint:errcheck
+	resp.Body.Close()
+
+	if !c.observeServerTime(resp.Header, start, time.Since(start)) {
+		return 0, errors.New("server did not report its time")
+	}
+	return c.ClockSkew(), nil
+}
+
+// now returns the time used to sign requests, corrected by the measured skew
+// when compensation is enabled
+func (c *HttpClient) now() time.Time {
+	if c.options.MaxClockSkew <= 0 {
+		return time.Now()
+	}
+	return time.Now().Add(c.ClockSkew())
+}
+
+// checkClockSkew returns ErrClockSkew if compensation is enabled and the
+// measured skew exceeds the maximum the client will compensate for
+func (c *HttpClient) checkClockSkew() error {
+	maxSkew := c.options.MaxClockSkew
+	if skew := c.ClockSkew(); maxSkew > 0 && absDuration(skew) > maxSkew {
+		return fmt.Errorf("%w: %v exceeds maximum compensation of %v", ErrClockSkew, skew, maxSkew)
+	}
+	return nil
+}
+
+// annotateAuthFailure tags an authentication failure with ErrClockSkew when
+// the measured skew is a likely cause
+func (c *HttpClient) annotateAuthFailure(err error) error {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnauthorized {
+		return err
+	}
+
+	skew := c.ClockSkew()
+	if absDuration(skew) < clockSkewTolerance {
+		return err
+	}
+	return fmt.Errorf("%w of %v: %w", ErrClockSkew, skew, err)
+}
+
+// observeServerTime records the server's clock offset from a response's `Date`
+// header, given when the request was sent and how long it took. Returns false
+// if the response carried no usable date
+func (c *HttpClient) observeServerTime(
+	header http.Header, sent time.Time, elapsed time.Duration,
+) bool {
+	serverTime, err := http.ParseTime(header.Get("Date"))
+	if err != nil {
+		return false
+	}
+
+	// The header is truncated to the second, so assume the server's clock read
+	// the middle of that second, at the midpoint of the round trip
+	serverTime = serverTime.Add(500 * time.Millisecond)
+	localTime := sent.Add(elapsed / 2)
+	c.clockOffset.Store(int64(serverTime.Sub(localTime)))
+	return true
+}
+
+// absDuration returns the absolute value of a duration
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// newSkewedServer creates a server whose clock runs ahead by the given offset,
+// recording the signature expiration of each request
+func newSkewedServer(
+	t *testing.T, offset time.Duration, status int, expirations *[]int64,
+) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if exp := r.Header.Get(expirationHeader); exp != "" {
+			parsed, _ := strconv.ParseInt(exp, 10, 64)
+			*expirations = append(*expirations, parsed)
+		}
+		w.Header().Set("Date", time.Now().Add(offset).UTC().Format(http.TimeFormat))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClockSkewCompensation(t *testing.T) {
+	offset := time.Hour
+	var expirations []int64
+	server := newSkewedServer(t, offset, http.StatusOK, &expirations)
+	options := NewHttpClientOptions().WithClockSkewCompensation(2 * time.Hour)
+	client := NewHttpClientWithOptions(server.URL, &wallet.HmacKey{}, options)
+
+	skew, err := client.SyncClock()
+	assert.NoError(t, err)
+	assert.InDelta(t, offset.Seconds(), skew.Seconds(), 2)
+
+	// The signature expiration is computed from the server's clock
+	var resp map[string]string
+	assert.NoError(t, client.GetWithAuth("/", nil /* body */, &resp))
+	uncompensated := time.Now().Add(signatureExpiration * time.Second).UnixMilli()
+	assert.InDelta(t, uncompensated+offset.Milliseconds(), expirations[0], 3000)
+}
+
+func TestClockSkewBeyondCompensation(t *testing.T) {
+	var expirations []int64
+	server := newSkewedServer(t, time.Hour, http.StatusOK, &expirations)
+	options := NewHttpClientOptions().WithClockSkewCompensation(time.Minute)
+	client := NewHttpClientWithOptions(server.URL, &wallet.HmacKey{}, options)
+
+	_, err := client.SyncClock()
+	assert.NoError(t, err)
+
+	var resp map[string]string
+	err = client.GetWithAuth("/", nil /* body */, &resp)
+	assert.True(t, errors.Is(err, ErrClockSkew))
+	assert.Empty(t, expirations)
+}
+
+func TestClockSkewAuthFailure(t *testing.T) {
+	var expirations []int64
+	server := newSkewedServer(t, -time.Hour, http.StatusUnauthorized, &expirations)
+	client := NewHttpClient(server.URL, &wallet.HmacKey{})
+
+	var resp map[string]string
+	err := client.GetWithAuth("/", nil /* body */, &resp)
+	assert.True(t, errors.Is(err, ErrClockSkew))
+
+	var statusErr *StatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+)
+
+const (
+	acceptHeader = "Accept"
+	// jsonAcceptFallback is appended to the Accept header of requests encoded
+	// with a non-JSON codec, so that servers without support reply in JSON
+	jsonAcceptFallback = contentTypeJSON + ";q=0.5"
+)
+
+// Codec encodes request bodies and decodes response bodies for a wire format.
+//
+// The relayer speaks JSON; a binary codec may be configured for latency
+// sensitive integrations against relayers that support it. Requests carry the
+// codec's content type, responses are decoded by the codec only if the server
+// replies in its content type, and a server that rejects the content type
+// causes the client to fall back to JSON for the rest of its lifetime
+type Codec interface {
+	// ContentType returns the media type of the codec's encoding
+	ContentType() string
+	// Marshal encodes a value
+	Marshal(v interface{}) ([]byte, error)
+	// Decode decodes a single value from the given reader
+	Decode(r io.Reader, v interface{}) error
+}
+
+// JSONCodec is the default codec, encoding bodies as JSON
+type JSONCodec struct {
+	// UseNumber decodes numbers into interface values as json.Number rather
+	// than float64, so that responses decoded into maps keep their exact
+	// digits. Typed fields are unaffected; see api_types.Decimal
+	UseNumber bool
+}
+
+// ContentType returns the JSON media type
+func (JSONCodec) ContentType() string {
+	return contentTypeJSON
+}
+
+// Marshal encodes a value as JSON
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Decode decodes a single JSON value, see DecodeJSON
+func (c JSONCodec) Decode(r io.Reader, v interface{}) error {
+	if c.UseNumber {
+		return DecodeJSONNumbers(r, v)
+	}
+	return DecodeJSON(r, v)
+}
+
+// isJSONCodec returns whether the codec encodes JSON
+func isJSONCodec(codec Codec) bool {
+	return sameMediaType(codec.ContentType(), contentTypeJSON)
+}
+
+// requestCodec returns the codec requests are currently encoded with
+func (c *HttpClient) requestCodec() Codec {
+	if c.options.Codec == nil || c.codecFallback.Load() {
+		return JSONCodec{}
+	}
+	return c.options.Codec
+}
+
+// responseCodec returns the codec matching a response's content type: the
+// request's codec if the server replied in its format, JSON otherwise
+func responseCodec(requestCodec Codec, header http.Header) Codec {
+	if sameMediaType(header.Get(contentTypeHeader), requestCodec.ContentType()) {
+		return requestCodec
+	}
+	return JSONCodec{}
+}
+
+// shouldFallBackToJSON returns whether a request encoded with the given codec
+// failed because the server does not support the codec's format
+func shouldFallBackToJSON(codec Codec, err error) bool {
+	if isJSONCodec(codec) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.StatusCode == http.StatusUnsupportedMediaType ||
+		statusErr.StatusCode == http.StatusNotAcceptable
+}
+
+// acceptValue returns the Accept header sent with requests encoded with the
+// given codec
+func acceptValue(codec Codec) string {
+	if isJSONCodec(codec) {
+		return contentTypeJSON
+	}
+	return codec.ContentType() + ", " + jsonAcceptFallback
+}
+
+// sameMediaType returns whether two content types name the same media type,
+// ignoring parameters such as the charset
+func sameMediaType(a, b string) bool {
+	aType, _, aErr := mime.ParseMediaType(a)
+	bType, _, bErr := mime.ParseMediaType(b)
+	return aErr == nil && bErr == nil && aType == bType
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const contentTypeGob = "application/x-gob"
+
+// gobCodec is a binary codec for testing
+type gobCodec struct{}
+
+func (gobCodec) ContentType() string {
+	return contentTypeGob
+}
+
+func (gobCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(v)
+	return buf.Bytes(), err
+}
+
+func (gobCodec) Decode(r io.Reader, v interface{}) error {
+	return gob.NewDecoder(r).Decode(v)
+}
+
+type codecMessage struct {
+	Value int `json:"value"`
+}
+
+// newCodecServer creates a server that echoes a codecMessage incremented by
+// one, replying in gob if gob is supported and the request is gob encoded
+func newCodecServer(t *testing.T, supportsGob bool, gobRequests *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg codecMessage
+		if r.Header.Get(contentTypeHeader) == contentTypeGob {
+			gobRequests.Add(1)
+			if !supportsGob {
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				return
+			}
+			if err := gob.NewDecoder(r.Body).Decode(&msg); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			msg.Value++
+			w.Header().Set(contentTypeHeader, contentTypeGob)
+			_ = gob.NewEncoder(w).Encode(msg)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		msg.Value++
+		w.Header().Set(contentTypeHeader, contentTypeJSON)
+		_ = json.NewEncoder(w).Encode(msg)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	var gobRequests atomic.Int32
+	server := newCodecServer(t, true /* supportsGob */, &gobRequests)
+	options := NewHttpClientOptions().WithCodec(gobCodec{})
+	client := NewHttpClientWithOptions(server.URL, nil /* authKey */, options)
+
+	var resp codecMessage
+	err := client.PostJSON("/", codecMessage{Value: 41}, &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, resp.Value)
+	assert.Equal(t, int32(1), gobRequests.Load())
+}
+
+func TestCodecFallsBackToJSON(t *testing.T) {
+	var gobRequests atomic.Int32
+	server := newCodecServer(t, false /* supportsGob */, &gobRequests)
+	options := NewHttpClientOptions().WithCodec(gobCodec{})
+	client := NewHttpClientWithOptions(server.URL, nil /* authKey */, options)
+
+	// The rejected request is retried as JSON
+	var resp codecMessage
+	err := client.PostJSON("/", codecMessage{Value: 1}, &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resp.Value)
+
+	// Later requests skip the codec
+	err = client.PostJSON("/", codecMessage{Value: 2}, &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, resp.Value)
+	assert.Equal(t, int32(1), gobRequests.Load())
+}
+
+func TestJSONCodecUseNumber(t *testing.T) {
+	body := `{"price": 0.123456789012345678901}`
+
+	var lossy map[string]interface{}
+	assert.NoError(t, JSONCodec{}.Decode(bytes.NewBufferString(body), &lossy))
+	assert.IsType(t, float64(0), lossy["price"])
+
+	var exact map[string]interface{}
+	assert.NoError(t, JSONCodec{UseNumber: true}.Decode(bytes.NewBufferString(body), &exact))
+	assert.Equal(t, json.Number("0.123456789012345678901"), exact["price"])
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	acceptEncodingHeader  = "Accept-Encoding"
+	contentEncodingHeader = "Content-Encoding"
+	// EncodingGzip is the gzip content encoding
+	EncodingGzip = "gzip"
+	// EncodingDeflate is the deflate (zlib) content encoding
+	EncodingDeflate = "deflate"
+	// encodingIdentity is the content encoding of an uncompressed body
+	encodingIdentity = "identity"
+	// defaultCompressionThreshold is the default size, in bytes, above which
+	// request bodies are compressed when request compression is enabled
+	defaultCompressionThreshold = 1024
+)
+
+// ErrUnsupportedEncoding is returned when a response is compressed with a
+// content encoding the client has no decompressor for
+var ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// Decompressor wraps a compressed response body in a reader over its
+// decompressed contents
+type Decompressor func(r io.Reader) (io.ReadCloser, error)
+
+// builtinDecompressors are the content encodings every client accepts
+var builtinDecompressors = map[string]Decompressor{
+	EncodingGzip: func(r io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(r)
+	},
+	EncodingDeflate: zlib.NewReader,
+}
+
+// acceptEncoding returns the Accept-Encoding header sent with every request:
+// the built in encodings followed by any registered in the options
+func (c *HttpClient) acceptEncoding() string {
+	encodings := []string{EncodingGzip, EncodingDeflate}
+
+	var extra []string
+	for encoding := range c.options.Decompressors {
+		if _, ok := builtinDecompressors[encoding]; !ok {
+			extra = append(extra, encoding)
+		}
+	}
+	sort.Strings(extra)
+	return strings.Join(append(extra, encodings...), ", ")
+}
+
+// decompressor returns the decompressor for a content encoding, preferring
+// one registered in the options over a built in one
+func (c *HttpClient) decompressor(encoding string) (Decompressor, bool) {
+	if d, ok := c.options.Decompressors[encoding]; ok {
+		return d, true
+	}
+	d, ok := builtinDecompressors[encoding]
+	return d, ok
+}
+
+// decompressResponse returns a reader over the decompressed body of a response
+func (c *HttpClient) decompressResponse(resp *http.Response) (io.ReadCloser, error) {
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get(contentEncodingHeader)))
+	if encoding == "" || encoding == encodingIdentity {
+		return resp.Body, nil
+	}
+
+	decompress, ok := c.decompressor(encoding)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, encoding)
+	}
+	body, err := decompress(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s response body: %w", encoding, err)
+	}
+	return body, nil
+}
+
+// compressRequestBody gzips a request body if request compression is enabled
+// and the body exceeds the threshold, returning whether it was compressed
+func (c *HttpClient) compressRequestBody(body []byte) ([]byte, bool, error) {
+	if !c.options.CompressRequests || len(body) < c.options.CompressionThreshold {
+		return body, false, nil
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		return nil, false, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, false, fmt.Errorf("failed to compress request body: %w", err)
+	}
+
+	// Send the body uncompressed if compression does not shrink it
+	if buf.Len() >= len(body) {
+		return body, false, nil
+	}
+	return buf.Bytes(), true, nil
+}
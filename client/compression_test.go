@@ -0,0 +1,171 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// gzipBytes compresses the given bytes with gzip
+func gzipBytes(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		t.Fatalf("failed to compress: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to compress: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestGzipResponseDecompressed(t *testing.T) {
+	body := `{"value":"` + strings.Repeat("a", 4096) + `"}`
+	var acceptEncoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get(acceptEncodingHeader)
+		w.Header().Set(contentEncodingHeader, EncodingGzip)
+		_, _ = w.Write(gzipBytes(t, []byte(body)))
+	}))
+	t.Cleanup(server.Close)
+	client := NewHttpClient(server.URL, nil /* authKey */)
+
+	var resp struct {
+		Value string `json:"value"`
+	}
+	err := client.GetJSON("/", nil /* body */, &resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Value, 4096)
+	assert.Equal(t, "gzip, deflate", acceptEncoding)
+}
+
+func TestDecompressedSizeLimit(t *testing.T) {
+	// The compressed body is well under the limit, the decompressed body is not
+	body := `{"value":"` + strings.Repeat("a", 4096) + `"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(contentEncodingHeader, EncodingGzip)
+		_, _ = w.Write(gzipBytes(t, []byte(body)))
+	}))
+	t.Cleanup(server.Close)
+	options := NewHttpClientOptions().WithMaxResponseSize(1024)
+	client := NewHttpClientWithOptions(server.URL, nil /* authKey */, options)
+
+	var resp struct{}
+	err := client.GetJSON("/", nil /* body */, &resp)
+	assert.True(t, errors.Is(err, ErrResponseTooLarge))
+}
+
+func TestRequestCompression(t *testing.T) {
+	var encodings []string
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := r.Header.Get(contentEncodingHeader)
+		var reader io.Reader = r.Body
+		if encoding == EncodingGzip {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			reader = gz
+		}
+
+		body, _ := io.ReadAll(reader)
+		encodings = append(encodings, encoding)
+		bodies = append(bodies, string(body))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	options := NewHttpClientOptions().WithRequestCompression(256)
+	client := NewHttpClientWithOptions(server.URL, nil /* authKey */, options)
+
+	// Bodies under the threshold are sent uncompressed
+	small := map[string]string{"value": "a"}
+	large := map[string]string{"value": strings.Repeat("a", 1024)}
+	var resp struct{}
+	assert.NoError(t, client.PostJSON("/", small, &resp))
+	assert.NoError(t, client.PostJSON("/", large, &resp))
+
+	assert.Equal(t, []string{"", EncodingGzip}, encodings)
+	assert.Equal(t, `{"value":"a"}`, bodies[0])
+	assert.Equal(t, `{"value":"`+strings.Repeat("a", 1024)+`"}`, bodies[1])
+}
+
+func TestCompressedRequestSignature(t *testing.T) {
+	key := wallet.HmacKey{1, 2, 3}
+	var valid bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server verifies the signature over the body as received
+		received, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, key[:])
+		mac.Write(appendHmacPayload(nil, r.URL.Path, r.Header, received))
+		expected := base64.RawStdEncoding.EncodeToString(mac.Sum(nil))
+		valid = r.Header.Get(contentEncodingHeader) == EncodingGzip &&
+			hmac.Equal([]byte(expected), []byte(r.Header.Get(signatureHeader)))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	options := NewHttpClientOptions().WithRequestCompression(256)
+	client := NewHttpClientWithOptions(server.URL, &key, options)
+
+	large := map[string]string{"value": strings.Repeat("a", 1024)}
+	var resp struct{}
+	assert.NoError(t, client.PostWithAuth("/v0/path", large, &resp))
+	assert.True(t, valid)
+}
+
+func TestCustomDecompressor(t *testing.T) {
+	var acceptEncoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get(acceptEncodingHeader)
+		w.Header().Set(contentEncodingHeader, "reverse")
+		_, _ = w.Write([]byte(`}24:"eulav"{`))
+	}))
+	t.Cleanup(server.Close)
+
+	reverse := func(r io.Reader) (io.ReadCloser, error) {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+			data[i], data[j] = data[j], data[i]
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	options := NewHttpClientOptions().WithDecompressor("reverse", reverse)
+	client := NewHttpClientWithOptions(server.URL, nil /* authKey */, options)
+
+	var resp struct {
+		Value int `json:"value"`
+	}
+	err := client.GetJSON("/", nil /* body */, &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, resp.Value)
+	assert.Equal(t, "reverse, gzip, deflate", acceptEncoding)
+}
+
+func TestUnsupportedEncoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(contentEncodingHeader, "br")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	client := NewHttpClient(server.URL, nil /* authKey */)
+
+	var resp struct{}
+	err := client.GetJSON("/", nil /* body */, &resp)
+	assert.True(t, errors.Is(err, ErrUnsupportedEncoding))
+}
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrorCode identifies a failure mode the relayer reports in an error
+// response, so that callers can switch on it rather than matching the
+// response body
+type ErrorCode string
+
+const (
+	// CodeUnknown is the code of errors that are not relayer error responses,
+	// or whose failure mode is not recognized
+	CodeUnknown ErrorCode = "unknown"
+	// CodeUnauthorized indicates the request's API key or signature was
+	// rejected
+	CodeUnauthorized ErrorCode = "unauthorized"
+	// CodeRateLimited indicates the request exceeded a rate limit
+	CodeRateLimited ErrorCode = "rate_limited"
+	// CodeBundleRateLimited indicates too many assembled bundles are
+	// unsettled for another to be assembled
+	CodeBundleRateLimited ErrorCode = "bundle_rate_limited"
+	// CodeQuoteExpired indicates a quote was assembled after it expired
+	CodeQuoteExpired ErrorCode = "quote_expired"
+	// CodeInvalidSignature indicates a signature in the request body, e.g. a
+	// wallet update's commitment signature, does not verify
+	CodeInvalidSignature ErrorCode = "invalid_signature"
+	// CodeMinFillUnmet indicates an order is smaller than the minimum fill
+	// size
+	CodeMinFillUnmet ErrorCode = "min_fill_unmet"
+	// CodeNoLiquidity indicates the relayer has no liquidity to match an
+	// order
+	CodeNoLiquidity ErrorCode = "no_liquidity"
+	// CodePairUnsupported indicates the relayer does not trade an order's
+	// pair or token
+	CodePairUnsupported ErrorCode = "pair_unsupported"
+	// CodeStaleWallet indicates a wallet update was built on a wallet whose
+	// shares, commitment, or nonce have since been changed by another update
+	CodeStaleWallet ErrorCode = "stale_wallet"
+	// CodeWalletNotFound indicates the relayer does not manage the wallet
+	CodeWalletNotFound ErrorCode = "wallet_not_found"
+	// CodeTaskNotFound indicates the relayer no longer tracks the task
+	CodeTaskNotFound ErrorCode = "task_not_found"
+	// CodeInternal indicates the relayer failed to serve a valid request
+	CodeInternal ErrorCode = "internal"
+)
+
+// errorCodePatterns map fragments of relayer error messages to the code they
+// report. Messages are matched in lowercase, in order, so more specific
+// fragments come first
+var errorCodePatterns = []struct {
+	fragment string
+	code     ErrorCode
+}{
+	{"quote expired", CodeQuoteExpired},
+	{"quote has expired", CodeQuoteExpired},
+	{"expired quote", CodeQuoteExpired},
+	{"unsettled bundle", CodeBundleRateLimited},
+	{"bundle rate limit", CodeBundleRateLimited},
+	{"rate limit", CodeRateLimited},
+	{"too many requests", CodeRateLimited},
+	{"no liquidity", CodeNoLiquidity},
+	{"insufficient liquidity", CodeNoLiquidity},
+	{"min fill", CodeMinFillUnmet},
+	{"minimum fill", CodeMinFillUnmet},
+	{"below the minimum", CodeMinFillUnmet},
+	{"below minimum", CodeMinFillUnmet},
+	{"too small", CodeMinFillUnmet},
+	{"unsupported pair", CodePairUnsupported},
+	{"pair unsupported", CodePairUnsupported},
+	{"pair is not supported", CodePairUnsupported},
+	{"unsupported token", CodePairUnsupported},
+	{"token not supported", CodePairUnsupported},
+	{"task not found", CodeTaskNotFound},
+	{"wallet not found", CodeWalletNotFound},
+	{"invalid signature", CodeInvalidSignature},
+	// Only the relayer's stale wallet rejections; other messages naming a
+	// commitment or nonce, e.g. a bad commitment signature, are not stale
+	// wallets and would fail again on retry
+	{"commitment does not match", CodeStaleWallet},
+	{"shares do not match", CodeStaleWallet},
+}
+
+// knownErrorCodes are the codes a JSON error body may name directly
+var knownErrorCodes = map[ErrorCode]bool{
+	CodeUnauthorized:      true,
+	CodeRateLimited:       true,
+	CodeBundleRateLimited: true,
+	CodeQuoteExpired:      true,
+	CodeInvalidSignature:  true,
+	CodeMinFillUnmet:      true,
+	CodeNoLiquidity:       true,
+	CodePairUnsupported:   true,
+	CodeStaleWallet:       true,
+	CodeWalletNotFound:    true,
+	CodeTaskNotFound:      true,
+	CodeInternal:          true,
+}
+
+// errorBody is a JSON error response, as returned by the relayer's gateway
+type errorBody struct {
+	Code    string `json:"code"`
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// ErrorCodeOf returns the code of the relayer error response the error wraps,
+// or CodeUnknown if it wraps none
+func ErrorCodeOf(err error) ErrorCode {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return CodeUnknown
+	}
+	return statusErr.Code()
+}
+
+// Code classifies the error response by its status code and message.
+// Authentication failures and server errors are classified by status alone;
+// other responses by the failure mode their message names
+func (e *StatusError) Code() ErrorCode {
+	code, message := e.parseBody()
+	if knownErrorCodes[ErrorCode(code)] {
+		return ErrorCode(code)
+	}
+
+	switch {
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return CodeUnauthorized
+	case e.StatusCode >= http.StatusInternalServerError:
+		return CodeInternal
+	}
+
+	lower := strings.ToLower(message)
+	for _, pattern := range errorCodePatterns {
+		if strings.Contains(lower, pattern.fragment) {
+			return pattern.code
+		}
+	}
+	if e.StatusCode == http.StatusTooManyRequests {
+		return CodeRateLimited
+	}
+	return CodeUnknown
+}
+
+// Message returns the relayer's explanation of the error: the message of a
+// JSON error body, or the trimmed body otherwise
+func (e *StatusError) Message() string {
+	_, message := e.parseBody()
+	return message
+}
+
+// parseBody extracts the code and message of the response body. Plain text
+// bodies are their own message
+func (e *StatusError) parseBody() (code, message string) {
+	body := strings.TrimSpace(string(e.Body))
+	if !strings.HasPrefix(body, "{") {
+		return "", body
+	}
+
+	var parsed errorBody
+	if err := json.Unmarshal([]byte(body), &parsed); err != nil {
+		return "", body
+	}
+	switch {
+	case parsed.Message != "":
+		return parsed.Code, parsed.Message
+	case parsed.Error != "":
+		return parsed.Code, parsed.Error
+	default:
+		return parsed.Code, body
+	}
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCodeFromBody(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		code   ErrorCode
+	}{
+		{http.StatusBadRequest, "Quote expired", CodeQuoteExpired},
+		{http.StatusBadRequest, "order size is below the minimum fill size", CodeMinFillUnmet},
+		{http.StatusNotFound, "no liquidity for pair", CodeNoLiquidity},
+		{http.StatusBadRequest, "Unsupported pair: 0x1/0x2", CodePairUnsupported},
+		{http.StatusTooManyRequests, "too many unsettled bundles", CodeBundleRateLimited},
+		{http.StatusTooManyRequests, "", CodeRateLimited},
+		{http.StatusBadRequest, "invalid wallet update: commitment does not match", CodeStaleWallet},
+		{http.StatusBadRequest, "wallet shares do not match", CodeStaleWallet},
+		{http.StatusBadRequest, "invalid commitment signature", CodeUnknown},
+		{http.StatusBadRequest, "invalid nonce format", CodeUnknown},
+		{http.StatusBadRequest, "invalid signature", CodeInvalidSignature},
+		{http.StatusNotFound, "task not found", CodeTaskNotFound},
+		{http.StatusUnauthorized, "invalid signature", CodeUnauthorized},
+		{http.StatusInternalServerError, "quote expired", CodeInternal},
+		{http.StatusBadRequest, "malformed request", CodeUnknown},
+
+		// JSON bodies are classified by their message, or their code if known
+		{http.StatusBadRequest, `{"error": "quote has expired"}`, CodeQuoteExpired},
+		{http.StatusBadRequest, `{"code": "min_fill_unmet", "message": "order too small"}`, CodeMinFillUnmet},
+		{http.StatusBadRequest, `{"code": "E42", "message": "no liquidity"}`, CodeNoLiquidity},
+		{http.StatusBadRequest, `{"code": "stale_wallet", "message": "wallet update rejected"}`, CodeStaleWallet},
+	}
+
+	for _, tc := range cases {
+		err := &StatusError{StatusCode: tc.status, Body: []byte(tc.body)}
+		assert.Equal(t, tc.code, err.Code(), tc.body)
+
+		// The code is found through wrapping errors
+		wrapped := fmt.Errorf("request failed: %w", err)
+		assert.Equal(t, tc.code, ErrorCodeOf(wrapped), tc.body)
+	}
+}
+
+func TestErrorCodeOfOtherErrors(t *testing.T) {
+	assert.Equal(t, CodeUnknown, ErrorCodeOf(nil))
+	assert.Equal(t, CodeUnknown, ErrorCodeOf(fmt.Errorf("dial tcp: connection refused")))
+}
+
+func TestErrorMessage(t *testing.T) {
+	plain := &StatusError{StatusCode: http.StatusBadRequest, Body: []byte(" quote expired\n")}
+	assert.Equal(t, "quote expired", plain.Message())
+
+	structured := &StatusError{StatusCode: http.StatusBadRequest, Body: []byte(`{"error": "quote expired"}`)}
+	assert.Equal(t, "quote expired", structured.Message())
+
+	unrecognized := &StatusError{StatusCode: http.StatusBadRequest, Body: []byte(`{"status": 400}`)}
+	assert.Equal(t, `{"status": 400}`, unrecognized.Message())
+}
@@ -0,0 +1,124 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// ErrMissingScope is returned when no API key of the client is scoped for a
+// request, or the key selected for it is not
+var ErrMissingScope = errors.New("api key is not scoped for the request")
+
+// ApiKeyScope is a set of the requests an API key is used for
+type ApiKeyScope uint8 //nolint:revive
+
+const (
+	// ScopeQuote allows requesting quotes and previewing gas sponsorship
+	ScopeQuote ApiKeyScope = 1 << iota
+	// ScopeAssemble allows assembling quotes and requesting direct matches,
+	// i.e. obtaining settlement transactions
+	ScopeAssemble
+
+	// ScopeAll allows every request
+	ScopeAll = ScopeQuote | ScopeAssemble
+)
+
+// Has returns whether the set includes every scope in other
+func (s ApiKeyScope) Has(other ApiKeyScope) bool {
+	return s&other == other
+}
+
+// String returns the scopes' names joined by a "|"
+func (s ApiKeyScope) String() string {
+	var names []string
+	if s.Has(ScopeQuote) {
+		names = append(names, "quote")
+	}
+	if s.Has(ScopeAssemble) {
+		names = append(names, "assemble")
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+// ScopedApiKey is an API key and secret the client uses for the requests in
+// its scopes
+type ScopedApiKey struct { //nolint:revive
+	// ApiKey is the API key
+	ApiKey string //nolint:revive
+	// ApiSecret is the API secret requests are signed with
+	ApiSecret *wallet.HmacKey //nolint:revive
+	// Scopes are the requests the key is used for
+	Scopes ApiKeyScope
+}
+
+// apiCredential is an API key along with the HTTP client signing its requests
+type apiCredential struct {
+	apiKey     string
+	scopes     ApiKeyScope
+	httpClient *client.HttpClient
+}
+
+// newApiCredentials creates the client's credentials: the primary key first,
+// then the options' additional keys
+func newApiCredentials( //nolint:revive
+	baseURL string, apiKey string, apiSecret *wallet.HmacKey, options *ExternalMatchClientOptions,
+) []*apiCredential {
+	scopes := options.ApiKeyScopes
+	if scopes == 0 {
+		scopes = ScopeAll
+	}
+
+	credentials := []*apiCredential{{
+		apiKey:     apiKey,
+		scopes:     scopes,
+		httpClient: client.NewHttpClientWithOptions(baseURL, apiSecret, options.HttpOptions),
+	}}
+	for _, key := range options.ScopedApiKeys {
+		credentials = append(credentials, &apiCredential{
+			apiKey:     key.ApiKey,
+			scopes:     key.Scopes,
+			httpClient: client.NewHttpClientWithOptions(baseURL, key.ApiSecret, options.HttpOptions),
+		})
+	}
+	return credentials
+}
+
+// selectCredential selects the key a request of the given scope is sent with.
+// A requested key is used if it is scoped for the request; otherwise the
+// least privileged key scoped for it is, preferring keys configured earlier
+func (c *ExternalMatchClient) selectCredential(scope ApiKeyScope, requested string) (*apiCredential, error) {
+	if requested != "" {
+		for _, cred := range c.credentials {
+			if cred.apiKey != requested {
+				continue
+			}
+			if !cred.scopes.Has(scope) {
+				return nil, fmt.Errorf("%w: key has scopes %v, request needs %v", ErrMissingScope, cred.scopes, scope)
+			}
+			return cred, nil
+		}
+		return nil, errors.New("requested api key is not configured on the client")
+	}
+
+	var selected *apiCredential
+	for _, cred := range c.credentials {
+		if !cred.scopes.Has(scope) {
+			continue
+		}
+		if selected == nil || bits.OnesCount8(uint8(cred.scopes)) < bits.OnesCount8(uint8(selected.scopes)) {
+			selected = cred
+		}
+	}
+	if selected == nil {
+		return nil, fmt.Errorf("%w: no key has scope %v", ErrMissingScope, scope)
+	}
+	return selected, nil
+}
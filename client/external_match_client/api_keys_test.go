@@ -0,0 +1,73 @@
+package external_match_client //nolint:revive
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// newScopedTestClient creates a client whose primary key may assemble and
+// whose additional key may only quote, recording the key each path is
+// requested with
+func newScopedTestClient(t *testing.T, keys map[string]string) *ExternalMatchClient {
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.URL.Path] = r.Header.Get(apiKeyHeader)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	options := NewExternalMatchClientOptions().
+		WithApiKeyScopes(ScopeAssemble).
+		WithScopedApiKey("pricing-key", &wallet.HmacKey{}, ScopeQuote)
+	return NewExternalMatchClientWithOptions(server.URL, server.URL, "execution-key", &wallet.HmacKey{}, options)
+}
+
+func TestScopedApiKeySelection(t *testing.T) {
+	keys := make(map[string]string)
+	c := newScopedTestClient(t, keys)
+
+	// Each request is sent with the key scoped for it
+	_, err := c.GetExternalMatchQuote(testOrder(t))
+	assert.NoError(t, err)
+	assert.Equal(t, "pricing-key", keys[api_types.GetExternalMatchQuotePath])
+
+	_, err = c.AssembleExternalQuote(&api_types.ApiSignedQuote{})
+	assert.NoError(t, err)
+	assert.Equal(t, "execution-key", keys[api_types.AssembleExternalQuotePath])
+
+	_, err = c.GetExternalMatchBundle(testOrder(t))
+	assert.NoError(t, err)
+	assert.Equal(t, "execution-key", keys[api_types.GetExternalMatchBundlePath])
+}
+
+func TestScopedApiKeyRejectsOutOfScopeRequests(t *testing.T) {
+	keys := make(map[string]string)
+	c := newScopedTestClient(t, keys)
+
+	// A key selected for a request outside its scopes is refused locally
+	options := NewAssembleExternalMatchOptions().WithApiKey("pricing-key")
+	_, err := c.AssembleExternalMatchWithOptions(&api_types.ApiSignedQuote{}, options)
+	assert.ErrorIs(t, err, ErrMissingScope)
+	_, err = c.GetExternalMatchQuoteWithOptions(testOrder(t), NewExternalQuoteOptions().WithApiKey("unknown"))
+	assert.Error(t, err)
+	assert.Empty(t, keys)
+
+	// A quote-only client cannot assemble at all
+	quoteOnly := NewExternalMatchClientWithOptions(
+		"http://localhost:1", "http://localhost:1", "pricing-key", &wallet.HmacKey{},
+		NewExternalMatchClientOptions().WithApiKeyScopes(ScopeQuote),
+	)
+	_, err = quoteOnly.GetExternalMatchBundle(testOrder(t))
+	assert.ErrorIs(t, err, ErrMissingScope)
+	assert.Equal(t, "quote", ScopeQuote.String())
+	assert.Equal(t, "quote|assemble", ScopeAll.String())
+}
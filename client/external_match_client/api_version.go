@@ -0,0 +1,103 @@
+package external_match_client //nolint:revive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// ApiVersion is a version of the external match API
+type ApiVersion int //nolint:revive
+
+const (
+	// ApiVersionAuto negotiates the version by probing the server
+	ApiVersionAuto ApiVersion = iota //nolint:revive
+	// ApiVersionV0 is the v0 API, served by every server
+	ApiVersionV0 //nolint:revive
+	// ApiVersionV2 is the v2 API
+	ApiVersionV2 //nolint:revive
+)
+
+// ErrUnsupportedApiVersion is returned when a method requires a newer API
+// version than the server supports
+var ErrUnsupportedApiVersion = errors.New("not supported by the server's API version") //nolint:revive
+
+// String returns the name of the version
+func (v ApiVersion) String() string {
+	switch v {
+	case ApiVersionAuto:
+		return "auto"
+	case ApiVersionV0:
+		return "v0"
+	case ApiVersionV2:
+		return "v2"
+	default:
+		return "unknown"
+	}
+}
+
+// ApiVersion returns the API version the client uses with the server.
+//
+// Unless the version is pinned with WithApiVersion, the first call probes the
+// server's v2 exchange metadata endpoint: a successful response selects v2
+// and a 404 falls back to v0. The result is cached; a probe that fails for
+// any other reason is returned as an error and retried on the next call
+func (c *ExternalMatchClient) ApiVersion() (ApiVersion, error) { //nolint:revive
+	if c.pinnedVersion != ApiVersionAuto {
+		return c.pinnedVersion, nil
+	}
+	if c.sandbox != nil {
+		return ApiVersionV0, nil
+	}
+
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+	if c.negotiatedVersion != ApiVersionAuto {
+		return c.negotiatedVersion, nil
+	}
+
+	version, err := c.probeApiVersion()
+	if err != nil {
+		return ApiVersionAuto, err
+	}
+
+	c.negotiatedVersion = version
+	return version, nil
+}
+
+// requireApiVersion returns ErrUnsupportedApiVersion if the negotiated API
+// version is older than the given version
+func (c *ExternalMatchClient) requireApiVersion(min ApiVersion) error {
+	version, err := c.ApiVersion()
+	if err != nil {
+		return err
+	}
+	if version < min {
+		return fmt.Errorf("%w: requires %v, server supports %v", ErrUnsupportedApiVersion, min, version)
+	}
+	return nil
+}
+
+// probeApiVersion probes the server for v2 support
+func (c *ExternalMatchClient) probeApiVersion() (ApiVersion, error) { //nolint:revive
+	headers := make(http.Header)
+	headers.Set(apiKeyHeader, c.apiKey)
+
+	var metadata json.RawMessage
+	err := c.httpClient.GetWithAuthAndHeaders(
+		api_types.ExchangeMetadataPath, &headers, nil /* body */, &metadata,
+	)
+	if err == nil {
+		return ApiVersionV2, nil
+	}
+
+	var statusErr *client.StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return ApiVersionV0, nil
+	}
+	return ApiVersionAuto, fmt.Errorf("failed to negotiate API version: %w", err)
+}
@@ -0,0 +1,66 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+func TestApiVersionNegotiation(t *testing.T) {
+	probes := 0
+	status := http.StatusInternalServerError
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api_types.ExchangeMetadataPath {
+			probes++
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	// A failed probe is not cached
+	_, err := client.ApiVersion()
+	assert.Error(t, err)
+
+	// A v0-only server returns 404 for the v2 metadata endpoint
+	status = http.StatusNotFound
+	version, err := client.ApiVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, ApiVersionV0, version)
+	assert.True(t, errors.Is(client.requireApiVersion(ApiVersionV2), ErrUnsupportedApiVersion))
+
+	// The negotiated version is cached
+	status = http.StatusOK
+	version, err = client.ApiVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, ApiVersionV0, version)
+	assert.Equal(t, 2, probes)
+
+	v2Client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+	version, err = v2Client.ApiVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, ApiVersionV2, version)
+}
+
+func TestApiVersionPinned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("pinned client should not probe the server")
+	}))
+	t.Cleanup(server.Close)
+
+	options := NewExternalMatchClientOptions().WithApiVersion(ApiVersionV2)
+	client := NewExternalMatchClientWithOptions(
+		server.URL, server.URL, "test-key", &wallet.HmacKey{}, options,
+	)
+
+	version, err := client.ApiVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, ApiVersionV2, version)
+}
@@ -0,0 +1,120 @@
+package external_match_client //nolint:revive
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// assemblyCache is an in-memory cache of assembled bundles, keyed by the
+// signed quote and assembly options they were assembled from
+type assemblyCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]assemblyCacheEntry
+}
+
+// assemblyCacheEntry is a cached bundle and the time after which it is stale
+type assemblyCacheEntry struct {
+	bundle *ExternalMatchBundle
+	expiry time.Time
+}
+
+// newAssemblyCache creates an assembly cache whose entries live for the given TTL
+func newAssemblyCache(ttl time.Duration) *assemblyCache {
+	return &assemblyCache{
+		ttl:     ttl,
+		entries: make(map[string]assemblyCacheEntry),
+	}
+}
+
+// get returns the cached bundle for the key, if one exists and is not stale
+func (c *assemblyCache) get(key string) (*ExternalMatchBundle, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !time.Now().Before(entry.expiry) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return entry.bundle.clone(), true
+}
+
+// put caches the bundle under the key, evicting any stale entries
+func (c *assemblyCache) put(key string, bundle *ExternalMatchBundle) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if !now.Before(entry.expiry) {
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = assemblyCacheEntry{bundle: bundle.clone(), expiry: now.Add(c.ttl)}
+}
+
+// clone deep copies the bundle, so that a cached bundle is never shared with
+// a caller that may modify it, e.g. when preparing a native ETH sell
+func (b *ExternalMatchBundle) clone() *ExternalMatchBundle {
+	out := *b
+	if b.MatchResult != nil {
+		matchResult := *b.MatchResult
+		matchResult.QuoteAmount = b.MatchResult.QuoteAmount.Clone()
+		matchResult.BaseAmount = b.MatchResult.BaseAmount.Clone()
+		out.MatchResult = &matchResult
+	}
+	if b.Fees != nil {
+		out.Fees = &api_types.ApiFee{
+			RelayerFee:  b.Fees.RelayerFee.Clone(),
+			ProtocolFee: b.Fees.ProtocolFee.Clone(),
+		}
+	}
+	out.Receive = cloneTransfer(b.Receive)
+	out.Send = cloneTransfer(b.Send)
+	if b.SettlementTx != nil {
+		tx := *b.SettlementTx
+		tx.Data = bytes.Clone(b.SettlementTx.Data)
+		if b.SettlementTx.Value != nil {
+			tx.Value = new(big.Int).Set(b.SettlementTx.Value)
+		}
+		out.SettlementTx = &tx
+	}
+
+	return &out
+}
+
+// cloneTransfer deep copies an asset transfer, which may be nil
+func cloneTransfer(t *api_types.ApiExternalAssetTransfer) *api_types.ApiExternalAssetTransfer {
+	if t == nil {
+		return nil
+	}
+	return &api_types.ApiExternalAssetTransfer{Mint: t.Mint, Amount: t.Amount.Clone()}
+}
+
+// assemblyCacheKey derives the cache key for an assembly request. The key
+// covers the quote signature as well as the assembly options, since the same
+// quote assembled with a different receiver or order yields a different bundle
+func assemblyCacheKey(req *api_types.AssembleExternalQuoteRequest) (string, error) {
+	encoded, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	digest := sha256.Sum256(encoded)
+	return req.Quote.Signature + ":" + hex.EncodeToString(digest[:]), nil
+}
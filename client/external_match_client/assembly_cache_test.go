@@ -0,0 +1,62 @@
+package external_match_client //nolint:revive
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+func TestAssemblyCache(t *testing.T) {
+	var assemblies atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		assemblies.Add(1)
+		_, _ = w.Write([]byte(`{"match_bundle":{"settlement_tx":{"to":"0x01","data":"0x","value":"0x0"}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	options := NewExternalMatchClientOptions().WithAssemblyCache(time.Minute)
+	client := NewExternalMatchClientWithOptions(
+		server.URL, server.URL, "test-key", &wallet.HmacKey{}, options,
+	)
+	quote := &api_types.ApiSignedQuote{Signature: "sig"}
+
+	// A repeated assembly reuses the cached bundle
+	first, err := client.AssembleExternalQuote(quote)
+	assert.NoError(t, err)
+	second, err := client.AssembleExternalQuote(quote)
+	assert.NoError(t, err)
+	assert.Equal(t, first.RequestID, second.RequestID)
+	assert.Equal(t, "sig", first.QuoteSignature)
+	assert.Equal(t, int32(1), assemblies.Load())
+
+	// Each caller receives its own copy, which it may modify freely
+	assert.NotSame(t, first, second)
+	first.SettlementTx.Value.SetInt64(100)
+	first.SettlementTx.Data = append(first.SettlementTx.Data[:0], 0xff)
+	third, err := client.AssembleExternalQuote(quote)
+	assert.NoError(t, err)
+	assert.Zero(t, third.SettlementTx.Value.Sign())
+	assert.Empty(t, third.SettlementTx.Data)
+
+	// Different assembly options are cached separately
+	receiver := "0x0000000000000000000000000000000000000002"
+	_, err = client.AssembleExternalQuoteWithReceiver(quote, &receiver)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), assemblies.Load())
+}
+
+func TestAssemblyCacheExpiry(t *testing.T) {
+	cache := newAssemblyCache(time.Millisecond)
+	cache.put("key", &ExternalMatchBundle{})
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok := cache.get("key")
+	assert.False(t, ok)
+}
@@ -0,0 +1,110 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+)
+
+// Multicall3Address is the address of the Multicall3 contract, deployed at the
+// same address on Arbitrum One and Arbitrum Sepolia
+var Multicall3Address = geth_common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// multicall3ABI is the subset of the Multicall3 ABI used to batch settlements
+const multicall3ABI = `[{
+	"type": "function",
+	"name": "aggregate3Value",
+	"stateMutability": "payable",
+	"inputs": [{
+		"name": "calls",
+		"type": "tuple[]",
+		"components": [
+			{"name": "target", "type": "address"},
+			{"name": "allowFailure", "type": "bool"},
+			{"name": "value", "type": "uint256"},
+			{"name": "callData", "type": "bytes"}
+		]
+	}],
+	"outputs": [{
+		"name": "returnData",
+		"type": "tuple[]",
+		"components": [
+			{"name": "success", "type": "bool"},
+			{"name": "returnData", "type": "bytes"}
+		]
+	}]
+}]`
+
+// multicall3 is the parsed Multicall3 ABI
+var multicall3 = mustParseABI(multicall3ABI)
+
+// multicallCall is a single call in an aggregate3Value batch
+type multicallCall struct {
+	Target       geth_common.Address
+	AllowFailure bool
+	Value        *big.Int
+	CallData     []byte
+}
+
+// BuildBatchSettlementTx aggregates the settlement transactions of several
+// bundles into a single aggregate3Value call on a Multicall3-compatible
+// contract, so that they settle atomically in one transaction.
+//
+// The darkpool sees the multicall contract as the sender of each settlement,
+// so the contract must hold and approve the tokens sold, e.g. a smart account
+// exposing aggregate3Value, and the bundles should be assembled with a
+// receiver. Use the public Multicall3Address only where the darkpool or gas
+// sponsor allows it
+func BuildBatchSettlementTx(
+	multicall geth_common.Address, bundles []*ExternalMatchBundle,
+) (*SettlementTransaction, error) {
+	if len(bundles) == 0 {
+		return nil, errors.New("no bundles to settle")
+	}
+
+	calls := make([]multicallCall, len(bundles))
+	totalValue := new(big.Int)
+	var totalGas uint64
+	allEstimated := true
+	for i, bundle := range bundles {
+		if bundle.Sandbox {
+			return nil, fmt.Errorf("bundle %d: %w", i, ErrSandboxSettlement)
+		}
+		if bundle.SettlementTx == nil {
+			return nil, fmt.Errorf("bundle %d has no settlement transaction", i)
+		}
+
+		value := new(big.Int)
+		if bundle.SettlementTx.Value != nil {
+			value.Set(bundle.SettlementTx.Value)
+		}
+		totalValue.Add(totalValue, value)
+		totalGas += bundle.SettlementTx.Gas
+		allEstimated = allEstimated && bundle.SettlementTx.Gas > 0
+
+		calls[i] = multicallCall{
+			Target:       bundle.SettlementTx.To,
+			AllowFailure: false,
+			Value:        value,
+			CallData:     bundle.SettlementTx.Data,
+		}
+	}
+
+	data, err := multicall3.Pack("aggregate3Value", calls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode batch settlement: %w", err)
+	}
+	if !allEstimated {
+		totalGas = 0
+	}
+
+	return &SettlementTransaction{
+		Type:  bundles[0].SettlementTx.Type,
+		To:    multicall,
+		Data:  data,
+		Value: totalValue,
+		Gas:   totalGas,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package external_match_client //nolint:revive
+
+import (
+	"math/big"
+	"testing"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSettlementBundle builds a bundle settling with the given calldata and value
+func testSettlementBundle(data []byte, value int64) *ExternalMatchBundle {
+	return &ExternalMatchBundle{SettlementTx: &SettlementTransaction{
+		Type:  "eip1559",
+		To:    geth_common.HexToAddress("0x9af58f1ff20ab22e819e40b57ffd784d115a9ef5"),
+		Data:  data,
+		Value: big.NewInt(value),
+	}}
+}
+
+func TestBuildBatchSettlementTx(t *testing.T) {
+	bundles := []*ExternalMatchBundle{
+		testSettlementBundle([]byte{0x01}, 0),
+		testSettlementBundle([]byte{0x02, 0x03}, 5),
+	}
+
+	tx, err := BuildBatchSettlementTx(Multicall3Address, bundles)
+	assert.NoError(t, err)
+	assert.Equal(t, Multicall3Address, tx.To)
+	assert.Equal(t, int64(5), tx.Value.Int64())
+	assert.Zero(t, tx.Gas)
+
+	// The calls decode back to the bundles' settlement transactions
+	method := multicall3.Methods["aggregate3Value"]
+	assert.Equal(t, method.ID, tx.Data[:4])
+	args, err := method.Inputs.Unpack(tx.Data[4:])
+	assert.NoError(t, err)
+
+	var calls []multicallCall
+	assert.NoError(t, method.Inputs.Copy(&calls, args))
+	assert.Len(t, calls, 2)
+	assert.Equal(t, []byte{0x02, 0x03}, calls[1].CallData)
+	assert.Equal(t, int64(5), calls[1].Value.Int64())
+	assert.False(t, calls[1].AllowFailure)
+
+	// The batch's estimate is the sum of the bundles' estimates
+	bundles[0].SettlementTx.Gas, bundles[1].SettlementTx.Gas = 400_000, 300_000
+	tx, err = BuildBatchSettlementTx(Multicall3Address, bundles)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(700_000), tx.Gas)
+
+	_, err = BuildBatchSettlementTx(Multicall3Address, nil)
+	assert.Error(t, err)
+
+	bundles[0].Sandbox = true
+	_, err = BuildBatchSettlementTx(Multicall3Address, bundles)
+	assert.ErrorIs(t, err, ErrSandboxSettlement)
+}
@@ -0,0 +1,154 @@
+package external_match_client //nolint:revive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Candle is the open, high, low, and close price of a token over an interval,
+// with the volume traded in it
+type Candle struct {
+	// Start is the start of the candle's interval
+	Start time.Time
+	Open  float64
+	High  float64
+	Low   float64
+	Close float64
+	// Volume is the amount of the base token traded in the interval, in its
+	// smallest denomination
+	Volume *big.Int
+	// Samples is the number of prices aggregated into the candle
+	Samples int
+}
+
+// CandleSeries is a time ordered series of candles of a fixed interval.
+// Intervals without any price are omitted
+type CandleSeries struct {
+	// Mint is the erc20 address of the token
+	Mint string
+	// Interval is the duration of each candle
+	Interval time.Duration
+	// Candles are the candles, ordered by start time
+	Candles []Candle
+}
+
+// CandleBuilder aggregates timestamped prices and trades into candles. It is
+// safe for concurrent use, so prices may be recorded from a background
+// routine, see RecordPrices
+type CandleBuilder struct {
+	mint     string
+	interval time.Duration
+
+	// mu guards the candles
+	mu sync.Mutex
+	// candles are the candles built so far, keyed by their start time in
+	// nanoseconds
+	candles map[int64]*candleState
+}
+
+// candleState is a candle being built, with the times of its open and close
+// prices so that prices may arrive out of order
+type candleState struct {
+	Candle
+	openAt  time.Time
+	closeAt time.Time
+}
+
+// NewCandleBuilder creates a builder aggregating prices of the given mint into
+// candles of the given interval
+func NewCandleBuilder(mint string, interval time.Duration) (*CandleBuilder, error) {
+	if interval <= 0 {
+		return nil, errors.New("candle interval must be positive")
+	}
+
+	return &CandleBuilder{
+		mint:     mint,
+		interval: interval,
+		candles:  make(map[int64]*candleState),
+	}, nil
+}
+
+// AddPrice records a price sampled at the given time
+func (b *CandleBuilder) AddPrice(t time.Time, price float64) {
+	b.AddTrade(t, price, nil /* volume */)
+}
+
+// AddTrade records a trade of the given base token volume at the given price
+// and time. Prices may be recorded out of order
+func (b *CandleBuilder) AddTrade(t time.Time, price float64, volume *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	start := t.Truncate(b.interval)
+	candle, ok := b.candles[start.UnixNano()]
+	if !ok {
+		candle = &candleState{
+			Candle:  Candle{Start: start, Open: price, High: price, Low: price, Close: price, Volume: new(big.Int)},
+			openAt:  t,
+			closeAt: t,
+		}
+		b.candles[start.UnixNano()] = candle
+	}
+
+	candle.High = max(candle.High, price)
+	candle.Low = min(candle.Low, price)
+	if t.Before(candle.openAt) {
+		candle.Open, candle.openAt = price, t
+	}
+	if !t.Before(candle.closeAt) {
+		candle.Close, candle.closeAt = price, t
+	}
+	candle.Samples++
+	if volume != nil {
+		candle.Volume.Add(candle.Volume, volume)
+	}
+}
+
+// Series returns the candles built so far, ordered by start time
+func (b *CandleBuilder) Series() *CandleSeries {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	candles := make([]Candle, 0, len(b.candles))
+	for _, candle := range b.candles {
+		c := candle.Candle
+		c.Volume = new(big.Int).Set(candle.Volume)
+		candles = append(candles, c)
+	}
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Start.Before(candles[j].Start)
+	})
+
+	return &CandleSeries{Mint: b.mint, Interval: b.interval, Candles: candles}
+}
+
+// RecordPrices samples a token's price from the relayer's price feed every
+// pollInterval and records it in the builder, until the context is done. It
+// returns the context's error; failed samples are skipped
+func (c *ExternalMatchClient) RecordPrices(
+	ctx context.Context, builder *CandleBuilder, pollInterval time.Duration,
+) error {
+	if pollInterval <= 0 {
+		return fmt.Errorf("price poll interval must be positive, got %s", pollInterval)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if depth, err := c.GetMarketDepth(builder.mint); err == nil && depth.Price > 0 {
+			builder.AddPrice(time.UnixMilli(int64(depth.Timestamp)), depth.Price) //nolint:gosec
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
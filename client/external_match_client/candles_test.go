@@ -0,0 +1,56 @@
+package external_match_client //nolint:revive
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCandleBuilder(t *testing.T) {
+	builder, err := NewCandleBuilder("0x1", time.Minute)
+	assert.NoError(t, err)
+
+	start := time.Unix(1_700_000_040, 0)
+	// Prices arrive out of order within the first candle
+	builder.AddPrice(start.Add(30*time.Second), 12)
+	builder.AddTrade(start.Add(10*time.Second), 10, big.NewInt(5))
+	builder.AddPrice(start.Add(20*time.Second), 15)
+	builder.AddTrade(start.Add(40*time.Second), 9, big.NewInt(7))
+	// The second candle is skipped, the third has a single price
+	builder.AddPrice(start.Add(150*time.Second), 20)
+
+	series := builder.Series()
+	assert.Equal(t, "0x1", series.Mint)
+	assert.Len(t, series.Candles, 2)
+
+	first := series.Candles[0]
+	assert.Equal(t, start, first.Start)
+	assert.Equal(t, 10.0, first.Open)
+	assert.Equal(t, 15.0, first.High)
+	assert.Equal(t, 9.0, first.Low)
+	assert.Equal(t, 9.0, first.Close)
+	assert.Equal(t, int64(12), first.Volume.Int64())
+	assert.Equal(t, 4, first.Samples)
+
+	second := series.Candles[1]
+	assert.Equal(t, start.Add(2*time.Minute), second.Start)
+	assert.Equal(t, 20.0, second.Open)
+	assert.Equal(t, 20.0, second.Close)
+	assert.Zero(t, second.Volume.Sign())
+
+	_, err = NewCandleBuilder("0x1", 0)
+	assert.Error(t, err)
+}
+
+func TestRecordPricesRequiresPositiveInterval(t *testing.T) {
+	builder, err := NewCandleBuilder("0x1", time.Minute)
+	assert.NoError(t, err)
+
+	// A non-positive interval is refused rather than panicking in the ticker
+	c := &ExternalMatchClient{}
+	assert.ErrorContains(t, c.RecordPrices(context.Background(), builder, 0), "must be positive")
+	assert.ErrorContains(t, c.RecordPrices(context.Background(), builder, -time.Second), "must be positive")
+}
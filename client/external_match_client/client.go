@@ -1,10 +1,14 @@
 package external_match_client //nolint:revive
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
+	"slices"
+	"sync"
+	"time"
 
 	geth_common "github.com/ethereum/go-ethereum/common"
 
@@ -22,6 +26,10 @@ const (
 	apiKeyHeader          = "X-Renegade-Api-Key" //nolint:gosec
 )
 
+// ErrLatencyBudgetExceeded is returned when the relayer does not respond to a
+// quote request within the latency budget set on the quote options
+var ErrLatencyBudgetExceeded = errors.New("quote latency budget exceeded")
+
 // ExternalMatchBundle is the application level analog to the ApiExternalMatchBundle
 type ExternalMatchBundle struct {
 	MatchResult  *api_types.ApiExternalMatchResult
@@ -29,6 +37,15 @@ type ExternalMatchBundle struct {
 	Receive      *api_types.ApiExternalAssetTransfer
 	Send         *api_types.ApiExternalAssetTransfer
 	SettlementTx *SettlementTransaction
+	// RequestID is the correlation ID of the request that produced the bundle,
+	// useful when reporting issues to the relayer operator
+	RequestID string
+	// QuoteSignature is the signature of the quote the bundle was assembled
+	// from, empty for bundles requested directly
+	QuoteSignature string
+	// Sandbox is set on bundles fabricated by a sandbox client, whose
+	// settlement transactions are not valid and must not be submitted
+	Sandbox bool
 }
 
 // SettlementTransaction is the application level analog to the ApiSettlementTransaction
@@ -37,6 +54,22 @@ type SettlementTransaction struct {
 	To    geth_common.Address
 	Data  []byte
 	Value *big.Int
+	// Gas is the relayer's gas estimate for the transaction, zero if gas
+	// estimation was not requested or the estimate could not be parsed
+	Gas uint64
+}
+
+// toExternalMatchBundle converts an ApiExternalMatchBundle received for the
+// given request to an ExternalMatchBundle
+func toExternalMatchBundle(b *api_types.ApiExternalMatchBundle, requestID string) *ExternalMatchBundle {
+	return &ExternalMatchBundle{
+		MatchResult:  &b.MatchResult,
+		Fees:         &b.Fees,
+		Receive:      &b.Receive,
+		Send:         &b.Send,
+		SettlementTx: toSettlementTransaction(&b.SettlementTx),
+		RequestID:    requestID,
+	}
 }
 
 // toSettlementTransaction converts an ApiSettlementTransaction to a SettlementTransaction
@@ -52,7 +85,84 @@ func toSettlementTransaction(tx *api_types.ApiSettlementTransaction) *Settlement
 		To:    to,
 		Data:  data,
 		Value: value,
+		Gas:   parseGasEstimate(tx.Gas),
+	}
+}
+
+// parseGasEstimate parses a gas quantity given in hex or decimal, returning
+// zero if it is empty or malformed
+func parseGasEstimate(gas string) uint64 {
+	if gas == "" {
+		return 0
+	}
+
+	estimate, ok := new(big.Int).SetString(gas, 0)
+	if !ok || estimate.Sign() < 0 || !estimate.IsUint64() {
+		return 0
 	}
+	return estimate.Uint64()
+}
+
+// ExternalQuoteOptions represents the options for a quote request
+type ExternalQuoteOptions struct {
+	// LatencyBudget is the maximum time to wait for the relayer to respond
+	// with a quote; a value of zero waits indefinitely
+	LatencyBudget time.Duration
+	// ApiKey, if set, selects the client's API key the quote is requested
+	// with; otherwise the least privileged key with ScopeQuote is used
+	ApiKey string //nolint:revive
+	// NoMatchError, if set, returns a NoMatchError rather than a nil quote
+	// when no match is found
+	NoMatchError bool
+	// RefundAddress, if set, receives the quote's gas sponsorship refund in
+	// place of the address that settles the match
+	RefundAddress *string
+	// StrategyTag, if set, names the registered strategy whose default refund
+	// address is used when RefundAddress is unset
+	StrategyTag string
+}
+
+// NewExternalQuoteOptions creates a new ExternalQuoteOptions with default values
+func NewExternalQuoteOptions() *ExternalQuoteOptions {
+	return &ExternalQuoteOptions{
+		LatencyBudget: 0,
+	}
+}
+
+// WithLatencyBudget sets the latency budget for the quote request. If the
+// relayer does not respond within the budget, the request is canceled and
+// ErrLatencyBudgetExceeded is returned
+func (o *ExternalQuoteOptions) WithLatencyBudget(budget time.Duration) *ExternalQuoteOptions {
+	o.LatencyBudget = budget
+	return o
+}
+
+// WithApiKey selects the client's API key the quote is requested with, see
+// ExternalMatchClientOptions.WithScopedApiKey
+func (o *ExternalQuoteOptions) WithApiKey(apiKey string) *ExternalQuoteOptions { //nolint:revive
+	o.ApiKey = apiKey
+	return o
+}
+
+// WithNoMatchError sets whether a NoMatchError is returned rather than a nil
+// quote when no match is found
+func (o *ExternalQuoteOptions) WithNoMatchError(enabled bool) *ExternalQuoteOptions {
+	o.NoMatchError = enabled
+	return o
+}
+
+// WithRefundAddress sets the address the quote's gas sponsorship refund is
+// paid to
+func (o *ExternalQuoteOptions) WithRefundAddress(address *string) *ExternalQuoteOptions {
+	o.RefundAddress = address
+	return o
+}
+
+// WithStrategyTag uses the default addresses of the strategy registered
+// under the tag, see ExternalMatchClient.SetStrategy
+func (o *ExternalQuoteOptions) WithStrategyTag(tag string) *ExternalQuoteOptions {
+	o.StrategyTag = tag
+	return o
 }
 
 // AssembleExternalMatchOptions represents the options for an assembly request
@@ -60,10 +170,26 @@ type AssembleExternalMatchOptions struct {
 	ReceiverAddress *string
 	DoGasEstimation bool
 	UpdatedOrder    *api_types.ApiExternalOrder
+	// ApiKey, if set, selects the client's API key the match is assembled
+	// with; otherwise the least privileged key with ScopeAssemble is used
+	ApiKey string //nolint:revive
+	// NoMatchError, if set, returns a NoMatchError rather than a nil bundle
+	// when no match is found
+	NoMatchError bool
+	// StrategyTag, if set, names the registered strategy whose default
+	// receiver address is used when ReceiverAddress is unset
+	StrategyTag string
 }
 
-// WithReceiverAddress sets the receiver address for the assembly options
+// WithReceiverAddress sets the receiver address for the assembly options. A
+// valid address is stored in its checksummed form; an invalid one is stored
+// as is and fails Validate and any request made with the options
 func (o *AssembleExternalMatchOptions) WithReceiverAddress(address *string) *AssembleExternalMatchOptions {
+	if address != nil {
+		if normalized, err := api_types.NormalizeAddress(*address); err == nil {
+			address = &normalized
+		}
+	}
 	o.ReceiverAddress = address
 	return o
 }
@@ -80,6 +206,47 @@ func (o *AssembleExternalMatchOptions) WithUpdatedOrder(order *api_types.ApiExte
 	return o
 }
 
+// WithApiKey selects the client's API key the match is assembled with, see
+// ExternalMatchClientOptions.WithScopedApiKey
+func (o *AssembleExternalMatchOptions) WithApiKey(apiKey string) *AssembleExternalMatchOptions { //nolint:revive
+	o.ApiKey = apiKey
+	return o
+}
+
+// WithNoMatchError sets whether a NoMatchError is returned rather than a nil
+// bundle when no match is found
+func (o *AssembleExternalMatchOptions) WithNoMatchError(enabled bool) *AssembleExternalMatchOptions {
+	o.NoMatchError = enabled
+	return o
+}
+
+// WithStrategyTag uses the default addresses of the strategy registered
+// under the tag, see ExternalMatchClient.SetStrategy
+func (o *AssembleExternalMatchOptions) WithStrategyTag(tag string) *AssembleExternalMatchOptions {
+	o.StrategyTag = tag
+	return o
+}
+
+// Validate checks the options' receiver address, see
+// api_types.NormalizeAddress
+func (o *AssembleExternalMatchOptions) Validate() error {
+	_, err := o.receiverAddress()
+	return err
+}
+
+// receiverAddress returns the checksummed receiver address, nil if unset
+func (o *AssembleExternalMatchOptions) receiverAddress() (*string, error) {
+	if o.ReceiverAddress == nil {
+		return nil, nil
+	}
+
+	normalized, err := api_types.NormalizeAddress(*o.ReceiverAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receiver address: %w", err)
+	}
+	return &normalized, nil
+}
+
 // NewAssembleExternalMatchOptions creates a new AssembleExternalMatchOptions with default values
 func NewAssembleExternalMatchOptions() *AssembleExternalMatchOptions {
 	return &AssembleExternalMatchOptions{
@@ -89,24 +256,280 @@ func NewAssembleExternalMatchOptions() *AssembleExternalMatchOptions {
 	}
 }
 
+// ExternalMatchClientOptions represents the options for constructing an ExternalMatchClient
+type ExternalMatchClientOptions struct {
+	// HttpOptions are the options applied to the auth server and relayer HTTP clients
+	HttpOptions *client.HttpClientOptions //nolint:revive
+	// AssemblyCacheTTL is how long an assembled bundle is reused for repeated
+	// assemblies of the same signed quote; a value of zero disables caching
+	AssemblyCacheTTL time.Duration
+	// MetadataCacheTTL is how long responses of static metadata endpoints, such
+	// as the supported tokens, are reused; a value of zero disables caching
+	MetadataCacheTTL time.Duration
+	// Sandbox, if set, puts the client in sandbox mode, fabricating quotes
+	// and bundles locally instead of requesting them from the relayer
+	Sandbox *SandboxOptions
+	// ApiVersion pins the API version used with the server; ApiVersionAuto
+	// negotiates it
+	ApiVersion ApiVersion //nolint:revive
+	// ChainID is the chain the client expects to settle on, checked by
+	// ValidateSettlementTx; a value of zero disables the check
+	ChainID uint64
+	// RiskLimits, if set, are checked before quotes are requested and matches
+	// assembled
+	RiskLimits *client.RiskLimits
+	// ApiKeyScopes are the scopes of the client's primary API key; zero
+	// allows every request
+	ApiKeyScopes ApiKeyScope //nolint:revive
+	// ScopedApiKeys are API keys used alongside the primary key for the
+	// requests in their scopes
+	ScopedApiKeys []ScopedApiKey
+	// MetadataPins, if set, are the values the server's exchange metadata
+	// must report
+	MetadataPins *MetadataPins
+	// Strategies are the default addresses of strategies, keyed by the tag
+	// request options name them with
+	Strategies map[string]StrategyAddresses
+
+	// sandboxFeeRates are the rates set by WithSandboxFeeRates, kept so that
+	// they apply regardless of whether sandbox mode is enabled before or after
+	// they are set
+	sandboxFeeRates *FeeRates
+}
+
+// NewExternalMatchClientOptions creates a new ExternalMatchClientOptions with default values
+func NewExternalMatchClientOptions() *ExternalMatchClientOptions {
+	return &ExternalMatchClientOptions{
+		HttpOptions:      client.NewHttpClientOptions(),
+		AssemblyCacheTTL: 0,
+	}
+}
+
+// WithHttpOptions sets the options for the underlying HTTP clients
+func (o *ExternalMatchClientOptions) WithHttpOptions( //nolint:revive
+	options *client.HttpClientOptions,
+) *ExternalMatchClientOptions {
+	o.HttpOptions = options
+	return o
+}
+
+// WithKeepAlive keeps warm connections open to the auth server and relayer,
+// pinging each at the given interval
+func (o *ExternalMatchClientOptions) WithKeepAlive(interval time.Duration) *ExternalMatchClientOptions {
+	o.HttpOptions.WithKeepAlive(interval)
+	return o
+}
+
+// WithCircuitBreaker enables a circuit breaker on the auth server and relayer
+// clients, opening an endpoint class after the given number of consecutive
+// failures
+func (o *ExternalMatchClientOptions) WithCircuitBreaker(
+	failureThreshold int, cooldown time.Duration,
+) *ExternalMatchClientOptions {
+	o.HttpOptions.WithCircuitBreaker(failureThreshold, cooldown)
+	return o
+}
+
+// WithTransport sets the HTTP transport of the auth server and relayer
+// clients, e.g. a client.FixtureTransport to record or replay interactions
+func (o *ExternalMatchClientOptions) WithTransport(
+	transport http.RoundTripper,
+) *ExternalMatchClientOptions {
+	o.HttpOptions.WithTransport(transport)
+	return o
+}
+
+// WithCodec sets the codec requests to the auth server and relayer are
+// encoded with, falling back to JSON where the server does not support it
+func (o *ExternalMatchClientOptions) WithCodec(codec client.Codec) *ExternalMatchClientOptions {
+	o.HttpOptions.WithCodec(codec)
+	return o
+}
+
+// WithSigningDebug calls the given hook with the signing details of every
+// authenticated request, e.g. client.LogSigningDebug. See
+// client.HttpClientOptions.WithSigningDebug
+func (o *ExternalMatchClientOptions) WithSigningDebug(
+	hook func(info *client.SigningDebugInfo),
+) *ExternalMatchClientOptions {
+	o.HttpOptions.WithSigningDebug(hook)
+	return o
+}
+
+// WithAssemblyCache enables caching of assembled bundles for the given TTL.
+//
+// Repeated assemblies of the same signed quote with the same options, e.g.
+// retries after a transient submission failure, reuse the cached bundle
+// rather than consuming the assembly rate limit. The TTL should not exceed
+// the lifetime of a bundle's settlement transaction.
+func (o *ExternalMatchClientOptions) WithAssemblyCache(ttl time.Duration) *ExternalMatchClientOptions {
+	o.AssemblyCacheTTL = ttl
+	return o
+}
+
+// WithMetadataCache enables caching of the supported tokens, exchange
+// metadata, and external match fees for the given TTL. See
+// ExternalMatchClient.InvalidateMetadataCache
+func (o *ExternalMatchClientOptions) WithMetadataCache(ttl time.Duration) *ExternalMatchClientOptions {
+	o.MetadataCacheTTL = ttl
+	return o
+}
+
+// WithSandbox puts the client in sandbox mode, filling every order in full at
+// the given price. See NewSandboxExternalMatchClient. Fee rates set by
+// WithSandboxFeeRates apply whether they are set before or after
+func (o *ExternalMatchClientOptions) WithSandbox(price float64) *ExternalMatchClientOptions {
+	o.Sandbox = &SandboxOptions{Price: price}
+	if o.sandboxFeeRates != nil {
+		o.Sandbox.FeeRates = *o.sandboxFeeRates
+	}
+	return o
+}
+
+// WithSandboxFeeRates charges fees at the given rates on sandbox matches, so
+// that sandbox bundles preview the amounts a live match would settle. It may
+// be called before or after WithSandbox, but has no effect unless sandbox mode
+// is enabled
+func (o *ExternalMatchClientOptions) WithSandboxFeeRates(rates FeeRates) *ExternalMatchClientOptions {
+	o.sandboxFeeRates = &rates
+	if o.Sandbox != nil {
+		o.Sandbox.FeeRates = rates
+	}
+	return o
+}
+
+// WithApiVersion pins the API version used with the server rather than
+// negotiating it
+func (o *ExternalMatchClientOptions) WithApiVersion( //nolint:revive
+	version ApiVersion,
+) *ExternalMatchClientOptions {
+	o.ApiVersion = version
+	return o
+}
+
+// WithChainID sets the chain the client expects to settle on. Bundles are
+// checked against the exchange metadata of this chain by ValidateSettlementTx
+func (o *ExternalMatchClientOptions) WithChainID(chainID uint64) *ExternalMatchClientOptions {
+	o.ChainID = chainID
+	return o
+}
+
+// WithRiskLimits enforces the given risk limits on the client's trades. Orders
+// sized in the quote token are checked before they are quoted, and every match
+// is reserved against the limits before it is assembled
+func (o *ExternalMatchClientOptions) WithRiskLimits(limits client.RiskLimits) *ExternalMatchClientOptions {
+	o.RiskLimits = &limits
+	return o
+}
+
+// WithApiKeyScopes restricts the client's primary API key to the given
+// scopes, e.g. ScopeQuote for a pricing service that must not obtain
+// settlement transactions
+func (o *ExternalMatchClientOptions) WithApiKeyScopes( //nolint:revive
+	scopes ApiKeyScope,
+) *ExternalMatchClientOptions {
+	o.ApiKeyScopes = scopes
+	return o
+}
+
+// WithScopedApiKey adds an API key used alongside the primary key. Each
+// request is sent with the key selected in its options or, by default, the
+// least privileged key scoped for it, so that a client holding a quote-only
+// key and an assembly key quotes with the former. Requests outside both
+// scopes, e.g. for metadata, use the primary key
+func (o *ExternalMatchClientOptions) WithScopedApiKey( //nolint:revive
+	apiKey string, apiSecret *wallet.HmacKey, scopes ApiKeyScope,
+) *ExternalMatchClientOptions {
+	o.ScopedApiKeys = append(o.ScopedApiKeys, ScopedApiKey{ApiKey: apiKey, ApiSecret: apiSecret, Scopes: scopes})
+	return o
+}
+
+// WithMetadataPins pins the values the server's exchange metadata must
+// report. Metadata that does not match is refused with a
+// *MetadataMismatchError, so that settlements are never validated against a
+// redirected contract. The testnet and mainnet constructors pin their
+// deployment's values
+func (o *ExternalMatchClientOptions) WithMetadataPins(pins MetadataPins) *ExternalMatchClientOptions {
+	o.MetadataPins = &pins
+	return o
+}
+
+// WithStrategy registers the default refund and receiver addresses of a
+// strategy, used by quote and assembly requests whose options name its tag
+// with WithStrategyTag. Invalid addresses fail the requests that use them
+func (o *ExternalMatchClientOptions) WithStrategy(
+	tag string, addresses StrategyAddresses,
+) *ExternalMatchClientOptions {
+	if o.Strategies == nil {
+		o.Strategies = make(map[string]StrategyAddresses)
+	}
+	o.Strategies[tag] = addresses
+	return o
+}
+
 // ExternalMatchClient represents a client for the external match API
 //
 // This client can be used to request external match bundles from a relayer.
-// The relayer will return a match and a transaction to submit on-chain
+// The relayer will return a match and a transaction to submit on-chain.
+//
+// An ExternalMatchClient is safe for concurrent use by multiple goroutines
 type ExternalMatchClient struct {
 	apiKey            string
 	httpClient        *client.HttpClient
 	relayerHttpClient *client.HttpClient //nolint:revive
+	// credentials are the client's API keys, the primary key first
+	credentials []*apiCredential
+	// assemblyCache caches assembled bundles, nil if caching is disabled
+	assemblyCache *assemblyCache
+	// metadataCache caches metadata responses, nil if caching is disabled
+	metadataCache *metadataCache
+	// sandbox configures sandbox mode, nil if the client talks to the relayer
+	sandbox *SandboxOptions
+	// pinnedVersion is the API version set in the options, ApiVersionAuto if
+	// the version is negotiated
+	pinnedVersion ApiVersion
+	// chainID is the chain the client expects to settle on, zero if unchecked
+	chainID uint64
+	// riskGuard enforces the client's risk limits, nil if none are set
+	riskGuard *client.RiskGuard
+	// metadataPins are checked against the server's exchange metadata, nil if
+	// unchecked
+	metadataPins *MetadataPins
+	// strategies are the default addresses of the client's strategies
+	strategies *strategyBook
+
+	// versionMu guards the negotiated API version
+	versionMu         sync.Mutex
+	negotiatedVersion ApiVersion
+
+	// sponsorshipMu guards the sponsorships of the last quote for each pair
+	sponsorshipMu sync.Mutex
+	sponsorships  map[string]sponsorshipObservation
+
+	// quotersMu guards the running quoters created by the client, which are
+	// stopped on shutdown
+	quotersMu sync.Mutex
+	quoters   map[*Quoter]struct{}
+	// flushers are the sinks flushed when the client closes
+	flushers client.Flushers
+
+	// The observers of the client's lifecycle events, see lifecycle.go
+	quoteObservers     client.Observers[QuoteReceivedEvent]
+	bundleObservers    client.Observers[BundleAssembledEvent]
+	submittedObservers client.Observers[SettlementSubmittedEvent]
+	confirmedObservers client.Observers[SettlementConfirmedEvent]
 }
 
 // NewTestnetExternalMatchClient creates a new ExternalMatchClient for the testnet
 func NewTestnetExternalMatchClient(apiKey string, apiSecret *wallet.HmacKey) *ExternalMatchClient {
-	return NewExternalMatchClient(testnetBaseUrl, testnetRelayerBaseUrl, apiKey, apiSecret)
+	options := NewExternalMatchClientOptions().WithMetadataPins(TestnetMetadataPins)
+	return NewExternalMatchClientWithOptions(testnetBaseUrl, testnetRelayerBaseUrl, apiKey, apiSecret, options)
 }
 
 // NewMainnetExternalMatchClient creates a new ExternalMatchClient for the mainnet
 func NewMainnetExternalMatchClient(apiKey string, apiSecret *wallet.HmacKey) *ExternalMatchClient {
-	return NewExternalMatchClient(mainnetBaseUrl, mainnetRelayerBaseUrl, apiKey, apiSecret)
+	options := NewExternalMatchClientOptions().WithMetadataPins(MainnetMetadataPins)
+	return NewExternalMatchClientWithOptions(mainnetBaseUrl, mainnetRelayerBaseUrl, apiKey, apiSecret, options)
 }
 
 // NewExternalMatchClient creates a new ExternalMatchClient with the given base
@@ -117,58 +540,167 @@ func NewExternalMatchClient(
 	apiKey string,
 	apiSecret *wallet.HmacKey,
 ) *ExternalMatchClient {
-	return &ExternalMatchClient{
+	return NewExternalMatchClientWithOptions(
+		baseURL, relayerBaseURL, apiKey, apiSecret, NewExternalMatchClientOptions(),
+	)
+}
+
+// NewExternalMatchClientWithOptions creates a new ExternalMatchClient with the
+// given base URLs, api key, api secret, and options
+func NewExternalMatchClientWithOptions(
+	baseURL string,
+	relayerBaseURL string,
+	apiKey string,
+	apiSecret *wallet.HmacKey,
+	options *ExternalMatchClientOptions,
+) *ExternalMatchClient {
+	var cache *assemblyCache
+	if options.AssemblyCacheTTL > 0 {
+		cache = newAssemblyCache(options.AssemblyCacheTTL)
+	}
+	var metadata *metadataCache
+	if options.MetadataCacheTTL > 0 {
+		metadata = newMetadataCache(options.MetadataCacheTTL)
+	}
+
+	credentials := newApiCredentials(baseURL, apiKey, apiSecret, options)
+	c := &ExternalMatchClient{
 		apiKey:            apiKey,
-		httpClient:        client.NewHttpClient(baseURL, apiSecret),
-		relayerHttpClient: client.NewHttpClient(relayerBaseURL, apiSecret),
+		httpClient:        credentials[0].httpClient,
+		credentials:       credentials,
+		relayerHttpClient: client.NewHttpClientWithOptions(relayerBaseURL, apiSecret, options.HttpOptions),
+		assemblyCache:     cache,
+		metadataCache:     metadata,
+		sandbox:           options.Sandbox,
+		pinnedVersion:     options.ApiVersion,
+		chainID:           options.ChainID,
+		metadataPins:      options.MetadataPins,
+		strategies:        newStrategyBook(options.Strategies),
+	}
+	if options.RiskLimits != nil {
+		c.riskGuard = client.NewRiskGuard(*options.RiskLimits)
+	}
+	return c
+}
+
+// WarmConnections pre-establishes connections to the auth server and relayer so
+// that the first quote after an idle period does not pay for connection setup
+func (c *ExternalMatchClient) WarmConnections() error {
+	for _, cred := range c.credentials {
+		if err := cred.httpClient.Warm(); err != nil {
+			return err
+		}
 	}
+	return c.relayerHttpClient.Warm()
 }
 
 // GetSupportedTokens requests the list of supported tokens from the relayer
 func (c *ExternalMatchClient) GetSupportedTokens() ([]api_types.ApiToken, error) {
-	var response api_types.GetSupportedTokensResponse
-	err := c.relayerHttpClient.GetJSON(
-		api_types.GetSupportedTokensPath,
-		nil, // body
-		&response,
-	)
+	tokens, err := cachedMetadata(c, supportedTokensCacheKey, func() ([]api_types.ApiToken, error) {
+		var response api_types.GetSupportedTokensResponse
+		err := c.relayerHttpClient.GetJSON(
+			api_types.GetSupportedTokensPath,
+			nil, // body
+			&response,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return response.Tokens, nil
+	}, slices.Clone)
 	if err != nil {
 		return nil, err
 	}
-
-	return response.Tokens, nil
+	return tokens, nil
 }
 
-// GetExternalMatchQuote requests a quote from the relayer
+// GetExternalMatchQuote requests a quote from the relayer with the given
+// options, e.g. WithLatencyBudget
 // returns nil if no match is found
 func (c *ExternalMatchClient) GetExternalMatchQuote(
+	order *api_types.ApiExternalOrder, opts ...QuoteOption,
+) (*api_types.ApiSignedQuote, error) {
+	return c.GetExternalMatchQuoteWithOptions(order, newQuoteOptions(opts))
+}
+
+// GetExternalMatchQuoteWithOptions requests a quote from the relayer with the
+// given options
+// returns nil if no match is found, or a NoMatchError if the options ask for one
+func (c *ExternalMatchClient) GetExternalMatchQuoteWithOptions(
 	order *api_types.ApiExternalOrder,
+	options *ExternalQuoteOptions,
 ) (*api_types.ApiSignedQuote, error) {
+	options, err := c.applyQuoteStrategy(options)
+	if err != nil {
+		return nil, err
+	}
+	refundAddress, err := normalizeOptionalAddress(options.RefundAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid refund address: %w", err)
+	}
+	if c.sandbox != nil {
+		quote, err := sandboxQuote(order, c.sandbox)
+		if err != nil {
+			return nil, err
+		}
+		c.notifyQuoteReceived(order, quote, "" /* requestID */)
+		return quote, nil
+	}
+	if err := c.checkOrderRisk(order); err != nil {
+		return nil, err
+	}
+	cred, err := c.selectCredential(ScopeQuote, options.ApiKey)
+	if err != nil {
+		return nil, err
+	}
+
 	requestBody := api_types.ExternalQuoteRequest{
 		ExternalOrder: *order,
 	}
 
+	requestID := client.NewRequestID()
+	ctx := client.WithRequestID(context.Background(), requestID)
+
+	// Bound the request by the latency budget, if one is set
+	if options.LatencyBudget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.LatencyBudget)
+		defer cancel()
+	}
+
 	var response api_types.ExternalQuoteResponse
-	success, err := c.doExternalMatchRequest(
-		api_types.GetExternalMatchQuotePath,
+	success, err := c.doExternalMatchRequestWithContext(
+		ctx,
+		cred,
+		api_types.BuildExternalMatchQuotePath(refundAddress),
 		requestBody,
 		&response,
 	)
 	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, &client.RequestError{
+				RequestID: requestID,
+				Err:       fmt.Errorf("%w: %v", ErrLatencyBudgetExceeded, options.LatencyBudget),
+			}
+		}
 		return nil, err
 	}
 	if !success {
-		return nil, nil
+		return nil, noMatch(options.NoMatchError)
 	}
+	c.recordSponsorship(order, response.GasSponsorshipInfo)
+	c.notifyQuoteReceived(order, &response.Quote, requestID)
 
 	return &response.Quote, nil
 }
 
-// AssembleExternalQuote generates an external match bundle from a signed quote
+// AssembleExternalQuote generates an external match bundle from a signed
+// quote with the given options, e.g. WithReceiver or WithGasEstimation
+// returns nil if no match is found
 func (c *ExternalMatchClient) AssembleExternalQuote(
-	quote *api_types.ApiSignedQuote,
+	quote *api_types.ApiSignedQuote, opts ...AssembleOption,
 ) (*ExternalMatchBundle, error) {
-	return c.AssembleExternalQuoteWithReceiver(quote, nil /* receiverAddress */)
+	return c.AssembleExternalMatchWithOptions(quote, newAssembleOptions(opts))
 }
 
 // AssembleExternalQuoteWithReceiver generates an external match bundle from a signed quote
@@ -186,41 +718,91 @@ func (c *ExternalMatchClient) AssembleExternalMatchWithOptions(
 	quote *api_types.ApiSignedQuote,
 	options *AssembleExternalMatchOptions,
 ) (*ExternalMatchBundle, error) {
+	options, err := c.applyAssembleStrategy(options)
+	if err != nil {
+		return nil, err
+	}
+	receiver, err := options.receiverAddress()
+	if err != nil {
+		return nil, err
+	}
+	if c.sandbox != nil {
+		bundle, err := sandboxBundle(quote, options, c.sandbox)
+		if err != nil {
+			return nil, err
+		}
+		c.notifyBundleAssembled(bundle, quote)
+		return bundle, nil
+	}
+	cred, err := c.selectCredential(ScopeAssemble, options.ApiKey)
+	if err != nil {
+		return nil, err
+	}
+
 	requestBody := api_types.AssembleExternalQuoteRequest{
 		Quote:           *quote,
-		ReceiverAddress: options.ReceiverAddress,
+		ReceiverAddress: receiver,
 		DoGasEstimation: options.DoGasEstimation,
 		UpdatedOrder:    options.UpdatedOrder,
 	}
 
+	// Reuse a previous assembly of the same quote, if one is cached
+	var cacheKey string
+	if c.assemblyCache != nil {
+		key, err := assemblyCacheKey(&requestBody)
+		if err != nil {
+			return nil, err
+		}
+		if bundle, ok := c.assemblyCache.get(key); ok {
+			return bundle, nil
+		}
+		cacheKey = key
+	}
+
+	// Reserve the match against the risk limits, releasing it if no bundle is
+	// assembled
+	reserved, err := c.reserveMatchRisk(&quote.Quote.MatchResult, options.UpdatedOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	requestID := client.NewRequestID()
+	ctx := client.WithRequestID(context.Background(), requestID)
+
 	var response api_types.ExternalMatchResponse
-	success, err := c.doExternalMatchRequest(
+	success, err := c.doExternalMatchRequestWithContext(
+		ctx,
+		cred,
 		api_types.AssembleExternalQuotePath,
 		requestBody,
 		&response,
 	)
-	if err != nil {
-		return nil, err
+	if err != nil || !success {
+		c.releaseMatchRisk(&quote.Quote.MatchResult, reserved)
+		if err != nil {
+			return nil, err
+		}
+		return nil, noMatch(options.NoMatchError)
 	}
-	if !success {
-		return nil, nil
+
+	bundle := toExternalMatchBundle(&response.Bundle, requestID)
+	bundle.QuoteSignature = quote.Signature
+
+	if c.assemblyCache != nil {
+		c.assemblyCache.put(cacheKey, bundle)
 	}
+	c.notifyBundleAssembled(bundle, quote)
 
-	return &ExternalMatchBundle{
-		MatchResult:  &response.Bundle.MatchResult,
-		Fees:         &response.Bundle.Fees,
-		Receive:      &response.Bundle.Receive,
-		Send:         &response.Bundle.Send,
-		SettlementTx: toSettlementTransaction(&response.Bundle.SettlementTx),
-	}, nil
+	return bundle, nil
 }
 
 // GetExternalMatchBundle requests an external match bundle from the relayer
+// with the given options, e.g. WithReceiver or WithGasEstimation
 // returns nil if no match is found
 func (c *ExternalMatchClient) GetExternalMatchBundle(
-	request *api_types.ApiExternalOrder,
+	request *api_types.ApiExternalOrder, opts ...AssembleOption,
 ) (*ExternalMatchBundle, error) {
-	return c.GetExternalMatchBundleWithReceiver(request, nil /* receiverAddress */)
+	return c.GetExternalMatchBundleWithOptions(request, newAssembleOptions(opts))
 }
 
 // GetExternalMatchBundleWithReceiver requests an external match bundle from the relayer
@@ -229,13 +811,61 @@ func (c *ExternalMatchClient) GetExternalMatchBundleWithReceiver(
 	request *api_types.ApiExternalOrder,
 	receiverAddress *string,
 ) (*ExternalMatchBundle, error) {
+	options := NewAssembleExternalMatchOptions().WithReceiverAddress(receiverAddress)
+	return c.GetExternalMatchBundleWithOptions(request, options)
+}
+
+// GetExternalMatchBundleWithOptions requests an external match bundle from
+// the relayer with the given options; the options' updated order does not
+// apply to direct matches and must be unset. Returns nil if no match is found
+func (c *ExternalMatchClient) GetExternalMatchBundleWithOptions(
+	request *api_types.ApiExternalOrder,
+	options *AssembleExternalMatchOptions,
+) (*ExternalMatchBundle, error) {
+	if options.UpdatedOrder != nil {
+		return nil, errors.New("an updated order only applies to quote assembly")
+	}
+	options, err := c.applyAssembleStrategy(options)
+	if err != nil {
+		return nil, err
+	}
+	receiver, err := options.receiverAddress()
+	if err != nil {
+		return nil, err
+	}
+	if c.sandbox != nil {
+		quote, err := sandboxQuote(request, c.sandbox)
+		if err != nil {
+			return nil, err
+		}
+		bundle, err := sandboxBundle(quote, options, c.sandbox)
+		if err != nil {
+			return nil, err
+		}
+		c.notifyBundleAssembled(bundle, nil /* quote */)
+		return bundle, nil
+	}
+	if err := c.checkOrderRisk(request); err != nil {
+		return nil, err
+	}
+	cred, err := c.selectCredential(ScopeAssemble, options.ApiKey)
+	if err != nil {
+		return nil, err
+	}
+
 	requestBody := api_types.ExternalMatchRequest{
 		ExternalOrder:   *request,
-		ReceiverAddress: receiverAddress,
+		DoGasEstimation: options.DoGasEstimation,
+		ReceiverAddress: receiver,
 	}
 
+	requestID := client.NewRequestID()
+	ctx := client.WithRequestID(context.Background(), requestID)
+
 	var response api_types.ExternalMatchResponse
-	success, err := c.doExternalMatchRequest(
+	success, err := c.doExternalMatchRequestWithContext(
+		ctx,
+		cred,
 		api_types.GetExternalMatchBundlePath,
 		requestBody,
 		&response,
@@ -244,42 +874,45 @@ func (c *ExternalMatchClient) GetExternalMatchBundleWithReceiver(
 		return nil, err
 	}
 	if !success {
-		return nil, nil
+		return nil, noMatch(options.NoMatchError)
 	}
 
-	return &ExternalMatchBundle{
-		MatchResult:  &response.Bundle.MatchResult,
-		SettlementTx: toSettlementTransaction(&response.Bundle.SettlementTx),
-	}, nil
+	// The match is sized by the relayer, so it is checked against the risk
+	// limits once received; a bundle that exceeds them is dropped
+	if _, err := c.reserveMatchRisk(&response.Bundle.MatchResult, nil /* updatedOrder */); err != nil {
+		return nil, err
+	}
+
+	bundle := toExternalMatchBundle(&response.Bundle, requestID)
+	c.notifyBundleAssembled(bundle, nil /* quote */)
+
+	return bundle, nil
 }
 
-// doExternalMatchRequest handles an external match request
+// doExternalMatchRequestWithContext handles an external match request bound to
+// the given context, sent with the given API key
 // returns false if the response was NO_CONTENT or if unmarshaling failed
-func (c *ExternalMatchClient) doExternalMatchRequest(
+func (c *ExternalMatchClient) doExternalMatchRequestWithContext(
+	ctx context.Context,
+	cred *apiCredential,
 	path string,
 	request interface{},
 	response interface{},
 ) (bool, error) {
-	headers := make(http.Header)
-	headers.Set(apiKeyHeader, c.apiKey)
-
-	// Send the request
-	statusCode, respBody, err := c.httpClient.PostWithAuthRaw(path, &headers, request)
-	if err != nil {
-		return false, err
+	// Sandbox clients hold no credentials, so any request not answered
+	// locally is refused
+	if c.sandbox != nil {
+		return false, ErrSandboxUnsupported
 	}
 
-	// Check the status code
-	if statusCode < 200 || statusCode >= 300 {
-		return false, fmt.Errorf("unexpected status code: %d, body: %s", statusCode, string(respBody))
-	} else if statusCode == http.StatusNoContent {
-		return false, nil
-	}
+	headers := make(http.Header)
+	headers.Set(apiKeyHeader, cred.apiKey)
 
-	// Unmarshal the response
-	if err := json.Unmarshal(respBody, response); err != nil {
-		return false, fmt.Errorf("failed to unmarshal response: %w", err)
+	// Send the request and decode the response
+	statusCode, err := cred.httpClient.PostWithAuthContext(ctx, path, &headers, request, response)
+	if err != nil {
+		return false, classifyNoMatch(err)
 	}
 
-	return true, nil
+	return statusCode != http.StatusNoContent, nil
 }
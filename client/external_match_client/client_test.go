@@ -0,0 +1,245 @@
+package external_match_client //nolint:revive
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// newTestClient creates a client whose auth server and relayer are both the given handler
+func newTestClient(t testing.TB, handler http.HandlerFunc) *ExternalMatchClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewExternalMatchClient(server.URL, server.URL, "test-key", &wallet.HmacKey{})
+}
+
+// newTestClientWithOptions creates a client with the given options whose auth
+// server and relayer are both the given handler
+func newTestClientWithOptions(
+	t testing.TB, options *ExternalMatchClientOptions, handler http.HandlerFunc,
+) *ExternalMatchClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewExternalMatchClientWithOptions(server.URL, server.URL, "test-key", &wallet.HmacKey{}, options)
+}
+
+// testOrder builds a simple external order
+func testOrder(t testing.TB) *api_types.ApiExternalOrder {
+	order, err := api_types.NewExternalOrderBuilder().
+		WithBaseMint("0x1").
+		WithQuoteMint("0x2").
+		WithBaseAmount(api_types.NewAmount(100)).
+		WithSide("Sell").
+		Build()
+	assert.NoError(t, err)
+	return order
+}
+
+func TestQuoteLatencyBudgetExceeded(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	options := NewExternalQuoteOptions().WithLatencyBudget(10 * time.Millisecond)
+	quote, err := c.GetExternalMatchQuoteWithOptions(testOrder(t), options)
+	assert.Nil(t, quote)
+	assert.True(t, errors.Is(err, ErrLatencyBudgetExceeded))
+	requestID, ok := client.RequestIDFromError(err)
+	assert.True(t, ok)
+	assert.NotEmpty(t, requestID)
+}
+
+func TestQuoteWithinLatencyBudget(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	options := NewExternalQuoteOptions().WithLatencyBudget(time.Second)
+	quote, err := client.GetExternalMatchQuoteWithOptions(testOrder(t), options)
+	assert.NoError(t, err)
+	assert.Nil(t, quote)
+}
+
+func TestQuoteRequestID(t *testing.T) {
+	var sentIDs []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sentIDs = append(sentIDs, r.Header.Get("X-Request-Id"))
+		_, _ = w.Write([]byte(`{"signed_quote":{"signature":"sig"}}`))
+	})
+
+	var events []QuoteReceivedEvent
+	client.OnQuoteReceived(func(e QuoteReceivedEvent) { events = append(events, e) })
+
+	quote, err := client.GetExternalMatchQuote(testOrder(t))
+	assert.NoError(t, err)
+	assert.NotNil(t, quote)
+	assert.Len(t, events, 1)
+	assert.Len(t, sentIDs, 1)
+	assert.NotEmpty(t, events[0].RequestID)
+	assert.Equal(t, sentIDs[0], events[0].RequestID)
+}
+
+func TestConcurrentClientUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api_types.ExchangeMetadataPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"match_bundle":{"settlement_tx":{"to":"0x01","data":"0x","value":"0x0"}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	options := NewExternalMatchClientOptions().
+		WithAssemblyCache(time.Minute).
+		WithCircuitBreaker(3 /* failureThreshold */, time.Second)
+	client := NewExternalMatchClientWithOptions(
+		server.URL, server.URL, "test-key", &wallet.HmacKey{}, options,
+	)
+
+	// Run under the race detector to check the client's internal locking
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.ApiVersion()
+			assert.NoError(t, err)
+			_, err = client.AssembleExternalQuote(&api_types.ApiSignedQuote{Signature: "sig"})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestBundleGasEstimate(t *testing.T) {
+	var gasRequested []bool
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			DoGasEstimation bool `json:"do_gas_estimation"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		gasRequested = append(gasRequested, body.DoGasEstimation)
+		_, _ = w.Write([]byte(`{"match_bundle":{"settlement_tx":{"to":"0x01","data":"0x","value":"0x0","gas":"0x7a120"}}}`))
+	})
+
+	options := NewAssembleExternalMatchOptions().WithGasEstimation(true)
+	bundle, err := client.GetExternalMatchBundleWithOptions(testOrder(t), options)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(500_000), bundle.SettlementTx.Gas)
+
+	bundle, err = client.AssembleExternalMatchWithOptions(&api_types.ApiSignedQuote{}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(500_000), bundle.SettlementTx.Gas)
+	assert.Equal(t, []bool{true, true}, gasRequested)
+
+	_, err = client.GetExternalMatchBundleWithOptions(testOrder(t), options.WithUpdatedOrder(testOrder(t)))
+	assert.Error(t, err)
+}
+
+func TestParseGasEstimate(t *testing.T) {
+	assert.Equal(t, uint64(500_000), parseGasEstimate("0x7a120"))
+	assert.Equal(t, uint64(500_000), parseGasEstimate("500000"))
+	assert.Zero(t, parseGasEstimate(""))
+	assert.Zero(t, parseGasEstimate("lots"))
+	assert.Zero(t, parseGasEstimate("-1"))
+}
+
+func TestReceiverAddressValidation(t *testing.T) {
+	client := newTestClient(t, func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("no request should be sent with an invalid receiver")
+	})
+
+	// Valid addresses are checksummed when set
+	receiver := "0xc5fe800a3d92112473e4e811296f194da7b26ba7"
+	options := NewAssembleExternalMatchOptions().WithReceiverAddress(&receiver)
+	assert.NoError(t, options.Validate())
+	assert.Equal(t, "0xC5fE800A3D92112473e4E811296F194DA7b26BA7", *options.ReceiverAddress)
+
+	for _, invalid := range []string{
+		"0xc5FE800A3D92112473e4E811296F194DA7b26BA7",
+		"0x0000000000000000000000000000000000000000",
+		"0x02",
+	} {
+		options := NewAssembleExternalMatchOptions().WithReceiverAddress(&invalid)
+		assert.True(t, errors.Is(options.Validate(), api_types.ErrInvalidAddress), invalid)
+
+		_, err := client.AssembleExternalMatchWithOptions(&api_types.ApiSignedQuote{}, options)
+		assert.True(t, errors.Is(err, api_types.ErrInvalidAddress))
+		_, err = client.GetExternalMatchBundleWithOptions(testOrder(t), options)
+		assert.True(t, errors.Is(err, api_types.ErrInvalidAddress))
+	}
+}
+
+// benchmarkCalldata is settlement calldata of a typical size
+var benchmarkCalldata = "0x" + strings.Repeat("0123456789abcdef", 256)
+
+func BenchmarkToSettlementTransaction(b *testing.B) {
+	tx := &api_types.ApiSettlementTransaction{
+		Type:  "eip1559",
+		To:    "0x9af58f1ff20ab22e819e40b57ffd784d115a9ef5",
+		Data:  benchmarkCalldata,
+		Value: "0x0",
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = toSettlementTransaction(tx)
+	}
+}
+
+func BenchmarkQuoteAndAssemble(b *testing.B) {
+	order := testOrder(b)
+	quote, err := sandboxQuote(order, &SandboxOptions{Price: 2})
+	assert.NoError(b, err)
+	bundle := api_types.ApiExternalMatchBundle{
+		MatchResult: quote.Quote.MatchResult,
+		Fees:        quote.Quote.Fees,
+		Receive:     quote.Quote.Receive,
+		Send:        quote.Quote.Send,
+		SettlementTx: api_types.ApiSettlementTransaction{
+			Type:  "eip1559",
+			To:    "0x9af58f1ff20ab22e819e40b57ffd784d115a9ef5",
+			Data:  benchmarkCalldata,
+			Value: "0x0",
+		},
+	}
+	quoteResponse, err := json.Marshal(api_types.ExternalQuoteResponse{Quote: *quote})
+	assert.NoError(b, err)
+	bundleResponse, err := json.Marshal(api_types.ExternalMatchResponse{Bundle: bundle})
+	assert.NoError(b, err)
+
+	client := newTestClient(b, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api_types.GetExternalMatchQuotePath {
+			_, _ = w.Write(quoteResponse)
+			return
+		}
+		_, _ = w.Write(bundleResponse)
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		signedQuote, err := client.GetExternalMatchQuote(order)
+		if err != nil || signedQuote == nil {
+			b.Fatalf("quote failed: %v", err)
+		}
+		if _, err := client.AssembleExternalQuote(signedQuote); err != nil {
+			b.Fatalf("assembly failed: %v", err)
+		}
+	}
+}
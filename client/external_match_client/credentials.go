@@ -0,0 +1,126 @@
+package external_match_client //nolint:revive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// ErrInvalidCredentials is returned when the server rejects an API key or the
+// signature made with its secret
+var ErrInvalidCredentials = errors.New("api credentials rejected by the server")
+
+// RateLimit is a rate limit the relayer applies to an API key's requests
+type RateLimit struct {
+	// Scope is the requests the limit applies to
+	Scope ApiKeyScope
+	// Limit is the number of requests allowed per period
+	Limit int
+	// Period is the period the limit replenishes over
+	Period time.Duration
+	// Description describes what the limit counts
+	Description string
+}
+
+// rateLimits are the relayer's documented rate limits on external matches
+var rateLimits = []RateLimit{
+	{
+		Scope:       ScopeQuote,
+		Limit:       100,
+		Period:      time.Minute,
+		Description: "quote requests",
+	},
+	{
+		Scope:       ScopeAssemble,
+		Limit:       5,
+		Period:      time.Minute,
+		Description: "assembled bundles not settled on-chain",
+	},
+}
+
+// CredentialStatus is the result of validating one of the client's API keys
+type CredentialStatus struct {
+	// ApiKey is the API key
+	ApiKey string //nolint:revive
+	// Valid is set if the server accepted the key and its signature
+	Valid bool
+	// Scopes are the requests the client uses the key for
+	Scopes ApiKeyScope
+	// RateLimits are the rate limits that apply to the key's scopes
+	RateLimits []RateLimit
+	// Err is the server's rejection of the key, if it is invalid
+	Err error
+}
+
+// ValidateCredentials sends a cheap authenticated request with each of the
+// client's API keys, reporting whether the server accepts it along with the
+// scopes and rate limits that apply to it, so that deployments fail fast at
+// startup rather than on their first order.
+//
+// The returned error wraps ErrInvalidCredentials if any key is rejected, or
+// the failure if a key could not be checked, e.g. because the server is
+// unreachable. A sandboxed client accepts any key
+func (c *ExternalMatchClient) ValidateCredentials() ([]CredentialStatus, error) {
+	statuses := make([]CredentialStatus, 0, len(c.credentials))
+	var errs []error
+	for _, cred := range c.credentials {
+		status := CredentialStatus{
+			ApiKey:     cred.apiKey,
+			Scopes:     cred.scopes,
+			RateLimits: rateLimitsFor(cred.scopes),
+		}
+
+		err := c.checkCredential(cred)
+		var statusErr *client.StatusError
+		switch {
+		case err == nil:
+			status.Valid = true
+		case errors.As(err, &statusErr) &&
+			(statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden):
+			status.Err = err
+			errs = append(errs, fmt.Errorf("%w: key %s: %w", ErrInvalidCredentials, cred.apiKey, err))
+		default:
+			errs = append(errs, fmt.Errorf("failed to validate key %s: %w", cred.apiKey, err))
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, errors.Join(errs...)
+}
+
+// checkCredential sends an authenticated request with the credential, for the
+// exchange metadata. Servers without the v2 API serve no cheap authenticated
+// endpoint, so credentials cannot be checked against them
+func (c *ExternalMatchClient) checkCredential(cred *apiCredential) error {
+	if c.sandbox != nil {
+		return nil
+	}
+
+	headers := make(http.Header)
+	headers.Set(apiKeyHeader, cred.apiKey)
+
+	var response json.RawMessage
+	err := cred.httpClient.GetWithAuthAndHeaders(
+		api_types.ExchangeMetadataPath, &headers, nil /* body */, &response,
+	)
+	var statusErr *client.StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: credential validation requires %v", ErrUnsupportedApiVersion, ApiVersionV2)
+	}
+	return err
+}
+
+// rateLimitsFor returns the rate limits that apply to requests in the scopes
+func rateLimitsFor(scopes ApiKeyScope) []RateLimit {
+	var limits []RateLimit
+	for _, limit := range rateLimits {
+		if scopes.Has(limit.Scope) {
+			limits = append(limits, limit)
+		}
+	}
+	return limits
+}
@@ -0,0 +1,65 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+func TestValidateCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(apiKeyHeader) == "revoked-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	options := NewExternalMatchClientOptions().
+		WithApiKeyScopes(ScopeAssemble).
+		WithScopedApiKey("revoked-key", &wallet.HmacKey{}, ScopeQuote)
+	c := NewExternalMatchClientWithOptions(server.URL, server.URL, "execution-key", &wallet.HmacKey{}, options)
+
+	statuses, err := c.ValidateCredentials()
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Len(t, statuses, 2)
+
+	// Each key reports its own scopes and rate limits
+	assert.True(t, statuses[0].Valid)
+	assert.Equal(t, ScopeAssemble, statuses[0].Scopes)
+	assert.Len(t, statuses[0].RateLimits, 1)
+	assert.Equal(t, 5, statuses[0].RateLimits[0].Limit)
+
+	assert.False(t, statuses[1].Valid)
+	assert.Equal(t, "revoked-key", statuses[1].ApiKey)
+	assert.Error(t, statuses[1].Err)
+	assert.Equal(t, ScopeQuote, statuses[1].RateLimits[0].Scope)
+}
+
+func TestValidateCredentialsRequiresV2(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	statuses, err := c.ValidateCredentials()
+	assert.ErrorIs(t, err, ErrUnsupportedApiVersion)
+	assert.False(t, errors.Is(err, ErrInvalidCredentials))
+	assert.False(t, statuses[0].Valid)
+}
+
+func TestValidateCredentialsReportsUnreachableServer(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	c := NewExternalMatchClient(server.URL, server.URL, "test-key", &wallet.HmacKey{})
+
+	statuses, err := c.ValidateCredentials()
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrInvalidCredentials))
+	assert.False(t, statuses[0].Valid)
+}
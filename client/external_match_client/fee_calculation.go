@@ -0,0 +1,106 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// FeeRates are the relayer and protocol fee rates charged on external matches,
+// as the fixed point numbers the relayer computes fees with
+type FeeRates struct {
+	// RelayerFeeRate is the relayer's fee rate
+	RelayerFeeRate wallet.FixedPoint
+	// ProtocolFeeRate is the protocol's fee rate
+	ProtocolFeeRate wallet.FixedPoint
+}
+
+// NewFeeRates creates fee rates from their decimal values, e.g. 0.0002 for
+// two basis points. The relayer converts its configured rates to fixed point
+// by flooring, as FixedPointFromFloat does, so the rates match its own
+func NewFeeRates(relayerFeeRate, protocolFeeRate float64) FeeRates {
+	return FeeRates{
+		RelayerFeeRate:  wallet.FixedPointFromFloat(relayerFeeRate),
+		ProtocolFeeRate: wallet.FixedPointFromFloat(protocolFeeRate),
+	}
+}
+
+// ParseFeeRates converts the fee rates served by the relayer's fee endpoint.
+// Each rate is parsed as an exact decimal, which must lie in [0, 1). The
+// relayer holds its rates as float64s, so a rate is then rounded to the
+// nearest float64 and floored to fixed point, as in NewFeeRates, to match the
+// relayer's own fixed point rate
+func ParseFeeRates(response *api_types.GetExternalMatchFeeResponse) (FeeRates, error) {
+	relayerFeeRate, err := parseFeeRate(response.RelayerFee)
+	if err != nil {
+		return FeeRates{}, fmt.Errorf("invalid relayer fee rate %q: %w", response.RelayerFee, err)
+	}
+	protocolFeeRate, err := parseFeeRate(response.ProtocolFee)
+	if err != nil {
+		return FeeRates{}, fmt.Errorf("invalid protocol fee rate %q: %w", response.ProtocolFee, err)
+	}
+	return FeeRates{RelayerFeeRate: relayerFeeRate, ProtocolFeeRate: protocolFeeRate}, nil
+}
+
+// parseFeeRate parses a fee rate served by the relayer into fixed point
+func parseFeeRate(raw string) (wallet.FixedPoint, error) {
+	rate, err := api_types.ParseDecimal(raw)
+	if err != nil {
+		return wallet.FixedPoint{}, err
+	}
+	if rate.Sign() < 0 || rate.Cmp(api_types.MustParseDecimal("1")) >= 0 {
+		return wallet.FixedPoint{}, errors.New("fee rate must be in [0, 1)")
+	}
+	return wallet.FixedPointFromFloat(rate.Float64()), nil
+}
+
+// GetFeeRates requests the fee rates charged on external matches that trade
+// the given token
+func (c *ExternalMatchClient) GetFeeRates(mint string) (FeeRates, error) {
+	response, err := c.GetExternalMatchFee(mint)
+	if err != nil {
+		return FeeRates{}, err
+	}
+	return ParseFeeRates(response)
+}
+
+// ComputeFees computes the fees the relayer charges on a match, exactly as it
+// does at settlement: each rate is applied separately to the external party's
+// receive amount before fees, and each fee is floored
+func ComputeFees(match *api_types.ApiExternalMatchResult, rates FeeRates) api_types.ApiFee {
+	receive := grossReceiveAmount(match)
+	return api_types.ApiFee{
+		RelayerFee:  api_types.Amount(*rates.RelayerFeeRate.FloorMulInt(receive)),
+		ProtocolFee: api_types.Amount(*rates.ProtocolFeeRate.FloorMulInt(receive)),
+	}
+}
+
+// ComputeTransfers computes the transfers of a match for the external party:
+// the amount it sends, and the amount it receives net of fees, which matches
+// settlement to the wei
+func ComputeTransfers(
+	match *api_types.ApiExternalMatchResult, fees *api_types.ApiFee,
+) (send, receive api_types.ApiExternalAssetTransfer) {
+	// The external party sends the quote token when buying and the base token
+	// when selling
+	send = api_types.ApiExternalAssetTransfer{Mint: match.QuoteMint, Amount: match.QuoteAmount}
+	receive = api_types.ApiExternalAssetTransfer{Mint: match.BaseMint, Amount: match.BaseAmount}
+	if match.Direction == "Sell" {
+		send, receive = receive, send
+	}
+
+	receive.Amount = receive.Amount.Sub(fees.Total())
+	return send, receive
+}
+
+// grossReceiveAmount returns the amount the external party receives from a
+// match before fees
+func grossReceiveAmount(match *api_types.ApiExternalMatchResult) *big.Int {
+	if match.Direction == "Sell" {
+		return new(big.Int).Set((*big.Int)(&match.QuoteAmount))
+	}
+	return new(big.Int).Set((*big.Int)(&match.BaseAmount))
+}
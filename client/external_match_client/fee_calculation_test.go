@@ -0,0 +1,118 @@
+package external_match_client //nolint:revive
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+func TestComputeFees(t *testing.T) {
+	// Fees computed by the relayer on the same matches. Rates are floored to
+	// fixed point, so two basis points of 1000 USDC is a wei short of 0.2 USDC
+	rates := NewFeeRates(0.0002 /* relayer */, 0.0001 /* protocol */)
+	oneEth, _ := new(big.Int).SetString("1000000000000000000", 10)
+	vectors := []struct {
+		direction   string
+		receive     api_types.Amount
+		relayerFee  int64
+		protocolFee int64
+	}{
+		{"Sell", api_types.NewAmount(1_000_000_000), 199_999, 99_999},
+		{"Buy", api_types.Amount(*oneEth), 199_999_999_999_999, 99_999_999_999_999},
+		{"Sell", api_types.NewAmount(12_345), 2, 1},
+		{"Buy", api_types.NewAmount(4_999), 0, 0},
+	}
+
+	for _, v := range vectors {
+		match := &api_types.ApiExternalMatchResult{
+			QuoteMint:   "0xusdc",
+			BaseMint:    "0xweth",
+			QuoteAmount: api_types.NewAmount(7),
+			BaseAmount:  api_types.NewAmount(7),
+			Direction:   v.direction,
+		}
+		if v.direction == "Sell" {
+			match.QuoteAmount = v.receive
+		} else {
+			match.BaseAmount = v.receive
+		}
+
+		fees := ComputeFees(match, rates)
+		assert.Equal(t, big.NewInt(v.relayerFee).String(), fees.RelayerFee.String())
+		assert.Equal(t, big.NewInt(v.protocolFee).String(), fees.ProtocolFee.String())
+
+		// The receive transfer is net of both fees
+		send, receive := ComputeTransfers(match, &fees)
+		assert.Equal(t, api_types.NewAmount(7), send.Amount)
+		expected := v.receive.Sub(fees.Total())
+		assert.Equal(t, expected.String(), receive.Amount.String())
+	}
+}
+
+func TestParseFeeRates(t *testing.T) {
+	rates, err := ParseFeeRates(&api_types.GetExternalMatchFeeResponse{RelayerFee: "0.0002", ProtocolFee: "0.0001"})
+	assert.NoError(t, err)
+	assert.Equal(t, NewFeeRates(0.0002, 0.0001), rates)
+	assert.Equal(t, wallet.FixedPointFromFloat(0.0002), rates.RelayerFeeRate)
+
+	for _, invalid := range []string{"two bps", "NaN", "Inf", "-0.0001", "1", "", "1/5000"} {
+		_, err = ParseFeeRates(&api_types.GetExternalMatchFeeResponse{RelayerFee: invalid, ProtocolFee: "0"})
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestParseFeeRatesRelayerParity(t *testing.T) {
+	// Fixed point reprs of the rates as the relayer computes them: the rate as
+	// a float64, scaled by 2^63 and floored. Where the float64 lies above the
+	// decimal, e.g. 0.0005, flooring the exact decimal would be a unit short
+	vectors := []struct {
+		rate string
+		repr string
+	}{
+		{"0", "0"},
+		{"0.0001", "922337203685477"},
+		{"0.0002", "1844674407370955"},
+		{"2e-4", "1844674407370955"},
+		{"0.00015", "1383505805528216"},
+		{"0.000125", "1152921504606847"},
+		{"0.00025", "2305843009213694"},
+		{"0.0005", "4611686018427388"},
+		{"0.001", "9223372036854776"},
+	}
+
+	for _, v := range vectors {
+		rates, err := ParseFeeRates(&api_types.GetExternalMatchFeeResponse{RelayerFee: v.rate, ProtocolFee: v.rate})
+		assert.NoError(t, err, v.rate)
+		assert.Equal(t, v.repr, rates.RelayerFeeRate.ToReprDecimalString(), v.rate)
+		assert.Equal(t, v.repr, rates.ProtocolFeeRate.ToReprDecimalString(), v.rate)
+	}
+}
+
+func TestSandboxFees(t *testing.T) {
+	options := NewExternalMatchClientOptions().
+		WithSandbox(2000).
+		WithSandboxFeeRates(NewFeeRates(0.0002, 0.0001))
+	c := NewExternalMatchClientWithOptions("", "", "", nil /* apiSecret */, options)
+
+	bundle, err := c.GetExternalMatchBundle(testOrder(t))
+	assert.NoError(t, err)
+	assert.Equal(t, ComputeFees(bundle.MatchResult, options.Sandbox.FeeRates), *bundle.Fees)
+
+	// Selling 100 base for 200000 quote, less fees floored to 39 and 19
+	assert.Equal(t, "199942", bundle.Receive.Amount.String())
+}
+
+func TestSandboxFeeRatesOrderIndependent(t *testing.T) {
+	rates := NewFeeRates(0.0002, 0.0001)
+	before := NewExternalMatchClientOptions().WithSandboxFeeRates(rates).WithSandbox(2000)
+	after := NewExternalMatchClientOptions().WithSandbox(2000).WithSandboxFeeRates(rates)
+	assert.Equal(t, rates, before.Sandbox.FeeRates)
+	assert.Equal(t, after.Sandbox, before.Sandbox)
+
+	// Rates alone do not enable sandbox mode
+	assert.Nil(t, NewExternalMatchClientOptions().WithSandboxFeeRates(rates).Sandbox)
+}
@@ -0,0 +1,68 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+// FeeBreakdown itemizes the fees charged on a bundle. Fees are charged in the
+// token the external party receives
+type FeeBreakdown struct {
+	// Mint is the token in which fees are charged
+	Mint string
+	// RelayerFee is the relayer's fee, in the token's smallest denomination
+	RelayerFee *big.Int
+	// ProtocolFee is the protocol's fee, in the token's smallest denomination
+	ProtocolFee *big.Int
+	// Total is the sum of the relayer and protocol fees
+	Total *big.Int
+	// RelayerFeeUsd is the relayer's fee valued in USD
+	RelayerFeeUsd float64 //nolint:revive
+	// ProtocolFeeUsd is the protocol's fee valued in USD
+	ProtocolFeeUsd float64 //nolint:revive
+	// TotalUsd is the total fee valued in USD
+	TotalUsd float64 //nolint:revive
+	// EffectiveBps is the total fee relative to the notional the fees are
+	// taken from, the external party's receive amount before fees, in basis
+	// points
+	EffectiveBps float64
+}
+
+// FeeBreakdown itemizes the bundle's fees, valuing them at the given USD
+// price per whole unit of the receive token, which has the given decimals
+func (b *ExternalMatchBundle) FeeBreakdown(usdPrice float64, decimals uint8) (*FeeBreakdown, error) {
+	if b.Fees == nil || b.Receive == nil {
+		return nil, errors.New("bundle has no fee information")
+	}
+
+	relayerFee := new(big.Int).Set((*big.Int)(&b.Fees.RelayerFee))
+	protocolFee := new(big.Int).Set((*big.Int)(&b.Fees.ProtocolFee))
+	total := new(big.Int).Add(relayerFee, protocolFee)
+
+	breakdown := &FeeBreakdown{
+		Mint:           b.Receive.Mint,
+		RelayerFee:     relayerFee,
+		ProtocolFee:    protocolFee,
+		Total:          total,
+		RelayerFeeUsd:  usdValue(relayerFee, usdPrice, decimals),
+		ProtocolFeeUsd: usdValue(protocolFee, usdPrice, decimals),
+		TotalUsd:       usdValue(total, usdPrice, decimals),
+	}
+
+	// The receive amount is net of fees
+	notional := new(big.Int).Add((*big.Int)(&b.Receive.Amount), total)
+	if notional.Sign() > 0 {
+		bps, _ := new(big.Rat).SetFrac(total, notional).Float64()
+		breakdown.EffectiveBps = bps * bpsPerUnit
+	}
+
+	return breakdown, nil
+}
+
+// usdValue values an amount in a token's smallest denomination at the given
+// USD price per whole unit of the token
+func usdValue(amount *big.Int, usdPrice float64, decimals uint8) float64 {
+	units, _ := new(big.Float).SetInt(amount).Float64()
+	return units * usdPrice / math.Pow10(int(decimals))
+}
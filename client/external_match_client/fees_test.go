@@ -0,0 +1,35 @@
+package external_match_client //nolint:revive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+func TestFeeBreakdown(t *testing.T) {
+	bundle := &ExternalMatchBundle{
+		Fees: &api_types.ApiFee{
+			RelayerFee:  api_types.NewAmount(1_000_000),
+			ProtocolFee: api_types.NewAmount(500_000),
+		},
+		// 998.5 USDC received after 1.5 USDC of fees
+		Receive: &api_types.ApiExternalAssetTransfer{
+			Mint:   "0xusdc",
+			Amount: api_types.NewAmount(998_500_000),
+		},
+	}
+
+	breakdown, err := bundle.FeeBreakdown(1 /* usdPrice */, 6 /* decimals */)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xusdc", breakdown.Mint)
+	assert.Equal(t, int64(1_500_000), breakdown.Total.Int64())
+	assert.InDelta(t, 1, breakdown.RelayerFeeUsd, 1e-9)
+	assert.InDelta(t, 0.5, breakdown.ProtocolFeeUsd, 1e-9)
+	assert.InDelta(t, 1.5, breakdown.TotalUsd, 1e-9)
+	assert.InDelta(t, 15, breakdown.EffectiveBps, 1e-9)
+
+	_, err = (&ExternalMatchBundle{}).FeeBreakdown(1, 6)
+	assert.Error(t, err)
+}
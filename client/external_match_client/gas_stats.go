@@ -0,0 +1,237 @@
+package external_match_client //nolint:revive
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// BundleKind is the kind of bundle a settlement transaction settles, which
+// determines its gas profile
+type BundleKind string
+
+const (
+	// BundleKindStandard is a bundle settled at a fixed size
+	BundleKindStandard BundleKind = "standard"
+	// BundleKindSponsored is a bundle whose gas is sponsored
+	BundleKindSponsored BundleKind = "sponsored"
+	// BundleKindMalleable is a bundle whose size is chosen at settlement
+	BundleKindMalleable BundleKind = "malleable"
+)
+
+// gasStatsHeader is the header row of a CSV gas stats export
+var gasStatsHeader = []string{
+	"base_mint",
+	"quote_mint",
+	"kind",
+	"settlements",
+	"failures",
+	"total_gas_used",
+	"min_gas_used",
+	"max_gas_used",
+	"mean_gas_used",
+}
+
+// GasStatsKey identifies the settlements a GasUsage aggregates
+type GasStatsKey struct {
+	// BaseMint is the erc20 address of the base token, lower case
+	BaseMint string `json:"base_mint"`
+	// QuoteMint is the erc20 address of the quote token, lower case
+	QuoteMint string `json:"quote_mint"`
+	// Kind is the kind of bundle settled
+	Kind BundleKind `json:"kind"`
+}
+
+// GasUsage aggregates the gas used by settlement transactions
+type GasUsage struct {
+	GasStatsKey
+	// Settlements is the number of settlement receipts recorded
+	Settlements uint64 `json:"settlements"`
+	// Failures is the number of recorded settlements that reverted
+	Failures uint64 `json:"failures"`
+	// TotalGasUsed is the gas used by all recorded settlements
+	TotalGasUsed uint64 `json:"total_gas_used"`
+	// MinGasUsed is the least gas used by a settlement
+	MinGasUsed uint64 `json:"min_gas_used"`
+	// MaxGasUsed is the most gas used by a settlement
+	MaxGasUsed uint64 `json:"max_gas_used"`
+}
+
+// MeanGasUsed returns the mean gas used per settlement
+func (u *GasUsage) MeanGasUsed() uint64 {
+	if u.Settlements == 0 {
+		return 0
+	}
+	return u.TotalGasUsed / u.Settlements
+}
+
+// GasStats collects the realized gas usage of settlement transactions from
+// their receipts, keyed by pair and bundle kind, to calibrate gas limits. It
+// is safe for concurrent use
+type GasStats struct {
+	// mu guards the usage map
+	mu sync.Mutex
+	// usage is the gas usage recorded for each key
+	usage map[GasStatsKey]*GasUsage
+}
+
+// NewGasStats creates an empty gas stats collector
+func NewGasStats() *GasStats {
+	return &GasStats{usage: make(map[GasStatsKey]*GasUsage)}
+}
+
+// RecordReceipt records the gas used by the settlement of a bundle, as
+// reported by its transaction receipt
+func (s *GasStats) RecordReceipt(bundle *ExternalMatchBundle, kind BundleKind, receipt *types.Receipt) error {
+	if bundle.MatchResult == nil {
+		return fmt.Errorf("bundle has no match result")
+	}
+	if receipt == nil {
+		return fmt.Errorf("missing receipt")
+	}
+
+	s.Record(
+		bundle.MatchResult.BaseMint,
+		bundle.MatchResult.QuoteMint,
+		kind,
+		receipt.GasUsed,
+		receipt.Status == types.ReceiptStatusSuccessful,
+	)
+	return nil
+}
+
+// Record records the gas used by a settlement of the given pair and kind
+func (s *GasStats) Record(baseMint, quoteMint string, kind BundleKind, gasUsed uint64, success bool) {
+	key := GasStatsKey{
+		BaseMint:  strings.ToLower(baseMint),
+		QuoteMint: strings.ToLower(quoteMint),
+		Kind:      kind,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage, ok := s.usage[key]
+	if !ok {
+		usage = &GasUsage{GasStatsKey: key, MinGasUsed: gasUsed}
+		s.usage[key] = usage
+	}
+
+	usage.Settlements++
+	if !success {
+		usage.Failures++
+	}
+	usage.TotalGasUsed += gasUsed
+	usage.MinGasUsed = min(usage.MinGasUsed, gasUsed)
+	usage.MaxGasUsed = max(usage.MaxGasUsed, gasUsed)
+}
+
+// Usage returns the gas usage recorded for a pair and kind, if any
+func (s *GasStats) Usage(baseMint, quoteMint string, kind BundleKind) (GasUsage, bool) {
+	key := GasStatsKey{
+		BaseMint:  strings.ToLower(baseMint),
+		QuoteMint: strings.ToLower(quoteMint),
+		Kind:      kind,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	usage, ok := s.usage[key]
+	if !ok {
+		return GasUsage{}, false
+	}
+	return *usage, true
+}
+
+// SuggestGasLimit suggests a gas limit for settling a pair and kind: the most
+// gas used by a recorded settlement, plus a margin in basis points. Returns
+// false if no settlement has been recorded
+func (s *GasStats) SuggestGasLimit(
+	baseMint, quoteMint string, kind BundleKind, marginBps uint64,
+) (uint64, bool) {
+	usage, ok := s.Usage(baseMint, quoteMint, kind)
+	if !ok {
+		return 0, false
+	}
+
+	limit := new(big.Int).SetUint64(usage.MaxGasUsed)
+	limit.Mul(limit, new(big.Int).SetUint64(bpsPerUnit+marginBps))
+	limit.Quo(limit, big.NewInt(bpsPerUnit))
+	if !limit.IsUint64() {
+		return 0, false
+	}
+	return limit.Uint64(), true
+}
+
+// Snapshot returns the gas usage of every recorded pair and kind, ordered by
+// pair then kind
+func (s *GasStats) Snapshot() []GasUsage {
+	s.mu.Lock()
+	snapshot := make([]GasUsage, 0, len(s.usage))
+	for _, usage := range s.usage {
+		snapshot = append(snapshot, *usage)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		a, b := snapshot[i].GasStatsKey, snapshot[j].GasStatsKey
+		if a.BaseMint != b.BaseMint {
+			return a.BaseMint < b.BaseMint
+		}
+		if a.QuoteMint != b.QuoteMint {
+			return a.QuoteMint < b.QuoteMint
+		}
+		return a.Kind < b.Kind
+	})
+	return snapshot
+}
+
+// Export writes the recorded gas usage to w in the given format
+func (s *GasStats) Export(w io.Writer, format SnapshotFormat) error {
+	snapshot := s.Snapshot()
+	switch format {
+	case SnapshotFormatJSON:
+		return json.NewEncoder(w).Encode(snapshot)
+	case SnapshotFormatCSV:
+		return writeGasStatsCSV(w, snapshot)
+	default:
+		return fmt.Errorf("unsupported snapshot format: %q", format)
+	}
+}
+
+// writeGasStatsCSV encodes gas usage as CSV
+func writeGasStatsCSV(w io.Writer, snapshot []GasUsage) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(gasStatsHeader); err != nil {
+		return err
+	}
+
+	for i := range snapshot {
+		usage := &snapshot[i]
+		row := []string{
+			usage.BaseMint,
+			usage.QuoteMint,
+			string(usage.Kind),
+			strconv.FormatUint(usage.Settlements, 10),
+			strconv.FormatUint(usage.Failures, 10),
+			strconv.FormatUint(usage.TotalGasUsed, 10),
+			strconv.FormatUint(usage.MinGasUsed, 10),
+			strconv.FormatUint(usage.MaxGasUsed, 10),
+			strconv.FormatUint(usage.MeanGasUsed(), 10),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,65 @@
+package external_match_client //nolint:revive
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+func TestGasStats(t *testing.T) {
+	stats := NewGasStats()
+	bundle := &ExternalMatchBundle{MatchResult: &api_types.ApiExternalMatchResult{BaseMint: "0xAB", QuoteMint: "0xCD"}}
+
+	assert.NoError(t, stats.RecordReceipt(bundle, BundleKindStandard, &types.Receipt{
+		Status: types.ReceiptStatusSuccessful, GasUsed: 400_000,
+	}))
+	assert.NoError(t, stats.RecordReceipt(bundle, BundleKindStandard, &types.Receipt{
+		Status: types.ReceiptStatusFailed, GasUsed: 200_000,
+	}))
+	stats.Record("0xab", "0xcd", BundleKindSponsored, 500_000, true /* success */)
+
+	// Mints are matched case-insensitively
+	usage, ok := stats.Usage("0xab", "0xcd", BundleKindStandard)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), usage.Settlements)
+	assert.Equal(t, uint64(1), usage.Failures)
+	assert.Equal(t, uint64(200_000), usage.MinGasUsed)
+	assert.Equal(t, uint64(400_000), usage.MaxGasUsed)
+	assert.Equal(t, uint64(300_000), usage.MeanGasUsed())
+
+	limit, ok := stats.SuggestGasLimit("0xAB", "0xCD", BundleKindStandard, 2_500 /* marginBps */)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(500_000), limit)
+
+	_, ok = stats.SuggestGasLimit("0xAB", "0xCD", BundleKindMalleable, 0 /* marginBps */)
+	assert.False(t, ok)
+
+	assert.Error(t, stats.RecordReceipt(&ExternalMatchBundle{}, BundleKindStandard, &types.Receipt{}))
+}
+
+func TestGasStatsExport(t *testing.T) {
+	stats := NewGasStats()
+	stats.Record("0xab", "0xcd", BundleKindStandard, 400_000, true /* success */)
+	stats.Record("0xab", "0xcd", BundleKindMalleable, 450_000, true /* success */)
+
+	var buf bytes.Buffer
+	assert.NoError(t, stats.Export(&buf, SnapshotFormatCSV))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, strings.Join(gasStatsHeader, ","), lines[0])
+	assert.Equal(t, "0xab,0xcd,malleable,1,0,450000,450000,450000,450000", lines[1])
+
+	buf.Reset()
+	assert.NoError(t, stats.Export(&buf, SnapshotFormatJSON))
+	var usage []GasUsage
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &usage))
+	assert.Equal(t, stats.Snapshot(), usage)
+
+	assert.Error(t, stats.Export(&buf, "xml"))
+}
@@ -0,0 +1,114 @@
+package external_match_client //nolint:revive
+
+import (
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// QuoteReceivedEvent reports a quote received from the relayer
+type QuoteReceivedEvent struct {
+	// Order is the order the quote was requested for
+	Order *api_types.ApiExternalOrder
+	// Quote is the signed quote
+	Quote *api_types.ApiSignedQuote
+	// RequestID is the correlation ID of the quote request, empty for
+	// sandbox quotes
+	RequestID string
+}
+
+// BundleAssembledEvent reports a newly assembled bundle
+type BundleAssembledEvent struct {
+	// Bundle is the assembled bundle
+	Bundle *ExternalMatchBundle
+	// Quote is the quote the bundle was assembled from, nil for a direct match
+	Quote *api_types.ApiSignedQuote
+}
+
+// SettlementSubmittedEvent reports a bundle's settlement transaction being
+// broadcast
+type SettlementSubmittedEvent struct {
+	// Bundle is the bundle being settled
+	Bundle *ExternalMatchBundle
+	// TxHash is the hash of the settlement transaction
+	TxHash geth_common.Hash
+}
+
+// SettlementConfirmedEvent reports a bundle's settlement transaction being
+// mined, successfully or not
+type SettlementConfirmedEvent struct {
+	// Bundle is the settled bundle
+	Bundle *ExternalMatchBundle
+	// Receipt is the settlement transaction's receipt
+	Receipt *types.Receipt
+}
+
+// Succeeded returns whether the settlement transaction succeeded
+func (e SettlementConfirmedEvent) Succeeded() bool {
+	return e.Receipt != nil && e.Receipt.Status == types.ReceiptStatusSuccessful
+}
+
+// OnQuoteReceived registers a callback invoked with every quote the client
+// receives, returning a function that unregisters it. Callbacks run
+// synchronously before the quote is returned, so they should not block
+func (c *ExternalMatchClient) OnQuoteReceived(callback func(QuoteReceivedEvent)) (unregister func()) {
+	return c.quoteObservers.Register(callback)
+}
+
+// OnBundleAssembled registers a callback invoked with every bundle the client
+// assembles, from a quote or as a direct match, returning a function that
+// unregisters it. Bundles served from the assembly cache are not reported
+// again
+func (c *ExternalMatchClient) OnBundleAssembled(callback func(BundleAssembledEvent)) (unregister func()) {
+	return c.bundleObservers.Register(callback)
+}
+
+// OnSettlementSubmitted registers a callback invoked with every settlement
+// reported through RecordSettlementSubmitted, returning a function that
+// unregisters it
+func (c *ExternalMatchClient) OnSettlementSubmitted(
+	callback func(SettlementSubmittedEvent),
+) (unregister func()) {
+	return c.submittedObservers.Register(callback)
+}
+
+// OnSettlementConfirmed registers a callback invoked with every settlement
+// reported through RecordSettlementConfirmed, returning a function that
+// unregisters it
+func (c *ExternalMatchClient) OnSettlementConfirmed(
+	callback func(SettlementConfirmedEvent),
+) (unregister func()) {
+	return c.confirmedObservers.Register(callback)
+}
+
+// RecordSettlementSubmitted reports that a bundle's settlement transaction
+// was broadcast. The client does not submit transactions itself, so whatever
+// submits them calls this to notify the OnSettlementSubmitted observers
+func (c *ExternalMatchClient) RecordSettlementSubmitted(bundle *ExternalMatchBundle, txHash geth_common.Hash) {
+	c.submittedObservers.Notify(SettlementSubmittedEvent{Bundle: bundle, TxHash: txHash})
+}
+
+// RecordSettlementConfirmed reports that a bundle's settlement transaction
+// was mined, notifying the OnSettlementConfirmed observers
+func (c *ExternalMatchClient) RecordSettlementConfirmed(bundle *ExternalMatchBundle, receipt *types.Receipt) {
+	c.confirmedObservers.Notify(SettlementConfirmedEvent{Bundle: bundle, Receipt: receipt})
+}
+
+// notifyQuoteReceived reports a received quote, if any
+func (c *ExternalMatchClient) notifyQuoteReceived(
+	order *api_types.ApiExternalOrder, quote *api_types.ApiSignedQuote, requestID string,
+) {
+	if quote != nil {
+		c.quoteObservers.Notify(QuoteReceivedEvent{Order: order, Quote: quote, RequestID: requestID})
+	}
+}
+
+// notifyBundleAssembled reports an assembled bundle, if any
+func (c *ExternalMatchClient) notifyBundleAssembled(
+	bundle *ExternalMatchBundle, quote *api_types.ApiSignedQuote,
+) {
+	if bundle != nil {
+		c.bundleObservers.Notify(BundleAssembledEvent{Bundle: bundle, Quote: quote})
+	}
+}
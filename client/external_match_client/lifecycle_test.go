@@ -0,0 +1,64 @@
+package external_match_client //nolint:revive
+
+import (
+	"testing"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycleObservers(t *testing.T) {
+	client := NewSandboxExternalMatchClient(2.5)
+
+	var quotes []QuoteReceivedEvent
+	var bundles []BundleAssembledEvent
+	client.OnQuoteReceived(func(e QuoteReceivedEvent) { quotes = append(quotes, e) })
+	unregister := client.OnBundleAssembled(func(e BundleAssembledEvent) { bundles = append(bundles, e) })
+
+	order := testOrder(t)
+	quote, err := client.GetExternalMatchQuote(order)
+	assert.NoError(t, err)
+	bundle, err := client.AssembleExternalQuote(quote)
+	assert.NoError(t, err)
+
+	assert.Len(t, quotes, 1)
+	assert.Same(t, order, quotes[0].Order)
+	assert.Same(t, quote, quotes[0].Quote)
+	assert.Len(t, bundles, 1)
+	assert.Same(t, bundle, bundles[0].Bundle)
+	assert.Same(t, quote, bundles[0].Quote)
+
+	// Direct matches are reported without a quote
+	direct, err := client.GetExternalMatchBundle(order)
+	assert.NoError(t, err)
+	assert.Len(t, bundles, 2)
+	assert.Same(t, direct, bundles[1].Bundle)
+	assert.Nil(t, bundles[1].Quote)
+
+	// Unregistered observers are no longer notified
+	unregister()
+	_, err = client.GetExternalMatchBundle(order)
+	assert.NoError(t, err)
+	assert.Len(t, bundles, 2)
+}
+
+func TestSettlementObservers(t *testing.T) {
+	client := NewSandboxExternalMatchClient(2.5)
+	bundle := &ExternalMatchBundle{}
+	hash := geth_common.HexToHash("0x1")
+
+	var submitted []SettlementSubmittedEvent
+	var confirmed []SettlementConfirmedEvent
+	client.OnSettlementSubmitted(func(e SettlementSubmittedEvent) { submitted = append(submitted, e) })
+	client.OnSettlementConfirmed(func(e SettlementConfirmedEvent) { confirmed = append(confirmed, e) })
+
+	client.RecordSettlementSubmitted(bundle, hash)
+	client.RecordSettlementConfirmed(bundle, &types.Receipt{TxHash: hash, Status: types.ReceiptStatusFailed})
+
+	assert.Len(t, submitted, 1)
+	assert.Equal(t, hash, submitted[0].TxHash)
+	assert.Len(t, confirmed, 1)
+	assert.Same(t, bundle, confirmed[0].Bundle)
+	assert.False(t, confirmed[0].Succeeded())
+}
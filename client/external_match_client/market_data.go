@@ -0,0 +1,213 @@
+package external_match_client //nolint:revive
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// The market data schema normalizes Renegade prices, depth and trades into flat
+// tick and bar records, so that they can be loaded by research pipelines
+// alongside data from other venues. Timestamps are in milliseconds since the
+// epoch, symbols are token mints, and amounts are decimal strings in the base
+// token's smallest denomination, as floats would lose precision. The struct
+// tags name the columns for CSV, JSON and Parquet writers
+
+// TickKind distinguishes price observations from trades
+type TickKind string
+
+const (
+	// TickKindQuote is a price observation from the relayer's price feed,
+	// with the depth resting on each side
+	TickKindQuote TickKind = "quote"
+	// TickKindTrade is a settled trade
+	TickKindTrade TickKind = "trade"
+)
+
+// Tick is a single price observation or trade
+type Tick struct {
+	// TimestampMs is the time of the observation or trade
+	TimestampMs int64 `json:"timestamp_ms" parquet:"timestamp_ms"`
+	// Symbol is the mint of the base token
+	Symbol string `json:"symbol" parquet:"symbol"`
+	// Kind is the kind of the tick
+	Kind TickKind `json:"kind" parquet:"kind"`
+	// Price is the price per whole base token. Its unit depends on the kind:
+	// quote ticks from the relayer's price feed, see TickFromDepth, are in
+	// USD, while trade ticks are in whole quote tokens
+	Price float64 `json:"price" parquet:"price"`
+	// Size is the base amount traded, empty for quotes
+	Size string `json:"size" parquet:"size"`
+	// BidSize is the base amount resting on the buy side, empty for trades
+	BidSize string `json:"bid_size" parquet:"bid_size"`
+	// AskSize is the base amount resting on the sell side, empty for trades
+	AskSize string `json:"ask_size" parquet:"ask_size"`
+}
+
+// Bar is an open, high, low and close bar of a fixed interval
+type Bar struct {
+	// TimestampMs is the start of the bar's interval
+	TimestampMs int64 `json:"timestamp_ms" parquet:"timestamp_ms"`
+	// Symbol is the mint of the base token
+	Symbol string `json:"symbol" parquet:"symbol"`
+	// IntervalMs is the duration of the bar
+	IntervalMs int64   `json:"interval_ms" parquet:"interval_ms"`
+	Open       float64 `json:"open" parquet:"open"`
+	High       float64 `json:"high" parquet:"high"`
+	Low        float64 `json:"low" parquet:"low"`
+	Close      float64 `json:"close" parquet:"close"`
+	// Volume is the base amount traded in the bar
+	Volume string `json:"volume" parquet:"volume"`
+	// Samples is the number of prices aggregated into the bar
+	Samples int `json:"samples" parquet:"samples"`
+}
+
+// TickFromDepth converts a token's price and depth into a quote tick, priced
+// in USD per whole token
+func TickFromDepth(depth *api_types.ApiPriceAndDepth) Tick {
+	return Tick{
+		TimestampMs: int64(depth.Timestamp), //nolint:gosec
+		Symbol:      depth.Address,
+		Kind:        TickKindQuote,
+		Price:       depth.Price,
+		BidSize:     depth.Buy.TotalQuantity.String(),
+		AskSize:     depth.Sell.TotalQuantity.String(),
+	}
+}
+
+// Bars converts the series into bars. Prices are in the units the candles
+// were built in
+func (s *CandleSeries) Bars() []Bar {
+	bars := make([]Bar, 0, len(s.Candles))
+	for _, candle := range s.Candles {
+		volume := "0"
+		if candle.Volume != nil {
+			volume = candle.Volume.String()
+		}
+		bars = append(bars, Bar{
+			TimestampMs: candle.Start.UnixMilli(),
+			Symbol:      s.Mint,
+			IntervalMs:  s.Interval.Milliseconds(),
+			Open:        candle.Open,
+			High:        candle.High,
+			Low:         candle.Low,
+			Close:       candle.Close,
+			Volume:      volume,
+			Samples:     candle.Samples,
+		})
+	}
+	return bars
+}
+
+// MarketDataSink receives normalized market data. The SDK provides a CSV sink;
+// other formats, e.g. Parquet, are supported by wrapping a writer for that
+// format, whose rows can be the Tick and Bar structs themselves
+type MarketDataSink interface {
+	// WriteTicks writes a batch of ticks
+	WriteTicks(ticks []Tick) error
+	// WriteBars writes a batch of bars
+	WriteBars(bars []Bar) error
+}
+
+// tickHeader is the header row of a CSV tick file
+var tickHeader = []string{"timestamp_ms", "symbol", "kind", "price", "size", "bid_size", "ask_size"}
+
+// barHeader is the header row of a CSV bar file
+var barHeader = []string{
+	"timestamp_ms", "symbol", "interval_ms", "open", "high", "low", "close", "volume", "samples",
+}
+
+// CSVSink writes ticks and bars as CSV, each to its own writer with a header
+// row before the first record
+type CSVSink struct {
+	ticks *csv.Writer
+	bars  *csv.Writer
+
+	wroteTickHeader bool
+	wroteBarHeader  bool
+}
+
+// NewCSVSink creates a sink writing ticks and bars to the given writers.
+// Either may be nil if that kind of data is not written
+func NewCSVSink(ticks, bars io.Writer) *CSVSink {
+	sink := &CSVSink{}
+	if ticks != nil {
+		sink.ticks = csv.NewWriter(ticks)
+	}
+	if bars != nil {
+		sink.bars = csv.NewWriter(bars)
+	}
+	return sink
+}
+
+// WriteTicks implements MarketDataSink
+func (s *CSVSink) WriteTicks(ticks []Tick) error {
+	if s.ticks == nil {
+		return errors.New("csv sink has no tick writer")
+	}
+	if !s.wroteTickHeader {
+		if err := s.ticks.Write(tickHeader); err != nil {
+			return err
+		}
+		s.wroteTickHeader = true
+	}
+
+	for _, tick := range ticks {
+		row := []string{
+			strconv.FormatInt(tick.TimestampMs, 10),
+			tick.Symbol,
+			string(tick.Kind),
+			formatFloat(tick.Price),
+			tick.Size,
+			tick.BidSize,
+			tick.AskSize,
+		}
+		if err := s.ticks.Write(row); err != nil {
+			return err
+		}
+	}
+
+	s.ticks.Flush()
+	return s.ticks.Error()
+}
+
+// WriteBars implements MarketDataSink
+func (s *CSVSink) WriteBars(bars []Bar) error {
+	if s.bars == nil {
+		return errors.New("csv sink has no bar writer")
+	}
+	if !s.wroteBarHeader {
+		if err := s.bars.Write(barHeader); err != nil {
+			return err
+		}
+		s.wroteBarHeader = true
+	}
+
+	for _, bar := range bars {
+		row := []string{
+			strconv.FormatInt(bar.TimestampMs, 10),
+			bar.Symbol,
+			strconv.FormatInt(bar.IntervalMs, 10),
+			formatFloat(bar.Open),
+			formatFloat(bar.High),
+			formatFloat(bar.Low),
+			formatFloat(bar.Close),
+			bar.Volume,
+			strconv.Itoa(bar.Samples),
+		}
+		if err := s.bars.Write(row); err != nil {
+			return err
+		}
+	}
+
+	s.bars.Flush()
+	return s.bars.Error()
+}
+
+// formatFloat formats a float with the fewest digits that represent it
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,54 @@
+package external_match_client //nolint:revive
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+func TestTickConversions(t *testing.T) {
+	depth := &api_types.ApiPriceAndDepth{
+		Address:   "0x1",
+		Price:     2500.5,
+		Timestamp: 1_700_000_000_000,
+		Buy:       api_types.ApiDepthSide{TotalQuantity: api_types.NewAmount(10)},
+		Sell:      api_types.ApiDepthSide{TotalQuantity: api_types.NewAmount(20)},
+	}
+	tick := TickFromDepth(depth)
+	assert.Equal(t, TickKindQuote, tick.Kind)
+	assert.Equal(t, 2500.5, tick.Price)
+	assert.Equal(t, "10", tick.BidSize)
+	assert.Equal(t, "20", tick.AskSize)
+	assert.Empty(t, tick.Size)
+}
+
+func TestCSVSink(t *testing.T) {
+	builder, err := NewCandleBuilder("0x1", time.Minute)
+	assert.NoError(t, err)
+	builder.AddTrade(time.UnixMilli(60_000), 1.5, big.NewInt(3))
+	builder.AddPrice(time.UnixMilli(90_000), 2)
+
+	var ticks, bars bytes.Buffer
+	sink := NewCSVSink(&ticks, &bars)
+	assert.NoError(t, sink.WriteBars(builder.Series().Bars()))
+	assert.NoError(t, sink.WriteTicks([]Tick{{TimestampMs: 1, Symbol: "0x1", Kind: TickKindTrade, Price: 0.1, Size: "7"}}))
+	assert.NoError(t, sink.WriteTicks([]Tick{{TimestampMs: 2, Symbol: "0x1", Kind: TickKindTrade, Price: 0.2, Size: "8"}}))
+
+	assert.Equal(t,
+		"timestamp_ms,symbol,interval_ms,open,high,low,close,volume,samples\n"+
+			"60000,0x1,60000,1.5,2,1.5,2,3,2\n",
+		bars.String())
+	assert.Equal(t,
+		"timestamp_ms,symbol,kind,price,size,bid_size,ask_size\n"+
+			"1,0x1,trade,0.1,7,,\n"+
+			"2,0x1,trade,0.2,8,,\n",
+		ticks.String())
+
+	var _ MarketDataSink = sink
+	assert.Error(t, NewCSVSink(nil, nil).WriteTicks(nil))
+}
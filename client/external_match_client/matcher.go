@@ -0,0 +1,221 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// ErrNoBundleFunc is returned by a FakeExternalMatcher's direct bundle
+// requests when no BundleFunc is scripted
+var ErrNoBundleFunc = errors.New("fake external matcher has no BundleFunc")
+
+// ExternalMatcher is the set of external match operations implemented by
+// ExternalMatchClient. Code that consumes the client should depend on this
+// interface so that it can be tested against a FakeExternalMatcher
+type ExternalMatcher interface {
+	// GetSupportedTokens requests the list of supported tokens
+	GetSupportedTokens() ([]api_types.ApiToken, error)
+	// GetExternalMatchQuote requests a quote with the given options, returning
+	// nil if no match is found
+	GetExternalMatchQuote(
+		order *api_types.ApiExternalOrder, opts ...QuoteOption,
+	) (*api_types.ApiSignedQuote, error)
+	// GetExternalMatchQuoteWithOptions requests a quote with the given options,
+	// returning nil if no match is found
+	GetExternalMatchQuoteWithOptions(
+		order *api_types.ApiExternalOrder, options *ExternalQuoteOptions,
+	) (*api_types.ApiSignedQuote, error)
+	// AssembleExternalQuote assembles a signed quote into a bundle with the
+	// given options, returning nil if no match is found
+	AssembleExternalQuote(
+		quote *api_types.ApiSignedQuote, opts ...AssembleOption,
+	) (*ExternalMatchBundle, error)
+	// AssembleExternalMatchWithOptions assembles a signed quote into a bundle
+	// with the given options, returning nil if no match is found
+	AssembleExternalMatchWithOptions(
+		quote *api_types.ApiSignedQuote, options *AssembleExternalMatchOptions,
+	) (*ExternalMatchBundle, error)
+	// GetExternalMatchBundle requests a bundle directly with the given
+	// options, returning nil if no match is found
+	GetExternalMatchBundle(
+		order *api_types.ApiExternalOrder, opts ...AssembleOption,
+	) (*ExternalMatchBundle, error)
+	// GetMarketDepth requests the price and order book depth of a token
+	GetMarketDepth(mint string) (*api_types.ApiPriceAndDepth, error)
+	// GetMarketDepths requests the price and order book depth of every
+	// supported token
+	GetMarketDepths() ([]api_types.ApiPriceAndDepth, error)
+	// GetMarket requests the price and order book depth of a token's market
+	// from the v2 markets API
+	GetMarket(mint string) (*api_types.ApiMarketDepth, error)
+}
+
+var _ ExternalMatcher = (*ExternalMatchClient)(nil)
+
+// FakeExternalMatcher is an in-memory ExternalMatcher with scriptable
+// responses, for testing code that consumes the client without network
+// access.
+//
+// Each match method calls the corresponding function field if it is set and
+// otherwise reports no match, except direct bundle requests, which fail with
+// ErrNoBundleFunc; the market methods answer from the Depths and
+// Markets fields. Requests are recorded for later assertions
+type FakeExternalMatcher struct {
+	// Tokens is the list returned by GetSupportedTokens
+	Tokens []api_types.ApiToken
+	// Depths are the prices and depths returned by GetMarketDepths, and by
+	// GetMarketDepth for their tokens
+	Depths []api_types.ApiPriceAndDepth
+	// Markets are the markets returned by GetMarket for their base tokens
+	Markets []api_types.ApiMarketDepth
+	// QuoteFunc, if set, answers quote requests
+	QuoteFunc func(
+		order *api_types.ApiExternalOrder, options *ExternalQuoteOptions,
+	) (*api_types.ApiSignedQuote, error)
+	// AssembleFunc, if set, answers assembly requests
+	AssembleFunc func(
+		quote *api_types.ApiSignedQuote, options *AssembleExternalMatchOptions,
+	) (*ExternalMatchBundle, error)
+	// BundleFunc answers direct bundle requests; without it they fail with
+	// ErrNoBundleFunc
+	BundleFunc func(
+		order *api_types.ApiExternalOrder, options *AssembleExternalMatchOptions,
+	) (*ExternalMatchBundle, error)
+
+	mu             sync.Mutex
+	quoteRequests  []*api_types.ApiExternalOrder
+	assembleQuotes []*api_types.ApiSignedQuote
+	bundleRequests []*api_types.ApiExternalOrder
+	bundleOptions  []*AssembleExternalMatchOptions
+}
+
+var _ ExternalMatcher = (*FakeExternalMatcher)(nil)
+
+// GetSupportedTokens returns the fake's tokens
+func (f *FakeExternalMatcher) GetSupportedTokens() ([]api_types.ApiToken, error) {
+	return f.Tokens, nil
+}
+
+// GetExternalMatchQuote records the order and answers it with QuoteFunc
+func (f *FakeExternalMatcher) GetExternalMatchQuote(
+	order *api_types.ApiExternalOrder, opts ...QuoteOption,
+) (*api_types.ApiSignedQuote, error) {
+	return f.GetExternalMatchQuoteWithOptions(order, newQuoteOptions(opts))
+}
+
+// GetExternalMatchQuoteWithOptions records the order and answers it with
+// QuoteFunc
+func (f *FakeExternalMatcher) GetExternalMatchQuoteWithOptions(
+	order *api_types.ApiExternalOrder,
+	options *ExternalQuoteOptions,
+) (*api_types.ApiSignedQuote, error) {
+	f.mu.Lock()
+	f.quoteRequests = append(f.quoteRequests, order)
+	f.mu.Unlock()
+
+	if f.QuoteFunc == nil {
+		return nil, nil
+	}
+	return f.QuoteFunc(order, options)
+}
+
+// AssembleExternalQuote records the quote and answers it with AssembleFunc
+func (f *FakeExternalMatcher) AssembleExternalQuote(
+	quote *api_types.ApiSignedQuote, opts ...AssembleOption,
+) (*ExternalMatchBundle, error) {
+	return f.AssembleExternalMatchWithOptions(quote, newAssembleOptions(opts))
+}
+
+// AssembleExternalMatchWithOptions records the quote and answers it with
+// AssembleFunc
+func (f *FakeExternalMatcher) AssembleExternalMatchWithOptions(
+	quote *api_types.ApiSignedQuote,
+	options *AssembleExternalMatchOptions,
+) (*ExternalMatchBundle, error) {
+	f.mu.Lock()
+	f.assembleQuotes = append(f.assembleQuotes, quote)
+	f.mu.Unlock()
+
+	if f.AssembleFunc == nil {
+		return nil, nil
+	}
+	return f.AssembleFunc(quote, options)
+}
+
+// GetExternalMatchBundle records the order and its options and answers them
+// with BundleFunc
+func (f *FakeExternalMatcher) GetExternalMatchBundle(
+	order *api_types.ApiExternalOrder, opts ...AssembleOption,
+) (*ExternalMatchBundle, error) {
+	options := newAssembleOptions(opts)
+	f.mu.Lock()
+	f.bundleRequests = append(f.bundleRequests, order)
+	f.bundleOptions = append(f.bundleOptions, options)
+	f.mu.Unlock()
+
+	if f.BundleFunc == nil {
+		return nil, ErrNoBundleFunc
+	}
+	return f.BundleFunc(order, options)
+}
+
+// GetMarketDepth returns the fake's depth for the token, or an error if it
+// has none
+func (f *FakeExternalMatcher) GetMarketDepth(mint string) (*api_types.ApiPriceAndDepth, error) {
+	for i := range f.Depths {
+		if f.Depths[i].Token().Equal(api_types.Mint(mint)) {
+			depth := f.Depths[i]
+			return &depth, nil
+		}
+	}
+	return nil, fmt.Errorf("no depth for token %s", mint)
+}
+
+// GetMarketDepths returns the fake's depths
+func (f *FakeExternalMatcher) GetMarketDepths() ([]api_types.ApiPriceAndDepth, error) {
+	return f.Depths, nil
+}
+
+// GetMarket returns the fake's market with the token as its base, or an
+// error if it has none
+func (f *FakeExternalMatcher) GetMarket(mint string) (*api_types.ApiMarketDepth, error) {
+	for i := range f.Markets {
+		if api_types.Mint(f.Markets[i].Market.Base.Address).Equal(api_types.Mint(mint)) {
+			market := f.Markets[i]
+			return &market, nil
+		}
+	}
+	return nil, fmt.Errorf("no market for token %s", mint)
+}
+
+// QuoteRequests returns the orders quoted so far
+func (f *FakeExternalMatcher) QuoteRequests() []*api_types.ApiExternalOrder {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*api_types.ApiExternalOrder(nil), f.quoteRequests...)
+}
+
+// AssembledQuotes returns the quotes assembled so far
+func (f *FakeExternalMatcher) AssembledQuotes() []*api_types.ApiSignedQuote {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*api_types.ApiSignedQuote(nil), f.assembleQuotes...)
+}
+
+// BundleRequests returns the orders for which bundles were requested so far
+func (f *FakeExternalMatcher) BundleRequests() []*api_types.ApiExternalOrder {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*api_types.ApiExternalOrder(nil), f.bundleRequests...)
+}
+
+// BundleOptions returns the options of the bundle requests so far, in the
+// order of BundleRequests
+func (f *FakeExternalMatcher) BundleOptions() []*AssembleExternalMatchOptions {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*AssembleExternalMatchOptions(nil), f.bundleOptions...)
+}
@@ -0,0 +1,92 @@
+package external_match_client //nolint:revive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// quoteAndAssemble is a consumer of the client under test
+func quoteAndAssemble(
+	matcher ExternalMatcher, order *api_types.ApiExternalOrder,
+) (*ExternalMatchBundle, error) {
+	quote, err := matcher.GetExternalMatchQuote(order)
+	if err != nil || quote == nil {
+		return nil, err
+	}
+	return matcher.AssembleExternalQuote(quote)
+}
+
+func TestFakeExternalMatcher(t *testing.T) {
+	sandbox := NewSandboxExternalMatchClient(2)
+	fake := &FakeExternalMatcher{
+		QuoteFunc:    sandbox.GetExternalMatchQuoteWithOptions,
+		AssembleFunc: sandbox.AssembleExternalMatchWithOptions,
+	}
+
+	order := testOrder(t)
+	bundle, err := quoteAndAssemble(fake, order)
+	assert.NoError(t, err)
+	assert.NotNil(t, bundle)
+	assert.Equal(t, []*api_types.ApiExternalOrder{order}, fake.QuoteRequests())
+	assert.Len(t, fake.AssembledQuotes(), 1)
+
+	// An unscripted fake reports no match
+	bundle, err = quoteAndAssemble(&FakeExternalMatcher{}, order)
+	assert.NoError(t, err)
+	assert.Nil(t, bundle)
+}
+
+func TestFakeExternalMatcherMarkets(t *testing.T) {
+	fake := &FakeExternalMatcher{
+		Depths: []api_types.ApiPriceAndDepth{{Address: "0xAbC", Price: 2500}},
+		Markets: []api_types.ApiMarketDepth{{
+			Market: api_types.ApiMarketInfo{Base: api_types.ApiToken{Address: "0xabc"}},
+		}},
+	}
+
+	// Depths are looked up by mint, whatever its case
+	depth, err := fake.GetMarketDepth("0xabc")
+	assert.NoError(t, err)
+	assert.Equal(t, 2500.0, depth.Price)
+	depths, err := fake.GetMarketDepths()
+	assert.NoError(t, err)
+	assert.Len(t, depths, 1)
+	market, err := fake.GetMarket("0xABC")
+	assert.NoError(t, err)
+	assert.Equal(t, "0xabc", market.Market.Base.Address)
+
+	// Tokens the fake has no depth for are an error
+	_, err = fake.GetMarketDepth("0xdef")
+	assert.Error(t, err)
+	_, err = fake.GetMarket("0xdef")
+	assert.Error(t, err)
+}
+
+func TestFakeExternalMatcherBundle(t *testing.T) {
+	order := testOrder(t)
+
+	// Without a scripted BundleFunc a bundle request fails
+	_, err := (&FakeExternalMatcher{}).GetExternalMatchBundle(order)
+	assert.ErrorIs(t, err, ErrNoBundleFunc)
+
+	// The options are recorded and passed to BundleFunc
+	var got *AssembleExternalMatchOptions
+	fake := &FakeExternalMatcher{
+		BundleFunc: func(
+			_ *api_types.ApiExternalOrder, options *AssembleExternalMatchOptions,
+		) (*ExternalMatchBundle, error) {
+			got = options
+			return &ExternalMatchBundle{}, nil
+		},
+	}
+	bundle, err := fake.GetExternalMatchBundle(order, WithReceiver("0x1"), WithGasEstimation())
+	assert.NoError(t, err)
+	assert.NotNil(t, bundle)
+	assert.Equal(t, "0x1", *got.ReceiverAddress)
+	assert.True(t, got.DoGasEstimation)
+	assert.Equal(t, []*api_types.ApiExternalOrder{order}, fake.BundleRequests())
+	assert.Equal(t, []*AssembleExternalMatchOptions{got}, fake.BundleOptions())
+}
@@ -0,0 +1,148 @@
+package external_match_client //nolint:revive
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// Keys of the cached metadata responses
+const (
+	supportedTokensCacheKey  = "supported-tokens"
+	exchangeMetadataCacheKey = "exchange-metadata"
+	externalMatchFeeCacheKey = "external-match-fee:"
+)
+
+// metadataCache is an in-memory cache of responses from endpoints whose
+// contents rarely change, such as the supported tokens and fee rates
+type metadataCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]metadataCacheEntry
+}
+
+// metadataCacheEntry is a cached response and the time after which it is stale
+type metadataCacheEntry struct {
+	value  interface{}
+	expiry time.Time
+}
+
+// newMetadataCache creates a metadata cache whose entries live for the given TTL
+func newMetadataCache(ttl time.Duration) *metadataCache {
+	return &metadataCache{
+		ttl:     ttl,
+		entries: make(map[string]metadataCacheEntry),
+	}
+}
+
+// get returns the cached value for the key, if one exists and is not stale
+func (c *metadataCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !time.Now().Before(entry.expiry) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+// put caches the value under the key
+func (c *metadataCache) put(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = metadataCacheEntry{value: value, expiry: time.Now().Add(c.ttl)}
+}
+
+// clear removes every entry from the cache
+func (c *metadataCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+// cachedMetadata returns the cached response for the key if metadata caching
+// is enabled and the entry is fresh, and otherwise fetches and caches it. The
+// response is returned as a copy made with clone, so that callers cannot
+// modify the cached one
+func cachedMetadata[T any](
+	c *ExternalMatchClient, key string, fetch func() (T, error), clone func(T) T,
+) (T, error) {
+	if c.metadataCache == nil {
+		return fetch()
+	}
+	if value, ok := c.metadataCache.get(key); ok {
+		return clone(value.(T)), nil //nolint:forcetypeassert
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+	c.metadataCache.put(key, value)
+	return clone(value), nil
+}
+
+// clonePointer returns a shallow copy of the value a pointer refers to
+func clonePointer[T any](value *T) *T {
+	valueCopy := *value
+	return &valueCopy
+}
+
+// InvalidateMetadataCache discards every cached metadata response, e.g. after
+// the relayer lists a new token. Has no effect if caching is disabled
+func (c *ExternalMatchClient) InvalidateMetadataCache() {
+	if c.metadataCache != nil {
+		c.metadataCache.clear()
+	}
+}
+
+// GetExternalMatchFee requests the fee rates charged on external matches that
+// trade the given token
+func (c *ExternalMatchClient) GetExternalMatchFee(mint string) (*api_types.GetExternalMatchFeeResponse, error) {
+	key := externalMatchFeeCacheKey + strings.ToLower(mint)
+	fee, err := cachedMetadata(c, key, func() (*api_types.GetExternalMatchFeeResponse, error) {
+		var response api_types.GetExternalMatchFeeResponse
+		err := c.relayerHttpClient.GetJSON(
+			api_types.BuildGetExternalMatchFeePath(mint),
+			nil, // body
+			&response,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &response, nil
+	}, clonePointer)
+	if err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
+
+// FindTokenAddr returns the address of the supported token with the given
+// symbol, in the case the relayer serves it in. Compare it to other mints as
+// an api_types.Mint, which ignores case, rather than as a string. The token
+// list is served from the metadata cache, if enabled
+func (c *ExternalMatchClient) FindTokenAddr(symbol string) (string, error) {
+	tokens, err := c.GetSupportedTokens()
+	if err != nil {
+		return "", err
+	}
+
+	for _, token := range tokens {
+		if token.Symbol == symbol {
+			return token.Address, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownToken, symbol)
+}
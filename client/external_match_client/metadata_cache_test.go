@@ -0,0 +1,103 @@
+package external_match_client //nolint:revive
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// newMetadataCacheClient creates a client with metadata caching enabled whose
+// server counts the requests for each path
+func newMetadataCacheClient(t *testing.T, ttl time.Duration, requests map[string]*atomic.Int32) *ExternalMatchClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if counter, ok := requests[r.URL.Path]; ok {
+			counter.Add(1)
+		}
+
+		switch r.URL.Path {
+		case api_types.GetSupportedTokensPath:
+			_ = json.NewEncoder(w).Encode(api_types.GetSupportedTokensResponse{
+				Tokens: []api_types.ApiToken{{Address: "0x1", Symbol: "WETH"}},
+			})
+		case "/v0/order_book/external-match-fee":
+			_ = json.NewEncoder(w).Encode(api_types.GetExternalMatchFeeResponse{
+				RelayerFee:  "0.0001",
+				ProtocolFee: "0.0002",
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	options := NewExternalMatchClientOptions().WithMetadataCache(ttl)
+	return NewExternalMatchClientWithOptions(server.URL, server.URL, "test-key", &wallet.HmacKey{}, options)
+}
+
+func TestMetadataCacheReusesResponses(t *testing.T) {
+	var tokenRequests, feeRequests atomic.Int32
+	client := newMetadataCacheClient(t, time.Minute, map[string]*atomic.Int32{
+		api_types.GetSupportedTokensPath:    &tokenRequests,
+		"/v0/order_book/external-match-fee": &feeRequests,
+	})
+
+	for i := 0; i < 3; i++ {
+		addr, err := client.FindTokenAddr("WETH")
+		assert.NoError(t, err)
+		assert.Equal(t, "0x1", addr)
+
+		fee, err := client.GetExternalMatchFee("0x1")
+		assert.NoError(t, err)
+		assert.Equal(t, "0.0001", fee.RelayerFee)
+	}
+	assert.Equal(t, int32(1), tokenRequests.Load())
+	assert.Equal(t, int32(1), feeRequests.Load())
+
+	// Callers cannot modify the cached token list
+	tokens, err := client.GetSupportedTokens()
+	assert.NoError(t, err)
+	tokens[0].Symbol = "WBTC"
+	_, err = client.FindTokenAddr("WETH")
+	assert.NoError(t, err)
+
+	// Unknown symbols are reported as such
+	_, err = client.FindTokenAddr("USDC")
+	assert.True(t, errors.Is(err, ErrUnknownToken))
+}
+
+func TestMetadataCacheInvalidation(t *testing.T) {
+	var tokenRequests atomic.Int32
+	client := newMetadataCacheClient(t, time.Minute, map[string]*atomic.Int32{
+		api_types.GetSupportedTokensPath: &tokenRequests,
+	})
+
+	_, err := client.GetSupportedTokens()
+	assert.NoError(t, err)
+	client.InvalidateMetadataCache()
+	_, err = client.GetSupportedTokens()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), tokenRequests.Load())
+}
+
+func TestMetadataCacheExpiry(t *testing.T) {
+	var tokenRequests atomic.Int32
+	client := newMetadataCacheClient(t, 20*time.Millisecond, map[string]*atomic.Int32{
+		api_types.GetSupportedTokensPath: &tokenRequests,
+	})
+
+	_, err := client.GetSupportedTokens()
+	assert.NoError(t, err)
+	time.Sleep(40 * time.Millisecond)
+	_, err = client.GetSupportedTokens()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), tokenRequests.Load())
+}
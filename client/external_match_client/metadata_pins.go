@@ -0,0 +1,109 @@
+package external_match_client //nolint:revive
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// MetadataPins are the values the server's exchange metadata must report. A
+// server reporting other values, e.g. because a DNS or endpoint compromise
+// redirects the client, could otherwise have settlements or fees sent to an
+// attacker's contract. Empty fields are not checked
+type MetadataPins struct {
+	// ChainID is the chain the server must settle on
+	ChainID uint64
+	// SettlementContract is the address of the darkpool contract the server
+	// must settle through
+	SettlementContract string
+	// FeeRecipient is the address the server must report as its fee
+	// recipient. Only checked if the server reports one
+	FeeRecipient string
+}
+
+var (
+	// MainnetMetadataPins are the exchange metadata values of the mainnet
+	// (Arbitrum One) deployment
+	MainnetMetadataPins = MetadataPins{
+		ChainID:            42161,
+		SettlementContract: "0x30bd8eab29181f790d7e495786d4b96d7afdc518",
+	}
+
+	// TestnetMetadataPins are the exchange metadata values of the testnet
+	// (Arbitrum Sepolia) deployment
+	TestnetMetadataPins = MetadataPins{
+		ChainID:            421614,
+		SettlementContract: "0x9af58f1ff20ab22e819e40b57ffd784d115a9ef5",
+	}
+)
+
+// MetadataMismatchError is returned when the server's exchange metadata does
+// not match the client's pins
+type MetadataMismatchError struct {
+	// Field names the mismatched value
+	Field string
+	// Pinned is the pinned value
+	Pinned string
+	// Reported is the value reported by the server
+	Reported string
+}
+
+// Error implements the error interface
+func (e *MetadataMismatchError) Error() string {
+	return fmt.Sprintf("exchange metadata mismatch: %s is %s, pinned %s", e.Field, e.Reported, e.Pinned)
+}
+
+// Verify checks exchange metadata against the pins, returning a
+// *MetadataMismatchError for the first mismatched value
+func (p *MetadataPins) Verify(metadata *api_types.ExchangeMetadataResponse) error {
+	if p.ChainID != 0 && p.ChainID != metadata.ChainId {
+		return &MetadataMismatchError{
+			Field:    "chain ID",
+			Pinned:   strconv.FormatUint(p.ChainID, 10),
+			Reported: strconv.FormatUint(metadata.ChainId, 10),
+		}
+	}
+	if p.SettlementContract != "" && !sameAddress(p.SettlementContract, metadata.SettlementContractAddress) {
+		return &MetadataMismatchError{
+			Field:    "settlement contract",
+			Pinned:   p.SettlementContract,
+			Reported: metadata.SettlementContractAddress,
+		}
+	}
+	if p.FeeRecipient != "" && metadata.FeeRecipient != "" && !sameAddress(p.FeeRecipient, metadata.FeeRecipient) {
+		return &MetadataMismatchError{
+			Field:    "fee recipient",
+			Pinned:   p.FeeRecipient,
+			Reported: metadata.FeeRecipient,
+		}
+	}
+	return nil
+}
+
+// VerifyExchangeMetadata fetches the server's exchange metadata and checks it
+// against the client's pins, if any. Requires the v2 API
+func (c *ExternalMatchClient) VerifyExchangeMetadata() error {
+	_, err := c.GetExchangeMetadata()
+	return err
+}
+
+// verifyMetadata checks freshly fetched exchange metadata against the
+// client's pins, logging an alert on mismatch
+func (c *ExternalMatchClient) verifyMetadata(metadata *api_types.ExchangeMetadataResponse) error {
+	if c.metadataPins == nil {
+		return nil
+	}
+	if err := c.metadataPins.Verify(metadata); err != nil {
+		log.Printf("ALERT: %v; the server may be compromised, refusing its metadata", err)
+		return err
+	}
+	return nil
+}
+
+// sameAddress returns whether two hex addresses are equal, ignoring case
+func sameAddress(a, b string) bool {
+	return strings.EqualFold(strings.TrimPrefix(a, "0x"), strings.TrimPrefix(b, "0x"))
+}
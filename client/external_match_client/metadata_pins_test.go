@@ -0,0 +1,69 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+func TestMetadataPinsVerify(t *testing.T) {
+	metadata := &api_types.ExchangeMetadataResponse{
+		ChainId:                   421614,
+		SettlementContractAddress: "0x9AF58F1FF20AB22E819E40B57FFD784D115A9EF5",
+	}
+	assert.NoError(t, TestnetMetadataPins.Verify(metadata))
+
+	var mismatch *MetadataMismatchError
+	err := MainnetMetadataPins.Verify(metadata)
+	assert.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, "chain ID", mismatch.Field)
+
+	pins := MetadataPins{SettlementContract: "0x1"}
+	err = pins.Verify(metadata)
+	assert.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, "settlement contract", mismatch.Field)
+
+	// The fee recipient is only checked if the server reports one
+	pins = TestnetMetadataPins
+	pins.FeeRecipient = "0xfee"
+	assert.NoError(t, pins.Verify(metadata))
+	metadata.FeeRecipient = "0xFEE"
+	assert.NoError(t, pins.Verify(metadata))
+	metadata.FeeRecipient = "0xbad"
+	err = pins.Verify(metadata)
+	assert.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, "fee recipient", mismatch.Field)
+	assert.Equal(t, "0xbad", mismatch.Reported)
+}
+
+func TestGetExchangeMetadataPinned(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api_types.ExchangeMetadataPath {
+			requests.Add(1)
+		}
+		_, _ = w.Write([]byte(`{"chain_id":421614,"settlement_contract_address":"0x1"}`))
+	}))
+	t.Cleanup(server.Close)
+	options := NewExternalMatchClientOptions().
+		WithApiVersion(ApiVersionV2).
+		WithMetadataCache(time.Minute).
+		WithMetadataPins(TestnetMetadataPins)
+	c := NewExternalMatchClientWithOptions(server.URL, server.URL, "test-key", &wallet.HmacKey{}, options)
+
+	var mismatch *MetadataMismatchError
+	assert.True(t, errors.As(c.VerifyExchangeMetadata(), &mismatch))
+
+	// Refused metadata is not cached
+	_, err := c.GetExchangeMetadata()
+	assert.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, int32(2), requests.Load())
+}
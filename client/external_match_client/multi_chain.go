@@ -0,0 +1,212 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+var (
+	// ErrUnknownChain is returned when no client is configured for a chain
+	ErrUnknownChain = errors.New("no client configured for chain")
+	// ErrUnknownToken is returned when no configured chain supports a token
+	ErrUnknownToken = errors.New("token not supported on any configured chain")
+)
+
+// ChainQuote is the result of a quote request on one chain
+type ChainQuote struct {
+	// ChainID is the chain the quote was requested on
+	ChainID uint64
+	// Quote is the quote, nil if the chain found no match or the request failed
+	Quote *api_types.ApiSignedQuote
+	// Err is the error of the request, if it failed
+	Err error
+}
+
+// MultiChainClient holds an ExternalMatchClient per chain, routing requests
+// by chain ID or token address, and can quote a pair on every chain at once to
+// pick the best venue
+type MultiChainClient struct {
+	// mu guards the fields below
+	mu sync.RWMutex
+	// clients are the per-chain clients, keyed by chain ID
+	clients map[uint64]*ExternalMatchClient
+	// tokenChains maps lowercase token addresses to the chain supporting them,
+	// populated from each chain's supported tokens on first use
+	tokenChains map[string]uint64
+}
+
+// NewMultiChainClient creates a MultiChainClient with no chains
+func NewMultiChainClient() *MultiChainClient {
+	return &MultiChainClient{clients: make(map[uint64]*ExternalMatchClient)}
+}
+
+// AddChain registers the client for a chain, replacing any existing client
+func (m *MultiChainClient) AddChain(chainID uint64, client *ExternalMatchClient) *MultiChainClient {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clients[chainID] = client
+	m.tokenChains = nil
+	return m
+}
+
+// Chains returns the IDs of the configured chains in ascending order
+func (m *MultiChainClient) Chains() []uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	chains := make([]uint64, 0, len(m.clients))
+	for chainID := range m.clients {
+		chains = append(chains, chainID)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i] < chains[j] })
+	return chains
+}
+
+// Client returns the client for the given chain
+func (m *MultiChainClient) Client(chainID uint64) (*ExternalMatchClient, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	client, ok := m.clients[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownChain, chainID)
+	}
+	return client, nil
+}
+
+// ChainForToken returns the chain that supports the given token address. The
+// supported tokens of every chain are fetched on first use
+func (m *MultiChainClient) ChainForToken(mint string) (uint64, error) {
+	if err := m.loadTokenChains(); err != nil {
+		return 0, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	chainID, ok := m.tokenChains[strings.ToLower(mint)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownToken, mint)
+	}
+	return chainID, nil
+}
+
+// ClientForToken returns the client for the chain that supports the given
+// token address
+func (m *MultiChainClient) ClientForToken(mint string) (*ExternalMatchClient, error) {
+	chainID, err := m.ChainForToken(mint)
+	if err != nil {
+		return nil, err
+	}
+	return m.Client(chainID)
+}
+
+// GetExternalMatchQuote routes a quote request to the chain supporting the
+// order's base token
+func (m *MultiChainClient) GetExternalMatchQuote(
+	order *api_types.ApiExternalOrder, opts ...QuoteOption,
+) (*api_types.ApiSignedQuote, error) {
+	client, err := m.ClientForToken(order.BaseMint)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetExternalMatchQuote(order, opts...)
+}
+
+// QuoteAll requests quotes for the given per-chain orders concurrently. Token
+// addresses differ between chains, so the same pair is given as one order per
+// chain. Results are returned in ascending chain ID order
+func (m *MultiChainClient) QuoteAll(orders map[uint64]*api_types.ApiExternalOrder) []ChainQuote {
+	chainIDs := make([]uint64, 0, len(orders))
+	for chainID := range orders {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
+
+	results := make([]ChainQuote, len(chainIDs))
+	var wg sync.WaitGroup
+	for i, chainID := range chainIDs {
+		wg.Add(1)
+		go func(i int, chainID uint64) {
+			defer wg.Done()
+			results[i] = ChainQuote{ChainID: chainID}
+			client, err := m.Client(chainID)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+			results[i].Quote, results[i].Err = client.GetExternalMatchQuote(orders[chainID])
+		}(i, chainID)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// BestQuote quotes the given per-chain orders concurrently and returns the
+// quote that gives the external party the most of its receive token per unit
+// of its send token. Returns nil if no chain found a match, along with the
+// errors of any failed requests
+func (m *MultiChainClient) BestQuote(
+	orders map[uint64]*api_types.ApiExternalOrder,
+) (*ChainQuote, error) {
+	var best *ChainQuote
+	var bestRate *big.Rat
+	var errs []error
+	for _, result := range m.QuoteAll(orders) {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("chain %d: %w", result.ChainID, result.Err))
+			continue
+		}
+		if result.Quote == nil || result.Quote.Quote.Send.Amount.IsZero() {
+			continue
+		}
+
+		q := &result.Quote.Quote
+		rate := new(big.Rat).SetFrac((*big.Int)(&q.Receive.Amount), (*big.Int)(&q.Send.Amount))
+		if bestRate == nil || rate.Cmp(bestRate) > 0 {
+			result := result
+			best, bestRate = &result, rate
+		}
+	}
+
+	if best == nil {
+		return nil, errors.Join(errs...)
+	}
+	return best, nil
+}
+
+// loadTokenChains populates the token routing table if it is empty
+func (m *MultiChainClient) loadTokenChains() error {
+	m.mu.RLock()
+	loaded := m.tokenChains != nil
+	clients := make(map[uint64]*ExternalMatchClient, len(m.clients))
+	for chainID, client := range m.clients {
+		clients[chainID] = client
+	}
+	m.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	tokenChains := make(map[string]uint64)
+	for chainID, client := range clients {
+		tokens, err := client.GetSupportedTokens()
+		if err != nil {
+			return fmt.Errorf("failed to fetch supported tokens of chain %d: %w", chainID, err)
+		}
+		for _, token := range tokens {
+			tokenChains[strings.ToLower(token.Address)] = chainID
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokenChains = tokenChains
+	return nil
+}
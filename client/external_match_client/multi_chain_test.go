@@ -0,0 +1,56 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+func TestMultiChainRouting(t *testing.T) {
+	arbitrum := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"tokens":[{"address":"0xAAAA","symbol":"WETH"}]}`))
+	})
+	base := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"tokens":[{"address":"0xbbbb","symbol":"WETH"}]}`))
+	})
+
+	multi := NewMultiChainClient().AddChain(42161, arbitrum).AddChain(8453, base)
+	assert.Equal(t, []uint64{8453, 42161}, multi.Chains())
+
+	chainID, err := multi.ChainForToken("0xaaaa")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42161), chainID)
+	client, err := multi.ClientForToken("0xBBBB")
+	assert.NoError(t, err)
+	assert.Same(t, base, client)
+
+	_, err = multi.ChainForToken("0xcccc")
+	assert.True(t, errors.Is(err, ErrUnknownToken))
+	_, err = multi.Client(1)
+	assert.True(t, errors.Is(err, ErrUnknownChain))
+}
+
+func TestMultiChainBestQuote(t *testing.T) {
+	multi := NewMultiChainClient().
+		AddChain(1, NewSandboxExternalMatchClient(2)).
+		AddChain(2, NewSandboxExternalMatchClient(3)).
+		AddChain(3, newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+
+	// Selling base, the chain with the highest price is best
+	order := testOrder(t)
+	orders := map[uint64]*api_types.ApiExternalOrder{1: order, 2: order, 3: order}
+	results := multi.QuoteAll(orders)
+	assert.Len(t, results, 3)
+	assert.Error(t, results[2].Err)
+
+	best, err := multi.BestQuote(orders)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), best.ChainID)
+	assert.Equal(t, "300", best.Quote.Quote.Receive.Amount.String())
+}
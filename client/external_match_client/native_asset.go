@@ -0,0 +1,72 @@
+package external_match_client //nolint:revive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+)
+
+// ErrInsufficientNativeBalance is returned when the sender of a native ETH
+// sell does not hold enough ETH to cover the settlement transaction's value
+var ErrInsufficientNativeBalance = errors.New("insufficient native ETH balance")
+
+// NativeBalanceReader reads the native ETH balance of an account, e.g. an
+// `ethclient.Client`
+type NativeBalanceReader interface {
+	BalanceAt(
+		ctx context.Context, account geth_common.Address, blockNumber *big.Int,
+	) (*big.Int, error)
+}
+
+// IsNativeAssetSell returns whether the bundle sells native ETH, i.e. whether
+// the external party sends the native asset sentinel into the match
+func (b *ExternalMatchBundle) IsNativeAssetSell() bool {
+	return b.Send != nil && b.Send.Token().IsNative()
+}
+
+// PrepareNativeAssetSell prepares the settlement transaction of a bundle that
+// sells native ETH for submission by the given sender.
+//
+// Native ETH is sent into the match as the transaction's value rather than
+// through an erc20 transfer, so the value is set to the bundle's send amount
+// and the sender's ETH balance is checked to cover it. Bundles that do not
+// sell native ETH are left untouched; their value must be zero.
+func (b *ExternalMatchBundle) PrepareNativeAssetSell(
+	ctx context.Context, reader NativeBalanceReader, sender geth_common.Address,
+) error {
+	if b.SettlementTx == nil {
+		return errors.New("bundle has no settlement transaction")
+	}
+
+	if !b.IsNativeAssetSell() {
+		if b.SettlementTx.Value != nil && b.SettlementTx.Value.Sign() != 0 {
+			return fmt.Errorf(
+				"settlement transaction carries value %s but does not sell native ETH",
+				b.SettlementTx.Value.String(),
+			)
+		}
+		return nil
+	}
+
+	value := new(big.Int).Set((*big.Int)(&b.Send.Amount))
+	if value.Sign() <= 0 {
+		return errors.New("native ETH sell has no send amount")
+	}
+	b.SettlementTx.Value = value
+
+	bal, err := reader.BalanceAt(ctx, sender, nil /* blockNumber */)
+	if err != nil {
+		return fmt.Errorf("failed to get ETH balance: %w", err)
+	}
+
+	if bal.Cmp(value) < 0 {
+		return fmt.Errorf(
+			"%w: have %s, need %s", ErrInsufficientNativeBalance, bal.String(), value.String(),
+		)
+	}
+
+	return nil
+}
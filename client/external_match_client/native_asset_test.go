@@ -0,0 +1,91 @@
+package external_match_client //nolint:revive
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// fixedBalanceReader reports the same balance for every account
+type fixedBalanceReader struct {
+	balance *big.Int
+}
+
+func (r fixedBalanceReader) BalanceAt(
+	_ context.Context, _ geth_common.Address, _ *big.Int,
+) (*big.Int, error) {
+	return r.balance, nil
+}
+
+func nativeSellBundle(amount int64) *ExternalMatchBundle {
+	return &ExternalMatchBundle{
+		Send: &api_types.ApiExternalAssetTransfer{
+			Mint:   api_types.NativeAssetAddr,
+			Amount: api_types.NewAmount(amount),
+		},
+		SettlementTx: &SettlementTransaction{Value: big.NewInt(0)},
+	}
+}
+
+func TestPrepareNativeAssetSell(t *testing.T) {
+	ctx := context.Background()
+	sender := geth_common.HexToAddress("0x01")
+
+	bundle := nativeSellBundle(100)
+	assert.True(t, bundle.IsNativeAssetSell())
+	err := bundle.PrepareNativeAssetSell(ctx, fixedBalanceReader{big.NewInt(100)}, sender)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), bundle.SettlementTx.Value)
+
+	bundle = nativeSellBundle(100)
+	err = bundle.PrepareNativeAssetSell(ctx, fixedBalanceReader{big.NewInt(99)}, sender)
+	assert.True(t, errors.Is(err, ErrInsufficientNativeBalance))
+
+	// An erc20 sell must not carry value
+	bundle = nativeSellBundle(100)
+	bundle.Send.Mint = "0x2"
+	bundle.SettlementTx.Value = big.NewInt(1)
+	assert.False(t, bundle.IsNativeAssetSell())
+	err = bundle.PrepareNativeAssetSell(ctx, fixedBalanceReader{big.NewInt(100)}, sender)
+	assert.Error(t, err)
+}
+
+func TestDirectBundleNativeAssetSell(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"match_bundle":{` +
+			`"match_result":{"quote_mint":"0x2","base_mint":"` + api_types.NativeAssetAddr + `",` +
+			`"quote_amount":200,"base_amount":100,"direction":"Buy"},` +
+			`"fees":{"relayer_fee":1,"protocol_fee":1},` +
+			`"receive":{"mint":"0x2","amount":198},` +
+			`"send":{"mint":"` + api_types.NativeAssetAddr + `","amount":100},` +
+			`"settlement_tx":{"to":"0x01","data":"0x","value":"0x0"}}}`))
+	})
+
+	order, err := api_types.NewExternalOrderBuilder().
+		WithBaseMint(api_types.NativeAssetAddr).
+		WithQuoteMint("0x2").
+		WithBaseAmount(api_types.NewAmount(100)).
+		WithSide("Sell").
+		Build()
+	require.NoError(t, err)
+	bundle, err := c.GetExternalMatchBundle(order)
+	require.NoError(t, err)
+
+	// The direct bundle carries its transfers, so the sell is recognized and
+	// the settlement transaction is given the send amount as its value
+	require.NotNil(t, bundle.Fees)
+	assert.Equal(t, api_types.NewAmount(198), bundle.Receive.Amount)
+	assert.True(t, bundle.IsNativeAssetSell())
+	sender := geth_common.HexToAddress("0x01")
+	err = bundle.PrepareNativeAssetSell(context.Background(), fixedBalanceReader{big.NewInt(100)}, sender)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), bundle.SettlementTx.Value)
+}
@@ -0,0 +1,105 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/renegade-fi/golang-sdk/client"
+)
+
+// ErrNoMatch matches every NoMatchError with errors.Is
+var ErrNoMatch = errors.New("no match found")
+
+// NoMatchReason is the cause of the relayer finding no match for an order
+type NoMatchReason int
+
+const (
+	// NoMatchNoLiquidity indicates the relayer has no liquidity to match the
+	// order, e.g. no resting counterparty within the order's limits
+	NoMatchNoLiquidity NoMatchReason = iota
+	// NoMatchBelowMinFill indicates the order is smaller than the minimum the
+	// relayer will fill
+	NoMatchBelowMinFill
+	// NoMatchPairUnsupported indicates the relayer does not trade the order's
+	// pair
+	NoMatchPairUnsupported
+)
+
+// String returns the name of the reason
+func (r NoMatchReason) String() string {
+	switch r {
+	case NoMatchNoLiquidity:
+		return "no liquidity"
+	case NoMatchBelowMinFill:
+		return "below minimum fill size"
+	case NoMatchPairUnsupported:
+		return "pair unsupported"
+	default:
+		return fmt.Sprintf("NoMatchReason(%d)", int(r))
+	}
+}
+
+// NoMatchError is returned in place of a nil quote or bundle when the request
+// options ask for no-match errors, and whenever the relayer rejects an order
+// for a reason that means it cannot be matched
+type NoMatchError struct {
+	// Reason is the cause of the missing match
+	Reason NoMatchReason
+	// Message is the relayer's explanation, empty if it gave none
+	Message string
+	// err is the underlying request error, nil for an empty response
+	err error
+}
+
+// Error implements the error interface
+func (e *NoMatchError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("no match found: %s", e.Reason)
+	}
+	return fmt.Sprintf("no match found: %s: %s", e.Reason, e.Message)
+}
+
+// Is reports whether the target is ErrNoMatch
+func (e *NoMatchError) Is(target error) bool {
+	return target == ErrNoMatch
+}
+
+// Unwrap returns the underlying request error, if any
+func (e *NoMatchError) Unwrap() error {
+	return e.err
+}
+
+// noMatchReasons map the relayer error codes that report a missing match to
+// their no-match reason
+var noMatchReasons = map[client.ErrorCode]NoMatchReason{
+	client.CodeNoLiquidity:     NoMatchNoLiquidity,
+	client.CodeMinFillUnmet:    NoMatchBelowMinFill,
+	client.CodePairUnsupported: NoMatchPairUnsupported,
+}
+
+// classifyNoMatch converts a relayer rejection that reports a missing match
+// into a NoMatchError, returning other errors unchanged
+func classifyNoMatch(err error) error {
+	var statusErr *client.StatusError
+	if !errors.As(err, &statusErr) {
+		return err
+	}
+	if statusErr.StatusCode != http.StatusBadRequest && statusErr.StatusCode != http.StatusNotFound {
+		return err
+	}
+
+	if reason, ok := noMatchReasons[statusErr.Code()]; ok {
+		return &NoMatchError{Reason: reason, Message: statusErr.Message(), err: err}
+	}
+	return err
+}
+
+// noMatch returns the result of a request that found no match: nil, or a
+// NoMatchError if the request asked for one
+func noMatch(report bool) error {
+	if report {
+		return &NoMatchError{Reason: NoMatchNoLiquidity}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client"
+)
+
+func TestNoMatchIsNilByDefault(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	quote, err := c.GetExternalMatchQuote(testOrder(t))
+	assert.NoError(t, err)
+	assert.Nil(t, quote)
+
+	// Opting in reports the missing match as an error
+	options := NewExternalQuoteOptions().WithNoMatchError(true)
+	quote, err = c.GetExternalMatchQuoteWithOptions(testOrder(t), options)
+	assert.Nil(t, quote)
+	assert.ErrorIs(t, err, ErrNoMatch)
+	var noMatchErr *NoMatchError
+	assert.True(t, errors.As(err, &noMatchErr))
+	assert.Equal(t, NoMatchNoLiquidity, noMatchErr.Reason)
+
+	bundleOptions := NewAssembleExternalMatchOptions().WithNoMatchError(true)
+	bundle, err := c.GetExternalMatchBundleWithOptions(testOrder(t), bundleOptions)
+	assert.Nil(t, bundle)
+	assert.ErrorIs(t, err, ErrNoMatch)
+}
+
+func TestNoMatchReasonsFromRelayerErrors(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		reason NoMatchReason
+	}{
+		{http.StatusBadRequest, "order size is below the minimum fill size", NoMatchBelowMinFill},
+		{http.StatusBadRequest, "Unsupported pair: 0x1/0x2", NoMatchPairUnsupported},
+		{http.StatusNotFound, "no liquidity for pair", NoMatchNoLiquidity},
+	}
+
+	for _, tc := range cases {
+		c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(tc.status)
+			_, _ = w.Write([]byte(tc.body))
+		})
+
+		_, err := c.GetExternalMatchQuote(testOrder(t))
+		var noMatchErr *NoMatchError
+		assert.True(t, errors.As(err, &noMatchErr), tc.body)
+		assert.Equal(t, tc.reason, noMatchErr.Reason, tc.body)
+		assert.Equal(t, tc.body, noMatchErr.Message)
+
+		// The underlying status error remains available
+		var statusErr *client.StatusError
+		assert.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, tc.status, statusErr.StatusCode)
+	}
+}
+
+func TestUnrelatedErrorsAreNotNoMatch(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("invalid signature"))
+	})
+
+	_, err := c.GetExternalMatchQuote(testOrder(t))
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrNoMatch))
+}
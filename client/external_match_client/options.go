@@ -0,0 +1,166 @@
+package external_match_client //nolint:revive
+
+import (
+	"time"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// QuoteOption configures a quote request, see GetExternalMatchQuote. An
+// *ExternalQuoteOptions is itself an option, replacing the options before it
+type QuoteOption interface {
+	applyQuote(options *ExternalQuoteOptions)
+}
+
+// AssembleOption configures an assembly or direct bundle request, see
+// AssembleExternalQuote and GetExternalMatchBundle. An
+// *AssembleExternalMatchOptions is itself an option, replacing the options
+// before it
+type AssembleOption interface {
+	applyAssemble(options *AssembleExternalMatchOptions)
+}
+
+// RequestOption configures quote, assembly, and direct bundle requests alike
+type RequestOption interface {
+	QuoteOption
+	AssembleOption
+}
+
+// quoteOptionFunc is a QuoteOption that modifies the options in place
+type quoteOptionFunc func(options *ExternalQuoteOptions)
+
+// applyQuote implements QuoteOption
+func (f quoteOptionFunc) applyQuote(options *ExternalQuoteOptions) {
+	f(options)
+}
+
+// assembleOptionFunc is an AssembleOption that modifies the options in place
+type assembleOptionFunc func(options *AssembleExternalMatchOptions)
+
+// applyAssemble implements AssembleOption
+func (f assembleOptionFunc) applyAssemble(options *AssembleExternalMatchOptions) {
+	f(options)
+}
+
+// requestOption is a RequestOption that sets the same field of quote and
+// assembly options
+type requestOption struct {
+	quote    quoteOptionFunc
+	assemble assembleOptionFunc
+}
+
+// applyQuote implements QuoteOption
+func (o requestOption) applyQuote(options *ExternalQuoteOptions) {
+	o.quote(options)
+}
+
+// applyAssemble implements AssembleOption
+func (o requestOption) applyAssemble(options *AssembleExternalMatchOptions) {
+	o.assemble(options)
+}
+
+// applyQuote implements QuoteOption, replacing the options with a copy of o
+func (o *ExternalQuoteOptions) applyQuote(options *ExternalQuoteOptions) {
+	if o != nil {
+		*options = *o
+	}
+}
+
+// applyAssemble implements AssembleOption, replacing the options with a copy
+// of o
+func (o *AssembleExternalMatchOptions) applyAssemble(options *AssembleExternalMatchOptions) {
+	if o != nil {
+		*options = *o
+	}
+}
+
+// WithLatencyBudget bounds the time to wait for the relayer to respond with a
+// quote, see ExternalQuoteOptions.WithLatencyBudget
+func WithLatencyBudget(budget time.Duration) QuoteOption {
+	return quoteOptionFunc(func(options *ExternalQuoteOptions) {
+		options.WithLatencyBudget(budget)
+	})
+}
+
+// WithRefundAddress pays the quote's gas sponsorship refund to the address in
+// place of the address that settles the match
+func WithRefundAddress(address string) QuoteOption {
+	return quoteOptionFunc(func(options *ExternalQuoteOptions) {
+		options.WithRefundAddress(&address)
+	})
+}
+
+// WithReceiver pays the external party's output to the address in place of
+// the address that settles the match, see
+// AssembleExternalMatchOptions.WithReceiverAddress
+func WithReceiver(address string) AssembleOption {
+	return assembleOptionFunc(func(options *AssembleExternalMatchOptions) {
+		options.WithReceiverAddress(&address)
+	})
+}
+
+// WithGasEstimation asks the relayer to estimate the gas of the settlement
+// transaction, see SettlementTransaction.Gas
+func WithGasEstimation() AssembleOption {
+	return assembleOptionFunc(func(options *AssembleExternalMatchOptions) {
+		options.WithGasEstimation(true)
+	})
+}
+
+// WithUpdatedOrder assembles the quote for the updated order, which must be
+// within the quoted bounds. It only applies to quote assembly
+func WithUpdatedOrder(order *api_types.ApiExternalOrder) AssembleOption {
+	return assembleOptionFunc(func(options *AssembleExternalMatchOptions) {
+		options.WithUpdatedOrder(order)
+	})
+}
+
+// WithApiKey selects the client's API key the request is made with, see
+// ExternalMatchClientOptions.WithScopedApiKey
+func WithApiKey(apiKey string) RequestOption { //nolint:revive
+	return requestOption{
+		quote:    func(options *ExternalQuoteOptions) { options.WithApiKey(apiKey) },
+		assemble: func(options *AssembleExternalMatchOptions) { options.WithApiKey(apiKey) },
+	}
+}
+
+// WithNoMatchError returns a NoMatchError rather than a nil result when no
+// match is found
+func WithNoMatchError() RequestOption {
+	return requestOption{
+		quote:    func(options *ExternalQuoteOptions) { options.WithNoMatchError(true) },
+		assemble: func(options *AssembleExternalMatchOptions) { options.WithNoMatchError(true) },
+	}
+}
+
+// WithStrategyTag uses the default addresses of the strategy registered
+// under the tag, see ExternalMatchClient.SetStrategy
+func WithStrategyTag(tag string) RequestOption {
+	return requestOption{
+		quote:    func(options *ExternalQuoteOptions) { options.WithStrategyTag(tag) },
+		assemble: func(options *AssembleExternalMatchOptions) { options.WithStrategyTag(tag) },
+	}
+}
+
+// newQuoteOptions applies the options, in order, to the default quote options
+func newQuoteOptions(opts []QuoteOption) *ExternalQuoteOptions {
+	options := NewExternalQuoteOptions()
+	for _, opt := range opts {
+		if opt != nil {
+			opt.applyQuote(options)
+		}
+	}
+	return options
+}
+
+// newAssembleOptions applies the options, in order, to the default assembly
+// options
+func newAssembleOptions(opts []AssembleOption) *AssembleExternalMatchOptions {
+	options := NewAssembleExternalMatchOptions()
+	for _, opt := range opts {
+		if opt != nil {
+			opt.applyAssemble(options)
+		}
+	}
+	return options
+}
@@ -0,0 +1,88 @@
+package external_match_client //nolint:revive
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+func TestFunctionalOptionsMatchStructs(t *testing.T) {
+	refund := testRefundAddress
+	quoteOptions := newQuoteOptions([]QuoteOption{
+		WithLatencyBudget(time.Second),
+		WithRefundAddress(refund),
+		WithApiKey("quote-key"),
+		WithNoMatchError(),
+		WithStrategyTag("mm-weth"),
+	})
+	expectedQuote := NewExternalQuoteOptions().
+		WithLatencyBudget(time.Second).
+		WithRefundAddress(&refund).
+		WithApiKey("quote-key").
+		WithNoMatchError(true).
+		WithStrategyTag("mm-weth")
+	assert.Equal(t, expectedQuote, quoteOptions)
+
+	receiver := "0x6b175474e89094c44da98b954eedeac495271d0f"
+	order := testOrder(t)
+	assembleOptions := newAssembleOptions([]AssembleOption{
+		WithReceiver(receiver),
+		WithGasEstimation(),
+		WithUpdatedOrder(order),
+		WithApiKey("assemble-key"),
+		WithNoMatchError(),
+		WithStrategyTag("mm-weth"),
+	})
+	expectedAssemble := NewAssembleExternalMatchOptions().
+		WithReceiverAddress(&receiver).
+		WithGasEstimation(true).
+		WithUpdatedOrder(order).
+		WithApiKey("assemble-key").
+		WithNoMatchError(true).
+		WithStrategyTag("mm-weth")
+	assert.Equal(t, expectedAssemble, assembleOptions)
+	assert.Equal(t, testReceiverAddress, *assembleOptions.ReceiverAddress)
+
+	// No options are the defaults
+	assert.Equal(t, NewExternalQuoteOptions(), newQuoteOptions(nil))
+	assert.Equal(t, NewAssembleExternalMatchOptions(), newAssembleOptions(nil))
+}
+
+func TestOptionStructsAsOptions(t *testing.T) {
+	// A struct replaces the options before it, and later options apply on top
+	base := NewAssembleExternalMatchOptions().WithGasEstimation(true).WithApiKey("base-key")
+	options := newAssembleOptions([]AssembleOption{WithNoMatchError(), base, WithApiKey("override-key")})
+	assert.True(t, options.DoGasEstimation)
+	assert.False(t, options.NoMatchError)
+	assert.Equal(t, "override-key", options.ApiKey)
+
+	// The struct is copied, not modified
+	assert.Equal(t, "base-key", base.ApiKey)
+
+	// A nil struct is ignored
+	var unset *ExternalQuoteOptions
+	assert.Equal(t, time.Second, newQuoteOptions([]QuoteOption{WithLatencyBudget(time.Second), unset}).LatencyBudget)
+}
+
+func TestFunctionalOptionsInRequests(t *testing.T) {
+	var body api_types.ExternalMatchRequest
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.GetExternalMatchBundle(testOrder(t), WithReceiver(testReceiverAddress), WithGasEstimation())
+	require.NoError(t, err)
+	require.NotNil(t, body.ReceiverAddress)
+	assert.Equal(t, testReceiverAddress, *body.ReceiverAddress)
+	assert.True(t, body.DoGasEstimation)
+
+	_, err = client.GetExternalMatchQuote(testOrder(t), WithNoMatchError())
+	assert.ErrorIs(t, err, ErrNoMatch)
+}
@@ -0,0 +1,157 @@
+package external_match_client //nolint:revive
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// SnapshotFormat is the encoding of a depth snapshot
+type SnapshotFormat string
+
+const (
+	// SnapshotFormatCSV encodes a snapshot as CSV with a header row, one row
+	// per token
+	SnapshotFormatCSV SnapshotFormat = "csv"
+	// SnapshotFormatJSON encodes a snapshot as a single JSON object
+	SnapshotFormatJSON SnapshotFormat = "json"
+)
+
+// depthSnapshotHeader is the header row of a CSV depth snapshot
+var depthSnapshotHeader = []string{
+	"snapshot_timestamp",
+	"address",
+	"price",
+	"price_timestamp",
+	"buy_quantity",
+	"buy_quantity_usd",
+	"sell_quantity",
+	"sell_quantity_usd",
+}
+
+// DepthSnapshot is the order book depth of every supported token at a point
+// in time
+type DepthSnapshot struct {
+	// Timestamp is the time the snapshot was taken, in milliseconds since the
+	// epoch
+	Timestamp uint64 `json:"timestamp"`
+	// Depths are the price and depth of each token
+	Depths []api_types.ApiPriceAndDepth `json:"depths"`
+}
+
+// GetMarketDepth requests the price and order book depth of a token from the
+// relayer
+func (c *ExternalMatchClient) GetMarketDepth(mint string) (*api_types.ApiPriceAndDepth, error) {
+	var response api_types.GetDepthByMintResponse
+	err := c.relayerHttpClient.GetJSON(
+		api_types.BuildGetDepthByMintPath(mint),
+		nil, // body
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Depth, nil
+}
+
+// GetMarket requests the price and order book depth of a token's market from
+// the v2 markets API. Requires the v2 API
+func (c *ExternalMatchClient) GetMarket(mint string) (*api_types.ApiMarketDepth, error) {
+	if c.sandbox != nil {
+		return nil, ErrSandboxUnsupported
+	}
+	if err := c.requireApiVersion(ApiVersionV2); err != nil {
+		return nil, err
+	}
+
+	headers := make(http.Header)
+	headers.Set(apiKeyHeader, c.apiKey)
+
+	var response api_types.MarketDepthByMintResponse
+	err := c.httpClient.GetWithAuthAndHeaders(
+		api_types.BuildMarketDepthByMintPath(mint), &headers, nil /* body */, &response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.MarketDepth, nil
+}
+
+// GetMarketDepths requests the price and order book depth of every supported
+// token from the relayer
+func (c *ExternalMatchClient) GetMarketDepths() ([]api_types.ApiPriceAndDepth, error) {
+	var response api_types.GetDepthForAllPairsResponse
+	err := c.relayerHttpClient.GetJSON(
+		api_types.GetDepthForAllPairsPath,
+		nil, // body
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Pairs, nil
+}
+
+// ExportDepthSnapshot fetches the depth of every supported token and writes
+// it to w in the given format, stamped with the time of the request
+func (c *ExternalMatchClient) ExportDepthSnapshot(w io.Writer, format SnapshotFormat) error {
+	depths, err := c.GetMarketDepths()
+	if err != nil {
+		return err
+	}
+
+	snapshot := DepthSnapshot{
+		Timestamp: uint64(time.Now().UnixMilli()), //nolint:gosec
+		Depths:    depths,
+	}
+	return snapshot.Write(w, format)
+}
+
+// Write encodes the snapshot to w in the given format
+func (s *DepthSnapshot) Write(w io.Writer, format SnapshotFormat) error {
+	switch format {
+	case SnapshotFormatJSON:
+		return json.NewEncoder(w).Encode(s)
+	case SnapshotFormatCSV:
+		return s.writeCSV(w)
+	default:
+		return fmt.Errorf("unsupported snapshot format: %q", format)
+	}
+}
+
+// writeCSV encodes the snapshot as CSV
+func (s *DepthSnapshot) writeCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(depthSnapshotHeader); err != nil {
+		return err
+	}
+
+	snapshotTimestamp := strconv.FormatUint(s.Timestamp, 10)
+	for _, depth := range s.Depths {
+		row := []string{
+			snapshotTimestamp,
+			depth.Address,
+			depth.PriceDecimal().String(),
+			strconv.FormatUint(depth.Timestamp, 10),
+			depth.Buy.TotalQuantity.String(),
+			depth.Buy.TotalQuantityUsdDecimal().String(),
+			depth.Sell.TotalQuantity.String(),
+			depth.Sell.TotalQuantityUsdDecimal().String(),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,38 @@
+package external_match_client //nolint:revive
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+func TestExportDepthSnapshot(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, api_types.GetDepthForAllPairsPath, r.URL.Path)
+		_, _ = w.Write([]byte(`{"pairs":[{"address":"0x01","price":1.5,"timestamp":7,` +
+			`"buy":{"total_quantity":100,"total_quantity_usd":150},` +
+			`"sell":{"total_quantity":20,"total_quantity_usd":30}}]}`))
+	})
+
+	var csvOut bytes.Buffer
+	assert.NoError(t, client.ExportDepthSnapshot(&csvOut, SnapshotFormatCSV))
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, strings.Join(depthSnapshotHeader, ","), lines[0])
+	assert.True(t, strings.HasSuffix(lines[1], ",0x01,1.5,7,100,150,20,30"))
+
+	var jsonOut bytes.Buffer
+	assert.NoError(t, client.ExportDepthSnapshot(&jsonOut, SnapshotFormatJSON))
+	var snapshot DepthSnapshot
+	assert.NoError(t, json.Unmarshal(jsonOut.Bytes(), &snapshot))
+	assert.NotZero(t, snapshot.Timestamp)
+	assert.Equal(t, "100", snapshot.Depths[0].Buy.TotalQuantity.String())
+
+	assert.Error(t, client.ExportDepthSnapshot(&jsonOut, "xml"))
+}
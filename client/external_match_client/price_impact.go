@@ -0,0 +1,112 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// bpsPerUnit is the number of basis points in one unit
+const bpsPerUnit = 10_000
+
+// PriceImpact describes how a quote's price compares to a reference price.
+// All prices are in units of the quote token per unit of the base token,
+// both in their smallest denomination
+type PriceImpact struct {
+	// ExecutionPrice is the price at which the match executes, before fees
+	ExecutionPrice float64
+	// EffectivePrice is the price the external party realizes after fees
+	EffectivePrice float64
+	// ImpactBps is the execution price's deviation from the reference, in
+	// basis points; positive when the price is worse for the external party
+	ImpactBps float64
+	// EffectiveSpreadBps is twice the distance of the effective price from the
+	// reference, in basis points
+	EffectiveSpreadBps float64
+}
+
+// EstimatePriceImpact compares a quote to a reference price, in units of the
+// quote token per unit of the base token, both in their smallest
+// denomination. ReferencePrice converts a USD price to these units.
+//
+// A nil quote, as returned when the relayer finds no match, has no price
+// impact and is reported as a NoMatchError
+func EstimatePriceImpact(
+	quote *api_types.ApiSignedQuote, referencePrice float64,
+) (*PriceImpact, error) {
+	if quote == nil {
+		return nil, &NoMatchError{Reason: NoMatchNoLiquidity}
+	}
+	if !(referencePrice > 0) {
+		return nil, errors.New("reference price must be positive")
+	}
+
+	q := &quote.Quote
+	executionPrice, err := amountRatio(q.MatchResult.QuoteAmount, q.MatchResult.BaseAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	// The external party sends quote tokens when buying and base tokens when
+	// selling; fees are taken from what it receives
+	var effectivePrice float64
+	if q.MatchResult.Direction == "Buy" {
+		effectivePrice, err = amountRatio(q.Send.Amount, q.Receive.Amount)
+	} else {
+		effectivePrice, err = amountRatio(q.Receive.Amount, q.Send.Amount)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	impact := (executionPrice - referencePrice) / referencePrice * bpsPerUnit
+	if q.MatchResult.Direction != "Buy" {
+		impact = -impact
+	}
+
+	return &PriceImpact{
+		ExecutionPrice:     executionPrice,
+		EffectivePrice:     effectivePrice,
+		ImpactBps:          impact,
+		EffectiveSpreadBps: 2 * math.Abs(effectivePrice-referencePrice) / referencePrice * bpsPerUnit,
+	}, nil
+}
+
+// ReferencePrice converts a token's USD price, e.g. from its order book
+// depth, to units of a USD stablecoin quote token per unit of the token, both
+// in their smallest denomination
+func ReferencePrice(usdPrice float64, baseDecimals, quoteDecimals uint8) float64 {
+	if math.IsNaN(usdPrice) || math.IsInf(usdPrice, 0) {
+		return math.NaN()
+	}
+	return api_types.NewPriceFromFloat(usdPrice).ToAtoms(baseDecimals, quoteDecimals).Float64()
+}
+
+// FetchReferencePrice fetches the price of a token's market from the v2
+// markets API and converts it to units of the market's quote token per unit
+// of the token, both in their smallest denomination. Requires the v2 API
+func (c *ExternalMatchClient) FetchReferencePrice(
+	baseMint string, baseDecimals, quoteDecimals uint8,
+) (float64, error) {
+	market, err := c.GetMarket(baseMint)
+	if err != nil {
+		return 0, err
+	}
+	if market.Market.Price.Price.Sign() <= 0 {
+		return 0, fmt.Errorf("market %s has no price", baseMint)
+	}
+
+	price := api_types.NewPriceFromDecimal(market.Market.Price.Price)
+	return price.ToAtoms(baseDecimals, quoteDecimals).Float64(), nil
+}
+
+// amountRatio returns the price implied by two amounts as a float
+func amountRatio(numerator, denominator api_types.Amount) (float64, error) {
+	price, err := api_types.NewPriceFromAmounts(numerator, denominator)
+	if err != nil {
+		return 0, errors.New("cannot compute price of a zero amount")
+	}
+	return price.Float64(), nil
+}
@@ -0,0 +1,85 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// testQuote builds a quote trading the given amounts in the given direction,
+// with fees taken from the external party's receive side
+func testQuote(direction string, base, quote, fee int64) *api_types.ApiSignedQuote {
+	send := api_types.ApiExternalAssetTransfer{Amount: api_types.NewAmount(quote)}
+	receive := api_types.ApiExternalAssetTransfer{Amount: api_types.NewAmount(base - fee)}
+	if direction == "Sell" {
+		send = api_types.ApiExternalAssetTransfer{Amount: api_types.NewAmount(base)}
+		receive = api_types.ApiExternalAssetTransfer{Amount: api_types.NewAmount(quote - fee)}
+	}
+
+	return &api_types.ApiSignedQuote{Quote: api_types.ApiExternalQuote{
+		MatchResult: api_types.ApiExternalMatchResult{
+			BaseAmount:  api_types.NewAmount(base),
+			QuoteAmount: api_types.NewAmount(quote),
+			Direction:   direction,
+		},
+		Send:    send,
+		Receive: receive,
+	}}
+}
+
+func TestEstimatePriceImpact(t *testing.T) {
+	// Buying at 101 against a reference of 100 is 100 bps adverse
+	impact, err := EstimatePriceImpact(testQuote("Buy", 1000, 101000, 0), 100)
+	assert.NoError(t, err)
+	assert.InDelta(t, 101, impact.ExecutionPrice, 1e-9)
+	assert.InDelta(t, 100, impact.ImpactBps, 1e-9)
+	assert.InDelta(t, 200, impact.EffectiveSpreadBps, 1e-9)
+
+	// Selling at 101 against a reference of 100 is favorable, but fees
+	// reduce the effective price
+	impact, err = EstimatePriceImpact(testQuote("Sell", 1000, 101000, 2000), 100)
+	assert.NoError(t, err)
+	assert.InDelta(t, -100, impact.ImpactBps, 1e-9)
+	assert.InDelta(t, 99, impact.EffectivePrice, 1e-9)
+	assert.InDelta(t, 200, impact.EffectiveSpreadBps, 1e-9)
+
+	_, err = EstimatePriceImpact(testQuote("Buy", 0, 0, 0), 100)
+	assert.Error(t, err)
+	_, err = EstimatePriceImpact(testQuote("Buy", 1000, 101000, 0), 0)
+	assert.Error(t, err)
+
+	// A quote request that found no match has no price impact
+	_, err = EstimatePriceImpact(nil /* quote */, 100)
+	assert.True(t, errors.Is(err, ErrNoMatch))
+}
+
+func TestReferencePrice(t *testing.T) {
+	// $2000 per 18-decimal token, quoted in a 6-decimal stablecoin
+	assert.InDelta(t, 2000e-12, ReferencePrice(2000, 18, 6), 1e-21)
+}
+
+func TestFetchReferencePrice(t *testing.T) {
+	const mint = "0x82af49447d8a07e3bd95bd0d56f35241523fbab1"
+	c := newTestClientWithOptions(t, NewExternalMatchClientOptions().WithApiVersion(ApiVersionV2),
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, api_types.BuildMarketDepthByMintPath(mint), r.URL.Path)
+			_, _ = w.Write([]byte(`{"market_depth":{"market":{"price":{"price":"2000.5","timestamp":1}}}}`))
+		})
+
+	// $2000.5 per 18-decimal token, quoted in a 6-decimal stablecoin
+	price, err := c.FetchReferencePrice(mint, 18, 6)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2000.5e-12, price, 1e-21)
+
+	// The v2 markets API is not served by older servers
+	c = newTestClientWithOptions(t, NewExternalMatchClientOptions().WithApiVersion(ApiVersionV0),
+		func(_ http.ResponseWriter, _ *http.Request) {
+			t.Error("no request should be sent to a v0 server")
+		})
+	_, err = c.FetchReferencePrice(mint, 18, 6)
+	assert.True(t, errors.Is(err, ErrUnsupportedApiVersion))
+}
@@ -0,0 +1,255 @@
+package external_match_client //nolint:revive
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+const (
+	// defaultRequoteInterval is the default interval at which a Quoter re-quotes
+	defaultRequoteInterval = 5 * time.Second
+	// defaultMaxQuoteAge is the default age after which a Quoter's quote is
+	// no longer considered executable
+	defaultMaxQuoteAge = 10 * time.Second
+)
+
+// QuoterOptions configures a Quoter
+type QuoterOptions struct {
+	// RequoteInterval is the interval at which the order is re-quoted
+	RequoteInterval time.Duration
+	// DepthPollInterval is the interval at which the order book depth of the
+	// order's base token is polled; the order is re-quoted as soon as the
+	// depth changes. A value of zero disables depth polling
+	DepthPollInterval time.Duration
+	// MaxQuoteAge is the age after which a quote is no longer returned by Latest
+	MaxQuoteAge time.Duration
+	// QuoteOptions are the options of each quote request
+	QuoteOptions *ExternalQuoteOptions
+}
+
+// NewQuoterOptions creates a new QuoterOptions with default values
+func NewQuoterOptions() *QuoterOptions {
+	return &QuoterOptions{
+		RequoteInterval: defaultRequoteInterval,
+		MaxQuoteAge:     defaultMaxQuoteAge,
+		QuoteOptions:    NewExternalQuoteOptions(),
+	}
+}
+
+// WithRequoteInterval sets the interval at which the order is re-quoted
+func (o *QuoterOptions) WithRequoteInterval(interval time.Duration) *QuoterOptions {
+	o.RequoteInterval = interval
+	return o
+}
+
+// WithDepthTrigger re-quotes the order whenever the order book depth of its
+// base token changes, polling the depth at the given interval
+func (o *QuoterOptions) WithDepthTrigger(pollInterval time.Duration) *QuoterOptions {
+	o.DepthPollInterval = pollInterval
+	return o
+}
+
+// WithMaxQuoteAge sets the age after which a quote is no longer executable
+func (o *QuoterOptions) WithMaxQuoteAge(age time.Duration) *QuoterOptions {
+	o.MaxQuoteAge = age
+	return o
+}
+
+// WithQuoteOptions sets the options of each quote request
+func (o *QuoterOptions) WithQuoteOptions(options *ExternalQuoteOptions) *QuoterOptions {
+	o.QuoteOptions = options
+	return o
+}
+
+// Validate checks that the re-quote interval and maximum quote age are
+// positive
+func (o *QuoterOptions) Validate() error {
+	if o.RequoteInterval <= 0 {
+		return fmt.Errorf("requote interval must be positive, got %s", o.RequoteInterval)
+	}
+	if o.MaxQuoteAge <= 0 {
+		return fmt.Errorf("max quote age must be positive, got %s", o.MaxQuoteAge)
+	}
+	return nil
+}
+
+// Quoter maintains a continuously refreshed quote for a standing order, for
+// RFQ-style integrations that must answer with an executable price at any
+// moment.
+//
+// The order is re-quoted on an interval and, optionally, as soon as the order
+// book depth of its base token changes. Latest returns the freshest quote
+type Quoter struct {
+	// fetchQuote requests a quote for the order, returning nil on no match
+	fetchQuote func() (*api_types.ApiSignedQuote, error)
+	// fetchDepth requests the depth of the order's base token
+	fetchDepth func() (*api_types.ApiPriceAndDepth, error)
+	// options configures the quoter
+	options QuoterOptions
+	// owner is the client that created the quoter, which stops it while
+	// running on shutdown; nil if none
+	owner *ExternalMatchClient
+
+	// mu guards the fields below
+	mu sync.Mutex
+	// latest is the most recent quote, nil if the last request found no match
+	latest *api_types.ApiSignedQuote
+	// quotedAt is the time the latest quote was received
+	quotedAt time.Time
+	// depth is the depth observed in the last depth poll
+	depth *api_types.ApiPriceAndDepth
+	// stop is closed to stop the refresh routine
+	stop chan struct{}
+}
+
+// NewQuoter creates a Quoter for the given order. The quoter does not quote
+// until started or refreshed, and is stopped if running when the client is
+// closed. Nil
+// options are treated as the defaults; options that fail Validate are rejected
+func (c *ExternalMatchClient) NewQuoter(
+	order *api_types.ApiExternalOrder, options *QuoterOptions,
+) (*Quoter, error) {
+	if options == nil {
+		options = NewQuoterOptions()
+	}
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	quoteOptions := options.QuoteOptions
+	if quoteOptions == nil {
+		quoteOptions = NewExternalQuoteOptions()
+	}
+
+	q := &Quoter{
+		fetchQuote: func() (*api_types.ApiSignedQuote, error) {
+			return c.GetExternalMatchQuoteWithOptions(order, quoteOptions)
+		},
+		fetchDepth: func() (*api_types.ApiPriceAndDepth, error) {
+			return c.GetMarketDepth(order.BaseMint)
+		},
+		options: *options,
+		owner:   c,
+	}
+	return q, nil
+}
+
+// Latest returns the freshest executable quote, or nil if the last request
+// found no match or the latest quote is older than the maximum quote age
+func (q *Quoter) Latest() *api_types.ApiSignedQuote {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.latest == nil || time.Since(q.quotedAt) > q.options.MaxQuoteAge {
+		return nil
+	}
+	return q.latest
+}
+
+// Start begins re-quoting in a background routine, quoting immediately.
+// Starting a running quoter is a no-op
+func (q *Quoter) Start() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.stop != nil {
+		return
+	}
+
+	q.stop = make(chan struct{})
+	if q.owner != nil {
+		q.owner.trackQuoter(q)
+	}
+	go q.run(q.stop)
+}
+
+// Stop stops the re-quoting routine. The latest quote remains available until
+// it ages out
+func (q *Quoter) Stop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.stop == nil {
+		return
+	}
+
+	close(q.stop)
+	q.stop = nil
+	if q.owner != nil {
+		q.owner.untrackQuoter(q)
+	}
+}
+
+// Refresh re-quotes the order once. A request that finds no match clears the
+// latest quote; a failed request leaves it in place to age out
+func (q *Quoter) Refresh() error {
+	quote, err := q.fetchQuote()
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.latest = quote
+	q.quotedAt = time.Now()
+	return nil
+}
+
+// checkDepth polls the depth of the order's base token, returning whether it
+// changed since the previous poll. The first poll records the depth
+func (q *Quoter) checkDepth() (bool, error) {
+	depth, err := q.fetchDepth()
+	if err != nil {
+		return false, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	prev := q.depth
+	q.depth = depth
+	if prev == nil {
+		return false, nil
+	}
+
+	return prev.Buy.TotalQuantity.Cmp(depth.Buy.TotalQuantity) != 0 ||
+		prev.Sell.TotalQuantity.Cmp(depth.Sell.TotalQuantity) != 0, nil
+}
+
+// run re-quotes until the stop channel is closed
+func (q *Quoter) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(q.options.RequoteInterval)
+	defer ticker.Stop()
+
+	// A nil channel never fires, disabling the depth trigger
+	var depthTicks <-chan time.Time
+	if q.options.DepthPollInterval > 0 {
+		depthTicker := time.NewTicker(q.options.DepthPollInterval)
+		defer depthTicker.Stop()
+		depthTicks = depthTicker.C
+	}
+
+	q.refreshAndLog()
+	for {
+		select {
+		case <-ticker.C:
+			q.refreshAndLog()
+		case <-depthTicks:
+			changed, err := q.checkDepth()
+			if err != nil {
+				log.Printf("quoter depth poll failed: %v", err)
+			} else if changed {
+				q.refreshAndLog()
+				ticker.Reset(q.options.RequoteInterval)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// refreshAndLog re-quotes the order, logging any failure
+func (q *Quoter) refreshAndLog() {
+	if err := q.Refresh(); err != nil {
+		log.Printf("quoter refresh failed: %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package external_match_client //nolint:revive
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// testDepth builds a depth with the given buy and sell quantities
+func testDepth(buy, sell int64) *api_types.ApiPriceAndDepth {
+	return &api_types.ApiPriceAndDepth{
+		Buy:  api_types.ApiDepthSide{TotalQuantity: api_types.NewAmount(buy)},
+		Sell: api_types.ApiDepthSide{TotalQuantity: api_types.NewAmount(sell)},
+	}
+}
+
+func TestQuoterLatest(t *testing.T) {
+	var quote *api_types.ApiSignedQuote
+	quoter := &Quoter{
+		fetchQuote: func() (*api_types.ApiSignedQuote, error) { return quote, nil },
+		options:    *NewQuoterOptions().WithMaxQuoteAge(20 * time.Millisecond),
+	}
+	assert.Nil(t, quoter.Latest())
+
+	quote = &api_types.ApiSignedQuote{Signature: "first"}
+	assert.NoError(t, quoter.Refresh())
+	assert.Equal(t, quote, quoter.Latest())
+
+	// Quotes age out
+	time.Sleep(30 * time.Millisecond)
+	assert.Nil(t, quoter.Latest())
+
+	// A request with no match clears the quote
+	assert.NoError(t, quoter.Refresh())
+	quote = nil
+	assert.NoError(t, quoter.Refresh())
+	assert.Nil(t, quoter.Latest())
+}
+
+func TestQuoterRequotesOnDepthChange(t *testing.T) {
+	var quotes, depthPolls atomic.Int32
+	quoter := &Quoter{
+		fetchQuote: func() (*api_types.ApiSignedQuote, error) {
+			quotes.Add(1)
+			return &api_types.ApiSignedQuote{}, nil
+		},
+		fetchDepth: func() (*api_types.ApiPriceAndDepth, error) {
+			// The depth changes on the third poll
+			if depthPolls.Add(1) < 3 {
+				return testDepth(100, 100), nil
+			}
+			return testDepth(100, 50), nil
+		},
+		options: *NewQuoterOptions().
+			WithRequoteInterval(time.Hour).
+			WithDepthTrigger(5 * time.Millisecond),
+	}
+
+	quoter.Start()
+	defer quoter.Stop()
+	assert.Eventually(t, func() bool { return quotes.Load() == 2 }, time.Second, time.Millisecond)
+	assert.NotNil(t, quoter.Latest())
+}
+
+func TestNewQuoterOptions(t *testing.T) {
+	c := NewSandboxExternalMatchClient(1)
+
+	// Nil options are the defaults
+	quoter, err := c.NewQuoter(testOrder(t), nil /* options */)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultRequoteInterval, quoter.options.RequoteInterval)
+
+	// Non-positive intervals and ages are rejected rather than panicking or
+	// expiring every quote
+	_, err = c.NewQuoter(testOrder(t), NewQuoterOptions().WithRequoteInterval(0))
+	assert.Error(t, err)
+	_, err = c.NewQuoter(testOrder(t), NewQuoterOptions().WithRequoteInterval(-time.Second))
+	assert.Error(t, err)
+	_, err = c.NewQuoter(testOrder(t), NewQuoterOptions().WithMaxQuoteAge(0))
+	assert.Error(t, err)
+}
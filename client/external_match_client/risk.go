@@ -0,0 +1,50 @@
+package external_match_client //nolint:revive
+
+import (
+	"log"
+	"math/big"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// checkOrderRisk checks an order against the risk limits before it is sent.
+// Only orders sized in the quote token have a notional known up front; the
+// notional of other orders is checked once the relayer sizes their match
+func (c *ExternalMatchClient) checkOrderRisk(order *api_types.ApiExternalOrder) error {
+	if c.riskGuard == nil || order == nil || order.QuoteAmount.IsZero() {
+		return nil
+	}
+	return c.riskGuard.CheckTrade(order.BaseMint, order.QuoteMint, (*big.Int)(&order.QuoteAmount))
+}
+
+// reserveMatchRisk reserves a match's notional against the risk limits,
+// returning the reserved notional
+func (c *ExternalMatchClient) reserveMatchRisk(
+	match *api_types.ApiExternalMatchResult, updatedOrder *api_types.ApiExternalOrder,
+) (*big.Int, error) {
+	if c.riskGuard == nil {
+		return nil, nil
+	}
+
+	// An updated order may resize the match up to its own quote amount
+	notional := new(big.Int).Set((*big.Int)(&match.QuoteAmount))
+	if updatedOrder != nil && (*big.Int)(&updatedOrder.QuoteAmount).Cmp(notional) > 0 {
+		notional.Set((*big.Int)(&updatedOrder.QuoteAmount))
+	}
+
+	if err := c.riskGuard.ReserveTrade(match.BaseMint, match.QuoteMint, notional); err != nil {
+		return nil, err
+	}
+	return notional, nil
+}
+
+// releaseMatchRisk releases a notional reserved by reserveMatchRisk for a match
+// that was not assembled
+func (c *ExternalMatchClient) releaseMatchRisk(match *api_types.ApiExternalMatchResult, notional *big.Int) {
+	if c.riskGuard == nil || notional == nil {
+		return
+	}
+	if err := c.riskGuard.ReleaseTrade(match.BaseMint, match.QuoteMint, notional); err != nil {
+		log.Printf("failed to release risk reservation: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// riskTestQuoteDecimals gives the test quote mint 18 decimals, so that
+// notionals are not rescaled
+var riskTestQuoteDecimals = map[string]uint8{"0x2": 18}
+
+// newRiskLimitedClient creates a client with the given risk limits whose
+// server answers every request with the given status and body
+func newRiskLimitedClient(
+	t *testing.T, limits client.RiskLimits, status int, body string, requests *atomic.Int32,
+) *ExternalMatchClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	options := NewExternalMatchClientOptions().WithRiskLimits(limits)
+	return NewExternalMatchClientWithOptions(server.URL, server.URL, "test-key", &wallet.HmacKey{}, options)
+}
+
+// riskTestQuote builds a signed quote for a match of the given quote amount
+func riskTestQuote(quoteAmount int64) *api_types.ApiSignedQuote {
+	return &api_types.ApiSignedQuote{
+		Quote: api_types.ApiExternalQuote{
+			MatchResult: api_types.ApiExternalMatchResult{
+				BaseMint:    "0x1",
+				QuoteMint:   "0x2",
+				QuoteAmount: api_types.NewAmount(quoteAmount),
+			},
+		},
+		Signature: "sig",
+	}
+}
+
+func TestQuoteRefusedOverNotionalLimit(t *testing.T) {
+	var requests atomic.Int32
+	limits := client.RiskLimits{MaxNotionalPerTrade: big.NewInt(1000), QuoteDecimals: riskTestQuoteDecimals}
+	c := newRiskLimitedClient(t, limits, http.StatusNoContent, "", &requests)
+
+	order, err := api_types.NewExternalOrderBuilder().
+		WithBaseMint("0x1").
+		WithQuoteMint("0x2").
+		WithQuoteAmount(api_types.NewAmount(5000)).
+		WithSide("Buy").
+		Build()
+	assert.NoError(t, err)
+
+	_, err = c.GetExternalMatchQuote(order)
+	var limitErr *client.RiskLimitError
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, int32(0), requests.Load())
+
+	// Orders sized in the base token are quoted and checked on assembly
+	_, err = c.GetExternalMatchQuote(testOrder(t))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestAssemblyReservesDailyVolume(t *testing.T) {
+	var requests atomic.Int32
+	limits := client.RiskLimits{MaxDailyVolumePerPair: big.NewInt(1000), QuoteDecimals: riskTestQuoteDecimals}
+	bundle := `{"match_bundle":{"settlement_tx":{"to":"0x01","data":"0x","value":"0x0"}}}`
+	c := newRiskLimitedClient(t, limits, http.StatusOK, bundle, &requests)
+
+	_, err := c.AssembleExternalQuote(riskTestQuote(600))
+	assert.NoError(t, err)
+
+	// The second assembly would exceed the pair's daily volume
+	_, err = c.AssembleExternalQuote(riskTestQuote(600))
+	var limitErr *client.RiskLimitError
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestFailedAssemblyReleasesVolume(t *testing.T) {
+	var requests atomic.Int32
+	limits := client.RiskLimits{MaxDailyVolumePerPair: big.NewInt(1000), QuoteDecimals: riskTestQuoteDecimals}
+	c := newRiskLimitedClient(t, limits, http.StatusNoContent, "", &requests)
+
+	// Assemblies that produce no bundle do not count towards the volume
+	for i := 0; i < 3; i++ {
+		bundle, err := c.AssembleExternalQuote(riskTestQuote(600))
+		assert.NoError(t, err)
+		assert.Nil(t, bundle)
+	}
+	assert.Equal(t, int32(3), requests.Load())
+}
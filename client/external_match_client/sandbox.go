@@ -0,0 +1,191 @@
+package external_match_client //nolint:revive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// sandboxSettlementTxType is the settlement transaction type of sandbox bundles
+const sandboxSettlementTxType = "sandbox"
+
+// sandboxGasEstimate is the gas estimate of sandbox bundles assembled with gas
+// estimation
+const sandboxGasEstimate = 500_000
+
+// ErrSandboxNoPrice is returned when a sandbox client is asked to quote
+// without a configured price
+var ErrSandboxNoPrice = errors.New("sandbox price must be positive")
+
+// ErrSandboxUnsupported is returned by sandbox clients for authenticated
+// calls they cannot answer locally, since they hold no API credentials
+var ErrSandboxUnsupported = errors.New("not supported in sandbox mode")
+
+// SandboxOptions configures a sandbox client, which fabricates quotes and
+// bundles locally instead of contacting the relayer
+type SandboxOptions struct {
+	// Price is the price at which every order is filled, in units of the
+	// quote token per unit of the base token, both in their smallest
+	// denomination
+	Price float64
+	// FeeRates are the fee rates charged on sandbox matches, computed as the
+	// relayer does; zero rates charge no fees
+	FeeRates FeeRates
+}
+
+// NewSandboxExternalMatchClient creates an ExternalMatchClient in sandbox
+// mode, filling every order in full at the given price.
+//
+// A sandbox client needs no API credentials and never contacts the auth
+// server or relayer for quotes or bundles. Its bundles are deterministic
+// functions of their quote but are not valid settlement transactions; they
+// are marked with Sandbox so that submitters can skip them. Unauthenticated
+// reads such as supported token lookups are not sandboxed; authenticated calls
+// that cannot be answered locally, e.g. GetExchangeMetadata, fail with
+// ErrSandboxUnsupported.
+func NewSandboxExternalMatchClient(price float64) *ExternalMatchClient {
+	options := NewExternalMatchClientOptions().WithSandbox(price)
+	return NewExternalMatchClientWithOptions(
+		testnetBaseUrl, testnetRelayerBaseUrl, "" /* apiKey */, nil /* apiSecret */, options,
+	)
+}
+
+// sandboxQuote fabricates a signed quote filling the order in full at the
+// sandbox price
+func sandboxQuote(
+	order *api_types.ApiExternalOrder, options *SandboxOptions,
+) (*api_types.ApiSignedQuote, error) {
+	if !(options.Price > 0) || math.IsInf(options.Price, 0) {
+		return nil, ErrSandboxNoPrice
+	}
+
+	// Price the quote at the shortest decimal form of the configured price
+	price, err := api_types.ParsePrice(strconv.FormatFloat(options.Price, 'f', -1, 64))
+	if err != nil {
+		return nil, err
+	}
+	return sandboxQuoteAtPrice(order, price, options.FeeRates)
+}
+
+// sandboxQuoteAtPrice fabricates a signed quote filling the order in full at
+// the given price, charging fees at the given rates
+func sandboxQuoteAtPrice(
+	order *api_types.ApiExternalOrder, price api_types.Price, rates FeeRates,
+) (*api_types.ApiSignedQuote, error) {
+	// Size the match from whichever side of the order is set
+	baseAmount := new(big.Int).Set((*big.Int)(&order.BaseAmount))
+	quoteAmount := new(big.Int).Set((*big.Int)(&order.QuoteAmount))
+	if !order.BaseAmount.IsZero() {
+		quoteAmount = price.QuoteAmount(baseAmount, wallet.RoundFloor)
+	} else {
+		baseAmount = price.BaseAmount(quoteAmount, wallet.RoundFloor)
+	}
+
+	matchResult := api_types.ApiExternalMatchResult{
+		QuoteMint:   order.QuoteMint,
+		BaseMint:    order.BaseMint,
+		QuoteAmount: api_types.Amount(*quoteAmount),
+		BaseAmount:  api_types.Amount(*baseAmount),
+		Direction:   order.Side,
+	}
+
+	fees := ComputeFees(&matchResult, rates)
+	send, receive := ComputeTransfers(&matchResult, &fees)
+
+	quote := api_types.ApiExternalQuote{
+		Order:       *order,
+		MatchResult: matchResult,
+		Fees:        fees,
+		Send:        send,
+		Receive:     receive,
+		Price: api_types.TimestampedPrice{
+			Timestamp: uint64(time.Now().UnixMilli()), //nolint:gosec
+			Price:     price.String(),
+		},
+		Timestamp: uint64(time.Now().UnixMilli()), //nolint:gosec
+	}
+
+	digest, err := sandboxDigest(quote)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api_types.ApiSignedQuote{
+		Quote:     quote,
+		Signature: sandboxSettlementTxType + ":" + digest.Hex(),
+	}, nil
+}
+
+// sandboxBundle fabricates a bundle from a signed quote. The bundle is a
+// deterministic function of the quote and assembly options
+func sandboxBundle(
+	quote *api_types.ApiSignedQuote, options *AssembleExternalMatchOptions, sandbox *SandboxOptions,
+) (*ExternalMatchBundle, error) {
+	q := quote.Quote
+	if options.UpdatedOrder != nil {
+		price, err := q.Price.ToPrice()
+		if err != nil {
+			return nil, fmt.Errorf("invalid sandbox quote price: %w", err)
+		}
+		updated, err := sandboxQuoteAtPrice(options.UpdatedOrder, price, sandbox.FeeRates)
+		if err != nil {
+			return nil, err
+		}
+		q = updated.Quote
+	}
+
+	digest, err := sandboxDigest(struct {
+		Quote        api_types.ApiSignedQuote
+		Receiver     *string
+		UpdatedOrder *api_types.ApiExternalOrder
+	}{Quote: *quote, Receiver: options.ReceiverAddress, UpdatedOrder: options.UpdatedOrder})
+	if err != nil {
+		return nil, err
+	}
+
+	value := big.NewInt(0)
+	if api_types.IsNativeAsset(q.Send.Mint) {
+		value = new(big.Int).Set((*big.Int)(&q.Send.Amount))
+	}
+
+	var gas uint64
+	if options.DoGasEstimation {
+		gas = sandboxGasEstimate
+	}
+
+	return &ExternalMatchBundle{
+		MatchResult: &q.MatchResult,
+		Fees:        &q.Fees,
+		Receive:     &q.Receive,
+		Send:        &q.Send,
+		SettlementTx: &SettlementTransaction{
+			Type:  sandboxSettlementTxType,
+			To:    geth_common.Address{},
+			Data:  digest.Bytes(),
+			Value: value,
+			Gas:   gas,
+		},
+		QuoteSignature: quote.Signature,
+		Sandbox:        true,
+	}, nil
+}
+
+// sandboxDigest hashes a value's JSON encoding
+func sandboxDigest(v interface{}) (geth_common.Hash, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return geth_common.Hash{}, fmt.Errorf("failed to encode sandbox value: %w", err)
+	}
+
+	return crypto.Keccak256Hash(encoded), nil
+}
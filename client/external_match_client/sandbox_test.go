@@ -0,0 +1,69 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+func TestSandboxQuoteAndAssemble(t *testing.T) {
+	client := NewSandboxExternalMatchClient(2.5)
+
+	// Sell 100 base at 2.5 quote per base
+	quote, err := client.GetExternalMatchQuote(testOrder(t))
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1", quote.Quote.Send.Mint)
+	assert.Equal(t, "100", quote.Quote.Send.Amount.String())
+	assert.Equal(t, "0x2", quote.Quote.Receive.Mint)
+	assert.Equal(t, "250", quote.Quote.Receive.Amount.String())
+
+	// Assembly is deterministic in the quote and never contacts the relayer
+	first, err := client.AssembleExternalQuote(quote)
+	assert.NoError(t, err)
+	second, err := client.AssembleExternalQuote(quote)
+	assert.NoError(t, err)
+	assert.True(t, first.Sandbox)
+	assert.Equal(t, first.SettlementTx, second.SettlementTx)
+
+	receiver := "0x0000000000000000000000000000000000000003"
+	third, err := client.AssembleExternalQuoteWithReceiver(quote, &receiver)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.SettlementTx.Data, third.SettlementTx.Data)
+}
+
+func TestSandboxQuoteDenominatedInQuote(t *testing.T) {
+	client := NewSandboxExternalMatchClient(4)
+	order, err := api_types.NewExternalOrderBuilder().
+		WithBaseMint("0x1").
+		WithQuoteMint("0x2").
+		WithQuoteAmount(api_types.NewAmount(100)).
+		WithSide("Buy").
+		Build()
+	assert.NoError(t, err)
+
+	bundle, err := client.GetExternalMatchBundle(order)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x2", bundle.Send.Mint)
+	assert.Equal(t, "100", bundle.Send.Amount.String())
+	assert.Equal(t, "25", bundle.Receive.Amount.String())
+}
+
+func TestSandboxRefusesAuthenticatedCalls(t *testing.T) {
+	// Pin v2 so that no call is refused for the API version alone
+	options := NewExternalMatchClientOptions().WithSandbox(1).WithApiVersion(ApiVersionV2)
+	client := NewExternalMatchClientWithOptions(
+		testnetBaseUrl, testnetRelayerBaseUrl, "" /* apiKey */, nil /* apiSecret */, options,
+	)
+
+	_, err := client.GetExchangeMetadata()
+	assert.True(t, errors.Is(err, ErrSandboxUnsupported))
+	_, err = client.GetMarket("0x1")
+	assert.True(t, errors.Is(err, ErrSandboxUnsupported))
+
+	// Credentials are answered locally
+	_, err = client.ValidateCredentials()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,111 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	geth_common "github.com/ethereum/go-ethereum/common"
+)
+
+// ErrUnknownSettlementMethod is returned when settlement calldata does not
+// call a known darkpool settlement method
+var ErrUnknownSettlementMethod = errors.New("unknown settlement method")
+
+// darkpoolSettlementABI is the subset of the darkpool ABI used to settle
+// external matches
+//
+//nolint:lll
+const darkpoolSettlementABI = `[
+	{"type":"function","name":"processAtomicMatchSettle","stateMutability":"payable","outputs":[],"inputs":[
+		{"name":"internal_party_match_payload","type":"bytes"},
+		{"name":"valid_match_settle_atomic_statement","type":"bytes"},
+		{"name":"match_proofs","type":"bytes"},
+		{"name":"match_linking_proofs","type":"bytes"}
+	]},
+	{"type":"function","name":"processAtomicMatchSettleWithReceiver","stateMutability":"payable","outputs":[],"inputs":[
+		{"name":"receiver","type":"address"},
+		{"name":"internal_party_match_payload","type":"bytes"},
+		{"name":"valid_match_settle_atomic_statement","type":"bytes"},
+		{"name":"match_proofs","type":"bytes"},
+		{"name":"match_linking_proofs","type":"bytes"}
+	]}
+]`
+
+// settlementABI is the parsed darkpool settlement ABI
+var settlementABI = mustParseABI(darkpoolSettlementABI)
+
+// SettlementCalldata is a structured view of the calldata of a darkpool
+// settlement transaction, for auditing a bundle before it is signed
+type SettlementCalldata struct {
+	// Method is the name of the darkpool method called
+	Method string
+	// Selector is the four byte function selector
+	Selector [4]byte
+	// Receiver is the address that receives the external party's output, if
+	// the method settles to an explicit receiver
+	Receiver *geth_common.Address
+	// InternalPartyMatchPayload is the serialized payload of the internal party
+	InternalPartyMatchPayload []byte
+	// MatchSettleStatement is the serialized statement of the settlement proof,
+	// committing to the match and the external party's transfers
+	MatchSettleStatement []byte
+	// MatchProofs are the serialized proofs of the match
+	MatchProofs []byte
+	// MatchLinkingProofs are the serialized proof-linking proofs of the match
+	MatchLinkingProofs []byte
+}
+
+// DecodeSettlementCalldata decodes the calldata of a darkpool settlement
+// transaction.
+//
+// Returns ErrUnknownSettlementMethod if the calldata does not call a known
+// settlement method, and an error if its arguments are malformed
+func DecodeSettlementCalldata(data []byte) (*SettlementCalldata, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("calldata too short: %d bytes", len(data))
+	}
+
+	method, err := settlementABI.MethodById(data[:4])
+	if err != nil {
+		return nil, fmt.Errorf("%w: selector %x", ErrUnknownSettlementMethod, data[:4])
+	}
+
+	args := make(map[string]interface{})
+	if err := method.Inputs.UnpackIntoMap(args, data[4:]); err != nil {
+		return nil, fmt.Errorf("failed to decode %s calldata: %w", method.Name, err)
+	}
+
+	decoded := &SettlementCalldata{Method: method.Name}
+	copy(decoded.Selector[:], data[:4])
+	if receiver, ok := args["receiver"].(geth_common.Address); ok {
+		decoded.Receiver = &receiver
+	}
+
+	decoded.InternalPartyMatchPayload, _ = args["internal_party_match_payload"].([]byte)
+	decoded.MatchSettleStatement, _ = args["valid_match_settle_atomic_statement"].([]byte)
+	decoded.MatchProofs, _ = args["match_proofs"].([]byte)
+	decoded.MatchLinkingProofs, _ = args["match_linking_proofs"].([]byte)
+
+	return decoded, nil
+}
+
+// DecodeSettlement decodes the calldata of the bundle's settlement transaction
+func (b *ExternalMatchBundle) DecodeSettlement() (*SettlementCalldata, error) {
+	if b.SettlementTx == nil {
+		return nil, errors.New("bundle has no settlement transaction")
+	}
+
+	return DecodeSettlementCalldata(b.SettlementTx.Data)
+}
+
+// mustParseABI parses a JSON ABI, panicking on error
+func mustParseABI(json string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(json))
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
@@ -0,0 +1,41 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"testing"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeSettlementCalldata(t *testing.T) {
+	receiver := geth_common.HexToAddress("0x01")
+	data, err := settlementABI.Pack(
+		"processAtomicMatchSettleWithReceiver",
+		receiver, []byte{1}, []byte{2, 2}, []byte{3, 3, 3}, []byte{},
+	)
+	assert.NoError(t, err)
+
+	decoded, err := DecodeSettlementCalldata(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "processAtomicMatchSettleWithReceiver", decoded.Method)
+	assert.Equal(t, data[:4], decoded.Selector[:])
+	assert.Equal(t, &receiver, decoded.Receiver)
+	assert.Equal(t, []byte{1}, decoded.InternalPartyMatchPayload)
+	assert.Equal(t, []byte{2, 2}, decoded.MatchSettleStatement)
+	assert.Equal(t, []byte{3, 3, 3}, decoded.MatchProofs)
+	assert.Empty(t, decoded.MatchLinkingProofs)
+
+	// Without a receiver
+	data, err = settlementABI.Pack("processAtomicMatchSettle", []byte{1}, []byte{2}, []byte{3}, []byte{4})
+	assert.NoError(t, err)
+	decoded, err = DecodeSettlementCalldata(data)
+	assert.NoError(t, err)
+	assert.Nil(t, decoded.Receiver)
+
+	// Malformed and unknown calldata
+	_, err = DecodeSettlementCalldata(data[:40])
+	assert.Error(t, err)
+	_, err = DecodeSettlementCalldata([]byte{0xde, 0xad, 0xbe, 0xef})
+	assert.True(t, errors.Is(err, ErrUnknownSettlementMethod))
+}
@@ -0,0 +1,133 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// ErrSandboxSettlement is returned when validating a bundle fabricated by a
+// sandbox client, whose settlement transaction must not be submitted
+var ErrSandboxSettlement = errors.New("sandbox bundles cannot be settled")
+
+// SettlementMismatchError is returned when a settlement transaction targets
+// neither the darkpool nor the gas sponsor of the client's configured chain,
+// e.g. a testnet bundle about to be signed for mainnet
+type SettlementMismatchError struct {
+	// ExpectedChainID is the chain the client is configured for, zero if
+	// unconfigured
+	ExpectedChainID uint64
+	// ServerChainID is the chain reported by the server's exchange metadata
+	ServerChainID uint64
+	// Darkpool is the settlement contract reported by the server
+	Darkpool geth_common.Address
+	// GasSponsor is the gas sponsorship contract reported by the server, the
+	// zero address if unreported
+	GasSponsor geth_common.Address
+	// To is the address targeted by the settlement transaction
+	To geth_common.Address
+}
+
+// Error implements the error interface
+func (e *SettlementMismatchError) Error() string {
+	if e.ExpectedChainID != 0 && e.ExpectedChainID != e.ServerChainID {
+		return fmt.Sprintf(
+			"settlement chain mismatch: client expects chain %d, server settles on chain %d",
+			e.ExpectedChainID, e.ServerChainID,
+		)
+	}
+	if e.GasSponsor != (geth_common.Address{}) {
+		return fmt.Sprintf(
+			"settlement contract mismatch: transaction targets %s, darkpool on chain %d is %s and gas sponsor is %s",
+			e.To.Hex(), e.ServerChainID, e.Darkpool.Hex(), e.GasSponsor.Hex(),
+		)
+	}
+	return fmt.Sprintf(
+		"settlement contract mismatch: transaction targets %s, darkpool on chain %d is %s",
+		e.To.Hex(), e.ServerChainID, e.Darkpool.Hex(),
+	)
+}
+
+// GetExchangeMetadata requests the exchange metadata from the server,
+// including the chain it settles on and its darkpool address. Metadata that
+// does not match the client's pins is refused with a *MetadataMismatchError.
+// Requires the v2 API
+func (c *ExternalMatchClient) GetExchangeMetadata() (*api_types.ExchangeMetadataResponse, error) {
+	if c.sandbox != nil {
+		return nil, ErrSandboxUnsupported
+	}
+	if err := c.requireApiVersion(ApiVersionV2); err != nil {
+		return nil, err
+	}
+
+	metadata, err := cachedMetadata(c, exchangeMetadataCacheKey, func() (*api_types.ExchangeMetadataResponse, error) {
+		headers := make(http.Header)
+		headers.Set(apiKeyHeader, c.apiKey)
+
+		var response api_types.ExchangeMetadataResponse
+		err := c.httpClient.GetWithAuthAndHeaders(
+			api_types.ExchangeMetadataPath, &headers, nil /* body */, &response,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.verifyMetadata(&response); err != nil {
+			return nil, err
+		}
+		return &response, nil
+	}, clonePointer)
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// ValidateSettlementTx checks, before submission, that a bundle's settlement
+// transaction targets the darkpool reported by the server's exchange metadata,
+// or its gas sponsor for sponsored bundles, and, if the client is configured
+// with a chain ID, that the server settles on that chain. Returns a
+// *SettlementMismatchError on mismatch
+func (c *ExternalMatchClient) ValidateSettlementTx(bundle *ExternalMatchBundle) error {
+	if bundle.Sandbox {
+		return ErrSandboxSettlement
+	}
+	if bundle.SettlementTx == nil {
+		return errors.New("bundle has no settlement transaction")
+	}
+
+	metadata, err := c.GetExchangeMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to fetch exchange metadata: %w", err)
+	}
+	if !geth_common.IsHexAddress(metadata.SettlementContractAddress) {
+		return fmt.Errorf("invalid settlement contract address: %q", metadata.SettlementContractAddress)
+	}
+
+	var sponsor geth_common.Address
+	if metadata.GasSponsorAddress != "" {
+		if !geth_common.IsHexAddress(metadata.GasSponsorAddress) {
+			return fmt.Errorf("invalid gas sponsor address: %q", metadata.GasSponsorAddress)
+		}
+		sponsor = geth_common.HexToAddress(metadata.GasSponsorAddress)
+	}
+
+	darkpool := geth_common.HexToAddress(metadata.SettlementContractAddress)
+	to := bundle.SettlementTx.To
+	chainMismatch := c.chainID != 0 && c.chainID != metadata.ChainId
+	sponsored := sponsor != (geth_common.Address{}) && to == sponsor
+	if chainMismatch || (to != darkpool && !sponsored) {
+		return &SettlementMismatchError{
+			ExpectedChainID: c.chainID,
+			ServerChainID:   metadata.ChainId,
+			Darkpool:        darkpool,
+			GasSponsor:      sponsor,
+			To:              to,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+func TestValidateSettlementTx(t *testing.T) {
+	const darkpool = "0x9af58f1ff20ab22e819e40b57ffd784d115a9ef5"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"chain_id":421614,"settlement_contract_address":"` + darkpool + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	newClient := func(chainID uint64) *ExternalMatchClient {
+		options := NewExternalMatchClientOptions().WithChainID(chainID)
+		return NewExternalMatchClientWithOptions(server.URL, server.URL, "test-key", &wallet.HmacKey{}, options)
+	}
+	bundle := func(to string) *ExternalMatchBundle {
+		return &ExternalMatchBundle{SettlementTx: &SettlementTransaction{To: geth_common.HexToAddress(to)}}
+	}
+
+	// Matching chain and darkpool
+	assert.NoError(t, newClient(421614).ValidateSettlementTx(bundle(darkpool)))
+	assert.NoError(t, newClient(0).ValidateSettlementTx(bundle(darkpool)))
+
+	// A bundle targeting another contract
+	var mismatch *SettlementMismatchError
+	err := newClient(421614).ValidateSettlementTx(bundle("0x1"))
+	assert.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, geth_common.HexToAddress(darkpool), mismatch.Darkpool)
+
+	// A testnet server used by a client configured for mainnet
+	err = newClient(42161).ValidateSettlementTx(bundle(darkpool))
+	assert.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, uint64(421614), mismatch.ServerChainID)
+	assert.Contains(t, err.Error(), "chain mismatch")
+
+	// Sandbox bundles are never valid
+	err = newClient(0).ValidateSettlementTx(&ExternalMatchBundle{Sandbox: true})
+	assert.True(t, errors.Is(err, ErrSandboxSettlement))
+}
+
+func TestValidateSponsoredSettlementTx(t *testing.T) {
+	const darkpool = "0x9af58f1ff20ab22e819e40b57ffd784d115a9ef5"
+	const sponsor = "0x3a7a7fc5b5b2a9c0b6b0bd5e1f5dd5cd0a2e3f4b"
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"chain_id":421614,"settlement_contract_address":"` + darkpool +
+			`","gas_sponsor_address":"` + sponsor + `"}`))
+	})
+	bundle := func(to string) *ExternalMatchBundle {
+		return &ExternalMatchBundle{SettlementTx: &SettlementTransaction{To: geth_common.HexToAddress(to)}}
+	}
+
+	// Sponsored bundles are sent to the gas sponsor, unsponsored ones to the
+	// darkpool
+	assert.NoError(t, c.ValidateSettlementTx(bundle(sponsor)))
+	assert.NoError(t, c.ValidateSettlementTx(bundle(darkpool)))
+
+	var mismatch *SettlementMismatchError
+	err := c.ValidateSettlementTx(bundle("0x1"))
+	assert.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, geth_common.HexToAddress(sponsor), mismatch.GasSponsor)
+	assert.Contains(t, err.Error(), "gas sponsor")
+}
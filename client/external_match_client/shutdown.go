@@ -0,0 +1,90 @@
+package external_match_client //nolint:revive
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/renegade-fi/golang-sdk/client"
+)
+
+// Close stops the client's background routines, i.e. keep-alive pings and the
+// running quoters created by the client, closes its HTTP clients, then flushes
+// the sinks registered with RegisterFlusher. Requests in flight are not waited
+// for, see Shutdown
+func (c *ExternalMatchClient) Close() {
+	c.stopQuoters()
+	for _, httpClient := range c.httpClients() {
+		httpClient.Close()
+	}
+	if err := c.flushers.Flush(); err != nil {
+		log.Printf("failed to flush sinks on close: %v", err)
+	}
+}
+
+// Shutdown stops the client's background routines, then waits for requests in
+// flight on each of its HTTP clients to complete and flushes the sinks
+// registered with RegisterFlusher. Requests made after shutdown begins fail
+// with client.ErrClientClosed. If the context ends first, the context's error
+// is returned
+func (c *ExternalMatchClient) Shutdown(ctx context.Context) error {
+	c.stopQuoters()
+
+	// Shut every HTTP client down even if one fails to drain, so that none
+	// accepts new requests
+	var firstErr error
+	for _, httpClient := range c.httpClients() {
+		if err := httpClient.Shutdown(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if err := c.flushers.Flush(); err != nil && firstErr == nil {
+		firstErr = fmt.Errorf("failed to flush sinks: %w", err)
+	}
+	return firstErr
+}
+
+// RegisterFlusher registers a sink, e.g. a buffered audit log fed from the
+// client's lifecycle events, to be flushed when the client closes
+func (c *ExternalMatchClient) RegisterFlusher(flusher client.Flusher) {
+	c.flushers.Add(flusher)
+}
+
+// httpClients returns the HTTP clients of every credential and the relayer
+func (c *ExternalMatchClient) httpClients() []*client.HttpClient {
+	clients := make([]*client.HttpClient, 0, len(c.credentials)+1)
+	for _, cred := range c.credentials {
+		clients = append(clients, cred.httpClient)
+	}
+	return append(clients, c.relayerHttpClient)
+}
+
+// stopQuoters stops every running quoter created by the client
+func (c *ExternalMatchClient) stopQuoters() {
+	c.quotersMu.Lock()
+	quoters := make([]*Quoter, 0, len(c.quoters))
+	for q := range c.quoters {
+		quoters = append(quoters, q)
+	}
+	c.quotersMu.Unlock()
+	for _, q := range quoters {
+		q.Stop()
+	}
+}
+
+// trackQuoter registers a running quoter, to be stopped on shutdown
+func (c *ExternalMatchClient) trackQuoter(q *Quoter) {
+	c.quotersMu.Lock()
+	defer c.quotersMu.Unlock()
+	if c.quoters == nil {
+		c.quoters = make(map[*Quoter]struct{})
+	}
+	c.quoters[q] = struct{}{}
+}
+
+// untrackQuoter deregisters a stopped quoter
+func (c *ExternalMatchClient) untrackQuoter(q *Quoter) {
+	c.quotersMu.Lock()
+	defer c.quotersMu.Unlock()
+	delete(c.quoters, q)
+}
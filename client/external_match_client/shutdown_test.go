@@ -0,0 +1,47 @@
+package external_match_client //nolint:revive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client"
+)
+
+func TestShutdownStopsQuotersAndRejectsRequests(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	quoter, err := c.NewQuoter(testOrder(t), NewQuoterOptions())
+	assert.NoError(t, err)
+	quoter.Start()
+	assert.NoError(t, c.Shutdown(context.Background()))
+
+	quoter.mu.Lock()
+	assert.Nil(t, quoter.stop)
+	quoter.mu.Unlock()
+
+	_, err = c.GetExternalMatchQuote(testOrder(t))
+	assert.True(t, errors.Is(err, client.ErrClientClosed))
+	_, err = c.GetSupportedTokens()
+	assert.True(t, errors.Is(err, client.ErrClientClosed))
+}
+
+func TestStoppedQuotersAreDeregistered(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Only running quoters are held by the client
+	quoter, err := c.NewQuoter(testOrder(t), NewQuoterOptions())
+	assert.NoError(t, err)
+	assert.Empty(t, c.quoters)
+	quoter.Start()
+	assert.Len(t, c.quoters, 1)
+	quoter.Stop()
+	assert.Empty(t, c.quoters)
+}
@@ -0,0 +1,199 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// ErrNoSponsorshipPreview is returned when no quote has been received for the
+// pair to derive a gas sponsorship preview from
+var ErrNoSponsorshipPreview = errors.New("no gas sponsorship preview available")
+
+// GasSponsorshipPreview is the gas sponsorship of the most recent quote for a
+// pair, which an order on the pair may expect if quoted
+type GasSponsorshipPreview struct {
+	// Sponsored is set if the order is expected to be sponsored
+	Sponsored bool
+	// RefundAmount is the expected refund, in wei if RefundNativeEth is set
+	// and otherwise in the smallest denomination of the token received; zero
+	// if not sponsored
+	RefundAmount *big.Int
+	// RefundNativeEth is set if the refund is paid in native ETH
+	RefundNativeEth bool
+	// ObservedAt is the time the sponsorship was quoted
+	ObservedAt time.Time
+}
+
+// sponsorshipMaxAge is the age past which a sponsorship observed in a quote
+// no longer says whether a pair qualifies
+const sponsorshipMaxAge = 5 * time.Minute
+
+// SponsorshipStatus is whether an order qualifies for gas sponsorship
+type SponsorshipStatus string
+
+const (
+	// SponsorshipEligible indicates the order is expected to be sponsored
+	SponsorshipEligible SponsorshipStatus = "eligible"
+	// SponsorshipIneligible indicates the order is not expected to be
+	// sponsored
+	SponsorshipIneligible SponsorshipStatus = "ineligible"
+	// SponsorshipUnknown indicates the client cannot tell without a quote
+	SponsorshipUnknown SponsorshipStatus = "unknown"
+)
+
+// SponsorshipEligibility is whether an order currently qualifies for gas
+// sponsorship, and the refund it is expected to receive
+type SponsorshipEligibility struct {
+	// Status is whether the order qualifies
+	Status SponsorshipStatus
+	// Reason explains an ineligible or unknown status
+	Reason string
+	// Preview is the sponsorship the status is based on, nil if none was
+	// available. A stale preview is kept for reference
+	Preview *GasSponsorshipPreview
+}
+
+// Eligible returns whether the order is expected to be sponsored
+func (e *SponsorshipEligibility) Eligible() bool {
+	return e.Status == SponsorshipEligible
+}
+
+// ExpectedRefund returns the refund the order is expected to receive, zero
+// unless eligible
+func (e *SponsorshipEligibility) ExpectedRefund() *big.Int {
+	if !e.Eligible() {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(e.Preview.RefundAmount)
+}
+
+// CheckSponsorshipEligibility returns whether the order's pair and size
+// currently qualify for gas sponsorship and the expected refund, so that
+// routing logic can decide before spending a quote request.
+//
+// Orders on pairs the relayer does not support are ineligible. Otherwise the
+// status follows PreviewGasSponsorship, inferred from the most recent quote for
+// the pair. The status is unknown if the pair has not been quoted, or was last
+// quoted more than five minutes ago
+func (c *ExternalMatchClient) CheckSponsorshipEligibility(
+	order *api_types.ApiExternalOrder,
+) (*SponsorshipEligibility, error) {
+	supported, err := c.pairSupported(order)
+	if err != nil {
+		return nil, err
+	}
+	if !supported {
+		return &SponsorshipEligibility{
+			Status: SponsorshipIneligible,
+			Reason: fmt.Sprintf("pair %s/%s is not supported", order.BaseMint, order.QuoteMint),
+		}, nil
+	}
+
+	preview, err := c.PreviewGasSponsorship(order)
+	if errors.Is(err, ErrNoSponsorshipPreview) {
+		return &SponsorshipEligibility{Status: SponsorshipUnknown, Reason: "pair has not been quoted"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	eligibility := &SponsorshipEligibility{Status: SponsorshipIneligible, Preview: preview}
+	age := time.Since(preview.ObservedAt)
+	switch {
+	case age > sponsorshipMaxAge:
+		eligibility.Status = SponsorshipUnknown
+		eligibility.Reason = fmt.Sprintf("pair was last quoted %s ago", age.Round(time.Second))
+	case preview.Sponsored:
+		eligibility.Status = SponsorshipEligible
+	default:
+		eligibility.Reason = "the relayer is not sponsoring the pair"
+	}
+	return eligibility, nil
+}
+
+// pairSupported returns whether the relayer supports both of the order's
+// tokens
+func (c *ExternalMatchClient) pairSupported(order *api_types.ApiExternalOrder) (bool, error) {
+	tokens, err := c.GetSupportedTokens()
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch supported tokens: %w", err)
+	}
+
+	var base, quote bool
+	for _, token := range tokens {
+		mint := api_types.Mint(token.Address)
+		base = base || mint.Equal(order.BaseToken())
+		quote = quote || mint.Equal(order.QuoteToken())
+	}
+	return base && quote, nil
+}
+
+// sponsorshipObservation is the sponsorship of the last quote for a pair
+type sponsorshipObservation struct {
+	info       *api_types.ApiGasSponsorshipInfo
+	observedAt time.Time
+}
+
+// PreviewGasSponsorship returns the gas sponsorship the order may expect,
+// without spending quote rate limit budget. The preview is the sponsorship of
+// the most recent quote for the order's pair, which was for that quote's size:
+// orders of other sizes may be refunded differently. Returns
+// ErrNoSponsorshipPreview if the pair has not been quoted
+func (c *ExternalMatchClient) PreviewGasSponsorship(
+	order *api_types.ApiExternalOrder,
+) (*GasSponsorshipPreview, error) {
+	c.sponsorshipMu.Lock()
+	observation, ok := c.sponsorships[sponsorshipKey(order.BaseMint, order.QuoteMint)]
+	c.sponsorshipMu.Unlock()
+	if !ok {
+		return nil, ErrNoSponsorshipPreview
+	}
+
+	return newGasSponsorshipPreview(observation.info, observation.observedAt), nil
+}
+
+// recordSponsorship records the sponsorship of a quote for the order's pair
+func (c *ExternalMatchClient) recordSponsorship(
+	order *api_types.ApiExternalOrder, sponsorship *api_types.ApiSignedGasSponsorshipInfo,
+) {
+	var info *api_types.ApiGasSponsorshipInfo
+	if sponsorship != nil {
+		info = &sponsorship.GasSponsorshipInfo
+	}
+
+	c.sponsorshipMu.Lock()
+	defer c.sponsorshipMu.Unlock()
+	if c.sponsorships == nil {
+		c.sponsorships = make(map[string]sponsorshipObservation)
+	}
+	c.sponsorships[sponsorshipKey(order.BaseMint, order.QuoteMint)] = sponsorshipObservation{
+		info:       info,
+		observedAt: time.Now(),
+	}
+}
+
+// newGasSponsorshipPreview builds a preview from a sponsorship, nil if the
+// order is not sponsored
+func newGasSponsorshipPreview(info *api_types.ApiGasSponsorshipInfo, observedAt time.Time) *GasSponsorshipPreview {
+	preview := &GasSponsorshipPreview{
+		RefundAmount: new(big.Int),
+		ObservedAt:   observedAt,
+	}
+	if info != nil {
+		preview.Sponsored = true
+		preview.RefundAmount.Set((*big.Int)(&info.RefundAmount))
+		preview.RefundNativeEth = info.RefundNativeEth
+	}
+
+	return preview
+}
+
+// sponsorshipKey returns the key under which a pair's sponsorship is recorded
+func sponsorshipKey(baseMint, quoteMint string) string {
+	return strings.ToLower(baseMint) + "/" + strings.ToLower(quoteMint)
+}
@@ -0,0 +1,110 @@
+package external_match_client //nolint:revive
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+func TestPreviewGasSponsorshipFromQuote(t *testing.T) {
+	sponsored := true
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == api_types.ExchangeMetadataPath:
+			w.WriteHeader(http.StatusNotFound)
+		case sponsored:
+			_, _ = w.Write([]byte(`{"signed_quote":{},"gas_sponsorship_info":{` +
+				`"gas_sponsorship_info":{"refund_amount":1000,"refund_native_eth":true},"signature":"sig"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"signed_quote":{}}`))
+		}
+	})
+
+	// A pair that has not been quoted cannot be previewed
+	_, err := client.PreviewGasSponsorship(testOrder(t))
+	assert.ErrorIs(t, err, ErrNoSponsorshipPreview)
+
+	_, err = client.GetExternalMatchQuote(testOrder(t))
+	assert.NoError(t, err)
+	preview, err := client.PreviewGasSponsorship(testOrder(t))
+	assert.NoError(t, err)
+	assert.True(t, preview.Sponsored)
+	assert.True(t, preview.RefundNativeEth)
+	assert.Equal(t, int64(1000), preview.RefundAmount.Int64())
+
+	// An unsponsored quote replaces the previous observation
+	sponsored = false
+	_, err = client.GetExternalMatchQuote(testOrder(t))
+	assert.NoError(t, err)
+	preview, err = client.PreviewGasSponsorship(testOrder(t))
+	assert.NoError(t, err)
+	assert.False(t, preview.Sponsored)
+	assert.Zero(t, preview.RefundAmount.Sign())
+}
+
+func TestCheckSponsorshipEligibilityUnsupportedPair(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case api_types.GetSupportedTokensPath:
+			_, _ = w.Write([]byte(`{"tokens":[{"address":"0x1","symbol":"WETH"},{"address":"0x2","symbol":"USDC"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"signed_quote":{},"gas_sponsorship_info":{` +
+				`"gas_sponsorship_info":{"refund_amount":250,"refund_native_eth":true},"signature":"sig"}}`))
+		}
+	})
+
+	// Unsupported pairs are ineligible, even if quoted as sponsored
+	order := testOrder(t)
+	order.QuoteMint = "0x3"
+	_, err := client.GetExternalMatchQuote(order)
+	assert.NoError(t, err)
+	eligibility, err := client.CheckSponsorshipEligibility(order)
+	assert.NoError(t, err)
+	assert.Equal(t, SponsorshipIneligible, eligibility.Status)
+	assert.Zero(t, eligibility.ExpectedRefund().Sign())
+}
+
+func TestCheckSponsorshipEligibilityFromQuote(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case api_types.GetSupportedTokensPath:
+			_, _ = w.Write([]byte(`{"tokens":[{"address":"0x1","symbol":"WETH"},{"address":"0x2","symbol":"USDC"}]}`))
+		case api_types.ExchangeMetadataPath:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			_, _ = w.Write([]byte(`{"signed_quote":{},"gas_sponsorship_info":{` +
+				`"gas_sponsorship_info":{"refund_amount":1000,"refund_native_eth":false},"signature":"sig"}}`))
+		}
+	})
+
+	// Eligibility is unknown until the pair is quoted
+	eligibility, err := client.CheckSponsorshipEligibility(testOrder(t))
+	assert.NoError(t, err)
+	assert.Equal(t, SponsorshipUnknown, eligibility.Status)
+	assert.Nil(t, eligibility.Preview)
+
+	_, err = client.GetExternalMatchQuote(testOrder(t))
+	assert.NoError(t, err)
+	eligibility, err = client.CheckSponsorshipEligibility(testOrder(t))
+	assert.NoError(t, err)
+	assert.True(t, eligibility.Eligible())
+	assert.Equal(t, int64(1000), eligibility.ExpectedRefund().Int64())
+
+	// A stale observation no longer decides eligibility
+	key := sponsorshipKey(testOrder(t).BaseMint, testOrder(t).QuoteMint)
+	client.sponsorshipMu.Lock()
+	observation := client.sponsorships[key]
+	observation.observedAt = time.Now().Add(-2 * sponsorshipMaxAge)
+	client.sponsorships[key] = observation
+	client.sponsorshipMu.Unlock()
+
+	eligibility, err = client.CheckSponsorshipEligibility(testOrder(t))
+	assert.NoError(t, err)
+	assert.Equal(t, SponsorshipUnknown, eligibility.Status)
+	assert.True(t, eligibility.Preview.Sponsored)
+	assert.Zero(t, eligibility.ExpectedRefund().Sign())
+}
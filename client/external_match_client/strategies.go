@@ -0,0 +1,149 @@
+package external_match_client //nolint:revive
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// ErrUnknownStrategy is returned when request options name a strategy tag
+// that is not registered with the client
+var ErrUnknownStrategy = errors.New("unknown strategy tag")
+
+// StrategyAddresses are the default addresses of a strategy's matches, used
+// by requests whose options name the strategy's tag
+type StrategyAddresses struct {
+	// RefundAddress receives the gas sponsorship refunds of the strategy's
+	// quotes; nil refunds the address that settles the match
+	RefundAddress *string
+	// ReceiverAddress receives the output of the strategy's bundles; nil pays
+	// the address that settles the match
+	ReceiverAddress *string
+}
+
+// strategyBook maps strategy tags to their default addresses
+type strategyBook struct {
+	mu      sync.RWMutex
+	entries map[string]StrategyAddresses
+}
+
+// newStrategyBook creates a strategy book holding the given strategies
+func newStrategyBook(strategies map[string]StrategyAddresses) *strategyBook {
+	entries := make(map[string]StrategyAddresses, len(strategies))
+	for tag, addresses := range strategies {
+		entries[tag] = addresses
+	}
+	return &strategyBook{entries: entries}
+}
+
+// set registers or replaces a strategy's addresses
+func (b *strategyBook) set(tag string, addresses StrategyAddresses) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries[tag] = addresses
+}
+
+// remove unregisters a strategy
+func (b *strategyBook) remove(tag string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.entries, tag)
+}
+
+// get returns a strategy's addresses, checksummed, or ErrUnknownStrategy if
+// the tag is not registered
+func (b *strategyBook) get(tag string) (StrategyAddresses, error) {
+	b.mu.RLock()
+	addresses, ok := b.entries[tag]
+	b.mu.RUnlock()
+	if !ok {
+		return StrategyAddresses{}, fmt.Errorf("%w: %q", ErrUnknownStrategy, tag)
+	}
+	return addresses.normalize(tag)
+}
+
+// normalize checksums the addresses, returning an error naming the strategy
+// if either is invalid
+func (a StrategyAddresses) normalize(tag string) (StrategyAddresses, error) {
+	refund, err := normalizeOptionalAddress(a.RefundAddress)
+	if err != nil {
+		return StrategyAddresses{}, fmt.Errorf("invalid refund address for strategy %q: %w", tag, err)
+	}
+	receiver, err := normalizeOptionalAddress(a.ReceiverAddress)
+	if err != nil {
+		return StrategyAddresses{}, fmt.Errorf("invalid receiver address for strategy %q: %w", tag, err)
+	}
+	return StrategyAddresses{RefundAddress: refund, ReceiverAddress: receiver}, nil
+}
+
+// SetStrategy registers the default addresses of a strategy, replacing any
+// previously registered for its tag. Requests whose options name the tag
+// with WithStrategyTag use them wherever the options set no address
+func (c *ExternalMatchClient) SetStrategy(tag string, addresses StrategyAddresses) error {
+	if _, err := addresses.normalize(tag); err != nil {
+		return err
+	}
+	c.strategies.set(tag, addresses)
+	return nil
+}
+
+// RemoveStrategy unregisters a strategy; requests naming its tag then fail
+// with ErrUnknownStrategy
+func (c *ExternalMatchClient) RemoveStrategy(tag string) {
+	c.strategies.remove(tag)
+}
+
+// Strategy returns the addresses registered for a strategy tag, checksummed,
+// or ErrUnknownStrategy if none are
+func (c *ExternalMatchClient) Strategy(tag string) (StrategyAddresses, error) {
+	return c.strategies.get(tag)
+}
+
+// applyQuoteStrategy returns the quote options with the refund address of
+// their strategy filled in, if they name one and set no refund address
+func (c *ExternalMatchClient) applyQuoteStrategy(options *ExternalQuoteOptions) (*ExternalQuoteOptions, error) {
+	if options.StrategyTag == "" || options.RefundAddress != nil {
+		return options, nil
+	}
+
+	addresses, err := c.strategies.get(options.StrategyTag)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *options
+	resolved.RefundAddress = addresses.RefundAddress
+	return &resolved, nil
+}
+
+// applyAssembleStrategy returns the assembly options with the receiver
+// address of their strategy filled in, if they name one and set no receiver
+func (c *ExternalMatchClient) applyAssembleStrategy(
+	options *AssembleExternalMatchOptions,
+) (*AssembleExternalMatchOptions, error) {
+	if options.StrategyTag == "" || options.ReceiverAddress != nil {
+		return options, nil
+	}
+
+	addresses, err := c.strategies.get(options.StrategyTag)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *options
+	resolved.ReceiverAddress = addresses.ReceiverAddress
+	return &resolved, nil
+}
+
+// normalizeOptionalAddress checksums an address, nil if unset
+func normalizeOptionalAddress(address *string) (*string, error) {
+	if address == nil {
+		return nil, nil
+	}
+
+	normalized, err := api_types.NormalizeAddress(*address)
+	if err != nil {
+		return nil, err
+	}
+	return &normalized, nil
+}
@@ -0,0 +1,106 @@
+package external_match_client //nolint:revive
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+const (
+	testRefundAddress   = "0xC5fE800A3D92112473e4E811296F194DA7b26BA7"
+	testReceiverAddress = "0x6B175474E89094C44Da98b954EedeAC495271d0F"
+)
+
+// strategyRequest is the addresses a request was sent with
+type strategyRequest struct {
+	refundAddress   string
+	receiverAddress *string
+}
+
+// newStrategyTestClient creates a client with an "mm-weth" strategy whose
+// server records the addresses of each request
+func newStrategyTestClient(t *testing.T) (*ExternalMatchClient, *strategyRequest) {
+	var recorded strategyRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorded.refundAddress = r.URL.Query().Get("refund_address")
+		var body api_types.ExternalMatchRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		recorded.receiverAddress = body.ReceiverAddress
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	refund, receiver := testRefundAddress, testReceiverAddress
+	options := NewExternalMatchClientOptions().WithStrategy("mm-weth", StrategyAddresses{
+		RefundAddress:   &refund,
+		ReceiverAddress: &receiver,
+	})
+	c := NewExternalMatchClientWithOptions(server.URL, server.URL, "test-key", &wallet.HmacKey{}, options)
+	return c, &recorded
+}
+
+func TestStrategyTagSetsDefaultAddresses(t *testing.T) {
+	c, recorded := newStrategyTestClient(t)
+
+	_, err := c.GetExternalMatchQuoteWithOptions(testOrder(t), NewExternalQuoteOptions().WithStrategyTag("mm-weth"))
+	require.NoError(t, err)
+	assert.Equal(t, testRefundAddress, recorded.refundAddress)
+
+	options := NewAssembleExternalMatchOptions().WithStrategyTag("mm-weth")
+	_, err = c.GetExternalMatchBundleWithOptions(testOrder(t), options)
+	require.NoError(t, err)
+	require.NotNil(t, recorded.receiverAddress)
+	assert.Equal(t, testReceiverAddress, *recorded.receiverAddress)
+
+	// The caller's options are not modified
+	assert.Nil(t, options.ReceiverAddress)
+
+	// Requests without a tag use no default addresses
+	_, err = c.GetExternalMatchQuote(testOrder(t))
+	require.NoError(t, err)
+	assert.Empty(t, recorded.refundAddress)
+}
+
+func TestExplicitAddressesOverrideStrategy(t *testing.T) {
+	c, recorded := newStrategyTestClient(t)
+	override := "0x0000000000000000000000000000000000000001"
+
+	quoteOptions := NewExternalQuoteOptions().WithStrategyTag("mm-weth").WithRefundAddress(&override)
+	_, err := c.GetExternalMatchQuoteWithOptions(testOrder(t), quoteOptions)
+	require.NoError(t, err)
+	assert.Equal(t, override, recorded.refundAddress)
+
+	bundleOptions := NewAssembleExternalMatchOptions().WithStrategyTag("mm-weth").WithReceiverAddress(&override)
+	_, err = c.GetExternalMatchBundleWithOptions(testOrder(t), bundleOptions)
+	require.NoError(t, err)
+	assert.Equal(t, override, *recorded.receiverAddress)
+}
+
+func TestUnknownStrategyTag(t *testing.T) {
+	c, _ := newStrategyTestClient(t)
+
+	_, err := c.GetExternalMatchQuoteWithOptions(testOrder(t), NewExternalQuoteOptions().WithStrategyTag("arb"))
+	assert.ErrorIs(t, err, ErrUnknownStrategy)
+
+	// Strategies may be registered and removed at runtime
+	receiver := testReceiverAddress
+	require.NoError(t, c.SetStrategy("arb", StrategyAddresses{ReceiverAddress: &receiver}))
+	addresses, err := c.Strategy("arb")
+	require.NoError(t, err)
+	assert.Nil(t, addresses.RefundAddress)
+	assert.Equal(t, testReceiverAddress, *addresses.ReceiverAddress)
+
+	c.RemoveStrategy("arb")
+	_, err = c.Strategy("arb")
+	assert.ErrorIs(t, err, ErrUnknownStrategy)
+
+	invalid := "0x1234"
+	assert.Error(t, c.SetStrategy("arb", StrategyAddresses{RefundAddress: &invalid}))
+}
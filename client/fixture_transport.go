@@ -0,0 +1,230 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// redactedValue replaces the value of secret headers in recorded fixtures
+const redactedValue = "REDACTED"
+
+// ErrNoRecordedInteraction is returned by a replaying transport when a
+// request has no unused recorded interaction with the same method, host, and
+// path
+var ErrNoRecordedInteraction = errors.New("no recorded interaction for request")
+
+// FixtureInteraction is a single recorded request and its response
+type FixtureInteraction struct {
+	// Method is the request method
+	Method string `json:"method"`
+	// Host is the request host, distinguishing the auth server and relayer
+	Host string `json:"host"`
+	// Path is the request path, including the query
+	Path string `json:"path"`
+	// RequestHeader is the request's headers, with secrets redacted
+	RequestHeader http.Header `json:"request_header,omitempty"`
+	// RequestBody is the request body
+	RequestBody string `json:"request_body,omitempty"`
+	// StatusCode is the response status code
+	StatusCode int `json:"status_code"`
+	// ResponseHeader is the response's headers, with secrets redacted
+	ResponseHeader http.Header `json:"response_header,omitempty"`
+	// ResponseBody is the response body
+	ResponseBody string `json:"response_body,omitempty"`
+}
+
+// fixtureFile is the on-disk format of a fixture
+type fixtureFile struct {
+	Interactions []FixtureInteraction `json:"interactions"`
+}
+
+// FixtureTransport is an http.RoundTripper that records live HTTP
+// interactions to a fixture file, or replays a previously recorded fixture
+// without touching the network.
+//
+// Install it with HttpClientOptions.WithTransport. Auth signatures, API keys,
+// and cookies are redacted before recording. During replay, each request is
+// answered by the first unused interaction with the same method, host, and
+// path, so a scenario replays in the order it was recorded
+type FixtureTransport struct {
+	path string
+	// next is the transport live requests are forwarded to; nil when replaying
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []FixtureInteraction
+	used         []bool
+}
+
+// NewRecordingTransport creates a transport that forwards requests to the
+// given transport, or http.DefaultTransport if nil, and records each
+// interaction. Call Save to write the fixture to the given path
+func NewRecordingTransport(path string, next http.RoundTripper) *FixtureTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &FixtureTransport{path: path, next: next}
+}
+
+// NewReplayingTransport creates a transport that answers requests from the
+// fixture recorded at the given path
+func NewReplayingTransport(path string) (*FixtureTransport, error) {
+	raw, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var fixture fixtureFile
+	if err := json.Unmarshal(raw, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture: %w", err)
+	}
+
+	return &FixtureTransport{
+		path:         path,
+		interactions: fixture.Interactions,
+		used:         make([]bool, len(fixture.Interactions)),
+	}, nil
+}
+
+// Interactions returns the interactions recorded or loaded so far
+func (t *FixtureTransport) Interactions() []FixtureInteraction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]FixtureInteraction(nil), t.interactions...)
+}
+
+// Save writes the recorded interactions to the transport's fixture path
+func (t *FixtureTransport) Save() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	raw, err := json.MarshalIndent(fixtureFile{Interactions: t.interactions}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+
+	if err := os.WriteFile(t.path, raw, 0o600); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.next == nil {
+		return t.replay(req)
+	}
+	return t.record(req)
+}
+
+// record forwards the request and records the interaction
+func (t *FixtureTransport) record(req *http.Request) (*http.Response, error) {
+	reqBody, err := readAndRestoreBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Request an uncompressed response, so that the recorded body is readable
+	req = req.Clone(req.Context())
+	req.Header.Set(acceptEncodingHeader, encodingIdentity)
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readAndRestoreBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.interactions = append(t.interactions, FixtureInteraction{
+		Method:         req.Method,
+		Host:           req.URL.Host,
+		Path:           req.URL.RequestURI(),
+		RequestHeader:  redactHeader(req.Header),
+		RequestBody:    string(reqBody),
+		StatusCode:     resp.StatusCode,
+		ResponseHeader: redactHeader(resp.Header),
+		ResponseBody:   string(respBody),
+	})
+	return resp, nil
+}
+
+// replay answers the request from the first unused matching interaction
+func (t *FixtureTransport) replay(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	path := req.URL.RequestURI()
+	for i, interaction := range t.interactions {
+		if t.used[i] || interaction.Method != req.Method ||
+			interaction.Host != req.URL.Host || interaction.Path != path {
+			continue
+		}
+
+		t.used[i] = true
+		status := interaction.StatusCode
+		header := interaction.ResponseHeader.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode:    status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.ResponseBody)),
+			ContentLength: int64(len(interaction.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf(
+		"%w: %s %s%s", ErrNoRecordedInteraction, req.Method, req.URL.Host, path,
+	)
+}
+
+// readAndRestoreBody reads a request or response body and replaces it with
+// an equivalent unread body
+func readAndRestoreBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	raw, err := io.ReadAll(*body)
+	//nolint:errcheck
+	(*body).Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	*body = io.NopCloser(bytes.NewReader(raw))
+	return raw, nil
+}
+
+// redactHeader copies a header, replacing the values of auth, API key, and
+// cookie headers
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for key := range redacted {
+		lowerKey := strings.ToLower(key)
+		if strings.HasPrefix(lowerKey, renegadeHeaderNamespace) ||
+			lowerKey == "authorization" || lowerKey == "cookie" || lowerKey == "set-cookie" {
+			redacted[key] = []string{redactedValue}
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+func TestFixtureTransportRecordReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+		switch r.URL.Path {
+		case "/quote":
+			_, _ = w.Write([]byte(`{"step":"quote"}`))
+		case "/assemble":
+			_, _ = w.Write([]byte(`{"step":"assemble"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	key := wallet.HmacKey{1, 2, 3}
+	recorder := NewRecordingTransport(path, nil /* next */)
+	live := NewHttpClientWithOptions(server.URL, &key, NewHttpClientOptions().WithTransport(recorder))
+
+	var quote, assemble map[string]string
+	assert.NoError(t, live.PostWithAuth("/quote", map[string]string{"side": "Buy"}, &quote))
+	assert.NoError(t, live.PostWithAuth("/assemble", nil /* body */, &assemble))
+	assert.NoError(t, recorder.Save())
+	server.Close()
+
+	// Secrets are redacted from the fixture
+	raw, err := os.ReadFile(path) //nolint:gosec
+	assert.NoError(t, err)
+	assert.NotContains(t, string(raw), "session=secret")
+	interactions := recorder.Interactions()
+	assert.Len(t, interactions, 2)
+	assert.Equal(t, redactedValue, interactions[0].RequestHeader.Get(signatureHeader))
+	assert.Equal(t, `{"side":"Buy"}`, strings.TrimSpace(interactions[0].RequestBody))
+
+	// The scenario replays without the server
+	replayer, err := NewReplayingTransport(path)
+	assert.NoError(t, err)
+	options := NewHttpClientOptions().WithTransport(replayer)
+	offline := NewHttpClientWithOptions(server.URL, &key, options)
+
+	var replayedQuote, replayedAssemble map[string]string
+	assert.NoError(t, offline.PostWithAuth("/quote", nil /* body */, &replayedQuote))
+	assert.NoError(t, offline.PostWithAuth("/assemble", nil /* body */, &replayedAssemble))
+	assert.Equal(t, quote, replayedQuote)
+	assert.Equal(t, assemble, replayedAssemble)
+
+	// Each interaction is replayed once
+	err = offline.PostWithAuth("/quote", nil /* body */, &replayedQuote)
+	assert.True(t, errors.Is(err, ErrNoRecordedInteraction))
+}
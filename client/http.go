@@ -3,17 +3,19 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
-	"encoding/binary"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/renegade-fi/golang-sdk/wallet"
@@ -28,20 +30,75 @@ const (
 	signatureExpiration     = 5 * time.Second
 )
 
+// ErrResponseTooLarge is returned when a response body exceeds the client's
+// configured maximum response size
+var ErrResponseTooLarge = errors.New("response body exceeds maximum size")
+
+// ErrNoAuthKey is returned when an authenticated request is made by a client
+// constructed without an auth key
+var ErrNoAuthKey = errors.New("client has no auth key")
+
 // HttpClient represents an HTTP client with a base URL and auth key
+//
+// An HttpClient is safe for concurrent use by multiple goroutines
 type HttpClient struct { //nolint:revive
 	baseURL    string
 	httpClient *http.Client
 	authKey    *wallet.HmacKey
+	options    *HttpClientOptions
+	// breaker is the per-endpoint-class circuit breaker, nil if disabled
+	breaker *circuitBreaker
+	// clockOffset is the measured offset of the server's clock, in nanoseconds
+	clockOffset atomic.Int64
+	// codecFallback is set once the server rejects the configured codec, after
+	// which requests are encoded as JSON
+	codecFallback atomic.Bool
+
+	// keepAliveMu guards the keep-alive routine's stop channel
+	keepAliveMu   sync.Mutex
+	stopKeepAlive chan struct{}
+
+	// lifecycleMu guards the fields below, see Shutdown
+	lifecycleMu sync.Mutex
+	// closed is set once the client is closed or shutting down
+	closed bool
+	// inFlight is the number of requests in flight
+	inFlight int
+	// idle is closed once no requests are in flight, nil if no shutdown is
+	// waiting on it
+	idle chan struct{}
 }
 
 // NewHttpClient creates a new HttpClient with the given base URL and auth key
 func NewHttpClient(baseURL string, authKey *wallet.HmacKey) *HttpClient { //nolint:revive
-	return &HttpClient{
+	return NewHttpClientWithOptions(baseURL, authKey, NewHttpClientOptions())
+}
+
+// NewHttpClientWithOptions creates a new HttpClient with the given base URL,
+// auth key, and options. Nil options are treated as the defaults
+func NewHttpClientWithOptions( //nolint:revive
+	baseURL string,
+	authKey *wallet.HmacKey,
+	options *HttpClientOptions,
+) *HttpClient {
+	if options == nil {
+		options = NewHttpClientOptions()
+	}
+
+	c := &HttpClient{
 		baseURL:    baseURL,
-		httpClient: &http.Client{},
+		httpClient: &http.Client{Transport: newTransport(options)},
 		authKey:    authKey,
+		options:    options,
 	}
+
+	if options.CircuitBreaker != nil {
+		c.breaker = newCircuitBreaker(*options.CircuitBreaker)
+	}
+	if options.KeepAliveInterval > 0 {
+		c.StartKeepAlive(options.KeepAliveInterval)
+	}
+	return c
 }
 
 // Get performs a GET request to the specified path
@@ -54,22 +111,14 @@ func (c *HttpClient) Post(path string, body interface{}) ([]byte, error) {
 	return c.doRequest(http.MethodPost, path, nil /* headers */, body, false /* withAuth */)
 }
 
-// GetJSON performs a GET request and unmarshals the response into the provided interface
+// GetJSON performs a GET request and decodes the response into the provided interface
 func (c *HttpClient) GetJSON(path string, body interface{}, response interface{}) error {
-	respBody, err := c.doRequest(http.MethodGet, path, nil /* headers */, body, false /* withAuth */)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(respBody, response)
+	return c.doJSONRequest(http.MethodGet, path, nil /* headers */, body, response, false /* withAuth */)
 }
 
-// PostJSON performs a POST request and unmarshals the response into the provided interface
+// PostJSON performs a POST request and decodes the response into the provided interface
 func (c *HttpClient) PostJSON(path string, body interface{}, response interface{}) error {
-	respBody, err := c.doRequest(http.MethodPost, path, nil /* headers */, body, false /* withAuth */)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(respBody, response)
+	return c.doJSONRequest(http.MethodPost, path, nil /* headers */, body, response, false /* withAuth */)
 }
 
 // GetWithAuth performs an authenticated GET request
@@ -84,11 +133,7 @@ func (c *HttpClient) GetWithAuthAndHeaders(
 	body interface{},
 	response interface{},
 ) error {
-	respBody, err := c.doRequest(http.MethodGet, path, headers, body, true /* withAuth */)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(respBody, response)
+	return c.doJSONRequest(http.MethodGet, path, headers, body, response, true /* withAuth */)
 }
 
 // PostWithAuth performs an authenticated POST request
@@ -107,11 +152,27 @@ func (c *HttpClient) PostWithAuthAndHeaders(
 	body interface{},
 	response interface{},
 ) error {
-	respBody, err := c.doRequest(http.MethodPost, path, headers, body, true /* withAuth */)
-	if err != nil {
-		return err
+	return c.doJSONRequest(http.MethodPost, path, headers, body, response, true /* withAuth */)
+}
+
+// PostWithAuthContext performs an authenticated POST request bound to the
+// given context and decodes the response with the client's codec, returning
+// the status code. A 204 No Content response leaves the response untouched
+func (c *HttpClient) PostWithAuthContext(
+	ctx context.Context,
+	path string,
+	headers *http.Header,
+	body interface{},
+	response interface{},
+) (int, error) {
+	decode := func(statusCode int, codec Codec, r io.Reader) error {
+		if statusCode == http.StatusNoContent {
+			return nil
+		}
+		return codec.Decode(r, response)
 	}
-	return json.Unmarshal(respBody, response)
+
+	return c.doStreamingRequest(ctx, http.MethodPost, path, headers, body, true /* withAuth */, decode)
 }
 
 // PostWithAuthRaw performs an authenticated POST request and returns the raw response
@@ -120,7 +181,18 @@ func (c *HttpClient) PostWithAuthRaw(
 	headers *http.Header,
 	body interface{},
 ) (int, []byte, error) {
-	return c.doRequestWithStatus(http.MethodPost, path, headers, body, true /* withAuth */)
+	return c.PostWithAuthRawContext(context.Background(), path, headers, body)
+}
+
+// PostWithAuthRawContext performs an authenticated POST request bound to the
+// given context and returns the raw response
+func (c *HttpClient) PostWithAuthRawContext(
+	ctx context.Context,
+	path string,
+	headers *http.Header,
+	body interface{},
+) (int, []byte, error) {
+	return c.doRequestWithStatus(ctx, http.MethodPost, path, headers, body, true /* withAuth */)
 }
 
 // doRequest performs an HTTP request with optional authentication
@@ -131,108 +203,256 @@ func (c *HttpClient) doRequest(
 	body interface{},
 	withAuth bool,
 ) ([]byte, error) {
-	_, respBody, err := c.doRequestWithStatus(method, path, headers, body, withAuth)
+	_, respBody, err := c.doRequestWithStatus(
+		context.Background(), method, path, headers, body, withAuth,
+	)
 	return respBody, err
 }
 
+// doJSONRequest performs an HTTP request with optional authentication and
+// decodes the response with the request's codec, see DecodeJSON
+func (c *HttpClient) doJSONRequest(
+	method,
+	path string,
+	headers *http.Header,
+	body interface{},
+	response interface{},
+	withAuth bool,
+) error {
+	decode := func(_ int, codec Codec, r io.Reader) error {
+		return codec.Decode(r, response)
+	}
+
+	_, err := c.doStreamingRequest(
+		context.Background(), method, path, headers, body, withAuth, decode,
+	)
+	return err
+}
+
 // doRequestWithStatus performs an HTTP request with optional authentication and
 // returns the raw response with the status code
 func (c *HttpClient) doRequestWithStatus(
+	ctx context.Context,
 	method,
 	path string,
 	headers *http.Header,
 	body interface{},
 	withAuth bool,
 ) (int, []byte, error) {
+	var respBody []byte
+	readBody := func(_ int, _ Codec, r io.Reader) error {
+		var readErr error
+		respBody, readErr = io.ReadAll(r)
+		if readErr != nil {
+			return fmt.Errorf("failed to read response body: %w", readErr)
+		}
+		return nil
+	}
+
+	statusCode, err := c.doStreamingRequest(ctx, method, path, headers, body, withAuth, readBody)
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusCode, statusErr.Body, err
+	}
+	return statusCode, respBody, err
+}
+
+// bodyHandler consumes the body of a 2xx response, given its status code and
+// the codec matching its content type
+type bodyHandler func(statusCode int, codec Codec, r io.Reader) error
+
+// doStreamingRequest performs an HTTP request with optional authentication and
+// hands a size-limited reader over the response body to the given handler
+//
+// Non-2xx responses are not passed to the handler; their (size-limited) body is
+// returned in a StatusError instead. All errors are tagged with the request's ID
+func (c *HttpClient) doStreamingRequest(
+	ctx context.Context,
+	method,
+	path string,
+	headers *http.Header,
+	body interface{},
+	withAuth bool,
+	handleBody bodyHandler,
+) (int, error) {
+	requestID := requestIDForContext(ctx)
+	if err := c.beginRequest(); err != nil {
+		return 0, &RequestError{RequestID: requestID, Err: err}
+	}
+	defer c.endRequest()
+
+	// Fail fast while the endpoint's circuit is open
+	class := EndpointClass(path)
+	if c.breaker != nil && !c.breaker.allow(class) {
+		err := fmt.Errorf("%w: %s", ErrCircuitOpen, class)
+		return 0, &RequestError{RequestID: requestID, Err: err}
+	}
+
+	codec := c.requestCodec()
+	statusCode, err := c.doStreamingRequestWithID(
+		ctx, requestID, codec, method, path, headers, body, withAuth, handleBody,
+	)
+	if shouldFallBackToJSON(codec, err) {
+		c.codecFallback.Store(true)
+		statusCode, err = c.doStreamingRequestWithID(
+			ctx, requestID, JSONCodec{}, method, path, headers, body, withAuth, handleBody,
+		)
+	}
+	if c.breaker != nil {
+		c.breaker.record(class, err)
+	}
+	if err != nil {
+		return statusCode, &RequestError{RequestID: requestID, Err: err}
+	}
+
+	return statusCode, nil
+}
+
+// doStreamingRequestWithID performs a streaming request encoded with the given
+// codec, sending the given request ID in the correlation header
+func (c *HttpClient) doStreamingRequestWithID(
+	ctx context.Context,
+	requestID string,
+	codec Codec,
+	method,
+	path string,
+	headers *http.Header,
+	body interface{},
+	withAuth bool,
+	handleBody bodyHandler,
+) (int, error) {
 	url := fmt.Sprintf("%s%s", c.baseURL, path)
 
 	// Marshal the body
 	var bodyBytes []byte
 	var err error
 	if body != nil {
-		bodyBytes, err = json.Marshal(body)
+		bodyBytes, err = codec.Marshal(body)
 		if err != nil {
-			return 0, nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return 0, fmt.Errorf("failed to marshal request body: %w", err)
 		}
 	}
 
+	// Compress the body; the signature below covers the bytes sent
+	sentBytes, compressed, err := c.compressRequestBody(bodyBytes)
+	if err != nil {
+		return 0, err
+	}
+
 	// Create the request
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(bodyBytes))
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(sentBytes))
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+		return 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
 	if headers != nil {
 		req.Header = *headers
 	}
-	req.Header.Set(contentTypeHeader, contentTypeJSON)
+	req.Header.Set(contentTypeHeader, codec.ContentType())
+	req.Header.Set(acceptHeader, acceptValue(codec))
+	req.Header.Set(acceptEncodingHeader, c.acceptEncoding())
+	if compressed {
+		req.Header.Set(contentEncodingHeader, EncodingGzip)
+	}
+	req.Header.Set(requestIDHeader, requestID)
 	if withAuth {
-		c.addAuth(req, bodyBytes)
+		if skewErr := c.checkClockSkew(); skewErr != nil {
+			return 0, skewErr
+		}
+		if err := c.addAuth(req, sentBytes); err != nil {
+			return 0, err
+		}
 	}
 
 	// Send the request
+	sent := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to send request: %w", err)
+		return 0, fmt.Errorf("failed to send request: %w", err)
 	}
 	//nolint:errcheck
 	defer resp.Body.Close()
+	c.observeServerTime(resp.Header, sent, time.Since(sent))
 
-	// Read and check the response
-	respBody, err := io.ReadAll(resp.Body)
+	// Reject responses that declare a length above the limit before reading them
+	statusCode := resp.StatusCode
+	maxSize := c.options.MaxResponseSize
+	if maxSize > 0 && resp.ContentLength > maxSize {
+		return statusCode, fmt.Errorf(
+			"%w: content length %d exceeds limit of %d bytes",
+			ErrResponseTooLarge, resp.ContentLength, maxSize,
+		)
+	}
+
+	// Decompress the body, limiting its decompressed size
+	decompressed, err := c.decompressResponse(resp)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
+		return statusCode, err
 	}
+	//nolint:errcheck
+	defer decompressed.Close()
+	bodyReader := newMaxBytesReader(decompressed, maxSize)
 
 	// Check the status code
-	statusCode := resp.StatusCode
 	if statusCode < 200 || statusCode >= 300 {
-		return statusCode, respBody, fmt.Errorf(
-			"unexpected status code: %d, body: %s",
-			statusCode, string(respBody),
-		)
+		respBody, readErr := io.ReadAll(bodyReader)
+		if readErr != nil {
+			return statusCode, fmt.Errorf("failed to read response body: %w", readErr)
+		}
+		statusErr := &StatusError{StatusCode: statusCode, Body: respBody}
+		if withAuth {
+			return statusCode, c.annotateAuthFailure(statusErr)
+		}
+		return statusCode, statusErr
 	}
 
-	return statusCode, respBody, nil
+	return statusCode, handleBody(statusCode, responseCodec(codec, resp.Header), bodyReader)
+}
+
+// hmacPayloadPool reuses the buffers request signature preimages are built in
+var hmacPayloadPool = sync.Pool{
+	New: func() interface{} { return new([]byte) },
 }
 
 // addAuth adds authentication headers to the request
-func (c *HttpClient) addAuth(req *http.Request, bodyBytes []byte) {
+func (c *HttpClient) addAuth(req *http.Request, bodyBytes []byte) error {
+	if c.authKey == nil {
+		return ErrNoAuthKey
+	}
+
 	// Compute the expiration time
-	expiration := time.Now().Add(signatureExpiration * time.Second).UnixMilli()
-	expirationBytes := make([]byte, 8)
-	binary.LittleEndian.PutUint64(expirationBytes, uint64(expiration)) //nolint:gosec
+	expiration := c.now().Add(signatureExpiration * time.Second).UnixMilli()
 	req.Header.Set(expirationHeader, strconv.FormatInt(expiration, 10))
 
 	// Create the hmac
 	h := hmac.New(sha256.New, c.authKey[:])
-	hmacPayload := c.getHmacPayload(req.URL.Path, req.Header, bodyBytes)
+	buf := hmacPayloadPool.Get().(*[]byte) //nolint:forcetypeassert
+	hmacPayload := appendHmacPayload((*buf)[:0], req.URL.Path, req.Header, bodyBytes)
 	h.Write(hmacPayload)
 
-	signature := base64.RawStdEncoding.EncodeToString(h.Sum(nil))
+	var mac [sha256.Size]byte
+	signature := base64.RawStdEncoding.EncodeToString(h.Sum(mac[:0]))
 	req.Header.Set(signatureHeader, signature)
+	c.emitSigningDebug(req, bodyBytes, hmacPayload, signature)
+
+	// The signing debug hook may retain the preimage, so its buffer is only
+	// reused without one
+	if c.options.SigningDebug == nil {
+		*buf = hmacPayload
+		hmacPayloadPool.Put(buf)
+	}
+	return nil
 }
 
-// getHmacPayload creates the payload for the hmac
-func (c *HttpClient) getHmacPayload(path string, headers http.Header, bodyBytes []byte) []byte {
+// appendHmacPayload appends the payload for the hmac to the given buffer
+func appendHmacPayload(payload []byte, path string, headers http.Header, bodyBytes []byte) []byte {
 	// Add the path
-	payload := []byte(path)
-
-	// Add the headers; filtered only for renegade headers
-	var validKeys []string
-	for key := range headers {
-		lowerKey := strings.ToLower(key)
-		if !strings.HasPrefix(lowerKey, renegadeHeaderNamespace) || lowerKey == signatureHeader {
-			continue
-		}
-
-		validKeys = append(validKeys, key)
-	}
+	payload = append(payload, path...)
 
 	// Add headers in sorted order
-	sort.Strings(validKeys)
-	for _, key := range validKeys {
+	for _, key := range signedHeaderKeys(headers) {
 		lowerKey := strings.ToLower(key)
 		for _, value := range headers[key] {
 			payload = append(payload, lowerKey...)
@@ -244,3 +464,20 @@ func (c *HttpClient) getHmacPayload(path string, headers http.Header, bodyBytes
 	payload = append(payload, bodyBytes...)
 	return payload
 }
+
+// signedHeaderKeys returns the keys of the headers covered by the signature,
+// i.e. the renegade headers other than the signature itself, in sorted order
+func signedHeaderKeys(headers http.Header) []string {
+	var validKeys []string
+	for key := range headers {
+		lowerKey := strings.ToLower(key)
+		if !strings.HasPrefix(lowerKey, renegadeHeaderNamespace) || lowerKey == signatureHeader {
+			continue
+		}
+
+		validKeys = append(validKeys, key)
+	}
+
+	sort.Strings(validKeys)
+	return validKeys
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultMaxResponseSize is the default maximum size of a response body, in bytes
+const defaultMaxResponseSize = 10 * 1024 * 1024 // 10 MiB
+
+// HttpClientOptions represents the configurable behavior of an HttpClient
+type HttpClientOptions struct { //nolint:revive
+	// MaxResponseSize is the maximum number of bytes read from a response
+	// body; a value of zero or less disables the limit
+	MaxResponseSize int64
+	// KeepAliveInterval is the interval at which the client pings its base URL
+	// to keep a warm connection open; a value of zero disables the pinger
+	KeepAliveInterval time.Duration
+	// CircuitBreaker configures the per-endpoint-class circuit breaker; nil
+	// disables it
+	CircuitBreaker *CircuitBreakerOptions
+	// MaxClockSkew is the largest measured offset between the local and server
+	// clocks that request signatures are corrected for; a value of zero
+	// disables compensation
+	MaxClockSkew time.Duration
+	// Transport, if set, replaces the client's HTTP transport, e.g. with a
+	// FixtureTransport to record or replay interactions
+	Transport http.RoundTripper
+	// Codec, if set, encodes request bodies in place of JSON, falling back to
+	// JSON if the server rejects its content type; nil uses JSON
+	Codec Codec
+	// CompressRequests enables gzip compression of request bodies of at least
+	// CompressionThreshold bytes
+	CompressRequests bool
+	// CompressionThreshold is the smallest request body, in bytes, that is
+	// compressed when request compression is enabled
+	CompressionThreshold int
+	// Decompressors registers response content encodings beyond the built in
+	// gzip and deflate, keyed by encoding name, e.g. "zstd"
+	Decompressors map[string]Decompressor
+	// SigningDebug, if set, is called with the signing details of every
+	// authenticated request
+	SigningDebug func(info *SigningDebugInfo)
+
+	// circuitStateHook is the hook set by WithCircuitStateHook, kept so that
+	// it applies regardless of whether the circuit breaker is enabled before
+	// or after it is set
+	circuitStateHook func(endpointClass string, from, to CircuitState)
+}
+
+// NewHttpClientOptions creates a new HttpClientOptions with default values
+func NewHttpClientOptions() *HttpClientOptions { //nolint:revive
+	return &HttpClientOptions{
+		MaxResponseSize:      defaultMaxResponseSize,
+		CompressionThreshold: defaultCompressionThreshold,
+	}
+}
+
+// WithMaxResponseSize sets the maximum response body size in bytes
+func (o *HttpClientOptions) WithMaxResponseSize(size int64) *HttpClientOptions {
+	o.MaxResponseSize = size
+	return o
+}
+
+// WithKeepAlive enables the keep-alive pinger with the given interval
+func (o *HttpClientOptions) WithKeepAlive(interval time.Duration) *HttpClientOptions {
+	o.KeepAliveInterval = interval
+	return o
+}
+
+// WithCircuitBreaker enables a circuit breaker per endpoint class that opens
+// after the given number of consecutive failures and allows a probe request
+// through once the cooldown has elapsed. A hook set by WithCircuitStateHook
+// applies whether it is set before or after the circuit breaker is enabled
+func (o *HttpClientOptions) WithCircuitBreaker(
+	failureThreshold int, cooldown time.Duration,
+) *HttpClientOptions {
+	o.CircuitBreaker = &CircuitBreakerOptions{
+		FailureThreshold: failureThreshold,
+		Cooldown:         cooldown,
+		OnStateChange:    o.circuitStateHook,
+	}
+	return o
+}
+
+// WithCircuitStateHook sets the callback invoked when a circuit changes state,
+// replacing any previously set hook. It may be set before or after
+// WithCircuitBreaker, but is never called unless the circuit breaker is enabled
+func (o *HttpClientOptions) WithCircuitStateHook(
+	hook func(endpointClass string, from, to CircuitState),
+) *HttpClientOptions {
+	o.circuitStateHook = hook
+	if o.CircuitBreaker != nil {
+		o.CircuitBreaker.OnStateChange = hook
+	}
+	return o
+}
+
+// WithClockSkewCompensation corrects request signature timestamps by the
+// server's measured clock offset, up to the given maximum. Beyond it, signed
+// requests fail with ErrClockSkew rather than being rejected by the server
+func (o *HttpClientOptions) WithClockSkewCompensation(maxSkew time.Duration) *HttpClientOptions {
+	o.MaxClockSkew = maxSkew
+	return o
+}
+
+// WithTransport sets the HTTP transport used by the client
+func (o *HttpClientOptions) WithTransport(transport http.RoundTripper) *HttpClientOptions {
+	o.Transport = transport
+	return o
+}
+
+// WithCodec sets the codec request bodies are encoded with, e.g. a binary
+// format for relayers that support one. See Codec
+func (o *HttpClientOptions) WithCodec(codec Codec) *HttpClientOptions {
+	o.Codec = codec
+	return o
+}
+
+// WithRequestCompression gzips request bodies of at least the given size in
+// bytes. The request signature covers the compressed body, as sent
+func (o *HttpClientOptions) WithRequestCompression(threshold int) *HttpClientOptions {
+	o.CompressRequests = true
+	o.CompressionThreshold = threshold
+	return o
+}
+
+// WithDecompressor registers a decompressor for a response content encoding,
+// which is then advertised in the Accept-Encoding header, e.g. a zstd decoder:
+//
+//	options.WithDecompressor("zstd", func(r io.Reader) (io.ReadCloser, error) {
+//		d, err := zstd.NewReader(r)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return d.IOReadCloser(), nil
+//	})
+func (o *HttpClientOptions) WithDecompressor(encoding string, decompressor Decompressor) *HttpClientOptions {
+	if o.Decompressors == nil {
+		o.Decompressors = make(map[string]Decompressor)
+	}
+	o.Decompressors[strings.ToLower(encoding)] = decompressor
+	return o
+}
+
+// WithSigningDebug calls the given hook with the signature preimage, signed
+// headers, and MAC of every authenticated request, e.g. LogSigningDebug, to
+// diagnose requests the server rejects as unauthorized. The details include
+// request bodies, so the hook should not be left enabled in production
+func (o *HttpClientOptions) WithSigningDebug(hook func(info *SigningDebugInfo)) *HttpClientOptions {
+	o.SigningDebug = hook
+	return o
+}
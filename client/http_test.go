@@ -0,0 +1,202 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// newTestServer creates a server that responds to every request with the given body
+func newTestServer(t *testing.T, status int, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetJSONStreamingDecode(t *testing.T) {
+	server := newTestServer(t, http.StatusOK, `{"value":42}`)
+	client := NewHttpClient(server.URL, nil /* authKey */)
+
+	var resp struct {
+		Value int `json:"value"`
+	}
+	err := client.GetJSON("/", nil /* body */, &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, resp.Value)
+}
+
+func TestDecodeJSONReusesBuffers(t *testing.T) {
+	// Decode a large body, then a small one into the same pooled buffer
+	long := strings.Repeat("a", 4096)
+	var resp map[string]string
+	assert.NoError(t, DecodeJSON(strings.NewReader(`{"value":"`+long+`"}`), &resp))
+	assert.Equal(t, long, resp["value"])
+
+	resp = nil
+	assert.NoError(t, DecodeJSON(strings.NewReader(`{"value":"b"}`), &resp))
+	assert.Equal(t, map[string]string{"value": "b"}, resp)
+
+	var number map[string]interface{}
+	assert.NoError(t, DecodeJSONNumbers(strings.NewReader(`{"n":12345678901234567890}`), &number))
+	assert.Equal(t, "12345678901234567890", number["n"].(json.Number).String())
+	assert.Error(t, DecodeJSON(strings.NewReader(`{"value":`), &resp))
+
+	// Trailing data after the value is rejected by both decoders
+	assert.Error(t, DecodeJSON(strings.NewReader(`{"value":"a"} {}`), &resp))
+	assert.Error(t, DecodeJSONNumbers(strings.NewReader(`{"n":1} {}`), &number))
+	assert.NoError(t, DecodeJSONNumbers(strings.NewReader("{\"n\":1}\n"), &number))
+}
+
+func TestResponseSizeLimit(t *testing.T) {
+	body := `{"value":"` + strings.Repeat("a", 1024) + `"}`
+	server := newTestServer(t, http.StatusOK, body)
+	options := NewHttpClientOptions().WithMaxResponseSize(512)
+	client := NewHttpClientWithOptions(server.URL, nil /* authKey */, options)
+
+	// Decoding should fail on the declared content length
+	var resp map[string]string
+	err := client.GetJSON("/", nil /* body */, &resp)
+	assert.True(t, errors.Is(err, ErrResponseTooLarge))
+
+	// Raw reads should fail in the same way
+	_, err = client.Get("/", nil /* body */)
+	assert.True(t, errors.Is(err, ErrResponseTooLarge))
+
+	// Disabling the limit allows the response through
+	client = NewHttpClientWithOptions(server.URL, nil, NewHttpClientOptions().WithMaxResponseSize(0))
+	err = client.GetJSON("/", nil /* body */, &resp)
+	assert.NoError(t, err)
+}
+
+func TestNilOptionsUseDefaults(t *testing.T) {
+	server := newTestServer(t, http.StatusOK, `{"value":"a"}`)
+	client := NewHttpClientWithOptions(server.URL, nil /* authKey */, nil /* options */)
+
+	var resp map[string]string
+	assert.NoError(t, client.GetJSON("/", nil /* body */, &resp))
+	assert.Equal(t, "a", resp["value"])
+}
+
+func TestAuthenticatedRequestWithoutAuthKey(t *testing.T) {
+	server := newTestServer(t, http.StatusOK, `{}`)
+	client := NewHttpClient(server.URL, nil /* authKey */)
+
+	var resp map[string]string
+	err := client.GetWithAuth("/", nil /* body */, &resp)
+	assert.True(t, errors.Is(err, ErrNoAuthKey))
+}
+
+func TestMaxBytesReaderWithoutContentLength(t *testing.T) {
+	reader := newMaxBytesReader(strings.NewReader(strings.Repeat("a", 100)), 10)
+	buf := make([]byte, 100)
+	n, err := reader.Read(buf)
+	assert.Equal(t, 10, n)
+	assert.True(t, errors.Is(err, ErrResponseTooLarge))
+}
+
+func TestStatusError(t *testing.T) {
+	server := newTestServer(t, http.StatusBadRequest, "bad request")
+	client := NewHttpClient(server.URL, &wallet.HmacKey{})
+
+	status, body, err := client.PostWithAuthRaw("/", nil /* headers */, nil /* body */)
+	var statusErr *StatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "bad request", string(body))
+	assert.Equal(t, "unexpected status code: 400, body: bad request", statusErr.Error())
+}
+
+func TestRequestIDPropagation(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(requestIDHeader)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+	client := NewHttpClient(server.URL, &wallet.HmacKey{})
+
+	// A generated request ID is sent and attached to the error
+	_, err := client.Get("/", nil /* body */)
+	requestID, ok := RequestIDFromError(err)
+	assert.True(t, ok)
+	assert.NotEmpty(t, received)
+	assert.Equal(t, received, requestID)
+
+	// A caller-provided request ID is used as-is
+	ctx := WithRequestID(context.Background(), "my-request")
+	_, _, err = client.PostWithAuthRawContext(ctx, "/", nil /* headers */, nil /* body */)
+	requestID, _ = RequestIDFromError(err)
+	assert.Equal(t, "my-request", received)
+	assert.Equal(t, "my-request", requestID)
+}
+
+func TestKeepAlivePings(t *testing.T) {
+	pings := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			pings <- struct{}{}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	options := NewHttpClientOptions().WithKeepAlive(10 * time.Millisecond)
+	client := NewHttpClientWithOptions(server.URL, nil /* authKey */, options)
+	defer client.Close()
+
+	// Expect the initial warm-up ping and at least one periodic ping
+	for i := 0; i < 2; i++ {
+		select {
+		case <-pings:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for keep-alive ping")
+		}
+	}
+}
+
+func BenchmarkSignedRequest(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"value":42}`))
+	}))
+	b.Cleanup(server.Close)
+	client := NewHttpClient(server.URL, &wallet.HmacKey{1, 2, 3})
+	body := map[string]string{"order": strings.Repeat("a", 512)}
+
+	var resp struct {
+		Value int `json:"value"`
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := client.PostWithAuth("/v0/path", body, &resp); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAddAuth(b *testing.B) {
+	client := NewHttpClient("http://localhost", &wallet.HmacKey{1, 2, 3})
+	body := []byte(`{"order":"` + strings.Repeat("a", 512) + `"}`)
+	req, err := http.NewRequest(http.MethodPost, "http://localhost/v0/path", nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := client.addAuth(req, body); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Warm pre-establishes a connection to the client's base URL by issuing a
+// lightweight HEAD request, so that the TCP and TLS handshakes are not paid by
+// the next API call
+func (c *HttpClient) Warm() error {
+	if err := c.beginRequest(); err != nil {
+		return err
+	}
+	defer c.endRequest()
+
+	req, err := http.NewRequest(http.MethodHead, c.baseURL, nil /* body */)
+	if err != nil {
+		return fmt.Errorf("failed to create warm-up request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to warm connection: %w", err)
+	}
+
+	// Drain the body so the connection is returned to the idle pool
+	//nolint:errcheck
+	io.Copy(io.Discard, resp.Body)
+	//nolint:errcheck
+	resp.Body.Close()
+	return nil
+}
+
+// StartKeepAlive starts a background routine that warms the connection
+// immediately and then pings the base URL at the given interval, keeping an
+// idle connection open. Calling StartKeepAlive on a client that is already
+// pinging is a no-op
+func (c *HttpClient) StartKeepAlive(interval time.Duration) {
+	c.keepAliveMu.Lock()
+	defer c.keepAliveMu.Unlock()
+	if c.stopKeepAlive != nil || interval <= 0 {
+		return
+	}
+
+	stop := make(chan struct{})
+	c.stopKeepAlive = stop
+	go c.keepAliveLoop(interval, stop)
+}
+
+// StopKeepAlive stops the keep-alive routine if one is running
+func (c *HttpClient) StopKeepAlive() {
+	c.keepAliveMu.Lock()
+	defer c.keepAliveMu.Unlock()
+	if c.stopKeepAlive != nil {
+		close(c.stopKeepAlive)
+		c.stopKeepAlive = nil
+	}
+}
+
+// keepAliveLoop pings the base URL until the stop channel is closed
+func (c *HttpClient) keepAliveLoop(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	c.ping()
+	for {
+		select {
+		case <-ticker.C:
+			c.ping()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// ping warms the connection, logging any failure
+func (c *HttpClient) ping() {
+	if err := c.Warm(); err != nil {
+		log.Printf("keep-alive ping to %s failed: %v", c.baseURL, err)
+	}
+}
+
+// newTransport creates the HTTP transport for a client, sizing the idle
+// connection timeout so that keep-alive pings can hold a connection open.
+// A transport set in the options is used as is
+func newTransport(options *HttpClientOptions) http.RoundTripper {
+	if options.Transport != nil {
+		return options.Transport
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.ForceAttemptHTTP2 = true
+	if options.KeepAliveInterval > 0 && transport.IdleConnTimeout <= options.KeepAliveInterval {
+		transport.IdleConnTimeout = 2 * options.KeepAliveInterval
+	}
+
+	return transport
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"log"
+	"sync"
+)
+
+// Observers is a set of callbacks notified of events of one kind, used by the
+// clients to report lifecycle events. The zero value is ready to use and it is
+// safe for concurrent use
+type Observers[E any] struct {
+	// mu guards the fields below
+	mu sync.RWMutex
+	// nextID is the ID assigned to the next registered callback
+	nextID uint64
+	// callbacks are the registered callbacks, in order of registration
+	callbacks []observer[E]
+}
+
+// observer is a registered callback
+type observer[E any] struct {
+	id       uint64
+	callback func(E)
+}
+
+// Register adds a callback notified of every subsequent event, returning a
+// function that removes it
+func (o *Observers[E]) Register(callback func(E)) (unregister func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	id := o.nextID
+	o.nextID++
+	o.callbacks = append(o.callbacks, observer[E]{id: id, callback: callback})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { o.remove(id) })
+	}
+}
+
+// Notify calls every registered callback with the event, synchronously and in
+// order of registration. A panicking callback is logged and does not prevent
+// the others from being called
+func (o *Observers[E]) Notify(event E) {
+	o.mu.RLock()
+	callbacks := make([]observer[E], len(o.callbacks))
+	copy(callbacks, o.callbacks)
+	o.mu.RUnlock()
+
+	for _, obs := range callbacks {
+		notifyObserver(obs.callback, event)
+	}
+}
+
+// Len returns the number of registered callbacks
+func (o *Observers[E]) Len() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return len(o.callbacks)
+}
+
+// remove removes the callback with the given ID
+func (o *Observers[E]) remove(id uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for i, obs := range o.callbacks {
+		if obs.id == id {
+			o.callbacks = append(o.callbacks[:i:i], o.callbacks[i+1:]...)
+			return
+		}
+	}
+}
+
+// notifyObserver calls a callback, recovering from a panic so that a faulty
+// observer cannot interrupt the operation reporting the event
+func notifyObserver[E any](callback func(E), event E) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("lifecycle observer panicked: %v", r)
+		}
+	}()
+	callback(event)
+}
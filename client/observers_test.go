@@ -0,0 +1,36 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserversNotifyInOrder(t *testing.T) {
+	var observers Observers[int]
+	var calls []string
+	observers.Register(func(e int) { calls = append(calls, "first") })
+	unregister := observers.Register(func(e int) { calls = append(calls, "second") })
+	observers.Register(func(e int) { calls = append(calls, "third") })
+
+	observers.Notify(1)
+	assert.Equal(t, []string{"first", "second", "third"}, calls)
+
+	// Unregistering is idempotent and leaves the other callbacks in order
+	unregister()
+	unregister()
+	calls = nil
+	observers.Notify(2)
+	assert.Equal(t, []string{"first", "third"}, calls)
+	assert.Equal(t, 2, observers.Len())
+}
+
+func TestObserversRecoverFromPanic(t *testing.T) {
+	var observers Observers[string]
+	var received []string
+	observers.Register(func(e string) { panic("faulty observer") })
+	observers.Register(func(e string) { received = append(received, e) })
+
+	assert.NotPanics(t, func() { observers.Notify("event") })
+	assert.Equal(t, []string{"event"}, received)
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// ErrNoAdminClient is returned when an order is placed in a matching pool by
+// a client without an admin client, see SetAdminClient
+var ErrNoAdminClient = errors.New("placing an order in a matching pool requires an admin client")
+
+// AdminClient is a client for the relayer's admin API, authenticated with the
+// relayer's admin key rather than a wallet's key. Market makers use it to
+// manage the matching pools their orders are segregated into
+type AdminClient struct {
+	httpClient *client.HttpClient
+}
+
+// NewAdminClient creates a new AdminClient with the given base URL and admin key
+func NewAdminClient(baseURL string, adminKey *wallet.HmacKey) *AdminClient {
+	return &AdminClient{httpClient: client.NewHttpClient(baseURL, adminKey)}
+}
+
+// CreateMatchingPool creates a matching pool with the given name
+func (c *AdminClient) CreateMatchingPool(pool string) error {
+	path := api_types.BuildAdminCreateMatchingPoolPath(pool)
+	_, _, err := c.httpClient.PostWithAuthRaw(path, nil /* headers */, nil /* body */)
+	return err
+}
+
+// DestroyMatchingPool destroys the matching pool with the given name
+func (c *AdminClient) DestroyMatchingPool(pool string) error {
+	path := api_types.BuildAdminDestroyMatchingPoolPath(pool)
+	_, _, err := c.httpClient.PostWithAuthRaw(path, nil /* headers */, nil /* body */)
+	return err
+}
+
+// AssignOrderToMatchingPool moves an existing order to the given matching pool
+func (c *AdminClient) AssignOrderToMatchingPool(orderID uuid.UUID, pool string) error {
+	path := api_types.BuildAdminAssignOrderPath(orderID, pool)
+	_, _, err := c.httpClient.PostWithAuthRaw(path, nil /* headers */, nil /* body */)
+	return err
+}
+
+// GetOrderMatchingPool returns the name of the matching pool an order is
+// matched in
+func (c *AdminClient) GetOrderMatchingPool(orderID uuid.UUID) (string, error) {
+	var resp api_types.AdminOrderMatchingPoolResponse
+	path := api_types.BuildAdminOrderMatchingPoolPath(orderID)
+	if err := c.httpClient.GetWithAuth(path, nil /* body */, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.MatchingPool, nil
+}
+
+// createOrderInMatchingPool posts a wallet's signed order creation to the
+// admin API, placing the order in the request's matching pool
+func (c *AdminClient) createOrderInMatchingPool(
+	walletID uuid.UUID, req *api_types.AdminCreateOrderInMatchingPoolRequest,
+) (uuid.UUID, error) {
+	path := api_types.BuildAdminCreateOrderInMatchingPoolPath(walletID)
+	resp := api_types.CreateOrderResponse{}
+	err := c.httpClient.PostWithAuth(path, req, &resp)
+	return resp.TaskId, err
+}
+
+// SetAdminClient sets the admin client that orders with a matching pool, see
+// wallet.OrderBuilder.WithMatchingPool, are placed through. The relayer only
+// places orders in a pool through its admin API; without an admin client such
+// orders are rejected with ErrNoAdminClient. A nil client unsets it
+func (c *RenegadeClient) SetAdminClient(admin *AdminClient) {
+	c.adminClient.Store(admin)
+}
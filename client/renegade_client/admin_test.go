@@ -0,0 +1,111 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+func TestAdminMatchingPools(t *testing.T) {
+	orderID := uuid.New()
+	var posted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("x-renegade-auth"))
+		switch r.URL.Path {
+		case api_types.BuildAdminCreateMatchingPoolPath("mm-flow"),
+			api_types.BuildAdminDestroyMatchingPoolPath("mm-flow"),
+			api_types.BuildAdminAssignOrderPath(orderID, "mm-flow"):
+			posted = append(posted, r.URL.Path)
+		case api_types.BuildAdminOrderMatchingPoolPath(orderID):
+			_, _ = w.Write([]byte(`{"matching_pool":"mm-flow"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	admin := NewAdminClient(server.URL, &wallet.HmacKey{1})
+	assert.NoError(t, admin.CreateMatchingPool("mm-flow"))
+	assert.Error(t, admin.CreateMatchingPool("unknown"))
+	assert.NoError(t, admin.AssignOrderToMatchingPool(orderID, "mm-flow"))
+	assert.NoError(t, admin.DestroyMatchingPool("mm-flow"))
+	assert.Equal(t, []string{
+		"/v0/admin/matching_pools/mm-flow",
+		"/v0/admin/orders/" + orderID.String() + "/assign-pool/mm-flow",
+		"/v0/admin/matching_pools/mm-flow/destroy",
+	}, posted)
+
+	pool, err := admin.GetOrderMatchingPool(orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, "mm-flow", pool)
+}
+
+func TestPlaceOrderInMatchingPool(t *testing.T) {
+	key, emptyWallet := newTestWallet(t)
+	apiWallet := toTestApiWallet(t, emptyWallet)
+
+	// The wallet's own relayer routes never see pooled orders
+	var walletPosts atomic.Int32
+	c := newTestClient(t, key, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/back-of-queue") {
+			_ = json.NewEncoder(w).Encode(api_types.GetWalletResponse{Wallet: *apiWallet})
+			return
+		}
+		if r.Method == http.MethodGet {
+			http.NotFound(w, r)
+			return
+		}
+		walletPosts.Add(1)
+		_ = json.NewEncoder(w).Encode(api_types.CreateOrderResponse{TaskId: uuid.New()})
+	})
+
+	var req api_types.AdminCreateOrderInMatchingPoolRequest
+	var adminPath string
+	adminServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		adminPath = r.URL.Path
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		_ = json.NewEncoder(w).Encode(api_types.CreateOrderResponse{TaskId: uuid.New()})
+	}))
+	t.Cleanup(adminServer.Close)
+
+	order := wallet.NewOrderBuilder().
+		WithBaseMintHex("0x1").
+		WithQuoteMintHex("0x2").
+		WithSide(wallet.Sell).
+		WithAmountBigInt(big.NewInt(1)).
+		WithMatchingPool("mm-flow").
+		Build()
+
+	// Without an admin client the order is refused before any request
+	err := c.placeOrder(&order, false /* blocking */)
+	assert.True(t, errors.Is(err, ErrNoAdminClient))
+
+	// With one, the signed order is created in the pool through the admin API
+	c.SetAdminClient(NewAdminClient(adminServer.URL, &wallet.HmacKey{1}))
+	assert.NoError(t, c.placeOrder(&order, false /* blocking */))
+	assert.Equal(t, api_types.BuildAdminCreateOrderInMatchingPoolPath(c.walletSecrets.Id), adminPath)
+	assert.Equal(t, "mm-flow", req.MatchingPool)
+	assert.NotNil(t, req.StatementSig)
+	assert.Zero(t, walletPosts.Load())
+
+	// Orders in the global pool are created through the wallet's routes
+	order = wallet.NewOrderBuilder().
+		WithBaseMintHex("0x1").
+		WithQuoteMintHex("0x2").
+		WithSide(wallet.Sell).
+		WithAmountBigInt(big.NewInt(1)).
+		Build()
+	assert.NoError(t, c.placeOrder(&order, false /* blocking */))
+	assert.Equal(t, int32(1), walletPosts.Load())
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"errors"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// FeePaymentHooks are optional callbacks invoked by the automatic fee payer
+type FeePaymentHooks struct {
+	// Approve is consulted before fees are paid; returning false skips the
+	// payment for this interval. A nil Approve approves every payment
+	Approve func(due []wallet.Balance) bool
+	// OnRejected is called when Approve rejects a payment
+	OnRejected func(due []wallet.Balance)
+	// OnPaid is called after a fee payment is attempted, with its error if any
+	OnPaid func(due []wallet.Balance, err error)
+}
+
+// EnableAutoPayFees starts a background routine that checks the wallet's
+// accrued fees at the given interval and pays them once the total fees owed
+// on any balance reach the threshold
+//
+// The hooks may be nil. Returns an error if auto payment is already enabled
+func (c *RenegadeClient) EnableAutoPayFees(
+	threshold *big.Int, interval time.Duration, hooks *FeePaymentHooks,
+) error {
+	if threshold == nil || threshold.Sign() <= 0 {
+		return errors.New("fee threshold must be positive")
+	}
+	if interval <= 0 {
+		return errors.New("fee check interval must be positive")
+	}
+	if hooks == nil {
+		hooks = &FeePaymentHooks{}
+	}
+
+	c.autoFeesMu.Lock()
+	defer c.autoFeesMu.Unlock()
+	if c.stopAutoFees != nil {
+		return errors.New("automatic fee payment is already enabled")
+	}
+
+	stop := make(chan struct{})
+	c.stopAutoFees = stop
+	go c.autoPayFeesLoop(new(big.Int).Set(threshold), interval, hooks, stop)
+	return nil
+}
+
+// DisableAutoPayFees stops the automatic fee payment routine, if running
+func (c *RenegadeClient) DisableAutoPayFees() {
+	c.autoFeesMu.Lock()
+	defer c.autoFeesMu.Unlock()
+	if c.stopAutoFees != nil {
+		close(c.stopAutoFees)
+		c.stopAutoFees = nil
+	}
+}
+
+// autoPayFeesLoop checks fees at each interval until stopped
+func (c *RenegadeClient) autoPayFeesLoop(
+	threshold *big.Int, interval time.Duration, hooks *FeePaymentHooks, stop <-chan struct{},
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.checkAndPayFees(threshold, hooks); err != nil {
+				log.Printf("automatic fee payment check failed: %v", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// checkAndPayFees pays the wallet's fees if any balance has crossed the threshold
+func (c *RenegadeClient) checkAndPayFees(threshold *big.Int, hooks *FeePaymentHooks) error {
+	w, err := c.getBackOfQueueWallet()
+	if err != nil {
+		return err
+	}
+
+	due := balancesWithFeesDue(w.Balances, threshold)
+	if len(due) == 0 {
+		return nil
+	}
+
+	if hooks.Approve != nil && !hooks.Approve(due) {
+		if hooks.OnRejected != nil {
+			hooks.OnRejected(due)
+		}
+		return nil
+	}
+
+	err = c.payFees()
+	if hooks.OnPaid != nil {
+		hooks.OnPaid(due, err)
+	}
+	return err
+}
+
+// balancesWithFeesDue returns the balances whose total owed fees are at least
+// the threshold
+func balancesWithFeesDue(balances []wallet.Balance, threshold *big.Int) []wallet.Balance {
+	var due []wallet.Balance
+	for _, balance := range balances {
+		fees := new(big.Int).Add(
+			balance.RelayerFeeBalance.ToBigInt(),
+			balance.ProtocolFeeBalance.ToBigInt(),
+		)
+		if fees.Cmp(threshold) >= 0 {
+			due = append(due, balance)
+		}
+	}
+
+	return due
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+func TestBalancesWithFeesDue(t *testing.T) {
+	belowThreshold := testBalance(1, 100)
+	belowThreshold.RelayerFeeBalance = new(wallet.Scalar).FromBigInt(big.NewInt(4))
+
+	atThreshold := testBalance(2, 100)
+	atThreshold.RelayerFeeBalance = new(wallet.Scalar).FromBigInt(big.NewInt(3))
+	atThreshold.ProtocolFeeBalance = new(wallet.Scalar).FromBigInt(big.NewInt(2))
+
+	balances := []wallet.Balance{belowThreshold, atThreshold}
+	due := balancesWithFeesDue(balances, big.NewInt(5))
+	assert.Equal(t, []wallet.Balance{atThreshold}, due)
+}
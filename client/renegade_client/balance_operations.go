@@ -6,6 +6,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
@@ -13,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
 
 	"github.com/renegade-fi/golang-sdk/abis"
 	"github.com/renegade-fi/golang-sdk/client/api_types"
@@ -23,20 +25,12 @@ import (
 func (c *RenegadeClient) deposit(
 	mint string, amount *big.Int, ethPrivateKey *ecdsa.PrivateKey, blocking bool,
 ) error {
-	// Get the back of the queue wallet
-	backOfQueueWallet, err := c.GetBackOfQueueWallet()
-	if err != nil {
+	// Check the relayer accepts the deposit and the wallet can hold it before
+	// spending gas on approvals
+	if err := c.checkMinDeposit(mint, amount); err != nil {
 		return err
 	}
-
-	// Add the balance to the wallet
-	bal := wallet.NewBalanceBuilder().WithMintHex(mint).WithAmountBigInt(amount).Build()
-	err = backOfQueueWallet.AddBalance(bal)
-	if err != nil {
-		return err
-	}
-	err = backOfQueueWallet.Reblind()
-	if err != nil {
+	if err := c.checkDepositCapacity(mint); err != nil {
 		return err
 	}
 
@@ -46,25 +40,41 @@ func (c *RenegadeClient) deposit(
 		return fmt.Errorf("failed to setup deposit: %w", err)
 	}
 
-	// Get the wallet update auth
-	auth, err := getWalletUpdateAuth(backOfQueueWallet)
+	return c.submitDeposit(req, amount, blocking)
+}
+
+// checkDepositCapacity returns a *wallet.CapacityError if the back of the
+// queue wallet has no slot for a balance in the given mint, first trying to
+// free one if a consolidation policy is set
+func (c *RenegadeClient) checkDepositCapacity(mint string) error {
+	mintScalar, err := new(wallet.Scalar).FromHexString(mint)
+	if err != nil {
+		return fmt.Errorf("invalid mint %s: %w", mint, err)
+	}
+
+	backOfQueueWallet, err := c.getBackOfQueueWallet()
 	if err != nil {
 		return err
 	}
-	req.WalletUpdateAuthorization = *auth
 
-	// Post the deposit to the relayer
-	walletID := c.walletSecrets.Id
-	path := api_types.BuildDepositPath(walletID)
+	err = backOfQueueWallet.CheckBalanceCapacity(mintScalar)
+	if policy := c.consolidationPolicy.Load(); policy != nil && errors.Is(err, wallet.ErrWalletFull) {
+		return c.consolidateBalances(backOfQueueWallet, mintScalar, policy)
+	}
+	return err
+}
 
-	resp := api_types.DepositResponse{}
-	err = c.httpClient.PostWithAuth(path, req, &resp)
+// submitDeposit enqueues a deposit, waiting for it to complete if blocking
+func (c *RenegadeClient) submitDeposit(
+	req *api_types.DepositRequest, amount *big.Int, blocking bool,
+) error {
+	taskID, err := c.enqueueDeposit(req, amount)
 	if err != nil {
-		return fmt.Errorf("failed to post deposit request: %w", err)
+		return err
 	}
 
 	if blocking {
-		if err := c.waitForTask(resp.TaskId); err != nil {
+		if err := c.waitForTask(taskID); err != nil {
 			return err
 		}
 	}
@@ -72,6 +82,36 @@ func (c *RenegadeClient) deposit(
 	return nil
 }
 
+// enqueueDeposit adds the deposited balance to the back of the queue wallet,
+// authorizes the update, and posts the deposit request to the relayer,
+// returning the ID of the deposit task
+func (c *RenegadeClient) enqueueDeposit(
+	req *api_types.DepositRequest, amount *big.Int,
+) (uuid.UUID, error) {
+	// The relayer pulls every deposit through a signed Permit2 permit
+	if req.PermitNonce == "" || req.PermitDeadline == "" || req.PermitSignature == "" {
+		return uuid.Nil, ErrMissingDepositPermit
+	}
+
+	// Add the balance to the wallet and post the deposit to the relayer
+	addBalance := func(w *wallet.Wallet) error {
+		bal := wallet.NewBalanceBuilder().WithMintHex(req.Mint).WithAmountBigInt(amount).Build()
+		return w.AddBalance(bal)
+	}
+	postDeposit := func(auth *api_types.WalletUpdateAuthorization) (uuid.UUID, error) {
+		req.WalletUpdateAuthorization = *auth
+		path := api_types.BuildDepositPath(c.walletSecrets.Id)
+
+		resp := api_types.DepositResponse{}
+		if err := c.httpClient.PostWithAuth(path, req, &resp); err != nil {
+			return uuid.Nil, fmt.Errorf("failed to post deposit request: %w", err)
+		}
+		return resp.TaskId, nil
+	}
+
+	return c.updateWallet(addBalance, postDeposit)
+}
+
 // setupDeposit sets up the deposit request, this includes approving the Permit2
 // contract, and generating the witness and signature
 func (c *RenegadeClient) setupDeposit(
@@ -113,55 +153,51 @@ func (c *RenegadeClient) withdraw(mint string, amount *big.Int, blocking bool) e
 func (c *RenegadeClient) withdrawToAddress(
 	mint string, amount *big.Int, destination string, blocking bool,
 ) error {
-	// Get the back of the queue wallet
-	backOfQueueWallet, err := c.GetBackOfQueueWallet()
-	if err != nil {
-		return err
-	}
-
-	// Remove the balance from the wallet
-	bal := wallet.NewBalanceBuilder().WithMintHex(mint).WithAmountBigInt(amount).Build()
-	err = backOfQueueWallet.RemoveBalance(bal)
-	if err != nil {
-		return err
-	}
-	err = backOfQueueWallet.Reblind()
+	// Reject malformed destinations, which would otherwise serialize to the
+	// zero address
+	normalized, err := api_types.NormalizeAddress(destination)
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid withdrawal destination: %w", err)
 	}
-
-	// Get the wallet update auth
-	auth, err := getWalletUpdateAuth(backOfQueueWallet)
-	if err != nil {
+	if err := c.checkWithdrawalDestination(destination); err != nil {
 		return err
 	}
+	destination = normalized
 
-	// Get the external transfer signature
 	// Construct the external transfer signature
-
 	externalTransferSig, err := c.generateWithdrawalSignature(mint, amount, destination)
 	if err != nil {
 		return fmt.Errorf("failed to generate external transfer signature: %w", err)
 	}
 
-	// Create the withdraw request
-	req := &api_types.WithdrawRequest{
-		DestinationAddr:           destination,
-		Amount:                    amount.String(),
-		ExternalTransferSig:       externalTransferSig,
-		WalletUpdateAuthorization: *auth,
+	// Remove the balance from the wallet and post the withdrawal to the relayer
+	removeBalance := func(w *wallet.Wallet) error {
+		bal := wallet.NewBalanceBuilder().WithMintHex(mint).WithAmountBigInt(amount).Build()
+		return w.RemoveBalance(bal)
 	}
+	postWithdrawal := func(auth *api_types.WalletUpdateAuthorization) (uuid.UUID, error) {
+		req := &api_types.WithdrawRequest{
+			DestinationAddr:           destination,
+			Amount:                    amount.String(),
+			ExternalTransferSig:       externalTransferSig,
+			WalletUpdateAuthorization: *auth,
+		}
 
-	// Post the request to the relayer
-	path := api_types.BuildWithdrawPath(c.walletSecrets.Id, mint)
-	var resp api_types.WithdrawResponse
-	err = c.httpClient.PostWithAuth(path, req, &resp)
+		path := api_types.BuildWithdrawPath(c.walletSecrets.Id, mint)
+		var resp api_types.WithdrawResponse
+		if err := c.httpClient.PostWithAuth(path, req, &resp); err != nil {
+			return uuid.Nil, fmt.Errorf("failed to post withdraw request: %w", err)
+		}
+		return resp.TaskId, nil
+	}
+
+	taskID, err := c.updateWallet(removeBalance, postWithdrawal)
 	if err != nil {
-		return fmt.Errorf("failed to post withdraw request: %w", err)
+		return err
 	}
 
 	if blocking {
-		if err := c.waitForTask(resp.TaskId); err != nil {
+		if err := c.waitForTask(taskID); err != nil {
 			return err
 		}
 	}
@@ -171,14 +207,21 @@ func (c *RenegadeClient) withdrawToAddress(
 
 // payFees pays the fees for the wallet
 func (c *RenegadeClient) payFees() error {
+	_, err := c.payFeesWithTasks()
+	return err
+}
+
+// payFeesWithTasks pays the fees for the wallet, returning the IDs of the
+// tasks enqueued to pay them
+func (c *RenegadeClient) payFeesWithTasks() ([]uuid.UUID, error) {
 	path := api_types.BuildPayFeesPath(c.walletSecrets.Id)
 	resp := api_types.PayFeesResponse{}
 	err := c.httpClient.PostWithAuth(path, nil /* body */, &resp)
 	if err != nil {
-		return fmt.Errorf("failed to pay fees: %w", err)
+		return nil, fmt.Errorf("failed to pay fees: %w", err)
 	}
 
-	return nil
+	return resp.TaskIds, nil
 }
 
 // --- Helpers --- //
@@ -257,60 +300,35 @@ func (c *RenegadeClient) approvePermit2Deposit(
 func (c *RenegadeClient) generatePermit2Signature(
 	mint string, amount *big.Int, ethPrivateKey *ecdsa.PrivateKey,
 ) (*PermitWitnessTransferFrom, []byte, error) {
-	// Construct the EIP712 domain
-	permit2Address := common.HexToAddress(c.chainConfig.Permit2Address)
-	chainID := big.NewInt(int64(c.chainConfig.ChainID)) //nolint:gosec
-	domain := ConstructEIP712Domain(chainID, permit2Address)
-
-	// Create the TokenPermissions struct
-	tokenPermissions := abis.ISignatureTransferTokenPermissions{
-		Token:  common.HexToAddress(mint),
-		Amount: amount,
-	}
-
-	// Generate nonce and deadline
-	nonce, err := randomU256()
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
-	}
-	deadline := new(big.Int).SetUint64(^uint64(0))
-
-	// Generate a random witness (replace this with actual witness generation if needed)
-	witness, err := c.getPermitWitness()
+	fromAddr := crypto.PubkeyToAddress(ethPrivateKey.PublicKey)
+	permit, err := c.NewDepositPermit(mint, amount, fromAddr)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to generate witness: %w", err)
-	}
-
-	// Create the PermitWitnessTransferFrom struct
-	permitWitnessTransferFrom := PermitWitnessTransferFrom{
-		Permitted: tokenPermissions,
-		Spender:   common.HexToAddress(c.chainConfig.DarkpoolAddress),
-		Nonce:     nonce,
-		Deadline:  deadline,
-		Witness:   witness,
+		return nil, nil, err
 	}
 
 	// Generate the signing hash
-	signingHash, err := getPermitSigningHash(permitWitnessTransferFrom, domain)
+	signingHash, err := permit.SigningHash()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get signing hash: %w", err)
 	}
 
 	// Sign the hash
-	signature, err := crypto.Sign(signingHash.Bytes(), ethPrivateKey)
+	signature, err := signPermit2Hash(signingHash, ethPrivateKey)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to sign permit: %w", err)
+		return nil, nil, err
 	}
 
-	// Add 27 to the last byte of the signature, we expect the bitcoin style replay protection
-	signature[len(signature)-1] += 27
-	return &permitWitnessTransferFrom, signature, nil
+	return &permit.Permit, signature, nil
 }
 
 // generateWithdrawalSignature generates a signature for the withdrawal
 func (c *RenegadeClient) generateWithdrawalSignature(
 	mint string, amount *big.Int, destination string,
 ) (*string, error) {
+	if err := c.requireRootKey(); err != nil {
+		return nil, err
+	}
+
 	rootKey := ecdsa.PrivateKey(*c.walletSecrets.Keychain.SkRoot())
 	sigBytes, err := postcardSerializeTransfer(mint, amount, destination)
 	if err != nil {
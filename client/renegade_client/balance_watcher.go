@@ -0,0 +1,207 @@
+package client
+
+import (
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// BalanceChanged is emitted by a BalanceWatcher when a wallet balance, or the
+// fees owed against it, changes between polls
+type BalanceChanged struct {
+	// Mint is the erc20 address of the balance's asset, as a hex string
+	Mint string
+	// Previous is the balance before the change; zero valued if the balance is new
+	Previous wallet.Balance
+	// Current is the balance after the change; zero valued if the balance was removed
+	Current wallet.Balance
+}
+
+// AmountDelta returns the change in the balance's amount
+func (e *BalanceChanged) AmountDelta() *big.Int {
+	return new(big.Int).Sub(e.Current.Amount.ToBigInt(), e.Previous.Amount.ToBigInt())
+}
+
+// BalanceWatcher periodically fetches the back of queue wallet, diffs its
+// balances against the previous poll, and pushes BalanceChanged events to
+// subscribers
+type BalanceWatcher struct {
+	// fetchWallet fetches the latest wallet state
+	fetchWallet func() (*wallet.Wallet, error)
+	// interval is the polling interval
+	interval time.Duration
+	// owner is the client that created the watcher, which stops it while
+	// running on shutdown; nil if none
+	owner *RenegadeClient
+
+	// mu guards the fields below
+	mu sync.Mutex
+	// subscribers are the channels to which events are pushed
+	subscribers []chan BalanceChanged
+	// balances are the balances observed in the last poll, keyed by mint
+	balances map[string]wallet.Balance
+	// initialized indicates whether a first poll has completed
+	initialized bool
+	// stop is closed to stop the polling routine
+	stop chan struct{}
+}
+
+// NewBalanceWatcher creates a BalanceWatcher over the client's wallet that
+// polls at the given interval. The watcher does not poll until started, and
+// is stopped if running when the client is closed
+func (c *RenegadeClient) NewBalanceWatcher(interval time.Duration) *BalanceWatcher {
+	return &BalanceWatcher{
+		fetchWallet: c.getBackOfQueueWallet,
+		interval:    interval,
+		owner:       c,
+		balances:    make(map[string]wallet.Balance),
+	}
+}
+
+// Subscribe registers a new subscriber and returns the channel on which it
+// receives events. Events are dropped for a subscriber whose buffer is full
+func (w *BalanceWatcher) Subscribe(bufferSize int) <-chan BalanceChanged {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ch := make(chan BalanceChanged, bufferSize)
+	w.subscribers = append(w.subscribers, ch)
+	return ch
+}
+
+// Start begins polling in a background routine. The first poll records the
+// initial balances without emitting events. Starting a running watcher is a no-op
+func (w *BalanceWatcher) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stop != nil {
+		return
+	}
+
+	w.stop = make(chan struct{})
+	if w.owner != nil {
+		w.owner.trackWatcher(w)
+	}
+	go w.run(w.stop)
+}
+
+// Stop stops the polling routine and closes all subscriber channels
+func (w *BalanceWatcher) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stop == nil {
+		return
+	}
+
+	close(w.stop)
+	w.stop = nil
+	if w.owner != nil {
+		w.owner.untrackWatcher(w)
+	}
+	for _, ch := range w.subscribers {
+		close(ch)
+	}
+	w.subscribers = nil
+}
+
+// Poll fetches the wallet once, emitting events for any balances that changed
+// since the previous poll
+func (w *BalanceWatcher) Poll() error {
+	wal, err := w.fetchWallet()
+	if err != nil {
+		return err
+	}
+
+	current := balancesByMint(wal.Balances)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.initialized {
+		w.balances = current
+		w.initialized = true
+		return nil
+	}
+
+	events := diffBalances(w.balances, current)
+	w.balances = current
+	for _, event := range events {
+		w.publish(event)
+	}
+
+	return nil
+}
+
+// run polls until the stop channel is closed
+func (w *BalanceWatcher) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.pollAndLog()
+	for {
+		select {
+		case <-ticker.C:
+			w.pollAndLog()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// pollAndLog polls the wallet, logging any failure
+func (w *BalanceWatcher) pollAndLog() {
+	if err := w.Poll(); err != nil {
+		log.Printf("balance watcher failed to poll wallet: %v", err)
+	}
+}
+
+// publish pushes an event to all subscribers without blocking
+//
+// Expects the lock to be held
+func (w *BalanceWatcher) publish(event BalanceChanged) {
+	for _, ch := range w.subscribers {
+		select {
+		case ch <- event:
+		default:
+			log.Printf("balance watcher dropped event for mint %s: subscriber buffer full", event.Mint)
+		}
+	}
+}
+
+// balancesByMint indexes the non-empty balances by their hex mint
+func balancesByMint(balances []wallet.Balance) map[string]wallet.Balance {
+	res := make(map[string]wallet.Balance)
+	for _, balance := range balances {
+		if balance.IsZero() {
+			continue
+		}
+		res[balance.Mint.ToHexString()] = balance
+	}
+
+	return res
+}
+
+// diffBalances returns an event for every mint whose balance or fees differ
+// between the two sets, sorted by mint
+func diffBalances(previous, current map[string]wallet.Balance) []BalanceChanged {
+	var events []BalanceChanged
+	for mint, curr := range current {
+		prev, ok := previous[mint]
+		if ok && prev == curr {
+			continue
+		}
+		events = append(events, BalanceChanged{Mint: mint, Previous: prev, Current: curr})
+	}
+
+	for mint, prev := range previous {
+		if _, ok := current[mint]; !ok {
+			events = append(events, BalanceChanged{Mint: mint, Previous: prev})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].Mint < events[j].Mint })
+	return events
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// testBalance creates a balance with the given mint and amount
+func testBalance(mint, amount int64) wallet.Balance {
+	return wallet.NewBalanceBuilder().
+		WithMint(new(wallet.Scalar).FromBigInt(big.NewInt(mint))).
+		WithAmountBigInt(big.NewInt(amount)).
+		Build()
+}
+
+func TestBalanceWatcherEmitsChanges(t *testing.T) {
+	balances := []wallet.Balance{testBalance(1, 100), testBalance(2, 200)}
+	watcher := &BalanceWatcher{
+		fetchWallet: func() (*wallet.Wallet, error) {
+			return &wallet.Wallet{Balances: balances}, nil
+		},
+	}
+	events := watcher.Subscribe(10 /* bufferSize */)
+
+	// The first poll records the initial state without emitting
+	assert.NoError(t, watcher.Poll())
+	assert.Len(t, events, 0)
+
+	// Change one balance, remove one, and add one
+	balances = []wallet.Balance{testBalance(1, 150), testBalance(3, 300)}
+	assert.NoError(t, watcher.Poll())
+	assert.Len(t, events, 3)
+
+	changed := <-events
+	assert.Equal(t, "01", changed.Mint)
+	assert.Equal(t, big.NewInt(50), changed.AmountDelta())
+
+	removed := <-events
+	assert.Equal(t, "02", removed.Mint)
+	assert.Equal(t, big.NewInt(-200), removed.AmountDelta())
+
+	added := <-events
+	assert.Equal(t, "03", added.Mint)
+	assert.Equal(t, big.NewInt(300), added.AmountDelta())
+
+	// An unchanged poll emits nothing
+	assert.NoError(t, watcher.Poll())
+	assert.Len(t, events, 0)
+}
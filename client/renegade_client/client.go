@@ -5,6 +5,8 @@ import (
 	"encoding/base64"
 	"fmt"
 	"math/big"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -25,6 +27,9 @@ type ChainConfig struct {
 	DarkpoolAddress string
 	// EthereumRpcUrl is the URL of the Ethereum RPC
 	EthereumRpcUrl string //nolint:revive
+	// WethAddress is the address of the chain's wrapped ether contract, used to
+	// wrap and unwrap native ETH around deposits and withdrawals
+	WethAddress string
 }
 
 var (
@@ -34,6 +39,7 @@ var (
 		Permit2Address:  "0x000000000022D473030F116dDEE9F6B43aC78BA3",
 		DarkpoolAddress: "0x30bd8eab29181f790d7e495786d4b96d7afdc518",
 		EthereumRpcUrl:  "https://arb1.arbitrum.io/rpc",
+		WethAddress:     "0x82aF49447D8a07e3bd95BD0d56f35241523fBab1",
 	}
 
 	// ArbitrumSepoliaConfig is the configuration for the Arbitrum Sepolia chain
@@ -46,10 +52,55 @@ var (
 )
 
 // RenegadeClient represents a client for the renegade API
+//
+// A RenegadeClient is safe for concurrent use by multiple goroutines. Wallet
+// updates issued concurrently are serialized until each is enqueued
 type RenegadeClient struct {
 	chainConfig   ChainConfig
 	walletSecrets *wallet.WalletSecrets
 	httpClient    *client.HttpClient
+
+	// walletUpdateMu serializes wallet updates, see lockWalletUpdate
+	walletUpdateMu sync.Mutex
+	// maxUpdateRetries is the number of times an update conflicting with a
+	// concurrent update is retried, see updateWallet
+	maxUpdateRetries int
+	// withdrawalPolicy is the allowlist of withdrawal destinations, nil if
+	// any destination is allowed
+	withdrawalPolicy atomic.Pointer[WithdrawalPolicy]
+	// riskGuard enforces the risk limits on placed orders, nil if none are set
+	riskGuard atomic.Pointer[client.RiskGuard]
+	// consolidationPolicy frees balance slots for deposits into a full wallet,
+	// nil if disabled
+	consolidationPolicy atomic.Pointer[ConsolidationPolicy]
+	// commitmentSigner signs wallet updates, nil to sign with the wallet's
+	// root key
+	commitmentSigner atomic.Pointer[commitmentSignerHolder]
+	// adminClient places orders in matching pools, nil if unset
+	adminClient atomic.Pointer[AdminClient]
+
+	// autoFeesMu guards the automatic fee payment routine's stop channel
+	autoFeesMu   sync.Mutex
+	stopAutoFees chan struct{}
+
+	// deadManMu guards the dead-man switch's stop channel
+	deadManMu   sync.Mutex
+	stopDeadMan chan struct{}
+	// lastHeartbeat is the time of the last heartbeat, in unix nanoseconds
+	lastHeartbeat atomic.Int64
+
+	// watchersMu guards the running balance watchers created by the client,
+	// which are stopped on shutdown
+	watchersMu sync.Mutex
+	watchers   map[*BalanceWatcher]struct{}
+	// flushers are the sinks flushed when the client closes
+	flushers client.Flushers
+
+	// taskObservers are notified of the state changes of awaited tasks
+	taskObservers client.Observers[TaskStateChangeEvent]
+
+	// minimums caches the relayer's minimum deposit and order amounts
+	minimums minimumsCache
 }
 
 // NewRenegadeClient creates a new Client with the given base URL and auth key
@@ -66,6 +117,14 @@ func NewSepoliaRenegadeClient(baseURL string, ethKey *ecdsa.PrivateKey) (*Renega
 // key, and chain config
 func NewRenegadeClientWithConfig(
 	baseURL string, ethKey *ecdsa.PrivateKey, config ChainConfig,
+) (*RenegadeClient, error) {
+	return NewRenegadeClientWithOptions(baseURL, ethKey, config, client.NewHttpClientOptions())
+}
+
+// NewRenegadeClientWithOptions creates a new Client with the given base URL,
+// auth key, chain config, and HTTP client options
+func NewRenegadeClientWithOptions(
+	baseURL string, ethKey *ecdsa.PrivateKey, config ChainConfig, options *client.HttpClientOptions,
 ) (*RenegadeClient, error) {
 	walletInfo, err := wallet.DeriveWalletSecrets(ethKey, config.ChainID)
 	if err != nil {
@@ -74,9 +133,10 @@ func NewRenegadeClientWithConfig(
 
 	authKey := walletInfo.Keychain.PrivateKeys.SymmetricKey
 	return &RenegadeClient{
-		chainConfig:   config,
-		walletSecrets: walletInfo,
-		httpClient:    client.NewHttpClient(baseURL, &authKey),
+		chainConfig:      config,
+		walletSecrets:    walletInfo,
+		httpClient:       client.NewHttpClientWithOptions(baseURL, &authKey, options),
+		maxUpdateRetries: defaultMaxUpdateRetries,
 	}, nil
 }
 
@@ -204,7 +264,9 @@ func (c *RenegadeClient) CreateWallet() (*wallet.Wallet, error) {
 // Returns:
 //   - *api_types.DepositResponse: Contains information about the deposit transaction,
 //     including the task ID and any relevant details from the Renegade protocol.
-//   - error: An error if the deposit process fails, nil otherwise.
+//   - error: An error if the deposit process fails, nil otherwise. A
+//     *wallet.CapacityError, before any approval is sent, if the wallet already
+//     holds wallet.MaxBalances balances in other mints.
 //
 // The method handles the entire deposit flow, including updating the local wallet
 // state, approving the Permit2 contract for spending, and submitting the deposit
@@ -277,7 +339,8 @@ func (c *RenegadeClient) PayFees() (*wallet.Wallet, error) {
 //
 // Returns:
 //   - *api_types.CreateOrderResponse: Contains the order ID and task ID if successful.
-//   - error: An error if the order creation fails, nil otherwise.
+//   - error: An error if the order creation fails, nil otherwise. A
+//     *wallet.CapacityError if the wallet already holds wallet.MaxOrders orders.
 func (c *RenegadeClient) PlaceOrder(order *wallet.Order) (*wallet.Wallet, error) {
 	if err := c.placeOrder(order, true /* blocking */); err != nil {
 		return nil, err
@@ -305,20 +368,37 @@ func (c *RenegadeClient) CancelOrder(orderId uuid.UUID) (*wallet.Wallet, error)
 	return c.GetWallet()
 }
 
+// UpdateOrder replaces the wallet's order with the same ID in a single wallet
+// update, e.g. to reprice it, rather than cancelling it and placing a new
+// one. The order keeps its matching pool
+func (c *RenegadeClient) UpdateOrder(order *wallet.Order) (*wallet.Wallet, error) {
+	taskID, err := c.submitUpdateOrder(order)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.waitForTask(taskID); err != nil {
+		return nil, err
+	}
+	return c.GetWallet()
+}
+
 // --- Helpers --- //
 
-// getWalletUpdateAuth gets the wallet update authorization for the given wallet
-func getWalletUpdateAuth(wallet *wallet.Wallet) (*api_types.WalletUpdateAuthorization, error) {
+// getWalletUpdateAuth gets the wallet update authorization for the given
+// wallet, signed by the given signer
+func getWalletUpdateAuth(
+	wallet *wallet.Wallet, signer wallet.CommitmentSigner,
+) (*api_types.WalletUpdateAuthorization, error) {
 	// Compute the commitment to the new wallet
 	commitment, err := wallet.GetShareCommitment()
 	if err != nil {
 		return nil, err
 	}
 
-	// Sign the commitment with skRoot
-	signature, err := wallet.SignCommitment(commitment)
+	// Sign the commitment
+	signature, err := signer.SignCommitment(commitment)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to sign wallet commitment: %w", err)
 	}
 
 	// base64 encode the signature without padding
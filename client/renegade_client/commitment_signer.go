@@ -0,0 +1,34 @@
+package client
+
+import (
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// commitmentSignerHolder holds the signer set with SetCommitmentSigner, so
+// that signers of different types can be swapped atomically
+type commitmentSignerHolder struct {
+	signer wallet.CommitmentSigner
+}
+
+// SetCommitmentSigner sets the signer that authorizes wallet updates by
+// signing the commitment to the updated wallet; nil, the default, signs with
+// the wallet's secp256k1 root key.
+//
+// A custom signer lets the root key live outside the process, e.g. in a
+// custody service, or slots in another signature scheme once the relayer
+// supports one. With a custom signer, a read-only client may update its wallet
+func (c *RenegadeClient) SetCommitmentSigner(signer wallet.CommitmentSigner) {
+	if signer == nil {
+		c.commitmentSigner.Store(nil)
+		return
+	}
+	c.commitmentSigner.Store(&commitmentSignerHolder{signer: signer})
+}
+
+// getCommitmentSigner returns the signer for updates to the given wallet
+func (c *RenegadeClient) getCommitmentSigner(w *wallet.Wallet) wallet.CommitmentSigner {
+	if holder := c.commitmentSigner.Load(); holder != nil {
+		return holder.signer
+	}
+	return wallet.NewSecp256k1CommitmentSigner(w.Keychain.SkRoot())
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+func TestCustomCommitmentSigner(t *testing.T) {
+	var cancellations atomic.Int32
+	c := newOrdersRelayer(t, 1, &cancellations)
+
+	// A custom signer authorizes the update
+	var signatures atomic.Int32
+	c.SetCommitmentSigner(wallet.CommitmentSignerFunc(func(commitment wallet.Scalar) ([]byte, error) {
+		signatures.Add(1)
+		return make([]byte, 65), nil
+	}))
+	assert.NoError(t, c.cancelAllOrders(false /* blocking */))
+	assert.Equal(t, int32(1), signatures.Load())
+	assert.Equal(t, int32(1), cancellations.Load())
+
+	// A failing signer aborts the update before it reaches the relayer
+	errSign := errors.New("custody service unavailable")
+	c.SetCommitmentSigner(wallet.CommitmentSignerFunc(func(wallet.Scalar) ([]byte, error) {
+		return nil, errSign
+	}))
+	assert.ErrorIs(t, c.cancelAllOrders(false /* blocking */), errSign)
+	assert.Equal(t, int32(1), cancellations.Load())
+
+	// Resetting restores the root key signer
+	c.SetCommitmentSigner(nil /* signer */)
+	assert.NoError(t, c.cancelAllOrders(false /* blocking */))
+	assert.Equal(t, int32(2), cancellations.Load())
+}
+
+func TestCommitmentSignerOnReadOnlyClient(t *testing.T) {
+	var cancellations atomic.Int32
+	full := newOrdersRelayer(t, 1, &cancellations)
+	c, err := NewReadOnlyRenegadeClient("http://localhost:1", full.ViewKeys(), ArbitrumSepoliaConfig)
+	assert.NoError(t, err)
+	c.httpClient = full.httpClient
+
+	// The root key lives with the signer, so the read-only client may update
+	c.SetCommitmentSigner(wallet.CommitmentSignerFunc(func(wallet.Scalar) ([]byte, error) {
+		return make([]byte, 65), nil
+	}))
+	assert.NoError(t, c.cancelAllOrders(false /* blocking */))
+	assert.Equal(t, int32(1), cancellations.Load())
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// ConsolidationPolicy configures the automatic consolidation of balances when
+// a deposit is made into a wallet that already holds wallet.MaxBalances
+// balances, see SetConsolidationPolicy
+type ConsolidationPolicy struct {
+	// Destination is the address dust balances are withdrawn to; empty
+	// withdraws to the wallet's owner
+	Destination string
+	// Protected lists mints that are never withdrawn, e.g. the quote token
+	Protected []string
+	// Value ranks balances by value, e.g. in USD, so that the least valuable
+	// balance is withdrawn. If nil, balances are ranked by their raw amount
+	Value func(mint string, amount *big.Int) (float64, error)
+}
+
+// isProtected returns whether the policy protects the given mint
+func (p *ConsolidationPolicy) isProtected(mint wallet.Scalar) bool {
+	for _, protected := range p.Protected {
+		protectedMint, err := new(wallet.Scalar).FromHexString(protected)
+		if err == nil && protectedMint == mint {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetConsolidationPolicy opts in to automatic balance consolidation; nil, the
+// default, disables it.
+//
+// When enabled, a deposit into a new mint that would not fit in the wallet
+// first frees a balance slot. A balance that holds only unpaid fees is freed
+// by paying the wallet's fees; otherwise the least valuable balance that is
+// neither protected nor backing an open order is withdrawn in full. Without a
+// policy such deposits fail with a *wallet.CapacityError
+func (c *RenegadeClient) SetConsolidationPolicy(policy *ConsolidationPolicy) {
+	c.consolidationPolicy.Store(policy)
+}
+
+// consolidateBalances frees a balance slot in the wallet for a deposit of the
+// given mint according to the policy
+func (c *RenegadeClient) consolidateBalances(
+	w *wallet.Wallet, depositMint wallet.Scalar, policy *ConsolidationPolicy,
+) error {
+	// Paying fees frees any slot held only by unpaid fees
+	if hasFeeOnlyBalance(w) {
+		log.Printf("wallet is full, paying fees to free a balance slot")
+		if err := c.payFeesAndWait(); err != nil {
+			return fmt.Errorf("failed to pay fees to free a balance slot: %w", err)
+		}
+		return nil
+	}
+
+	dust, err := selectDustBalance(w, depositMint, policy)
+	if err != nil {
+		return err
+	}
+
+	// Fees must be paid before a balance may be withdrawn
+	if !dust.RelayerFeeBalance.IsZero() || !dust.ProtocolFeeBalance.IsZero() {
+		if err := c.payFeesAndWait(); err != nil {
+			return fmt.Errorf("failed to pay fees before consolidating: %w", err)
+		}
+	}
+
+	destination := policy.Destination
+	if destination == "" {
+		destination = c.walletSecrets.Address
+	}
+
+	mint := mintToAddress(dust.Mint)
+	amount := dust.Amount.ToBigInt()
+	log.Printf("wallet is full, withdrawing %s of %s to free a balance slot", amount, mint)
+	if err := c.withdrawToAddress(mint, amount, destination, true /* blocking */); err != nil {
+		return fmt.Errorf("failed to withdraw dust balance %s: %w", mint, err)
+	}
+
+	return nil
+}
+
+// hasFeeOnlyBalance returns whether any balance in the wallet holds unpaid
+// fees but no funds
+func hasFeeOnlyBalance(w *wallet.Wallet) bool {
+	for _, balance := range w.GetNonzeroBalances() {
+		if balance.Amount.IsZero() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selectDustBalance selects the least valuable balance that may be withdrawn
+// to make room for a deposit of the given mint
+func selectDustBalance(
+	w *wallet.Wallet, depositMint wallet.Scalar, policy *ConsolidationPolicy,
+) (*wallet.Balance, error) {
+	// Balances sold by open orders must stay in the wallet
+	inUse := make(map[wallet.Scalar]struct{})
+	for _, order := range w.GetNonzeroOrders() {
+		if order.Side.IsZero() {
+			inUse[order.QuoteMint] = struct{}{}
+		} else {
+			inUse[order.BaseMint] = struct{}{}
+		}
+	}
+
+	var dust *wallet.Balance
+	var dustValue *big.Float
+	for i := range w.Balances {
+		balance := &w.Balances[i]
+		if balance.Amount.IsZero() || balance.Mint == depositMint || policy.isProtected(balance.Mint) {
+			continue
+		}
+		if _, ok := inUse[balance.Mint]; ok {
+			continue
+		}
+
+		value, err := balanceValue(balance, policy)
+		if err != nil {
+			return nil, err
+		}
+		if dust == nil || value.Cmp(dustValue) < 0 {
+			dust, dustValue = balance, value
+		}
+	}
+
+	if dust == nil {
+		return nil, fmt.Errorf("%w: no balance may be consolidated", wallet.ErrWalletFull)
+	}
+	return dust, nil
+}
+
+// balanceValue values a balance according to the policy
+func balanceValue(balance *wallet.Balance, policy *ConsolidationPolicy) (*big.Float, error) {
+	amount := balance.Amount.ToBigInt()
+	if policy.Value == nil {
+		return new(big.Float).SetInt(amount), nil
+	}
+
+	mint := mintToAddress(balance.Mint)
+	value, err := policy.Value(mint, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to value balance %s: %w", mint, err)
+	}
+	if math.IsNaN(value) {
+		return nil, fmt.Errorf("invalid value for balance %s: %v", mint, value)
+	}
+	return big.NewFloat(value), nil
+}
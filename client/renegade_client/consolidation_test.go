@@ -0,0 +1,76 @@
+package client
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// consolidationTestWallet builds a wallet with balances of the given amounts
+// in mints 0x1, 0x2, ...
+func consolidationTestWallet(t *testing.T, amounts ...int64) *wallet.Wallet {
+	w := &wallet.Wallet{}
+	for i, amount := range amounts {
+		balance := wallet.NewBalanceBuilder().
+			WithMint(*new(wallet.Scalar).SetUint64(uint64(i + 1))).
+			WithAmountBigInt(big.NewInt(amount)).
+			Build()
+		assert.NoError(t, w.AddBalance(balance))
+	}
+	return w
+}
+
+func TestSelectDustBalance(t *testing.T) {
+	w := consolidationTestWallet(t, 50, 10, 30, 20)
+	depositMint := *new(wallet.Scalar).SetUint64(100)
+
+	// The smallest balance is selected by default
+	dust, err := selectDustBalance(w, depositMint, &ConsolidationPolicy{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), dust.Mint.Uint64())
+
+	// Protected mints and mints sold by open orders are skipped
+	sellOrder := wallet.NewOrderBuilder().
+		WithBaseMint(*new(wallet.Scalar).SetUint64(4)).
+		WithQuoteMint(*new(wallet.Scalar).SetUint64(1)).
+		WithSide(wallet.Sell).
+		WithAmountBigInt(big.NewInt(1)).
+		Build()
+	assert.NoError(t, w.NewOrder(sellOrder))
+	policy := &ConsolidationPolicy{Protected: []string{mintToAddress(*new(wallet.Scalar).SetUint64(2))}}
+	dust, err = selectDustBalance(w, depositMint, policy)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), dust.Mint.Uint64())
+
+	// A valuation overrides the raw amounts
+	policy.Value = func(mint string, amount *big.Int) (float64, error) {
+		if mint == mintToAddress(*new(wallet.Scalar).SetUint64(1)) {
+			return 0.5, nil
+		}
+		return float64(amount.Int64()), nil
+	}
+	dust, err = selectDustBalance(w, depositMint, policy)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), dust.Mint.Uint64())
+}
+
+func TestSelectDustBalanceNoCandidate(t *testing.T) {
+	w := consolidationTestWallet(t, 10)
+	policy := &ConsolidationPolicy{Protected: []string{mintToAddress(*new(wallet.Scalar).SetUint64(1))}}
+
+	_, err := selectDustBalance(w, *new(wallet.Scalar).SetUint64(100), policy)
+	assert.True(t, errors.Is(err, wallet.ErrWalletFull))
+}
+
+func TestHasFeeOnlyBalance(t *testing.T) {
+	w := consolidationTestWallet(t, 10)
+	assert.False(t, hasFeeOnlyBalance(w))
+
+	w.Balances[0].Amount = wallet.Scalar{}
+	w.Balances[0].RelayerFeeBalance.SetUint64(1)
+	assert.True(t, hasFeeOnlyBalance(w))
+}
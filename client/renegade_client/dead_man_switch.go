@@ -0,0 +1,154 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+const (
+	// deadManChecksPerTimeout is the number of times per timeout the dead-man
+	// switch checks for a missed heartbeat
+	deadManChecksPerTimeout = 4
+	// deadManMaxRetryDelay bounds the backoff between the dead-man switch's
+	// attempts to cancel orders after a failed attempt
+	deadManMaxRetryDelay = time.Minute
+)
+
+// CancelAllOrders cancels every open order in the wallet, waiting for the
+// cancellations to complete.
+//
+// All cancellations are enqueued before any is awaited, so that a failure to
+// cancel one order does not leave the others open; every failure is returned
+// together once all orders have been attempted
+func (c *RenegadeClient) CancelAllOrders() (*wallet.Wallet, error) {
+	if err := c.cancelAllOrders(true /* blocking */); err != nil {
+		return nil, err
+	}
+	return c.GetWallet()
+}
+
+// cancelAllOrders cancels every open order in the back of the queue wallet
+func (c *RenegadeClient) cancelAllOrders(blocking bool) error {
+	backOfQueueWallet, err := c.getBackOfQueueWallet()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	var taskIDs []uuid.UUID
+	for _, order := range backOfQueueWallet.GetNonzeroOrders() {
+		taskID, err := c.submitCancelOrder(order.Id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to cancel order %s: %w", order.Id, err))
+			continue
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	if blocking {
+		for _, taskID := range taskIDs {
+			if err := c.waitForTask(taskID); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// EnableDeadManSwitch starts a watchdog that cancels every open order in the
+// wallet if Heartbeat is not called for longer than the given timeout, e.g.
+// because the application has hung or lost connectivity.
+//
+// Enabling the switch counts as a heartbeat. Once triggered, the switch
+// retries a failed cancellation with exponential backoff until it succeeds or
+// the switch is disabled, then stays idle until the next heartbeat. The
+// optional onTrigger callback is invoked after each cancellation attempt with
+// its error, if any. Returns an error if the switch is already enabled
+func (c *RenegadeClient) EnableDeadManSwitch(timeout time.Duration, onTrigger func(err error)) error {
+	if timeout <= 0 {
+		return errors.New("dead-man switch timeout must be positive")
+	}
+
+	c.deadManMu.Lock()
+	defer c.deadManMu.Unlock()
+	if c.stopDeadMan != nil {
+		return errors.New("dead-man switch is already enabled")
+	}
+
+	c.Heartbeat()
+	stop := make(chan struct{})
+	c.stopDeadMan = stop
+	go c.deadManLoop(timeout, onTrigger, stop)
+	return nil
+}
+
+// DisableDeadManSwitch stops the dead-man switch, if running
+func (c *RenegadeClient) DisableDeadManSwitch() {
+	c.deadManMu.Lock()
+	defer c.deadManMu.Unlock()
+	if c.stopDeadMan != nil {
+		close(c.stopDeadMan)
+		c.stopDeadMan = nil
+	}
+}
+
+// Heartbeat signals to the dead-man switch that the application is alive,
+// re-arming it if it has triggered
+func (c *RenegadeClient) Heartbeat() {
+	c.lastHeartbeat.Store(time.Now().UnixNano())
+}
+
+// deadManLoop checks for missed heartbeats until stopped
+func (c *RenegadeClient) deadManLoop(timeout time.Duration, onTrigger func(err error), stop <-chan struct{}) {
+	ticker := time.NewTicker(timeout / deadManChecksPerTimeout)
+	defer ticker.Stop()
+
+	// triggeredAt is the heartbeat the switch last triggered on
+	var triggeredAt int64
+	for {
+		select {
+		case <-ticker.C:
+			last := c.lastHeartbeat.Load()
+			if last == triggeredAt || time.Since(time.Unix(0, last)) <= timeout {
+				continue
+			}
+
+			triggeredAt = last
+			log.Printf("no heartbeat for %v, cancelling all orders", timeout)
+			c.deadManCancel(timeout/deadManChecksPerTimeout, onTrigger, stop)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// deadManCancel cancels every open order, retrying after a failure with
+// exponential backoff from the given delay until the cancellation succeeds or
+// the switch is stopped
+func (c *RenegadeClient) deadManCancel(delay time.Duration, onTrigger func(err error), stop <-chan struct{}) {
+	for {
+		err := c.cancelAllOrders(false /* blocking */)
+		if err != nil {
+			log.Printf("dead-man switch failed to cancel orders, retrying in %v: %v", delay, err)
+		}
+		if onTrigger != nil {
+			onTrigger(err)
+		}
+		if err == nil {
+			return
+		}
+
+		select {
+		case <-time.After(delay):
+			delay = min(2*delay, deadManMaxRetryDelay)
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// newOrdersRelayer creates a client backed by a fake relayer that serves a
+// wallet holding the given number of orders, counting the cancellations posted
+func newOrdersRelayer(t *testing.T, numOrders int, cancellations *atomic.Int32) *RenegadeClient {
+	return newFailingOrdersRelayer(t, numOrders, 0 /* failures */, cancellations)
+}
+
+// newFailingOrdersRelayer creates a client backed by a fake relayer like
+// newOrdersRelayer's, which fails the given number of cancellations before
+// accepting any
+func newFailingOrdersRelayer(
+	t *testing.T, numOrders int, failures int32, cancellations *atomic.Int32,
+) *RenegadeClient {
+	var failed atomic.Int32
+	key, w := newTestWallet(t)
+	for i := 0; i < numOrders; i++ {
+		order := wallet.NewOrderBuilder().
+			WithBaseMintHex("0x01").
+			WithQuoteMintHex("0x02").
+			WithSide(wallet.Buy).
+			WithAmountBigInt(big.NewInt(100)).
+			Build()
+		assert.NoError(t, w.NewOrder(order))
+	}
+	apiWallet := toTestApiWallet(t, w)
+
+	return newTestClient(t, key, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/back-of-queue"):
+			_ = json.NewEncoder(w).Encode(api_types.GetWalletResponse{Wallet: *apiWallet})
+		case strings.HasSuffix(r.URL.Path, "/cancel"):
+			if failed.Add(1) <= failures {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			cancellations.Add(1)
+			_ = json.NewEncoder(w).Encode(api_types.CancelOrderResponse{TaskId: uuid.New()})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestCancelAllOrdersCancelsEveryOrder(t *testing.T) {
+	var cancellations atomic.Int32
+	c := newOrdersRelayer(t, 3, &cancellations)
+
+	assert.NoError(t, c.cancelAllOrders(false /* blocking */))
+	assert.Equal(t, int32(3), cancellations.Load())
+}
+
+func TestDeadManSwitchTriggersOnMissedHeartbeat(t *testing.T) {
+	var cancellations atomic.Int32
+	c := newOrdersRelayer(t, 2, &cancellations)
+
+	triggered := make(chan error, 1)
+	assert.NoError(t, c.EnableDeadManSwitch(40*time.Millisecond, func(err error) { triggered <- err }))
+	defer c.DisableDeadManSwitch()
+	assert.Error(t, c.EnableDeadManSwitch(time.Second, nil))
+
+	select {
+	case err := <-triggered:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("dead-man switch did not trigger")
+	}
+	assert.Equal(t, int32(2), cancellations.Load())
+
+	// The switch does not trigger again until re-armed by a heartbeat
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(2), cancellations.Load())
+}
+
+func TestDeadManSwitchRetriesFailedCancellation(t *testing.T) {
+	var cancellations atomic.Int32
+	c := newFailingOrdersRelayer(t, 1, 1 /* failures */, &cancellations)
+
+	triggered := make(chan error, 2)
+	assert.NoError(t, c.EnableDeadManSwitch(40*time.Millisecond, func(err error) { triggered <- err }))
+	defer c.DisableDeadManSwitch()
+
+	// The first attempt fails and is retried without a further missed heartbeat
+	for _, expectErr := range []bool{true, false} {
+		select {
+		case err := <-triggered:
+			assert.Equal(t, expectErr, err != nil)
+		case <-time.After(time.Second):
+			t.Fatal("dead-man switch did not retry")
+		}
+	}
+	assert.Equal(t, int32(1), cancellations.Load())
+}
+
+func TestDeadManSwitchHeartbeat(t *testing.T) {
+	var cancellations atomic.Int32
+	c := newOrdersRelayer(t, 2, &cancellations)
+
+	assert.NoError(t, c.EnableDeadManSwitch(50*time.Millisecond, nil))
+	for i := 0; i < 10; i++ {
+		time.Sleep(10 * time.Millisecond)
+		c.Heartbeat()
+	}
+	c.DisableDeadManSwitch()
+
+	assert.Equal(t, int32(0), cancellations.Load())
+}
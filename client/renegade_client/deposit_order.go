@@ -0,0 +1,99 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// DepositAndPlaceOrder deposits funds and places an order that sells them,
+// the usual first steps of a new wallet.
+//
+// Both the deposit's balance slot and the order's slot are checked against the
+// back of the queue wallet before any approval is sent, so that a full wallet
+// fails with a *wallet.CapacityError without spending gas. The order placement
+// is then enqueued directly behind the deposit, built on the wallet the
+// deposit leaves, rather than after waiting for the deposit to settle; the
+// relayer processes the two in order. The method waits for both tasks and
+// returns the resulting wallet.
+//
+// The order must sell the deposited mint: its quote mint if it buys, its base
+// mint if it sells. If the order cannot be placed once the deposit has been
+// enqueued, e.g. because a concurrent update took the last order slot, the
+// deposit still completes and the returned error names its task
+func (c *RenegadeClient) DepositAndPlaceOrder(
+	mint string, amount *big.Int, order *wallet.Order, ethPrivateKey *ecdsa.PrivateKey,
+) (*wallet.Wallet, error) {
+	if err := c.checkDepositAndOrder(mint, amount, order); err != nil {
+		return nil, err
+	}
+
+	req, err := c.setupDeposit(mint, amount, ethPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup deposit: %w", err)
+	}
+
+	if err := c.depositAndPlaceOrder(req, amount, order); err != nil {
+		return nil, err
+	}
+	return c.GetWallet()
+}
+
+// checkDepositAndOrder checks that the order sells the deposited mint, that
+// the relayer accepts both, and that the back of the queue wallet has room
+// for them
+func (c *RenegadeClient) checkDepositAndOrder(mint string, amount *big.Int, order *wallet.Order) error {
+	mintScalar, err := new(wallet.Scalar).FromHexString(mint)
+	if err != nil {
+		return fmt.Errorf("invalid mint %s: %w", mint, err)
+	}
+	sellMint := order.BaseMint
+	if order.Side.IsZero() {
+		sellMint = order.QuoteMint
+	}
+	if sellMint != mintScalar {
+		return fmt.Errorf("order sells %s, not the deposited mint %s", sellMint.ToHexString(), mint)
+	}
+
+	if err := c.checkMinDeposit(mint, amount); err != nil {
+		return err
+	}
+	if err := c.checkMinOrder(order); err != nil {
+		return err
+	}
+	if err := c.checkDepositCapacity(mint); err != nil {
+		return err
+	}
+	backOfQueueWallet, err := c.getBackOfQueueWallet()
+	if err != nil {
+		return err
+	}
+	return backOfQueueWallet.CheckOrderCapacity()
+}
+
+// depositAndPlaceOrder enqueues a deposit and the placement of an order behind
+// it, then waits for both tasks
+func (c *RenegadeClient) depositAndPlaceOrder(
+	req *api_types.DepositRequest, amount *big.Int, order *wallet.Order,
+) error {
+	depositTaskID, err := c.enqueueDeposit(req, amount)
+	if err != nil {
+		return err
+	}
+
+	orderTaskID, err := c.submitPlaceOrder(order)
+	if err != nil {
+		return fmt.Errorf("deposit enqueued in task %s, but placing the order failed: %w", depositTaskID, err)
+	}
+
+	if err := c.waitForTask(depositTaskID); err != nil {
+		return fmt.Errorf("deposit failed: %w", err)
+	}
+	if err := c.waitForTask(orderTaskID); err != nil {
+		return fmt.Errorf("order placement failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// testSellOrder builds an order selling the base mint 0x01 for the quote mint
+// 0x02
+func testSellOrder() *wallet.Order {
+	order := wallet.NewOrderBuilder().
+		WithBaseMintHex("0x01").
+		WithQuoteMintHex("0x02").
+		WithSide(wallet.Sell).
+		WithAmountBigInt(big.NewInt(100)).
+		Build()
+	return &order
+}
+
+func TestDepositAndPlaceOrderChecksBeforeApproval(t *testing.T) {
+	var cancellations atomic.Int32
+
+	// The order must sell the deposited mint
+	c := newOrdersRelayer(t, 0, &cancellations)
+	_, err := c.DepositAndPlaceOrder("0x02", big.NewInt(100), testSellOrder(), nil /* ethPrivateKey */)
+	assert.ErrorContains(t, err, "not the deposited mint")
+
+	// A wallet without an order slot is refused before any approval is sent,
+	// which would fail without a key
+	c = newOrdersRelayer(t, wallet.MaxOrders, &cancellations)
+	_, err = c.DepositAndPlaceOrder("0x01", big.NewInt(100), testSellOrder(), nil /* ethPrivateKey */)
+	var capacityErr *wallet.CapacityError
+	require.True(t, errors.As(err, &capacityErr), err)
+	assert.Equal(t, "orders", capacityErr.Resource)
+}
+
+func TestDepositAndPlaceOrderEnqueuesOrderBehindDeposit(t *testing.T) {
+	key, emptyWallet := newTestWallet(t)
+	apiWallet := toTestApiWallet(t, emptyWallet)
+
+	// The wallet the deposit leaves at the back of the queue
+	require.NoError(t, emptyWallet.AddBalance(
+		wallet.NewBalanceBuilder().WithMintHex("0x01").WithAmountBigInt(big.NewInt(100)).Build(),
+	))
+	depositedWallet := toTestApiWallet(t, emptyWallet)
+
+	// The relayer records the updates posted, and whether the order was built
+	// on the wallet the deposit leaves
+	var mu sync.Mutex
+	var posted []string
+	var depositQueued, orderBuiltOnDeposit bool
+	var tasks []api_types.ApiHistoricalTask
+	c := newTestClient(t, key, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		taskID := uuid.New()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/back-of-queue"):
+			backOfQueue := apiWallet
+			if depositQueued {
+				backOfQueue = depositedWallet
+				orderBuiltOnDeposit = true
+			}
+			_ = json.NewEncoder(w).Encode(api_types.GetWalletResponse{Wallet: *backOfQueue})
+			return
+		case strings.HasSuffix(r.URL.Path, "/task-history"):
+			_ = json.NewEncoder(w).Encode(api_types.TaskHistoryResponse{Tasks: tasks})
+			return
+		case strings.HasSuffix(r.URL.Path, "/balances/deposit"):
+			depositQueued = true
+			posted = append(posted, "deposit")
+			_ = json.NewEncoder(w).Encode(api_types.DepositResponse{TaskId: taskID})
+		case strings.HasSuffix(r.URL.Path, "/orders"):
+			posted = append(posted, "order")
+			_ = json.NewEncoder(w).Encode(api_types.CreateOrderResponse{TaskId: taskID})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// Tasks complete as soon as they are enqueued
+		tasks = append(tasks, api_types.ApiHistoricalTask{Id: taskID, State: "Completed"})
+	})
+
+	req := &api_types.DepositRequest{
+		Mint:            "0x01",
+		Amount:          "100",
+		PermitNonce:     "1",
+		PermitDeadline:  "1900000000",
+		PermitSignature: "sig",
+	}
+	require.NoError(t, c.depositAndPlaceOrder(req, big.NewInt(100), testSellOrder()))
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"deposit", "order"}, posted)
+	assert.True(t, orderBuiltOnDeposit)
+}
+
+func TestDepositWithoutPermitRejectedLocally(t *testing.T) {
+	var cancellations atomic.Int32
+	c := newOrdersRelayer(t, 0, &cancellations)
+
+	req := &api_types.DepositRequest{Mint: "0x01", Amount: "100"}
+	_, err := c.enqueueDeposit(req, big.NewInt(100))
+	assert.ErrorIs(t, err, ErrMissingDepositPermit)
+}
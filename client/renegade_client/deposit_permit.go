@@ -0,0 +1,175 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/renegade-fi/golang-sdk/abis"
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// ErrInvalidPermitSignature is returned when an externally produced deposit
+// signature does not recover to the permit's depositor
+var ErrInvalidPermitSignature = errors.New("invalid permit signature")
+
+// ErrMissingDepositPermit is returned when a deposit request lacks the signed
+// Permit2 permit the relayer pulls the deposit through
+var ErrMissingDepositPermit = errors.New("deposit request has no signed permit")
+
+// DepositPermit is an unsigned Permit2 permit authorizing a deposit into the
+// client's wallet, for signing outside of the client, e.g. by a front-end or
+// hardware wallet
+type DepositPermit struct {
+	// FromAddr is the address the deposit is pulled from, which must sign the permit
+	FromAddr common.Address
+	// Permit is the permit to sign
+	Permit PermitWitnessTransferFrom
+	// Domain is the EIP-712 domain the permit is signed under
+	Domain EIP712Domain
+}
+
+// NewDepositPermit builds an unsigned Permit2 permit for depositing the given
+// amount of the mint from the given address.
+//
+// The permit commits to the client's wallet through its witness, and is
+// signed with a fresh random nonce and no deadline
+func (c *RenegadeClient) NewDepositPermit(
+	mint string, amount *big.Int, fromAddr common.Address,
+) (*DepositPermit, error) {
+	// Construct the EIP712 domain
+	permit2Address := common.HexToAddress(c.chainConfig.Permit2Address)
+	chainID := big.NewInt(int64(c.chainConfig.ChainID)) //nolint:gosec
+	domain := ConstructEIP712Domain(chainID, permit2Address)
+
+	// Create the TokenPermissions struct
+	tokenPermissions := abis.ISignatureTransferTokenPermissions{
+		Token:  common.HexToAddress(mint),
+		Amount: amount,
+	}
+
+	// Generate nonce and deadline
+	nonce, err := randomU256()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	deadline := new(big.Int).SetUint64(^uint64(0))
+
+	witness, err := c.getPermitWitness()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate witness: %w", err)
+	}
+
+	return &DepositPermit{
+		FromAddr: fromAddr,
+		Permit: PermitWitnessTransferFrom{
+			Permitted: tokenPermissions,
+			Spender:   common.HexToAddress(c.chainConfig.DarkpoolAddress),
+			Nonce:     nonce,
+			Deadline:  deadline,
+			Witness:   witness,
+		},
+		Domain: domain,
+	}, nil
+}
+
+// SigningHash returns the EIP-712 hash of the permit
+func (p *DepositPermit) SigningHash() (common.Hash, error) {
+	return getPermitSigningHash(p.Permit, p.Domain)
+}
+
+// EIP712Hashes returns the permit's domain separator and struct hash, which
+// hardware wallets sign in place of the full typed data, e.g. in the Ledger
+// Ethereum app's hashed EIP-712 mode
+func (p *DepositPermit) EIP712Hashes() (domainSeparator, structHash common.Hash) {
+	return p.Domain.Hash(), getPermitStructHash(p.Permit)
+}
+
+// TypedData returns the EIP-712 typed data of the permit
+func (p *DepositPermit) TypedData() *TypedData {
+	return p.Permit.TypedData(p.Domain)
+}
+
+// TypedDataJSON returns the EIP-712 typed data of the permit serialized as
+// the JSON payload of an `eth_signTypedData_v4` request
+func (p *DepositPermit) TypedDataJSON() ([]byte, error) {
+	return json.Marshal(p.TypedData())
+}
+
+// DepositWithSignature deposits funds into the wallet using a permit signed
+// outside of the client.
+//
+// The signature must be a 65 byte secp256k1 signature by the permit's
+// depositor over its signing hash; a recovery id of either 0/1 or 27/28 is
+// accepted. The depositor must already have approved the Permit2 contract to
+// spend the deposited amount, as the client holds no key to do so.
+func (c *RenegadeClient) DepositWithSignature(
+	permit *DepositPermit, signature []byte,
+) (*wallet.Wallet, error) {
+	sig, err := normalizePermitSignature(permit, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := permit.Permit.Permitted.Amount
+	if err := c.checkMinDeposit(permit.Permit.Permitted.Token.Hex(), amount); err != nil {
+		return nil, err
+	}
+	req := &api_types.DepositRequest{
+		FromAddr:        permit.FromAddr.Hex(),
+		Mint:            permit.Permit.Permitted.Token.Hex(),
+		Amount:          amount.String(),
+		PermitNonce:     permit.Permit.Nonce.String(),
+		PermitDeadline:  permit.Permit.Deadline.String(),
+		PermitSignature: base64.RawStdEncoding.EncodeToString(sig),
+	}
+
+	if err := c.submitDeposit(req, amount, true /* blocking */); err != nil {
+		return nil, err
+	}
+	return c.GetWallet()
+}
+
+// --- Helpers --- //
+
+// normalizePermitSignature checks that the signature recovers to the permit's
+// depositor and returns it with the recovery id offset by 27, as Permit2 expects
+func normalizePermitSignature(permit *DepositPermit, signature []byte) ([]byte, error) {
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf(
+			"%w: expected %d bytes, got %d",
+			ErrInvalidPermitSignature, crypto.SignatureLength, len(signature),
+		)
+	}
+
+	sig := make([]byte, len(signature))
+	copy(sig, signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	hash, err := permit.SigningHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signing hash: %w", err)
+	}
+
+	pubkey, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPermitSignature, err)
+	}
+	if signer := crypto.PubkeyToAddress(*pubkey); signer != permit.FromAddr {
+		return nil, fmt.Errorf(
+			"%w: signed by %s, expected %s",
+			ErrInvalidPermitSignature, signer.Hex(), permit.FromAddr.Hex(),
+		)
+	}
+
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// WalletSource is how EnsureWallet obtained the wallet
+type WalletSource string
+
+const (
+	// WalletSourceRelayer indicates the relayer already managed the wallet
+	WalletSourceRelayer WalletSource = "relayer"
+	// WalletSourceLookup indicates the wallet was recovered from on-chain
+	// state into the relayer
+	WalletSourceLookup WalletSource = "lookup"
+	// WalletSourceCreated indicates the wallet had never existed and was
+	// created
+	WalletSourceCreated WalletSource = "created"
+)
+
+// EnsureWallet returns the client's wallet, bootstrapping it in the relayer
+// if needed, and reports which path was taken.
+//
+// The wallet is fetched from the relayer if it manages it. Otherwise it is
+// looked up from on-chain state, e.g. after a relayer restart, and only if the
+// lookup finds no wallet is a new one created. Failures that do not show the
+// wallet to be missing, e.g. an unreachable relayer, are returned rather than
+// falling through to creation, so that an existing wallet is never replaced
+func (c *RenegadeClient) EnsureWallet() (*wallet.Wallet, WalletSource, error) {
+	w, err := c.getWallet()
+	if err == nil {
+		return w, WalletSourceRelayer, nil
+	}
+	if !isWalletNotFound(err) {
+		return nil, "", err
+	}
+
+	err = c.lookupWallet(true /* blocking */)
+	if err == nil {
+		w, err = c.getWallet()
+		if err != nil {
+			return nil, "", err
+		}
+		return w, WalletSourceLookup, nil
+	}
+	if !errors.Is(err, ErrTaskFailed) && !isWalletNotFound(err) {
+		return nil, "", err
+	}
+
+	if err := c.createWallet(true /* blocking */); err != nil {
+		return nil, "", err
+	}
+	w, err = c.getWallet()
+	if err != nil {
+		return nil, "", err
+	}
+	return w, WalletSourceCreated, nil
+}
+
+// isWalletNotFound returns whether the relayer rejected a request because it
+// has no record of the wallet
+func isWalletNotFound(err error) bool {
+	var statusErr *client.StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.StatusCode == http.StatusNotFound || statusErr.Code() == client.CodeWalletNotFound
+}
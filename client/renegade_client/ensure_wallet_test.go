@@ -0,0 +1,132 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// bootstrapRelayer is a fake relayer tracking whether it manages a wallet
+// and whether the wallet exists on-chain
+type bootstrapRelayer struct {
+	mu        sync.Mutex
+	managed   bool
+	onChain   bool
+	creates   atomic.Int32
+	tasks     map[uuid.UUID]string
+	apiWallet *api_types.ApiWallet
+}
+
+// newBootstrapRelayer creates a client backed by a fake relayer in the given
+// state
+func newBootstrapRelayer(t *testing.T, managed, onChain bool) (*RenegadeClient, *bootstrapRelayer) {
+	key, emptyWallet := newTestWallet(t)
+	relayer := &bootstrapRelayer{
+		managed:   managed,
+		onChain:   onChain,
+		tasks:     make(map[uuid.UUID]string),
+		apiWallet: toTestApiWallet(t, emptyWallet),
+	}
+	return newTestClient(t, key, relayer.serve), relayer
+}
+
+// serve handles the wallet, lookup, creation, and task endpoints
+func (r *bootstrapRelayer) serve(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path := req.URL.Path
+	switch {
+	case req.Method == http.MethodPost && path == api_types.LookupWalletPath:
+		state := "Failed"
+		if r.onChain {
+			state = "Completed"
+			r.managed = true
+		}
+		_ = json.NewEncoder(w).Encode(api_types.LookupWalletResponse{TaskId: r.newTask(state)})
+	case req.Method == http.MethodPost && path == api_types.CreateWalletPath:
+		r.creates.Add(1)
+		r.managed, r.onChain = true, true
+		_ = json.NewEncoder(w).Encode(api_types.CreateWalletResponse{TaskId: r.newTask("Completed")})
+	case strings.HasSuffix(path, "/task-history"):
+		var resp api_types.TaskHistoryResponse
+		for id, state := range r.tasks {
+			resp.Tasks = append(resp.Tasks, api_types.ApiHistoricalTask{Id: id, State: state})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	case strings.HasPrefix(path, "/v0/tasks/"):
+		id := uuid.MustParse(strings.TrimPrefix(path, "/v0/tasks/"))
+		_ = json.NewEncoder(w).Encode(api_types.TaskResponse{Status: api_types.ApiTaskStatus{ID: id, State: r.tasks[id]}})
+	case strings.HasPrefix(path, "/v0/wallet/") && r.managed:
+		_ = json.NewEncoder(w).Encode(api_types.GetWalletResponse{Wallet: *r.apiWallet})
+	default:
+		http.Error(w, "wallet not found", http.StatusNotFound)
+	}
+}
+
+// newTask records a task in the given state
+func (r *bootstrapRelayer) newTask(state string) uuid.UUID {
+	id := uuid.New()
+	r.tasks[id] = state
+	return id
+}
+
+func TestEnsureWalletPaths(t *testing.T) {
+	cases := []struct {
+		name    string
+		managed bool
+		onChain bool
+		source  WalletSource
+	}{
+		{"managed", true, true, WalletSourceRelayer},
+		{"on-chain only", false, true, WalletSourceLookup},
+		{"never existed", false, false, WalletSourceCreated},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, relayer := newBootstrapRelayer(t, tc.managed, tc.onChain)
+
+			w, source, err := c.EnsureWallet()
+			require.NoError(t, err)
+			assert.NotNil(t, w)
+			assert.Equal(t, tc.source, source)
+
+			// The wallet is only created if it never existed
+			expectedCreates := int32(0)
+			if tc.source == WalletSourceCreated {
+				expectedCreates = 1
+			}
+			assert.Equal(t, expectedCreates, relayer.creates.Load())
+
+			// Once bootstrapped, the relayer serves the wallet
+			_, source, err = c.EnsureWallet()
+			require.NoError(t, err)
+			assert.Equal(t, WalletSourceRelayer, source)
+		})
+	}
+}
+
+func TestEnsureWalletDoesNotCreateOnOtherFailures(t *testing.T) {
+	var creates atomic.Int32
+	key, _ := newTestWallet(t)
+	c := newTestClient(t, key, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api_types.CreateWalletPath {
+			creates.Add(1)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, _, err := c.EnsureWallet()
+	assert.Error(t, err)
+	assert.Zero(t, creates.Load())
+}
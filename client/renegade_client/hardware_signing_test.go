@@ -0,0 +1,65 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+	"github.com/renegade-fi/golang-sdk/wallet/ledger"
+)
+
+// TestLedgerSignsDepositPermits checks that a Ledger signs deposit permits in
+// the Ethereum app's hashed EIP-712 mode, producing a signature the client
+// accepts for DepositWithSignature
+func TestLedgerSignsDepositPermits(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	device := ledger.NewDevice(ledger.NewEmulator(key), ledger.DefaultDerivationPath)
+	from, err := device.Address()
+	assert.NoError(t, err)
+
+	permit := testDepositPermit(from)
+	sig, err := device.SignTypedDataHash(permit.EIP712Hashes())
+	assert.NoError(t, err)
+
+	normalized, err := normalizePermitSignature(permit, sig)
+	assert.NoError(t, err)
+	assert.Equal(t, sig, normalized)
+}
+
+// TestLedgerCannotSignCommitments documents why a wallet's root key cannot be
+// held on a Ledger: the relayer verifies commitment signatures over the raw
+// keccak256 digest, while the device only signs prefixed payloads
+func TestLedgerCannotSignCommitments(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	device := ledger.NewDevice(ledger.NewEmulator(key), ledger.DefaultDerivationPath)
+
+	commitment, err := wallet.RandomScalar()
+	assert.NoError(t, err)
+	digest := crypto.Keccak256(commitment.ToBigInt().Bytes())
+
+	// The software signer's signature recovers to the root key from the digest
+	rootKey := wallet.PrivateSigningKey(*key)
+	sig, err := wallet.NewSecp256k1CommitmentSigner(&rootKey).SignCommitment(commitment)
+	assert.NoError(t, err)
+	pubkey, err := crypto.SigToPub(digest, sig)
+	assert.NoError(t, err)
+	assert.Equal(t, key.PublicKey, *pubkey)
+
+	// The closest the device offers, a personal signature over the digest,
+	// does not
+	sig, err = device.SignPersonalMessage(digest)
+	assert.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] -= 27
+	pubkey, err = crypto.SigToPub(digest, sig)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key.PublicKey, *pubkey)
+
+	pubkey, err = crypto.SigToPub(accounts.TextHash(digest), sig)
+	assert.NoError(t, err)
+	assert.Equal(t, key.PublicKey, *pubkey)
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// newTestWallet generates a root key and the empty wallet derived from it
+func newTestWallet(t testing.TB) (*ecdsa.PrivateKey, *wallet.Wallet) {
+	key, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	require.NoError(t, err)
+	w, err := wallet.NewEmptyWallet(key, ArbitrumSepoliaConfig.ChainID)
+	require.NoError(t, err)
+	return key, w
+}
+
+// toTestApiWallet converts a wallet into the form the relayer serves it in
+func toTestApiWallet(t testing.TB, w *wallet.Wallet) *api_types.ApiWallet {
+	apiWallet, err := new(api_types.ApiWallet).FromWallet(w)
+	require.NoError(t, err)
+	return apiWallet
+}
+
+// newTestClient creates a client for the given key, backed by a fake relayer
+// serving the given handler
+func newTestClient(t testing.TB, key *ecdsa.PrivateKey, handler http.HandlerFunc) *RenegadeClient {
+	return newTestClientWithConfig(t, key, ArbitrumSepoliaConfig, handler)
+}
+
+// newTestClientWithConfig creates a client for the given key and chain,
+// backed by a fake relayer serving the given handler
+func newTestClientWithConfig(
+	t testing.TB, key *ecdsa.PrivateKey, config ChainConfig, handler http.HandlerFunc,
+) *RenegadeClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := NewRenegadeClientWithConfig(server.URL, key, config)
+	require.NoError(t, err)
+	return c
+}
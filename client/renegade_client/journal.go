@@ -0,0 +1,180 @@
+package client
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// Task and wallet update types recognized in the task history
+const (
+	taskTypeUpdateWallet  = "updatewallet"
+	taskTypeSettleMatch   = "settlematch"
+	taskTypePayOfflineFee = "payofflinefee"
+	updateTypeDeposit     = "deposit"
+	updateTypeWithdraw    = "withdraw"
+	updateTypePlaceOrder  = "placeorder"
+	updateTypeCancelOrder = "cancelorder"
+)
+
+// WalletEvent is an entry in a wallet's event journal, one of
+// DepositCompleted, WithdrawalCompleted, OrderPlaced, OrderCancelled,
+// MatchSettled, or FeesPaid
+type WalletEvent interface {
+	// EventTaskID returns the ID of the task that produced the event
+	EventTaskID() uuid.UUID
+	// EventTime returns the time at which the event's task was created
+	EventTime() time.Time
+}
+
+// EventHeader holds the fields common to all wallet events
+type EventHeader struct {
+	// TaskID is the ID of the task that produced the event
+	TaskID uuid.UUID
+	// Timestamp is the time at which the event's task was created
+	Timestamp time.Time
+}
+
+// EventTaskID returns the ID of the task that produced the event
+func (h EventHeader) EventTaskID() uuid.UUID {
+	return h.TaskID
+}
+
+// EventTime returns the time at which the event's task was created
+func (h EventHeader) EventTime() time.Time {
+	return h.Timestamp
+}
+
+// DepositCompleted records a deposit into the wallet
+type DepositCompleted struct {
+	EventHeader
+	Mint   string
+	Amount *big.Int
+}
+
+// WithdrawalCompleted records a withdrawal from the wallet
+type WithdrawalCompleted struct {
+	EventHeader
+	Mint   string
+	Amount *big.Int
+}
+
+// OrderPlaced records an order placed in the wallet
+type OrderPlaced struct {
+	EventHeader
+	Order api_types.ApiOrder
+}
+
+// OrderCancelled records an order cancelled in the wallet
+type OrderCancelled struct {
+	EventHeader
+	Order api_types.ApiOrder
+}
+
+// MatchSettled records a match settled against one of the wallet's orders
+type MatchSettled struct {
+	EventHeader
+	BaseMint  string
+	QuoteMint string
+	// IsSell is set if the wallet sold the base token
+	IsSell bool
+	// Volume is the amount of the base token traded
+	Volume *big.Int
+}
+
+// FeesPaid records a fee paid from the wallet
+type FeesPaid struct {
+	EventHeader
+	Mint   string
+	Amount *big.Int
+	// IsProtocol is set if the fee was paid to the protocol, rather than the
+	// relayer
+	IsProtocol bool
+}
+
+// BuildWalletJournal converts task history entries into the wallet's event
+// journal, ordered by time.
+//
+// Only completed tasks produce events; tasks that do not change the wallet's
+// holdings or orders, e.g. wallet creation, are skipped
+func BuildWalletJournal(tasks []api_types.ApiHistoricalTask) []WalletEvent {
+	sorted := make([]api_types.ApiHistoricalTask, len(tasks))
+	copy(sorted, tasks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt < sorted[j].CreatedAt
+	})
+
+	events := make([]WalletEvent, 0, len(sorted))
+	for i := range sorted {
+		if !strings.EqualFold(sorted[i].State, taskCompletedStatus) {
+			continue
+		}
+		if event := taskToEvent(&sorted[i]); event != nil {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// GetWalletJournal builds the wallet's event journal from its full task
+// history, see BuildWalletJournal
+func (c *RenegadeClient) GetWalletJournal() ([]WalletEvent, error) {
+	var tasks []api_types.ApiHistoricalTask
+	iter := c.TaskHistoryIter(NewTaskHistoryOptions().WithStates("Completed"))
+	for iter.Next() {
+		tasks = append(tasks, iter.Task())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	return BuildWalletJournal(tasks), nil
+}
+
+// taskToEvent converts a completed task into an event, returning nil if the
+// task produces no event
+func taskToEvent(task *api_types.ApiHistoricalTask) WalletEvent {
+	header := EventHeader{
+		TaskID:    task.Id,
+		Timestamp: time.UnixMilli(int64(task.CreatedAt)),
+	}
+	info := &task.TaskInfo
+
+	switch strings.ToLower(info.TaskType) {
+	case taskTypeUpdateWallet:
+		switch strings.ToLower(info.UpdateType) {
+		case updateTypeDeposit:
+			return DepositCompleted{header, info.Mint, amountToBigInt(info.Amount)}
+		case updateTypeWithdraw:
+			return WithdrawalCompleted{header, info.Mint, amountToBigInt(info.Amount)}
+		case updateTypePlaceOrder:
+			if info.Order != nil {
+				return OrderPlaced{header, *info.Order}
+			}
+		case updateTypeCancelOrder:
+			if info.Order != nil {
+				return OrderCancelled{header, *info.Order}
+			}
+		}
+	case taskTypeSettleMatch:
+		return MatchSettled{header, info.Base, info.Quote, info.IsSell, amountToBigInt(info.Volume)}
+	case taskTypePayOfflineFee:
+		return FeesPaid{header, info.Mint, amountToBigInt(info.Amount), info.IsProtocol}
+	}
+
+	return nil
+}
+
+// amountToBigInt converts an optional amount to a big.Int, zero if unset
+func amountToBigInt(amount *api_types.Amount) *big.Int {
+	if amount == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set((*big.Int)(amount))
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+const journalTestHistory = `{"tasks": [
+	{"id": "00000000-0000-0000-0000-000000000004", "state": "Completed", "created_at": 4000,
+	 "task_info": {"task_type": "SettleMatch", "base": "0x01", "quote": "0x02", "is_sell": true, "volume": 40}},
+	{"id": "00000000-0000-0000-0000-000000000001", "state": "Completed", "created_at": 1000,
+	 "task_info": {"task_type": "NewWallet"}},
+	{"id": "00000000-0000-0000-0000-000000000002", "state": "Completed", "created_at": 2000,
+	 "task_info": {"task_type": "UpdateWallet", "update_type": "Deposit", "mint": "0x01", "amount": 100}},
+	{"id": "00000000-0000-0000-0000-000000000003", "state": "Completed", "created_at": 3000,
+	 "task_info": {"task_type": "UpdateWallet", "update_type": "PlaceOrder",
+	               "order": {"id": "00000000-0000-0000-0000-0000000000aa", "base_mint": "0x01",
+	                         "quote_mint": "0x02", "amount": 50, "side": "Sell"}}},
+	{"id": "00000000-0000-0000-0000-000000000005", "state": "Failed", "created_at": 5000,
+	 "task_info": {"task_type": "UpdateWallet", "update_type": "Withdraw", "mint": "0x02", "amount": 7}},
+	{"id": "00000000-0000-0000-0000-000000000006", "state": "Completed", "created_at": 6000,
+	 "task_info": {"task_type": "PayOfflineFee", "mint": "0x02", "amount": 3, "is_protocol": true}}
+]}`
+
+func TestBuildWalletJournal(t *testing.T) {
+	var history api_types.TaskHistoryResponse
+	assert.NoError(t, json.Unmarshal([]byte(journalTestHistory), &history))
+
+	events := BuildWalletJournal(history.Tasks)
+	assert.Len(t, events, 4)
+
+	deposit, ok := events[0].(DepositCompleted)
+	assert.True(t, ok)
+	assert.Equal(t, "0x01", deposit.Mint)
+	assert.Equal(t, big.NewInt(100), deposit.Amount)
+	assert.Equal(t, time.UnixMilli(2000), deposit.EventTime())
+
+	placed, ok := events[1].(OrderPlaced)
+	assert.True(t, ok)
+	assert.Equal(t, "Sell", placed.Order.Side)
+
+	settled, ok := events[2].(MatchSettled)
+	assert.True(t, ok)
+	assert.True(t, settled.IsSell)
+	assert.Equal(t, big.NewInt(40), settled.Volume)
+
+	// The failed withdrawal produces no event
+	fees, ok := events[3].(FeesPaid)
+	assert.True(t, ok)
+	assert.True(t, fees.IsProtocol)
+	assert.Equal(t, big.NewInt(3), fees.Amount)
+}
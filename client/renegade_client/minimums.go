@@ -0,0 +1,189 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// minimumsTTL is how long minimums, or the failure to load them, are reused
+// before they are loaded again
+const minimumsTTL = 10 * time.Minute
+
+// ErrBelowMinimum matches every *MinimumError with errors.Is
+var ErrBelowMinimum = errors.New("amount is below the relayer's minimum")
+
+// MinimumError is returned when a deposit or order is smaller than the
+// relayer accepts for its token. It is returned before the wallet update is
+// built and signed, rather than by a task the relayer fails
+type MinimumError struct {
+	// Resource is what was refused, "deposit" or "order"
+	Resource string
+	// Mint is the token of the deposit, or the base token of the order
+	Mint string
+	// Amount is the amount refused
+	Amount *big.Int
+	// Minimum is the smallest amount the relayer accepts
+	Minimum *big.Int
+}
+
+// Error implements the error interface
+func (e *MinimumError) Error() string {
+	return fmt.Sprintf(
+		"%s of %s %s is below the relayer's minimum of %s", e.Resource, e.Amount, e.Mint, e.Minimum,
+	)
+}
+
+// Unwrap allows matching the error against ErrBelowMinimum
+func (e *MinimumError) Unwrap() error {
+	return ErrBelowMinimum
+}
+
+// TokenMinimums are the smallest deposit and order amounts the relayer
+// accepts for a token
+type TokenMinimums struct {
+	// Mint is the token's address
+	Mint string
+	// MinDepositAmount is the smallest deposit accepted, in the token's
+	// smallest denomination; nil if any amount is accepted
+	MinDepositAmount *big.Int
+	// MinOrderAmount is the smallest order accepted with the token as its
+	// base, in the token's smallest denomination; nil if any amount is
+	// accepted
+	MinOrderAmount *big.Int
+}
+
+// MinimumsSource loads the relayer's per-token minimums, e.g. from the
+// operator's configuration or an endpoint of their own. The relayer does not
+// serve its minimums, so they must be configured to match it
+type MinimumsSource func() ([]TokenMinimums, error)
+
+// StaticMinimums returns a source of fixed minimums, e.g. read from
+// configuration
+func StaticMinimums(minimums ...TokenMinimums) MinimumsSource {
+	return func() ([]TokenMinimums, error) {
+		return minimums, nil
+	}
+}
+
+// minimumsCache caches the loaded minimums by token
+type minimumsCache struct {
+	// source loads the minimums, nil if no minimums are checked
+	source MinimumsSource
+
+	mu sync.Mutex
+	// byMint are the loaded minimums, empty if loading them failed
+	byMint map[wallet.Scalar]TokenMinimums
+	// loadedAt is the time the minimums were loaded, zero if they must be
+	// loaded again
+	loadedAt time.Time
+	// generation is incremented when the source changes or the cache is
+	// invalidated, so that a load started before is discarded
+	generation uint64
+}
+
+// SetMinimumsSource sets the source of the minimums deposits and orders are
+// checked against before their wallet updates are built and signed. The
+// minimums, or the failure to load them, are cached for ten minutes; while
+// they cannot be loaded, nothing is checked. A nil source disables the checks
+func (c *RenegadeClient) SetMinimumsSource(source MinimumsSource) {
+	c.minimums.mu.Lock()
+	defer c.minimums.mu.Unlock()
+	c.minimums.source = source
+	c.minimums.byMint = nil
+	c.minimums.loadedAt = time.Time{}
+	c.minimums.generation++
+}
+
+// InvalidateMinimums drops the cached minimums, so that the next deposit or
+// order loads them again
+func (c *RenegadeClient) InvalidateMinimums() {
+	c.minimums.mu.Lock()
+	defer c.minimums.mu.Unlock()
+	c.minimums.loadedAt = time.Time{}
+	c.minimums.generation++
+}
+
+// tokenMinimums returns the minimums for a token, loading them if they are
+// not cached. A token without minimums has zero minimums, as does every token
+// while the minimums cannot be loaded: the checks are a courtesy, the relayer
+// enforces its minimums regardless. The lock is not held while loading
+func (c *RenegadeClient) tokenMinimums(mint wallet.Scalar) TokenMinimums {
+	c.minimums.mu.Lock()
+	source, generation := c.minimums.source, c.minimums.generation
+	if source == nil || time.Since(c.minimums.loadedAt) <= minimumsTTL {
+		minimums := c.minimums.byMint[mint]
+		c.minimums.mu.Unlock()
+		return minimums
+	}
+	c.minimums.mu.Unlock()
+
+	// A failure is cached like a success, so that an unavailable source is
+	// not retried on every deposit and order
+	byMint, err := loadMinimums(source)
+	if err != nil {
+		log.Printf("skipping relayer minimum checks: %v", err)
+	}
+
+	c.minimums.mu.Lock()
+	defer c.minimums.mu.Unlock()
+	if c.minimums.generation == generation {
+		c.minimums.byMint = byMint
+		c.minimums.loadedAt = time.Now()
+	}
+	return byMint[mint]
+}
+
+// loadMinimums loads the minimums from the source, keyed by mint
+func loadMinimums(source MinimumsSource) (map[wallet.Scalar]TokenMinimums, error) {
+	tokens, err := source()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load relayer minimums: %w", err)
+	}
+
+	byMint := make(map[wallet.Scalar]TokenMinimums, len(tokens))
+	for _, token := range tokens {
+		mint, err := new(wallet.Scalar).FromHexString(token.Mint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mint %s in relayer minimums: %w", token.Mint, err)
+		}
+		byMint[mint] = token
+	}
+	return byMint, nil
+}
+
+// checkMinDeposit returns a *MinimumError if the deposit is smaller than the
+// relayer accepts
+func (c *RenegadeClient) checkMinDeposit(mint string, amount *big.Int) error {
+	mintScalar, err := new(wallet.Scalar).FromHexString(mint)
+	if err != nil {
+		return fmt.Errorf("invalid mint %s: %w", mint, err)
+	}
+
+	minimums := c.tokenMinimums(mintScalar)
+	if minimums.MinDepositAmount != nil && amount.Cmp(minimums.MinDepositAmount) < 0 {
+		return &MinimumError{Resource: "deposit", Mint: mint, Amount: amount, Minimum: minimums.MinDepositAmount}
+	}
+	return nil
+}
+
+// checkMinOrder returns a *MinimumError if the order is smaller than the
+// relayer accepts for its base token
+func (c *RenegadeClient) checkMinOrder(order *wallet.Order) error {
+	minimums := c.tokenMinimums(order.BaseMint)
+	amount := order.Amount.ToBigInt()
+	if minimums.MinOrderAmount != nil && amount.Cmp(minimums.MinOrderAmount) < 0 {
+		return &MinimumError{
+			Resource: "order",
+			Mint:     minimums.Mint,
+			Amount:   amount,
+			Minimum:  minimums.MinOrderAmount,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// newMinimumsRelayer creates a client backed by a fake relayer that accepts
+// orders, counting the orders posted
+func newMinimumsRelayer(t *testing.T, posts *atomic.Int32) *RenegadeClient {
+	key, emptyWallet := newTestWallet(t)
+	apiWallet := toTestApiWallet(t, emptyWallet)
+
+	return newTestClient(t, key, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/back-of-queue"):
+			_ = json.NewEncoder(w).Encode(api_types.GetWalletResponse{Wallet: *apiWallet})
+		default:
+			posts.Add(1)
+			_ = json.NewEncoder(w).Encode(api_types.CreateOrderResponse{TaskId: uuid.New()})
+		}
+	})
+}
+
+// countingMinimums returns a source of the given minimums, or of the given
+// error, counting its loads
+func countingMinimums(tokens []TokenMinimums, err error, loads *atomic.Int32) MinimumsSource {
+	return func() ([]TokenMinimums, error) {
+		loads.Add(1)
+		return tokens, err
+	}
+}
+
+// testOrder builds a buy order of the given size of base token 0x01
+func testOrder(amount int64) wallet.Order {
+	return wallet.NewOrderBuilder().
+		WithBaseMintHex("0x01").
+		WithQuoteMintHex("0x02").
+		WithSide(wallet.Buy).
+		WithAmountBigInt(big.NewInt(amount)).
+		Build()
+}
+
+func TestOrderBelowMinimumRejectedLocally(t *testing.T) {
+	var loads, posts atomic.Int32
+	c := newMinimumsRelayer(t, &posts)
+	tokens := []TokenMinimums{{Mint: "0x01", MinOrderAmount: big.NewInt(100)}}
+	c.SetMinimumsSource(countingMinimums(tokens, nil /* err */, &loads))
+
+	// An order below the minimum fails before the update is built or posted
+	small := testOrder(99)
+	err := c.placeOrder(&small, false /* blocking */)
+	assert.ErrorIs(t, err, ErrBelowMinimum)
+	var minErr *MinimumError
+	assert.True(t, errors.As(err, &minErr))
+	assert.Equal(t, "order", minErr.Resource)
+	assert.Equal(t, "0x01", minErr.Mint)
+	assert.Equal(t, big.NewInt(100), minErr.Minimum)
+	assert.Equal(t, int32(0), posts.Load())
+
+	// An order at the minimum is placed, reusing the cached minimums
+	order := testOrder(100)
+	assert.NoError(t, c.placeOrder(&order, false /* blocking */))
+	assert.Equal(t, int32(1), posts.Load())
+	assert.Equal(t, int32(1), loads.Load())
+
+	// Invalidating the cache loads the minimums again
+	c.InvalidateMinimums()
+	assert.NoError(t, c.placeOrder(&order, false /* blocking */))
+	assert.Equal(t, int32(2), loads.Load())
+}
+
+func TestDepositBelowMinimumRejectedLocally(t *testing.T) {
+	var posts atomic.Int32
+	c := newMinimumsRelayer(t, &posts)
+	c.SetMinimumsSource(StaticMinimums(TokenMinimums{Mint: "0x01", MinDepositAmount: big.NewInt(1000)}))
+
+	err := c.checkMinDeposit("0x01", big.NewInt(999))
+	var minErr *MinimumError
+	assert.True(t, errors.As(err, &minErr))
+	assert.Equal(t, "deposit", minErr.Resource)
+	assert.Equal(t, "0x01", minErr.Mint)
+	assert.NoError(t, c.checkMinDeposit("0x01", big.NewInt(1000)))
+
+	// Tokens without minimums accept any amount
+	assert.NoError(t, c.checkMinDeposit("0x02", big.NewInt(1)))
+}
+
+func TestNoMinimumsSource(t *testing.T) {
+	var posts atomic.Int32
+	c := newMinimumsRelayer(t, &posts)
+
+	order := testOrder(1)
+	assert.NoError(t, c.placeOrder(&order, false /* blocking */))
+	assert.NoError(t, c.checkMinDeposit("0x01", big.NewInt(1)))
+	assert.Equal(t, int32(1), posts.Load())
+
+	// Unsetting the source disables the checks again
+	c.SetMinimumsSource(StaticMinimums(TokenMinimums{Mint: "0x01", MinDepositAmount: big.NewInt(1000)}))
+	assert.ErrorIs(t, c.checkMinDeposit("0x01", big.NewInt(1)), ErrBelowMinimum)
+	c.SetMinimumsSource(nil)
+	assert.NoError(t, c.checkMinDeposit("0x01", big.NewInt(1)))
+}
+
+func TestMinimumsFailureCached(t *testing.T) {
+	var loads, posts atomic.Int32
+	c := newMinimumsRelayer(t, &posts)
+	c.SetMinimumsSource(countingMinimums(nil /* tokens */, errors.New("unavailable"), &loads))
+
+	// A failed load skips the checks and is not retried within the TTL
+	order := testOrder(1)
+	assert.NoError(t, c.placeOrder(&order, false /* blocking */))
+	assert.NoError(t, c.checkMinDeposit("0x01", big.NewInt(1)))
+	assert.Equal(t, int32(1), loads.Load())
+	assert.Equal(t, int32(1), posts.Load())
+
+	// Invalidating the cache retries the load
+	c.InvalidateMinimums()
+	assert.NoError(t, c.checkMinDeposit("0x01", big.NewInt(1)))
+	assert.Equal(t, int32(2), loads.Load())
+}
@@ -0,0 +1,236 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// ErrNoWallets is returned by a MultiWalletManager that manages no wallets
+var ErrNoWallets = errors.New("no wallets are managed")
+
+// MultiWalletManager manages many isolated wallets derived from one master key
+// by account index, see wallet.DeriveAccountKey. Each wallet has its own
+// RenegadeClient, and so its own queue of wallet updates, while all of them
+// share one HTTP transport and its connection pool
+type MultiWalletManager struct {
+	baseURL     string
+	masterKey   *ecdsa.PrivateKey
+	chainConfig ChainConfig
+	// httpOptions are the options every wallet's client is created with,
+	// holding the shared transport
+	httpOptions *client.HttpClientOptions
+
+	// mu guards the fields below
+	mu sync.RWMutex
+	// clients are the managed wallets' clients, keyed by account index
+	clients map[uint32]*RenegadeClient
+	// indices are the managed account indices in ascending order
+	indices []uint32
+
+	// next is the round robin cursor used to place orders
+	next atomic.Uint64
+}
+
+// NewMultiWalletManager creates a manager for wallets derived from the given
+// master key
+func NewMultiWalletManager(
+	baseURL string, masterKey *ecdsa.PrivateKey, config ChainConfig,
+) *MultiWalletManager {
+	return NewMultiWalletManagerWithOptions(baseURL, masterKey, config, client.NewHttpClientOptions())
+}
+
+// NewMultiWalletManagerWithOptions creates a manager for wallets derived from
+// the given master key, whose clients are created with the given HTTP options.
+// If the options set no transport, one is created and shared by every wallet
+func NewMultiWalletManagerWithOptions(
+	baseURL string, masterKey *ecdsa.PrivateKey, config ChainConfig, options *client.HttpClientOptions,
+) *MultiWalletManager {
+	httpOptions := *options
+	if httpOptions.Transport == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+		transport.ForceAttemptHTTP2 = true
+		httpOptions.Transport = transport
+	}
+
+	return &MultiWalletManager{
+		baseURL:     baseURL,
+		masterKey:   masterKey,
+		chainConfig: config,
+		httpOptions: &httpOptions,
+		clients:     make(map[uint32]*RenegadeClient),
+	}
+}
+
+// AddWallet starts managing the wallet of the given account index, returning
+// its client. Adding a wallet that is already managed returns its client
+func (m *MultiWalletManager) AddWallet(index uint32) (*RenegadeClient, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok := m.clients[index]; ok {
+		return c, nil
+	}
+
+	accountKey, err := wallet.DeriveAccountKey(m.masterKey, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive account %d: %w", index, err)
+	}
+	c, err := NewRenegadeClientWithOptions(m.baseURL, accountKey, m.chainConfig, m.httpOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for account %d: %w", index, err)
+	}
+
+	m.clients[index] = c
+	m.indices = append(m.indices, index)
+	sort.Slice(m.indices, func(i, j int) bool { return m.indices[i] < m.indices[j] })
+	return c, nil
+}
+
+// Wallet returns the client of the wallet with the given account index, if
+// it is managed
+func (m *MultiWalletManager) Wallet(index uint32) (*RenegadeClient, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.clients[index]
+	return c, ok
+}
+
+// Indices returns the managed account indices in ascending order
+func (m *MultiWalletManager) Indices() []uint32 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]uint32(nil), m.indices...)
+}
+
+// Close closes every managed wallet's client, see RenegadeClient.Close
+func (m *MultiWalletManager) Close() {
+	_, clients := m.managedClients()
+	for _, c := range clients {
+		c.Close()
+	}
+}
+
+// Shutdown shuts every managed wallet's client down, see
+// RenegadeClient.Shutdown. Every client is shut down even if one fails to
+// drain; the errors are returned joined
+func (m *MultiWalletManager) Shutdown(ctx context.Context) error {
+	_, clients := m.managedClients()
+	var errs []error
+	for _, c := range clients {
+		if err := c.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// managedClients returns the managed clients in account index order
+func (m *MultiWalletManager) managedClients() ([]uint32, []*RenegadeClient) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	clients := make([]*RenegadeClient, len(m.indices))
+	for i, index := range m.indices {
+		clients[i] = m.clients[index]
+	}
+	return append([]uint32(nil), m.indices...), clients
+}
+
+// AggregatedBalances is a view of the balances of every managed wallet
+type AggregatedBalances struct {
+	// Totals are the summed balances across wallets, keyed by mint
+	Totals map[string]*big.Int
+	// ByWallet are the non-zero balances of each wallet, keyed by account
+	// index
+	ByWallet map[uint32][]wallet.Balance
+}
+
+// GetBalances fetches every managed wallet concurrently and aggregates their
+// balances. A wallet that fails to fetch does not stop the others: the error
+// joins every failure, and the view covers the wallets that were fetched
+func (m *MultiWalletManager) GetBalances() (*AggregatedBalances, error) {
+	indices, clients := m.managedClients()
+	wallets := make([]*wallet.Wallet, len(clients))
+	errs := make([]error, len(clients))
+
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func(i int, c *RenegadeClient) {
+			defer wg.Done()
+			wallets[i], errs[i] = c.GetWallet()
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("account %d: %w", indices[i], errs[i])
+			}
+		}(i, c)
+	}
+	wg.Wait()
+
+	balances := &AggregatedBalances{
+		Totals:   make(map[string]*big.Int),
+		ByWallet: make(map[uint32][]wallet.Balance),
+	}
+	for i, w := range wallets {
+		if w == nil {
+			continue
+		}
+
+		nonzero := w.GetNonzeroBalances()
+		balances.ByWallet[indices[i]] = nonzero
+		for _, balance := range nonzero {
+			mint := mintToAddress(balance.Mint)
+			if balances.Totals[mint] == nil {
+				balances.Totals[mint] = new(big.Int)
+			}
+			balances.Totals[mint].Add(balances.Totals[mint], balance.Amount.ToBigInt())
+		}
+	}
+
+	return balances, errors.Join(errs...)
+}
+
+// PlaceOrder places the order in the managed wallets in round robin order,
+// skipping wallets that have no free order slot. It returns the account index
+// of the wallet the order was placed in and that wallet after the update
+func (m *MultiWalletManager) PlaceOrder(order *wallet.Order) (uint32, *wallet.Wallet, error) {
+	index, c, err := m.placeOrder(order, true /* blocking */)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	w, err := c.GetWallet()
+	return index, w, err
+}
+
+// placeOrder places the order in the next wallet with a free order slot
+func (m *MultiWalletManager) placeOrder(
+	order *wallet.Order, blocking bool,
+) (uint32, *RenegadeClient, error) {
+	indices, clients := m.managedClients()
+	if len(clients) == 0 {
+		return 0, nil, ErrNoWallets
+	}
+
+	start := m.next.Add(1) - 1
+	for i := range clients {
+		slot := int((start + uint64(i)) % uint64(len(clients)))
+		err := clients[slot].placeOrder(order, blocking)
+		if errors.Is(err, wallet.ErrWalletFull) {
+			continue
+		}
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to place order in account %d: %w", indices[slot], err)
+		}
+		return indices[slot], clients[slot], nil
+	}
+
+	return 0, nil, fmt.Errorf("%w: every managed wallet is full", wallet.ErrWalletFull)
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// newMultiWalletRelayer creates a manager of the given number of accounts
+// backed by a fake relayer holding a balance of the given mint in each wallet,
+// and filling the wallets of the full accounts with orders. Orders placed are
+// recorded by wallet ID
+func newMultiWalletRelayer(
+	t *testing.T, numAccounts uint32, mint string, full map[uint32]bool,
+) (*MultiWalletManager, map[uuid.UUID]int) {
+	masterKey, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	assert.NoError(t, err)
+
+	wallets := make(map[string]*api_types.ApiWallet)
+	for index := uint32(0); index < numAccounts; index++ {
+		accountKey, err := wallet.DeriveAccountKey(masterKey, index)
+		assert.NoError(t, err)
+		w, err := wallet.NewEmptyWallet(accountKey, ArbitrumSepoliaConfig.ChainID)
+		assert.NoError(t, err)
+		balance := wallet.NewBalanceBuilder().WithMintHex(mint).WithAmountBigInt(big.NewInt(100)).Build()
+		assert.NoError(t, w.AddBalance(balance))
+		for i := 0; full[index] && i < wallet.MaxOrders; i++ {
+			order := wallet.NewOrderBuilder().WithSide(wallet.Buy).WithAmountBigInt(big.NewInt(1)).Build()
+			assert.NoError(t, w.NewOrder(order))
+		}
+
+		apiWallet, err := new(api_types.ApiWallet).FromWallet(w)
+		assert.NoError(t, err)
+		wallets[w.Id.String()] = apiWallet
+	}
+
+	var mu sync.Mutex
+	placed := make(map[uuid.UUID]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		if len(parts) < 4 || wallets[parts[3]] == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		walletID := parts[3]
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/orders"):
+			mu.Lock()
+			placed[uuid.MustParse(walletID)]++
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(api_types.CreateOrderResponse{TaskId: uuid.New()})
+		default:
+			_ = json.NewEncoder(w).Encode(api_types.GetWalletResponse{Wallet: *wallets[walletID]})
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	m := NewMultiWalletManager(server.URL, masterKey, ArbitrumSepoliaConfig)
+	for index := uint32(0); index < numAccounts; index++ {
+		_, err := m.AddWallet(index)
+		assert.NoError(t, err)
+	}
+	return m, placed
+}
+
+func TestDeriveAccountKeys(t *testing.T) {
+	masterKey, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	assert.NoError(t, err)
+
+	m := NewMultiWalletManager("http://localhost:1", masterKey, ArbitrumSepoliaConfig)
+	first, err := m.AddWallet(0)
+	assert.NoError(t, err)
+	second, err := m.AddWallet(1)
+	assert.NoError(t, err)
+
+	// Account zero is the wallet a plain client derives from the master key
+	plain, err := NewRenegadeClientWithConfig("http://localhost:1", masterKey, ArbitrumSepoliaConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, plain.walletSecrets.Id, first.walletSecrets.Id)
+	assert.NotEqual(t, first.walletSecrets.Id, second.walletSecrets.Id)
+
+	// Wallets share a transport and are added once
+	assert.NotNil(t, m.httpOptions.Transport)
+	again, err := m.AddWallet(1)
+	assert.NoError(t, err)
+	assert.Same(t, second, again)
+	assert.Equal(t, []uint32{0, 1}, m.Indices())
+}
+
+func TestMultiWalletAggregatedBalances(t *testing.T) {
+	mint := "0x000000000000000000000000000000000000000a"
+	m, _ := newMultiWalletRelayer(t, 3, mint, nil /* full */)
+
+	balances, err := m.GetBalances()
+	assert.NoError(t, err)
+	assert.Len(t, balances.ByWallet, 3)
+	assert.Equal(t, big.NewInt(300), balances.Totals[common.HexToAddress(mint).Hex()])
+}
+
+func TestMultiWalletRoundRobinOrders(t *testing.T) {
+	mint := "0x000000000000000000000000000000000000000a"
+	m, placed := newMultiWalletRelayer(t, 3, mint, map[uint32]bool{1: true})
+
+	order := wallet.NewOrderBuilder().
+		WithBaseMintHex(mint).
+		WithQuoteMintHex("0x0b").
+		WithSide(wallet.Sell).
+		WithAmountBigInt(big.NewInt(1)).
+		Build()
+	var indices []uint32
+	for i := 0; i < 4; i++ {
+		index, _, err := m.placeOrder(&order, false /* blocking */)
+		assert.NoError(t, err)
+		indices = append(indices, index)
+	}
+
+	// The full wallet is skipped
+	assert.Equal(t, []uint32{0, 2, 2, 0}, indices)
+	first, _ := m.Wallet(0)
+	assert.Equal(t, 2, placed[first.walletSecrets.Id])
+
+	_, _, err := NewMultiWalletManager("http://localhost:1", nil /* masterKey */, ArbitrumSepoliaConfig).
+		placeOrder(&order, false /* blocking */)
+	assert.ErrorIs(t, err, ErrNoWallets)
+}
+
+func TestMultiWalletClose(t *testing.T) {
+	m, _ := newMultiWalletRelayer(t, 2, "0x01", nil /* full */)
+	m.Close()
+
+	// Every managed client rejects requests once closed
+	for _, index := range m.Indices() {
+		c, ok := m.Wallet(index)
+		assert.True(t, ok)
+		_, err := c.GetWallet()
+		assert.ErrorIs(t, err, client.ErrClientClosed)
+	}
+}
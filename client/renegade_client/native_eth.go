@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/renegade-fi/golang-sdk/abis"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// DepositNativeEth wraps the given amount of native ETH into WETH and
+// deposits the WETH into the wallet.
+//
+// The darkpool only holds erc20 balances, so the deposit is made in the
+// chain's configured WETH token. The wrap transaction is mined before the
+// deposit begins; if the deposit then fails, the wrapped WETH remains in the
+// account of the given key.
+func (c *RenegadeClient) DepositNativeEth(
+	amount *big.Int, ethPrivateKey *ecdsa.PrivateKey,
+) (*wallet.Wallet, error) {
+	// Check the relayer accepts the deposit before wrapping
+	if err := c.checkMinDeposit(c.chainConfig.WethAddress, amount); err != nil {
+		return nil, err
+	}
+	if err := c.wrapEth(amount, ethPrivateKey); err != nil {
+		return nil, err
+	}
+
+	return c.Deposit(c.chainConfig.WethAddress, amount, ethPrivateKey)
+}
+
+// WithdrawAsNativeEth withdraws the given amount of WETH from the wallet to
+// the address of the given key, then unwraps it into native ETH.
+//
+// If the unwrap fails after the withdrawal succeeds, the WETH remains in the
+// account of the given key.
+func (c *RenegadeClient) WithdrawAsNativeEth(
+	amount *big.Int, ethPrivateKey *ecdsa.PrivateKey,
+) (*wallet.Wallet, error) {
+	if c.chainConfig.WethAddress == "" {
+		return nil, fmt.Errorf("no WETH address configured for chain %d", c.chainConfig.ChainID)
+	}
+
+	destination := crypto.PubkeyToAddress(ethPrivateKey.PublicKey).Hex()
+	mint := c.chainConfig.WethAddress
+	if err := c.withdrawToAddress(mint, amount, destination, true /* blocking */); err != nil {
+		return nil, err
+	}
+
+	if err := c.unwrapEth(amount, ethPrivateKey); err != nil {
+		return nil, err
+	}
+
+	return c.GetWallet()
+}
+
+// --- Helpers --- //
+
+// wrapEth wraps the given amount of native ETH into WETH
+func (c *RenegadeClient) wrapEth(amount *big.Int, ethPrivateKey *ecdsa.PrivateKey) error {
+	rpcClient, weth, auth, err := c.setupWethTransaction(ethPrivateKey)
+	if err != nil {
+		return err
+	}
+
+	// Check the native balance covers the amount to wrap
+	bal, err := rpcClient.BalanceAt(context.Background(), auth.From, nil /* blockNumber */)
+	if err != nil {
+		return fmt.Errorf("failed to get ETH balance: %w", err)
+	}
+
+	if bal.Cmp(amount) < 0 {
+		return fmt.Errorf(
+			"insufficient ETH balance to wrap: have %s, need %s",
+			bal.String(), amount.String(),
+		)
+	}
+
+	log.Printf("Wrapping %s wei into WETH", amount.String())
+	auth.Value = amount
+	tx, err := weth.Deposit(auth)
+	if err != nil {
+		return fmt.Errorf("failed to wrap ETH: %w", err)
+	}
+
+	return waitForWethTransaction(rpcClient, tx)
+}
+
+// unwrapEth unwraps the given amount of WETH into native ETH
+func (c *RenegadeClient) unwrapEth(amount *big.Int, ethPrivateKey *ecdsa.PrivateKey) error {
+	rpcClient, weth, auth, err := c.setupWethTransaction(ethPrivateKey)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Unwrapping %s wei of WETH", amount.String())
+	tx, err := weth.Withdraw(auth, amount)
+	if err != nil {
+		return fmt.Errorf("failed to unwrap WETH: %w", err)
+	}
+
+	return waitForWethTransaction(rpcClient, tx)
+}
+
+// setupWethTransaction creates the RPC client, WETH contract, and transactor
+// used to wrap or unwrap ETH
+func (c *RenegadeClient) setupWethTransaction(
+	ethPrivateKey *ecdsa.PrivateKey,
+) (*ethclient.Client, *abis.WETH, *bind.TransactOpts, error) {
+	if c.chainConfig.WethAddress == "" {
+		return nil, nil, nil, fmt.Errorf(
+			"no WETH address configured for chain %d", c.chainConfig.ChainID,
+		)
+	}
+
+	rpcClient, err := c.createRpcClient()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create RPC client: %w", err)
+	}
+
+	weth, err := abis.NewWETH(common.HexToAddress(c.chainConfig.WethAddress), rpcClient)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create WETH contract: %w", err)
+	}
+
+	auth, err := c.createTransactor(ethPrivateKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return rpcClient, weth, auth, nil
+}
+
+// waitForWethTransaction waits for a wrap or unwrap transaction to be mined
+// and checks that it succeeded
+func waitForWethTransaction(rpcClient *ethclient.Client, tx *types.Transaction) error {
+	receipt, err := bind.WaitMined(context.Background(), rpcClient, tx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for WETH transaction: %w", err)
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("WETH transaction reverted: %s", receipt.TxHash.Hex())
+	}
+	log.Printf("WETH transaction hash: %s", receipt.TxHash.Hex())
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+var (
+	// testWethAddress is the WETH contract of the fake chain
+	testWethAddress = common.HexToAddress("0x82aF49447D8a07e3bd95BD0d56f35241523fBab1")
+	// wethDepositSelector and wethWithdrawSelector are the selectors of WETH's
+	// deposit() and withdraw(uint256)
+	wethDepositSelector  = hexutil.MustDecode("0xd0e30db0")
+	wethWithdrawSelector = hexutil.MustDecode("0x2e1a7d4d")
+)
+
+// fakeNode is a fake JSON-RPC node holding a native balance for every
+// account, recording the transactions sent to it
+type fakeNode struct {
+	mu      sync.Mutex
+	balance *big.Int
+	sent    []*types.Transaction
+}
+
+// newFakeNode starts a fake node, returning it with its URL
+func newFakeNode(t *testing.T, balance *big.Int) (*fakeNode, string) {
+	node := &fakeNode{balance: balance}
+	server := httptest.NewServer(http.HandlerFunc(node.serve))
+	t.Cleanup(server.Close)
+	return node, server.URL
+}
+
+// serve answers the JSON-RPC calls made to wrap or unwrap ETH
+func (n *fakeNode) serve(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result interface{}
+	switch req.Method {
+	case "eth_getBalance":
+		result = hexutil.EncodeBig(n.balance)
+	case "eth_getBlockByNumber":
+		// A header without a base fee selects legacy transactions
+		result = map[string]interface{}{
+			"parentHash":       common.Hash{},
+			"sha3Uncles":       types.EmptyUncleHash,
+			"miner":            common.Address{},
+			"stateRoot":        common.Hash{},
+			"transactionsRoot": types.EmptyTxsHash,
+			"receiptsRoot":     types.EmptyReceiptsHash,
+			"logsBloom":        types.Bloom{},
+			"difficulty":       "0x0",
+			"number":           "0x1",
+			"gasLimit":         "0x1c9c380",
+			"gasUsed":          "0x0",
+			"timestamp":        "0x1",
+			"extraData":        "0x",
+		}
+	case "eth_getCode":
+		result = "0x00"
+	case "eth_gasPrice":
+		result = "0x1"
+	case "eth_getTransactionCount":
+		result = hexutil.EncodeUint64(uint64(len(n.sent)))
+	case "eth_estimateGas":
+		result = "0xc350"
+	case "eth_sendRawTransaction":
+		var raw hexutil.Bytes
+		_ = json.Unmarshal(req.Params[0], &raw)
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		n.sent = append(n.sent, tx)
+		result = tx.Hash()
+	case "eth_getTransactionReceipt":
+		var hash common.Hash
+		_ = json.Unmarshal(req.Params[0], &hash)
+		result = map[string]interface{}{
+			"status":            "0x1",
+			"cumulativeGasUsed": "0xc350",
+			"gasUsed":           "0xc350",
+			"logsBloom":         types.Bloom{},
+			"logs":              []interface{}{},
+			"transactionHash":   hash,
+			"blockNumber":       "0x1",
+		}
+	default:
+		http.Error(w, "unsupported method "+req.Method, http.StatusBadRequest)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+}
+
+// sentTransactions returns the transactions sent to the node
+func (n *fakeNode) sentTransactions() []*types.Transaction {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*types.Transaction(nil), n.sent...)
+}
+
+// newNativeEthClient creates a client on a chain whose RPC is the given node,
+// backed by a fake relayer that completes withdrawals, recording their paths
+func newNativeEthClient(t *testing.T, rpcURL string, withdrawals *[]string) *RenegadeClient {
+	key, w := newTestWallet(t)
+	weth := wallet.NewBalanceBuilder().WithMintHex(testWethAddress.Hex()).WithAmountBigInt(big.NewInt(1000)).Build()
+	require.NoError(t, w.AddBalance(weth))
+	apiWallet := toTestApiWallet(t, w)
+
+	config := ArbitrumSepoliaConfig
+	config.EthereumRpcUrl = rpcURL
+	config.WethAddress = testWethAddress.Hex()
+
+	var mu sync.Mutex
+	var tasks []api_types.ApiHistoricalTask
+	return newTestClientWithConfig(t, key, config, func(rw http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/task-history"):
+			_ = json.NewEncoder(rw).Encode(api_types.TaskHistoryResponse{Tasks: tasks})
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(rw).Encode(api_types.GetWalletResponse{Wallet: *apiWallet})
+		default:
+			*withdrawals = append(*withdrawals, r.URL.Path)
+			taskID := uuid.New()
+			tasks = append(tasks, api_types.ApiHistoricalTask{Id: taskID, State: "Completed"})
+			_ = json.NewEncoder(rw).Encode(api_types.WithdrawResponse{TaskId: taskID})
+		}
+	})
+}
+
+func TestWithdrawAsNativeEthUnwrapsAfterWithdrawal(t *testing.T) {
+	node, rpcURL := newFakeNode(t, big.NewInt(0))
+	var withdrawals []string
+	c := newNativeEthClient(t, rpcURL, &withdrawals)
+	ethKey, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = c.WithdrawAsNativeEth(big.NewInt(500), ethKey)
+	require.NoError(t, err)
+
+	// The WETH balance is withdrawn, then unwrapped in a single transaction
+	walletID := c.walletSecrets.Id
+	assert.Equal(t, []string{api_types.BuildWithdrawPath(walletID, testWethAddress.Hex())}, withdrawals)
+	sent := node.sentTransactions()
+	require.Len(t, sent, 1)
+	assert.Equal(t, testWethAddress, *sent[0].To())
+	assert.Equal(t, wethWithdrawSelector, sent[0].Data()[:4])
+	assert.Equal(t, big.NewInt(500), new(big.Int).SetBytes(sent[0].Data()[4:]))
+	assert.Zero(t, sent[0].Value().Sign())
+}
+
+func TestWrapEth(t *testing.T) {
+	node, rpcURL := newFakeNode(t, big.NewInt(1000))
+	var withdrawals []string
+	c := newNativeEthClient(t, rpcURL, &withdrawals)
+	ethKey, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	// Wrapping sends the amount as value to WETH's deposit
+	require.NoError(t, c.wrapEth(big.NewInt(400), ethKey))
+	sent := node.sentTransactions()
+	require.Len(t, sent, 1)
+	assert.Equal(t, testWethAddress, *sent[0].To())
+	assert.Equal(t, wethDepositSelector, sent[0].Data())
+	assert.Equal(t, big.NewInt(400), sent[0].Value())
+
+	// An amount above the native balance is refused before sending
+	_, err = c.DepositNativeEth(big.NewInt(2000), ethKey)
+	assert.ErrorContains(t, err, "insufficient ETH balance")
+	assert.Len(t, node.sentTransactions(), 1)
+}
+
+func TestNativeEthRequiresWethAddress(t *testing.T) {
+	key, _ := newTestWallet(t)
+	c := newTestClient(t, key, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := c.WithdrawAsNativeEth(big.NewInt(1), key)
+	assert.ErrorContains(t, err, "no WETH address")
+	assert.ErrorContains(t, c.wrapEth(big.NewInt(1), key), "no WETH address")
+}
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// OrderDiff is the set of wallet updates that turns a wallet's orders into a
+// set of target orders
+type OrderDiff struct {
+	// Cancel are the IDs of the orders to cancel
+	Cancel []uuid.UUID
+	// Amend are the orders to replace in place, each carrying the ID of the
+	// existing order it replaces
+	Amend []wallet.Order
+	// Place are the orders to place
+	Place []wallet.Order
+	// Unchanged are the IDs of the existing orders that already match a target
+	Unchanged []uuid.UUID
+}
+
+// Updates returns the number of wallet updates the diff requires
+func (d *OrderDiff) Updates() int {
+	return len(d.Cancel) + len(d.Amend) + len(d.Place)
+}
+
+// IsEmpty returns whether the wallet's orders already match the targets
+func (d *OrderDiff) IsEmpty() bool {
+	return d.Updates() == 0
+}
+
+// DiffOrders computes the fewest wallet updates that turn the current orders
+// into the target orders. Each cancellation, amendment and placement is one
+// wallet update, so an existing order is amended rather than cancelled and
+// replaced wherever one is left over.
+//
+// A target matches an existing order with the same ID, or otherwise one with
+// the same pair, side, amount and worst case price, which is left unchanged.
+// Unmatched targets amend unmatched orders, preferring orders of the same pair
+// and side, and the rest are placed; orders left over are cancelled. Matching
+// pools are not compared, as the wallet does not record them
+func DiffOrders(current, targets []wallet.Order) *OrderDiff {
+	diff := &OrderDiff{}
+	matched := make([]bool, len(current))
+	var pending []wallet.Order
+
+	// Targets that name an existing order keep or amend it
+	byID := make(map[uuid.UUID]int, len(current))
+	for i, order := range current {
+		byID[order.Id] = i
+	}
+	for _, target := range targets {
+		i, ok := byID[target.Id]
+		if !ok || matched[i] {
+			pending = append(pending, target)
+			continue
+		}
+
+		matched[i] = true
+		if sameOrder(&current[i], &target) {
+			diff.Unchanged = append(diff.Unchanged, target.Id)
+		} else {
+			diff.Amend = append(diff.Amend, target)
+		}
+	}
+
+	// Then targets identical to an existing order keep it, and the rest amend
+	// an order of the same pair and side, then any order
+	passes := []func(existing, target *wallet.Order) bool{sameOrder, samePairAndSide, anyOrder}
+	for pass, matches := range passes {
+		var unmatched []wallet.Order
+		for _, target := range pending {
+			i := findUnmatched(current, matched, &target, matches)
+			if i == -1 {
+				unmatched = append(unmatched, target)
+				continue
+			}
+
+			matched[i] = true
+			if pass == 0 {
+				diff.Unchanged = append(diff.Unchanged, current[i].Id)
+			} else {
+				target.Id = current[i].Id
+				diff.Amend = append(diff.Amend, target)
+			}
+		}
+		pending = unmatched
+	}
+
+	diff.Place = pending
+	for i, order := range current {
+		if !matched[i] {
+			diff.Cancel = append(diff.Cancel, order.Id)
+		}
+	}
+	return diff
+}
+
+// ReconcileOrders brings the wallet's open orders to the given targets with
+// the fewest wallet updates, see DiffOrders, and returns the diff applied.
+//
+// Cancellations are enqueued first so that their slots are free for
+// placements, then amendments and placements. Every update is enqueued before
+// any is awaited; failures are returned together once all have been attempted
+func (c *RenegadeClient) ReconcileOrders(targets []wallet.Order) (*OrderDiff, error) {
+	backOfQueueWallet, err := c.getBackOfQueueWallet()
+	if err != nil {
+		return nil, err
+	}
+	diff := DiffOrders(backOfQueueWallet.GetNonzeroOrders(), targets)
+
+	var errs []error
+	var taskIDs []uuid.UUID
+	enqueue := func(action string, orderID uuid.UUID, taskID uuid.UUID, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to %s order %s: %w", action, orderID, err))
+			return
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	for _, orderID := range diff.Cancel {
+		taskID, err := c.submitCancelOrder(orderID)
+		enqueue("cancel", orderID, taskID, err)
+	}
+	for i := range diff.Amend {
+		taskID, err := c.submitUpdateOrder(&diff.Amend[i])
+		enqueue("amend", diff.Amend[i].Id, taskID, err)
+	}
+	for i := range diff.Place {
+		taskID, err := c.submitPlaceOrder(&diff.Place[i])
+		enqueue("place", diff.Place[i].Id, taskID, err)
+	}
+
+	for _, taskID := range taskIDs {
+		if err := c.waitForTask(taskID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return diff, errors.Join(errs...)
+}
+
+// findUnmatched returns the index of the first unmatched order that matches
+// the target, or -1 if there is none
+func findUnmatched(
+	current []wallet.Order, matched []bool, target *wallet.Order,
+	matches func(existing, target *wallet.Order) bool,
+) int {
+	for i := range current {
+		if !matched[i] && matches(&current[i], target) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameOrder returns whether two orders trade the same pair, side, amount and
+// worst case price
+func sameOrder(a, b *wallet.Order) bool {
+	return samePairAndSide(a, b) && a.Amount == b.Amount && a.WorstCasePrice == b.WorstCasePrice
+}
+
+// samePairAndSide returns whether two orders trade the same pair on the same
+// side
+func samePairAndSide(a, b *wallet.Order) bool {
+	return a.BaseMint == b.BaseMint && a.QuoteMint == b.QuoteMint && a.Side == b.Side
+}
+
+// anyOrder matches every order
+func anyOrder(_, _ *wallet.Order) bool {
+	return true
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// testTargetOrder builds a target order trading the given base mint against a
+// fixed quote mint
+func testTargetOrder(baseMint string, side wallet.OrderSide, amount int64, price float64) wallet.Order {
+	return wallet.NewOrderBuilder().
+		WithBaseMintHex(baseMint).
+		WithQuoteMintHex("0x02").
+		WithSide(side).
+		WithAmountBigInt(big.NewInt(amount)).
+		WithWorstCasePrice(wallet.FixedPointFromFloat(price)).
+		Build()
+}
+
+func TestDiffOrders(t *testing.T) {
+	bid := testTargetOrder("0x01", wallet.Buy, 100, 1.0)
+	ask := testTargetOrder("0x01", wallet.Sell, 100, 1.2)
+	stale := testTargetOrder("0x03", wallet.Sell, 50, 2.0)
+	current := []wallet.Order{bid, ask, stale}
+
+	// The same orders under new IDs change nothing
+	diff := DiffOrders(current, []wallet.Order{
+		testTargetOrder("0x01", wallet.Sell, 100, 1.2),
+		testTargetOrder("0x01", wallet.Buy, 100, 1.0),
+	})
+	assert.ElementsMatch(t, []uuid.UUID{bid.Id, ask.Id}, diff.Unchanged)
+	assert.Equal(t, []uuid.UUID{stale.Id}, diff.Cancel)
+	assert.Equal(t, 1, diff.Updates())
+
+	// Repricing both sides amends the existing orders in place, and the
+	// stale order is amended into the new pair rather than cancelled
+	newBid := testTargetOrder("0x01", wallet.Buy, 100, 1.05)
+	newAsk := testTargetOrder("0x01", wallet.Sell, 100, 1.15)
+	third := testTargetOrder("0x04", wallet.Buy, 10, 3.0)
+	diff = DiffOrders(current, []wallet.Order{newAsk, newBid, third})
+	assert.Empty(t, diff.Cancel)
+	assert.Empty(t, diff.Place)
+	assert.Len(t, diff.Amend, 3)
+	assert.Equal(t, ask.Id, diff.Amend[0].Id)
+	assert.Equal(t, newAsk.WorstCasePrice, diff.Amend[0].WorstCasePrice)
+	assert.Equal(t, bid.Id, diff.Amend[1].Id)
+	assert.Equal(t, stale.Id, diff.Amend[2].Id)
+	assert.Equal(t, third.BaseMint, diff.Amend[2].BaseMint)
+
+	// A target naming an existing order amends that order
+	named := bid
+	named.Amount = new(wallet.Scalar).FromBigInt(big.NewInt(200))
+	diff = DiffOrders(current, []wallet.Order{named})
+	assert.Len(t, diff.Amend, 1)
+	assert.Equal(t, bid.Id, diff.Amend[0].Id)
+	assert.ElementsMatch(t, []uuid.UUID{ask.Id, stale.Id}, diff.Cancel)
+
+	// Extra targets are placed
+	diff = DiffOrders(nil, []wallet.Order{bid, ask})
+	assert.Len(t, diff.Place, 2)
+	assert.True(t, DiffOrders(current, current).IsEmpty())
+}
+
+func TestReconcileOrders(t *testing.T) {
+	key, w := newTestWallet(t)
+	bid := testTargetOrder("0x01", wallet.Buy, 100, 1.0)
+	ask := testTargetOrder("0x01", wallet.Sell, 100, 1.2)
+	assert.NoError(t, w.NewOrder(bid))
+	assert.NoError(t, w.NewOrder(ask))
+	apiWallet := toTestApiWallet(t, w)
+
+	var mu sync.Mutex
+	var requests []string
+	var tasks []api_types.ApiHistoricalTask
+	c := newTestClient(t, key, func(rw http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/back-of-queue"):
+			_ = json.NewEncoder(rw).Encode(api_types.GetWalletResponse{Wallet: *apiWallet})
+		case r.Method == http.MethodPost:
+			requests = append(requests, r.URL.Path)
+			taskID := uuid.New()
+			tasks = append(tasks, api_types.ApiHistoricalTask{Id: taskID, State: "Completed"})
+			_ = json.NewEncoder(rw).Encode(api_types.UpdateOrderResponse{TaskId: taskID})
+		case strings.Contains(r.URL.Path, "/task-history"):
+			_ = json.NewEncoder(rw).Encode(api_types.TaskHistoryResponse{Tasks: tasks})
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	// Keep the bid and reprice the ask in one update
+	diff, err := c.ReconcileOrders([]wallet.Order{
+		testTargetOrder("0x01", wallet.Buy, 100, 1.0),
+		testTargetOrder("0x01", wallet.Sell, 100, 1.1),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{bid.Id}, diff.Unchanged)
+	assert.Equal(t, []string{api_types.BuildUpdateOrderPath(w.Id, ask.Id)}, requests)
+}
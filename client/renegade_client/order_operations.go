@@ -9,101 +9,182 @@ import (
 
 // placeOrder creates an order via the Renegade API
 func (c *RenegadeClient) placeOrder(order *wallet.Order, blocking bool) error {
-	// Get the back of the queue wallet
-	backOfQueueWallet, err := c.GetBackOfQueueWallet()
+	taskID, err := c.submitPlaceOrder(order)
 	if err != nil {
 		return err
 	}
 
-	// Add the order to the wallet and reblind
-	err = backOfQueueWallet.NewOrder(*order)
-	if err != nil {
-		return err
-	}
-	err = backOfQueueWallet.Reblind()
-	if err != nil {
-		return err
+	// If blocking, wait for the task to complete
+	if blocking {
+		if err := c.waitForTask(taskID); err != nil {
+			return err
+		}
 	}
 
-	// Sign the commitment to the new wallet
-	auth, err := getWalletUpdateAuth(backOfQueueWallet)
-	if err != nil {
-		return err
-	}
+	return nil
+}
 
-	// Post the order to the relayer
+// submitPlaceOrder enqueues the placement of an order, returning the ID of the
+// placement task
+func (c *RenegadeClient) submitPlaceOrder(order *wallet.Order) (uuid.UUID, error) {
 	apiOrder, err := new(api_types.ApiOrder).FromOrder(order)
 	if err != nil {
-		return err
+		return uuid.Nil, err
 	}
-
-	req := api_types.CreateOrderRequest{
-		Order:                     *apiOrder,
-		WalletUpdateAuthorization: *auth,
+	if err := c.checkMinOrder(order); err != nil {
+		return uuid.Nil, err
+	}
+	admin := c.adminClient.Load()
+	if order.MatchingPool != "" && admin == nil {
+		return uuid.Nil, ErrNoAdminClient
 	}
 
-	walletID := c.walletSecrets.Id
-	path := api_types.BuildCreateOrderPath(walletID)
-	resp := api_types.CreateOrderResponse{}
-
-	err = c.httpClient.PostWithAuth(path, req, &resp)
+	// Reserve the order's notional against the risk limits
+	guard, notional, err := c.reserveOrderRisk(order, apiOrder)
 	if err != nil {
-		return err
+		return uuid.Nil, err
 	}
 
-	// If blocking, wait for the task to complete
-	if blocking {
-		if err := c.waitForTask(resp.TaskId); err != nil {
+	// Add the order to the wallet and post it to the relayer
+	addOrder := func(w *wallet.Wallet) error {
+		if err := w.CheckOrderCapacity(); err != nil {
 			return err
 		}
+		if guard != nil {
+			if err := guard.CheckOpenOrders(len(w.GetNonzeroOrders())); err != nil {
+				return err
+			}
+		}
+		return w.NewOrder(*order)
 	}
+	postOrder := func(auth *api_types.WalletUpdateAuthorization) (uuid.UUID, error) {
+		// Orders are only placed in a matching pool through the admin API
+		if order.MatchingPool != "" {
+			return admin.createOrderInMatchingPool(c.walletSecrets.Id, &api_types.AdminCreateOrderInMatchingPoolRequest{
+				Order:                     *apiOrder,
+				WalletUpdateAuthorization: *auth,
+				MatchingPool:              order.MatchingPool,
+			})
+		}
 
-	return nil
-}
+		req := api_types.CreateOrderRequest{
+			Order:                     *apiOrder,
+			WalletUpdateAuthorization: *auth,
+		}
 
-// cancelOrder cancels an order via the Renegade API
-func (c *RenegadeClient) cancelOrder(orderID uuid.UUID, blocking bool) error {
-	// Get the back of the queue wallet
-	backOfQueueWallet, err := c.GetBackOfQueueWallet()
-	if err != nil {
-		return err
+		path := api_types.BuildCreateOrderPath(c.walletSecrets.Id)
+		resp := api_types.CreateOrderResponse{}
+		err := c.httpClient.PostWithAuth(path, req, &resp)
+		return resp.TaskId, err
 	}
 
-	// Cancel the order
-	err = backOfQueueWallet.CancelOrder(orderID)
+	taskID, err := c.updateWallet(addOrder, postOrder)
 	if err != nil {
-		return err
+		releaseOrderRisk(guard, apiOrder, notional)
+		return uuid.Nil, err
 	}
-	err = backOfQueueWallet.Reblind()
+	return taskID, nil
+}
+
+// submitUpdateOrder enqueues the replacement of the order with the same ID,
+// returning the ID of the update task. The new order's notional is reserved
+// against the risk limits
+func (c *RenegadeClient) submitUpdateOrder(order *wallet.Order) (uuid.UUID, error) {
+	apiOrder, err := new(api_types.ApiOrder).FromOrder(order)
 	if err != nil {
-		return err
+		return uuid.Nil, err
+	}
+	if err := c.checkMinOrder(order); err != nil {
+		return uuid.Nil, err
 	}
 
-	// Get the wallet update auth
-	auth, err := getWalletUpdateAuth(backOfQueueWallet)
+	guard, notional, err := c.reserveOrderRisk(order, apiOrder)
 	if err != nil {
-		return err
+		return uuid.Nil, err
 	}
 
-	// Post the order to the relayer
-	walletID := c.walletSecrets.Id
-	path := api_types.BuildCancelOrderPath(walletID, orderID)
-	req := api_types.CancelOrderRequest{
-		WalletUpdateAuthorization: *auth,
+	// Replace the order in the wallet and post the update to the relayer
+	replaceOrder := func(w *wallet.Wallet) error {
+		return w.UpdateOrder(*order)
 	}
+	postUpdate := func(auth *api_types.WalletUpdateAuthorization) (uuid.UUID, error) {
+		req := api_types.UpdateOrderRequest{
+			Order:                     *apiOrder,
+			WalletUpdateAuthorization: *auth,
+		}
 
-	resp := api_types.CancelOrderResponse{}
-	err = c.httpClient.PostWithAuth(path, req, &resp)
+		path := api_types.BuildUpdateOrderPath(c.walletSecrets.Id, order.Id)
+		resp := api_types.UpdateOrderResponse{}
+		err := c.httpClient.PostWithAuth(path, req, &resp)
+		return resp.TaskId, err
+	}
+
+	taskID, err := c.updateWallet(replaceOrder, postUpdate)
+	if err != nil {
+		releaseOrderRisk(guard, apiOrder, notional)
+		return uuid.Nil, err
+	}
+	return taskID, nil
+}
+
+// cancelOrder cancels an order via the Renegade API
+func (c *RenegadeClient) cancelOrder(orderID uuid.UUID, blocking bool) error {
+	taskID, err := c.submitCancelOrder(orderID)
 	if err != nil {
 		return err
 	}
 
 	// If blocking, wait for the task to complete
 	if blocking {
-		if err := c.waitForTask(resp.TaskId); err != nil {
+		if err := c.waitForTask(taskID); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+// submitCancelOrder enqueues the cancellation of an order, returning the ID of
+// the cancellation task
+func (c *RenegadeClient) submitCancelOrder(orderID uuid.UUID) (uuid.UUID, error) {
+	// Cancel the order in the wallet and post the cancellation to the relayer
+	removeOrder := func(w *wallet.Wallet) error {
+		return w.CancelOrder(orderID)
+	}
+	postCancel := func(auth *api_types.WalletUpdateAuthorization) (uuid.UUID, error) {
+		req := api_types.CancelOrderRequest{
+			WalletUpdateAuthorization: *auth,
+		}
+
+		path := api_types.BuildCancelOrderPath(c.walletSecrets.Id, orderID)
+		resp := api_types.CancelOrderResponse{}
+		err := c.httpClient.PostWithAuth(path, req, &resp)
+		return resp.TaskId, err
+	}
+
+	return c.updateWallet(removeOrder, postCancel)
+}
+
+// GetOrderStatus returns the relayer's record of an order, including its
+// state and fills, without fetching the whole wallet
+func (c *RenegadeClient) GetOrderStatus(orderID uuid.UUID) (*api_types.ApiOrderMetadata, error) {
+	path := api_types.BuildOrderMetadataPath(c.walletSecrets.Id, orderID)
+	resp := api_types.GetOrderMetadataResponse{}
+	if err := c.httpClient.GetWithAuth(path, nil /* body */, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Order, nil
+}
+
+// GetOrderHistory returns the relayer's record of the wallet's orders,
+// including cancelled and filled orders no longer in the wallet
+func (c *RenegadeClient) GetOrderHistory() ([]api_types.ApiOrderMetadata, error) {
+	path := api_types.BuildOrderHistoryPath(c.walletSecrets.Id)
+	resp := api_types.GetOrderHistoryResponse{}
+	if err := c.httpClient.GetWithAuth(path, nil /* body */, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Orders, nil
+}
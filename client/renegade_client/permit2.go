@@ -1,6 +1,8 @@
 package client
 
 import (
+	"crypto/ecdsa"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -60,6 +62,16 @@ func (domain EIP712Domain) Hash() common.Hash {
 func getPermitSigningHash(
 	permit PermitWitnessTransferFrom, domain EIP712Domain,
 ) (common.Hash, error) {
+	// Compute the final hash
+	return crypto.Keccak256Hash(
+		[]byte("\x19\x01"),
+		domain.Hash().Bytes(),
+		getPermitStructHash(permit).Bytes(),
+	), nil
+}
+
+// getPermitStructHash gets the eip712 struct hash of the permit
+func getPermitStructHash(permit PermitWitnessTransferFrom) common.Hash {
 	// EIP-712 type hashes
 	//nolint:lll
 	permitTypeHash := crypto.Keccak256(
@@ -75,7 +87,7 @@ func getPermitSigningHash(
 
 	// Construct the struct hash
 	witnessHash := hashPermit2Witness(permit.Witness)
-	structHash := crypto.Keccak256(
+	return crypto.Keccak256Hash(
 		permitTypeHash,
 		tokenPermissionsHash,
 		common.LeftPadBytes(permit.Spender.Bytes(), 32),
@@ -83,13 +95,6 @@ func getPermitSigningHash(
 		common.LeftPadBytes(permit.Deadline.Bytes(), 32),
 		witnessHash,
 	)
-
-	// Compute the final hash
-	return crypto.Keccak256Hash(
-		[]byte("\x19\x01"),
-		domain.Hash().Bytes(),
-		structHash,
-	), nil
 }
 
 // hashPermit2Witness hashes the DepositWitness struct
@@ -113,3 +118,82 @@ func hashPermit2Witness(permit *DepositWitness) []byte {
 
 	return witnessHash
 }
+
+// signPermit2Hash signs a Permit2 eip712 hash with the given key
+func signPermit2Hash(hash common.Hash, ethPrivateKey *ecdsa.PrivateKey) ([]byte, error) {
+	signature, err := crypto.Sign(hash.Bytes(), ethPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign permit: %w", err)
+	}
+
+	// Permit2 expects the recovery id offset by 27
+	signature[len(signature)-1] += 27
+	return signature, nil
+}
+
+// TypedDataField is a single field in an EIP-712 type definition
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData is an EIP-712 typed data payload in the format accepted by
+// `eth_signTypedData_v4`
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]interface{}      `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// TypedData returns the EIP-712 typed data for the permit under the given
+// domain. Signing this payload produces the same signature as signing the
+// permit's signing hash
+func (permit PermitWitnessTransferFrom) TypedData(domain EIP712Domain) *TypedData {
+	pkRoot := make([]string, len(permit.Witness.PkRoot))
+	for i, limb := range permit.Witness.PkRoot {
+		pkRoot[i] = limb.String()
+	}
+
+	return &TypedData{
+		Types: map[string][]TypedDataField{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"PermitWitnessTransferFrom": {
+				{Name: "permitted", Type: "TokenPermissions"},
+				{Name: "spender", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+				{Name: "witness", Type: "DepositWitness"},
+			},
+			"TokenPermissions": {
+				{Name: "token", Type: "address"},
+				{Name: "amount", Type: "uint256"},
+			},
+			"DepositWitness": {
+				{Name: "pkRoot", Type: "uint256[4]"},
+			},
+		},
+		PrimaryType: "PermitWitnessTransferFrom",
+		Domain: map[string]interface{}{
+			"name":              domain.Name,
+			"chainId":           domain.ChainId.String(),
+			"verifyingContract": domain.VerifyingContract.Hex(),
+		},
+		Message: map[string]interface{}{
+			"permitted": map[string]interface{}{
+				"token":  permit.Permitted.Token.Hex(),
+				"amount": permit.Permitted.Amount.String(),
+			},
+			"spender":  permit.Spender.Hex(),
+			"nonce":    permit.Nonce.String(),
+			"deadline": permit.Deadline.String(),
+			"witness": map[string]interface{}{
+				"pkRoot": pkRoot,
+			},
+		},
+	}
+}
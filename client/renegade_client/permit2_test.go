@@ -0,0 +1,83 @@
+package client
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/abis"
+)
+
+var (
+	testPermit2Address  = common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3")
+	testDarkpoolAddress = common.HexToAddress("0x30bd8eab29181f790d7e495786d4b96d7afdc518")
+)
+
+func testDepositPermit(from common.Address) *DepositPermit {
+	return &DepositPermit{
+		FromAddr: from,
+		Permit: PermitWitnessTransferFrom{
+			Permitted: abis.ISignatureTransferTokenPermissions{
+				Token:  common.HexToAddress("0xaf88d065e77c8cC2239327C5EDb3A432268e5831"),
+				Amount: big.NewInt(1_000_000),
+			},
+			Spender:  testDarkpoolAddress,
+			Nonce:    big.NewInt(42),
+			Deadline: new(big.Int).SetUint64(^uint64(0)),
+			Witness: &DepositWitness{
+				PkRoot: [4]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)},
+			},
+		},
+		Domain: ConstructEIP712Domain(big.NewInt(42161), testPermit2Address),
+	}
+}
+
+func TestDepositPermitTypedDataJSON(t *testing.T) {
+	permit := testDepositPermit(common.Address{})
+	raw, err := permit.TypedDataJSON()
+	assert.NoError(t, err)
+
+	var decoded TypedData
+	assert.NoError(t, json.Unmarshal(raw, &decoded))
+	assert.Equal(t, "PermitWitnessTransferFrom", decoded.PrimaryType)
+	assert.Equal(t, "42161", decoded.Domain["chainId"])
+	assert.Equal(t, "42", decoded.Message["nonce"])
+	assert.Equal(t,
+		map[string]interface{}{"pkRoot": []interface{}{"1", "2", "3", "4"}},
+		decoded.Message["witness"],
+	)
+}
+
+func TestNormalizePermitSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	permit := testDepositPermit(crypto.PubkeyToAddress(key.PublicKey))
+
+	hash, err := permit.SigningHash()
+	assert.NoError(t, err)
+	rawSig, err := crypto.Sign(hash.Bytes(), key)
+	assert.NoError(t, err)
+
+	// Both raw and 27-offset recovery ids are accepted and normalized
+	sig, err := normalizePermitSignature(permit, rawSig)
+	assert.NoError(t, err)
+	assert.Equal(t, rawSig[64]+27, sig[64])
+
+	offsetSig := append([]byte{}, rawSig...)
+	offsetSig[64] += 27
+	sig, err = normalizePermitSignature(permit, offsetSig)
+	assert.NoError(t, err)
+	assert.Equal(t, rawSig[64]+27, sig[64])
+
+	// A signature by another key is rejected
+	other := testDepositPermit(common.HexToAddress("0x01"))
+	_, err = normalizePermitSignature(other, rawSig)
+	assert.ErrorIs(t, err, ErrInvalidPermitSignature)
+
+	_, err = normalizePermitSignature(permit, rawSig[:64])
+	assert.ErrorIs(t, err, ErrInvalidPermitSignature)
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// ErrReadOnlyClient is returned by methods that sign or need the wallet's
+// secrets when called on a read-only client
+var ErrReadOnlyClient = errors.New("client is read-only: it holds no root key")
+
+// ViewKeys are the keys that authenticate reads of a wallet, without the root
+// key needed to update it
+type ViewKeys struct {
+	// WalletID is the ID of the wallet
+	WalletID uuid.UUID
+	// SymmetricKey authenticates requests to the relayer
+	SymmetricKey wallet.HmacKey
+}
+
+// ViewKeys returns the keys a read-only client of the wallet is created from
+func (c *RenegadeClient) ViewKeys() ViewKeys {
+	return ViewKeys{
+		WalletID:     c.walletSecrets.Id,
+		SymmetricKey: c.walletSecrets.Keychain.PrivateKeys.SymmetricKey,
+	}
+}
+
+// NewReadOnlyRenegadeClient creates a client of a wallet from its view keys,
+// e.g. for a monitoring dashboard. The client fetches the wallet, its orders,
+// and its task history, but holds no root key: methods that sign an update or
+// a withdrawal, or that need the wallet's seeds, return ErrReadOnlyClient.
+// Requests the relayer authorizes with the symmetric key alone, such as
+// RefreshWallet and PayFees, are still sent
+func NewReadOnlyRenegadeClient(
+	baseURL string, keys ViewKeys, config ChainConfig,
+) (*RenegadeClient, error) {
+	return NewReadOnlyRenegadeClientWithOptions(baseURL, keys, config, client.NewHttpClientOptions())
+}
+
+// NewReadOnlyRenegadeClientWithOptions creates a read-only client with the
+// given HTTP client options, see NewReadOnlyRenegadeClient
+func NewReadOnlyRenegadeClientWithOptions(
+	baseURL string, keys ViewKeys, config ChainConfig, options *client.HttpClientOptions,
+) (*RenegadeClient, error) {
+	if keys.WalletID == uuid.Nil {
+		return nil, errors.New("view keys have no wallet ID")
+	}
+
+	secrets := &wallet.WalletSecrets{
+		Id: keys.WalletID,
+		Keychain: &wallet.Keychain{
+			PrivateKeys: wallet.PrivateKeychain{SymmetricKey: keys.SymmetricKey},
+		},
+	}
+	return &RenegadeClient{
+		chainConfig:      config,
+		walletSecrets:    secrets,
+		httpClient:       client.NewHttpClientWithOptions(baseURL, &keys.SymmetricKey, options),
+		maxUpdateRetries: defaultMaxUpdateRetries,
+	}, nil
+}
+
+// IsReadOnly returns whether the client was created from view keys only
+func (c *RenegadeClient) IsReadOnly() bool {
+	return c.walletSecrets.Keychain.PrivateKeys.SkRoot == nil
+}
+
+// requireRootKey returns ErrReadOnlyClient if the client holds no root key
+func (c *RenegadeClient) requireRootKey() error {
+	if c.IsReadOnly() {
+		return ErrReadOnlyClient
+	}
+	return nil
+}
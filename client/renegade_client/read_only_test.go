@@ -0,0 +1,37 @@
+package client
+
+import (
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOnlyClient(t *testing.T) {
+	var cancellations atomic.Int32
+	full := newOrdersRelayer(t, 2, &cancellations)
+
+	c, err := NewReadOnlyRenegadeClient("http://localhost:1", full.ViewKeys(), ArbitrumSepoliaConfig)
+	assert.NoError(t, err)
+	c.httpClient = full.httpClient
+	assert.True(t, c.IsReadOnly())
+	assert.False(t, full.IsReadOnly())
+
+	// Reads succeed without the root key
+	w, err := c.GetBackOfQueueWallet()
+	assert.NoError(t, err)
+	assert.Equal(t, full.walletSecrets.Id, w.Id)
+	assert.Len(t, w.GetNonzeroOrders(), 2)
+
+	// Signing is refused before anything is sent
+	assert.ErrorIs(t, c.cancelAllOrders(false /* blocking */), ErrReadOnlyClient)
+	assert.Equal(t, int32(0), cancellations.Load())
+	_, err = c.generateWithdrawalSignature("0x01", big.NewInt(1), "0x02")
+	assert.ErrorIs(t, err, ErrReadOnlyClient)
+	assert.ErrorIs(t, c.createWallet(false /* blocking */), ErrReadOnlyClient)
+	assert.ErrorIs(t, c.lookupWallet(false /* blocking */), ErrReadOnlyClient)
+
+	_, err = NewReadOnlyRenegadeClient("http://localhost:1", ViewKeys{}, ArbitrumSepoliaConfig)
+	assert.Error(t, err)
+}
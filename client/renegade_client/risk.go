@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"log"
+	"math/big"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// ErrUnboundedNotional is returned when a notional risk limit is set and a buy
+// order has no worst case price, so that its notional cannot be bounded
+var ErrUnboundedNotional = errors.New("order has no worst case price; its notional cannot be checked against the risk limits")
+
+// SetRiskLimits sets the risk limits orders are checked against before they
+// are placed; nil removes them. Daily volume counters start from the limits'
+// store, or from zero if it has none
+func (c *RenegadeClient) SetRiskLimits(limits *client.RiskLimits) {
+	if limits == nil {
+		c.riskGuard.Store(nil)
+		return
+	}
+	c.riskGuard.Store(client.NewRiskGuard(*limits))
+}
+
+// orderNotional returns an order's notional at its worst case price, in atoms
+// of the quote token. A buy's worst case price bounds its notional from above;
+// a sell's bounds it only from below, so that a sell without one reserves none
+func orderNotional(order *wallet.Order) *big.Int {
+	return order.WorstCasePrice.MulInt(order.Amount.ToBigInt(), wallet.RoundCeil)
+}
+
+// reserveOrderRisk reserves an order's notional against the risk limits,
+// returning the guard it was reserved with, if any
+func (c *RenegadeClient) reserveOrderRisk(
+	order *wallet.Order, apiOrder *api_types.ApiOrder,
+) (*client.RiskGuard, *big.Int, error) {
+	guard := c.riskGuard.Load()
+	if guard == nil {
+		return nil, nil, nil
+	}
+
+	limits := guard.Limits()
+	if limits.MaxNotionalPerTrade == nil && limits.MaxDailyVolumePerPair == nil {
+		return guard, nil, nil
+	}
+	if order.WorstCasePrice.Repr.IsZero() && !order.Side.IsOne() {
+		return nil, nil, ErrUnboundedNotional
+	}
+
+	notional := orderNotional(order)
+	if err := guard.ReserveTrade(apiOrder.BaseMint, apiOrder.QuoteMint, notional); err != nil {
+		return nil, nil, err
+	}
+	return guard, notional, nil
+}
+
+// releaseOrderRisk releases a notional reserved by reserveOrderRisk for an
+// order that was not placed
+func releaseOrderRisk(guard *client.RiskGuard, apiOrder *api_types.ApiOrder, notional *big.Int) {
+	if guard == nil || notional == nil {
+		return
+	}
+	if err := guard.ReleaseTrade(apiOrder.BaseMint, apiOrder.QuoteMint, notional); err != nil {
+		log.Printf("failed to release risk reservation: %v", err)
+	}
+}
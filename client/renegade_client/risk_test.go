@@ -0,0 +1,64 @@
+package client
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// riskTestOrder builds a buy order for 100 units at the given worst case price
+func riskTestOrder(price float64) wallet.Order {
+	return riskTestOrderWithSide(wallet.Buy, price)
+}
+
+// riskTestOrderWithSide builds an order on the given side for 100 units at the
+// given worst case price
+func riskTestOrderWithSide(side wallet.OrderSide, price float64) wallet.Order {
+	return wallet.NewOrderBuilder().
+		WithBaseMintHex("0x01").
+		WithQuoteMintHex("0x02").
+		WithSide(side).
+		WithAmountBigInt(big.NewInt(100)).
+		WithWorstCasePrice(wallet.FixedPointFromFloat(price)).
+		Build()
+}
+
+func TestPlaceOrderRiskLimits(t *testing.T) {
+	c := newTestRelayer(t, &testRelayer{})
+	c.SetRiskLimits(&client.RiskLimits{
+		MaxNotionalPerTrade:   big.NewInt(1000),
+		MaxDailyVolumePerPair: big.NewInt(1500),
+		QuoteDecimals:         map[string]uint8{"0x02": 18},
+	})
+
+	// Buys without a worst case price have no bounded notional...
+	order := riskTestOrder(0)
+	assert.ErrorIs(t, c.placeOrder(&order, false /* blocking */), ErrUnboundedNotional)
+
+	// ...while sells without one accept any price and reserve no notional
+	order = riskTestOrderWithSide(wallet.Sell, 0)
+	assert.NoError(t, c.placeOrder(&order, false /* blocking */))
+
+	// 100 units at a price of 20 exceed the per-trade notional
+	var limitErr *client.RiskLimitError
+	order = riskTestOrder(20)
+	assert.True(t, errors.As(c.placeOrder(&order, false /* blocking */), &limitErr))
+	assert.Equal(t, "trade notional", limitErr.Limit)
+
+	// Two orders at a price of 8 exceed the daily volume
+	order = riskTestOrder(8)
+	assert.NoError(t, c.placeOrder(&order, false /* blocking */))
+	order = riskTestOrder(8)
+	assert.True(t, errors.As(c.placeOrder(&order, false /* blocking */), &limitErr))
+	assert.Equal(t, "daily pair volume", limitErr.Limit)
+
+	// Removing the limits allows any order
+	c.SetRiskLimits(nil)
+	order = riskTestOrder(0)
+	assert.NoError(t, c.placeOrder(&order, false /* blocking */))
+}
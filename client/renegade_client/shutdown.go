@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/renegade-fi/golang-sdk/client"
+)
+
+// Close stops the client's background routines, i.e. automatic fee payment,
+// the dead-man switch and the running balance watchers created by the client,
+// closes its HTTP client, then flushes the sinks registered with
+// RegisterFlusher. Requests in flight are not waited for, see Shutdown
+func (c *RenegadeClient) Close() {
+	c.stopBackgroundRoutines()
+	c.httpClient.Close()
+	if err := c.flushers.Flush(); err != nil {
+		log.Printf("failed to flush sinks on close: %v", err)
+	}
+}
+
+// Shutdown stops the client's background routines, then waits for requests in
+// flight to complete before closing its HTTP client and flushing the sinks
+// registered with RegisterFlusher. Requests made after shutdown begins fail
+// with client.ErrClientClosed. If the context ends first, the context's error
+// is returned
+func (c *RenegadeClient) Shutdown(ctx context.Context) error {
+	c.stopBackgroundRoutines()
+	err := c.httpClient.Shutdown(ctx)
+	if flushErr := c.flushers.Flush(); flushErr != nil && err == nil {
+		err = fmt.Errorf("failed to flush sinks: %w", flushErr)
+	}
+	return err
+}
+
+// RegisterFlusher registers a sink, e.g. a buffered audit log of the wallet's
+// events, to be flushed when the client closes
+func (c *RenegadeClient) RegisterFlusher(flusher client.Flusher) {
+	c.flushers.Add(flusher)
+}
+
+// stopBackgroundRoutines stops every background routine owned by the client
+func (c *RenegadeClient) stopBackgroundRoutines() {
+	c.DisableAutoPayFees()
+	c.DisableDeadManSwitch()
+
+	c.watchersMu.Lock()
+	watchers := make([]*BalanceWatcher, 0, len(c.watchers))
+	for w := range c.watchers {
+		watchers = append(watchers, w)
+	}
+	c.watchersMu.Unlock()
+	for _, w := range watchers {
+		w.Stop()
+	}
+}
+
+// trackWatcher registers a running balance watcher, to be stopped on shutdown
+func (c *RenegadeClient) trackWatcher(w *BalanceWatcher) {
+	c.watchersMu.Lock()
+	defer c.watchersMu.Unlock()
+	if c.watchers == nil {
+		c.watchers = make(map[*BalanceWatcher]struct{})
+	}
+	c.watchers[w] = struct{}{}
+}
+
+// untrackWatcher deregisters a stopped balance watcher
+func (c *RenegadeClient) untrackWatcher(w *BalanceWatcher) {
+	c.watchersMu.Lock()
+	defer c.watchersMu.Unlock()
+	delete(c.watchers, w)
+}
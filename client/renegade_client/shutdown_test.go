@@ -0,0 +1,67 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client"
+)
+
+func TestShutdownStopsBackgroundRoutines(t *testing.T) {
+	var cancellations atomic.Int32
+	c := newOrdersRelayer(t, 1, &cancellations)
+
+	watcher := c.NewBalanceWatcher(time.Hour)
+	events := watcher.Subscribe(1 /* bufferSize */)
+	watcher.Start()
+	assert.NoError(t, c.EnableAutoPayFees(big.NewInt(1), time.Hour, nil /* hooks */))
+	assert.NoError(t, c.EnableDeadManSwitch(time.Hour, nil /* onTrigger */))
+
+	assert.NoError(t, c.Shutdown(context.Background()))
+
+	// Stopping the watcher closes its subscribers' channels
+	_, open := <-events
+	assert.False(t, open)
+	assert.Nil(t, c.stopAutoFees)
+	assert.Nil(t, c.stopDeadMan)
+
+	// Requests after shutdown are rejected
+	_, err := c.GetWallet()
+	assert.True(t, errors.Is(err, client.ErrClientClosed))
+}
+
+func TestStoppedWatchersAreDeregistered(t *testing.T) {
+	var cancellations atomic.Int32
+	c := newOrdersRelayer(t, 1, &cancellations)
+
+	// Only running watchers are held by the client
+	watcher := c.NewBalanceWatcher(time.Hour)
+	assert.Empty(t, c.watchers)
+	watcher.Start()
+	assert.Len(t, c.watchers, 1)
+	watcher.Stop()
+	assert.Empty(t, c.watchers)
+}
+
+func TestCloseFlushesSinks(t *testing.T) {
+	var cancellations atomic.Int32
+	c := newOrdersRelayer(t, 1, &cancellations)
+
+	var audit bytes.Buffer
+	writer := bufio.NewWriter(&audit)
+	c.RegisterFlusher(writer)
+	_, err := writer.WriteString("order placed")
+	assert.NoError(t, err)
+	assert.Zero(t, audit.Len())
+
+	c.Close()
+	assert.Equal(t, "order placed", audit.String())
+}
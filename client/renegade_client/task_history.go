@@ -0,0 +1,189 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// defaultTaskHistoryPageSize is the number of tasks requested per page when
+// iterating over the task history without an explicit limit
+const defaultTaskHistoryPageSize = 100
+
+// TaskHistoryOptions represents the pagination and filter options for a task
+// history request
+type TaskHistoryOptions struct {
+	// Limit is the maximum number of tasks per page; zero uses the relayer's
+	// default
+	Limit int
+	// Cursor is the cursor of the page to fetch; empty fetches the first page
+	Cursor string
+	// TaskTypes restricts the tasks to the given task types (e.g.
+	// "SettleMatch") or wallet update types (e.g. "Deposit")
+	TaskTypes []string
+	// States restricts the tasks to the given states (e.g. "Completed")
+	States []string
+}
+
+// NewTaskHistoryOptions creates a new TaskHistoryOptions with default values
+func NewTaskHistoryOptions() *TaskHistoryOptions {
+	return &TaskHistoryOptions{}
+}
+
+// WithLimit sets the maximum number of tasks per page
+func (o *TaskHistoryOptions) WithLimit(limit int) *TaskHistoryOptions {
+	o.Limit = limit
+	return o
+}
+
+// WithCursor sets the cursor of the page to fetch
+func (o *TaskHistoryOptions) WithCursor(cursor string) *TaskHistoryOptions {
+	o.Cursor = cursor
+	return o
+}
+
+// WithTaskTypes restricts the tasks to the given task or wallet update types
+func (o *TaskHistoryOptions) WithTaskTypes(taskTypes ...string) *TaskHistoryOptions {
+	o.TaskTypes = taskTypes
+	return o
+}
+
+// WithStates restricts the tasks to the given states
+func (o *TaskHistoryOptions) WithStates(states ...string) *TaskHistoryOptions {
+	o.States = states
+	return o
+}
+
+// matches returns whether the task passes the options' filters
+func (o *TaskHistoryOptions) matches(task *api_types.ApiHistoricalTask) bool {
+	if len(o.TaskTypes) > 0 {
+		matched := false
+		for _, taskType := range o.TaskTypes {
+			if task.HasType(taskType) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(o.States) > 0 {
+		for _, state := range o.States {
+			if strings.EqualFold(task.State, state) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// GetTaskHistoryPage fetches a single page of the wallet's task history.
+//
+// Pass the returned NextCursor to WithCursor to fetch the following page; an
+// empty NextCursor marks the last page. Filters are also applied to the
+// returned page, so they hold even against relayers that ignore them
+func (c *RenegadeClient) GetTaskHistoryPage(
+	options *TaskHistoryOptions,
+) (*api_types.TaskHistoryResponse, error) {
+	if options == nil {
+		options = NewTaskHistoryOptions()
+	}
+
+	path := api_types.BuildTaskHistoryQueryPath(c.walletSecrets.Id, api_types.TaskHistoryQuery{
+		Limit:     options.Limit,
+		Cursor:    options.Cursor,
+		TaskTypes: options.TaskTypes,
+		States:    options.States,
+	})
+	resp := api_types.TaskHistoryResponse{}
+	if err := c.httpClient.GetWithAuth(path, nil /* body */, &resp); err != nil {
+		return nil, err
+	}
+
+	tasks := resp.Tasks[:0]
+	for i := range resp.Tasks {
+		if options.matches(&resp.Tasks[i]) {
+			tasks = append(tasks, resp.Tasks[i])
+		}
+	}
+	resp.Tasks = tasks
+
+	return &resp, nil
+}
+
+// TaskHistoryIterator iterates over the wallet's task history one page at a
+// time, see RenegadeClient.TaskHistoryIter
+type TaskHistoryIterator struct {
+	client  *RenegadeClient
+	options TaskHistoryOptions
+	// page is the current page of tasks, and idx the position in it
+	page []api_types.ApiHistoricalTask
+	idx  int
+	// done is set once the last page has been fetched
+	done bool
+	err  error
+}
+
+// TaskHistoryIter returns an iterator over the wallet's task history that
+// fetches pages lazily, starting at the options' cursor. Usage:
+//
+//	iter := client.TaskHistoryIter(NewTaskHistoryOptions().WithStates("Completed"))
+//	for iter.Next() {
+//		task := iter.Task()
+//		...
+//	}
+//	if err := iter.Err(); err != nil {
+//		...
+//	}
+func (c *RenegadeClient) TaskHistoryIter(options *TaskHistoryOptions) *TaskHistoryIterator {
+	iter := &TaskHistoryIterator{client: c}
+	if options != nil {
+		iter.options = *options
+	}
+	if iter.options.Limit <= 0 {
+		iter.options.Limit = defaultTaskHistoryPageSize
+	}
+
+	return iter
+}
+
+// Next advances the iterator to the next task, fetching the next page if
+// needed. Returns false when the history is exhausted or an error occurs
+func (it *TaskHistoryIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.idx++
+	for it.idx >= len(it.page) {
+		if it.done {
+			return false
+		}
+
+		resp, err := it.client.GetTaskHistoryPage(&it.options)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.page, it.idx = resp.Tasks, 0
+		it.options.Cursor = resp.NextCursor
+		it.done = resp.NextCursor == ""
+	}
+
+	return true
+}
+
+// Task returns the task the iterator is positioned at
+func (it *TaskHistoryIterator) Task() api_types.ApiHistoricalTask {
+	return it.page[it.idx]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *TaskHistoryIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// newTaskHistoryRelayer creates a client backed by a fake relayer that pages
+// through the given tasks, using the index of the next task as the cursor
+func newTaskHistoryRelayer(t *testing.T, tasks []api_types.ApiHistoricalTask) *RenegadeClient {
+	key, _ := newTestWallet(t)
+	return newTestClient(t, key, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		start, _ := strconv.Atoi(query.Get("cursor"))
+		limit, err := strconv.Atoi(query.Get("limit"))
+		assert.NoError(t, err)
+
+		resp := api_types.TaskHistoryResponse{}
+		end := min(start+limit, len(tasks))
+		resp.Tasks = tasks[start:end]
+		if end < len(tasks) {
+			resp.NextCursor = strconv.Itoa(end)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// historicalTask builds a completed task of the given wallet update type
+func historicalTask(updateType string) api_types.ApiHistoricalTask {
+	return api_types.ApiHistoricalTask{
+		Id:    uuid.New(),
+		State: "Completed",
+		TaskInfo: api_types.ApiHistoricalTaskInfo{
+			TaskType:   "UpdateWallet",
+			UpdateType: updateType,
+		},
+	}
+}
+
+func TestTaskHistoryIterPages(t *testing.T) {
+	var tasks []api_types.ApiHistoricalTask
+	for i := 0; i < 7; i++ {
+		tasks = append(tasks, historicalTask("Deposit"))
+	}
+	c := newTaskHistoryRelayer(t, tasks)
+
+	page, err := c.GetTaskHistoryPage(NewTaskHistoryOptions().WithLimit(3))
+	assert.NoError(t, err)
+	assert.Equal(t, tasks[:3], page.Tasks)
+	assert.Equal(t, "3", page.NextCursor)
+
+	var seen []api_types.ApiHistoricalTask
+	iter := c.TaskHistoryIter(NewTaskHistoryOptions().WithLimit(3))
+	for iter.Next() {
+		seen = append(seen, iter.Task())
+	}
+	assert.NoError(t, iter.Err())
+	assert.Equal(t, tasks, seen)
+}
+
+func TestTaskHistoryFilters(t *testing.T) {
+	tasks := []api_types.ApiHistoricalTask{
+		historicalTask("Deposit"),
+		historicalTask("PlaceOrder"),
+		historicalTask("Deposit"),
+	}
+	tasks[2].State = "Failed"
+	c := newTaskHistoryRelayer(t, tasks)
+
+	// The fake relayer ignores the filters, so they are applied by the client
+	var seen []api_types.ApiHistoricalTask
+	options := NewTaskHistoryOptions().WithLimit(2).WithTaskTypes("deposit").WithStates("completed")
+	iter := c.TaskHistoryIter(options)
+	for iter.Next() {
+		seen = append(seen, iter.Task())
+	}
+	assert.NoError(t, iter.Err())
+	assert.Equal(t, tasks[:1], seen)
+}
+
+func TestBuildTaskHistoryQueryPath(t *testing.T) {
+	walletID := uuid.New()
+	assert.Equal(t,
+		api_types.BuildTaskHistoryPath(walletID),
+		api_types.BuildTaskHistoryQueryPath(walletID, api_types.TaskHistoryQuery{}),
+	)
+
+	path := api_types.BuildTaskHistoryQueryPath(walletID, api_types.TaskHistoryQuery{
+		Limit:     10,
+		Cursor:    "abc",
+		TaskTypes: []string{"Deposit", "Withdraw"},
+	})
+	assert.Equal(t, api_types.BuildTaskHistoryPath(walletID)+"?cursor=abc&limit=10&task_type=Deposit&task_type=Withdraw", path)
+}
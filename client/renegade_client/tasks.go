@@ -1,6 +1,7 @@
 package client
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/renegade-fi/golang-sdk/client"
 	"github.com/renegade-fi/golang-sdk/client/api_types"
 )
 
@@ -18,6 +20,15 @@ const (
 	taskTimeout         = 45 * time.Second
 )
 
+// ErrTaskFailed is returned when a task the client waits on fails in the
+// relayer
+var ErrTaskFailed = errors.New("task failed")
+
+// GetTaskHistory returns the task history of the client's wallet
+func (c *RenegadeClient) GetTaskHistory() ([]api_types.ApiHistoricalTask, error) {
+	return c.getTaskHistory()
+}
+
 // getTaskHistory gets the task history for a given wallet
 func (c *RenegadeClient) getTaskHistory() ([]api_types.ApiHistoricalTask, error) {
 	walletID := c.walletSecrets.Id
@@ -55,7 +66,7 @@ func (c *RenegadeClient) getTaskStatusDirect(taskID uuid.UUID) (string, error) {
 	err := c.httpClient.GetWithAuth(path, nil /* body */, &resp)
 
 	// If the task is no longer registered, check task history
-	if err != nil && strings.Contains(err.Error(), "task not found") {
+	if client.ErrorCodeOf(err) == client.CodeTaskNotFound {
 		return c.getTaskStatusFromHistory(taskID)
 	}
 
@@ -74,15 +85,46 @@ func (c *RenegadeClient) getTaskStatus(taskID uuid.UUID, direct bool) (string, e
 	return c.getTaskStatusFromHistory(taskID)
 }
 
+// TaskStateChangeEvent reports a change in the state of a task the client is
+// waiting on
+type TaskStateChangeEvent struct {
+	// TaskID is the ID of the task
+	TaskID uuid.UUID
+	// State is the task's new state, as reported by the relayer
+	State string
+	// Previous is the task's previous state, empty when the task is first
+	// observed
+	Previous string
+}
+
+// Terminal returns whether the task has completed or failed
+func (e TaskStateChangeEvent) Terminal() bool {
+	state := strings.ToLower(e.State)
+	return state == taskCompletedStatus || state == taskFailedStatus
+}
+
+// OnTaskStateChange registers a callback invoked whenever a task the client
+// waits on, e.g. an order placement or deposit, changes state, returning a
+// function that unregisters it. Callbacks run synchronously in the polling
+// loop, so they should not block
+func (c *RenegadeClient) OnTaskStateChange(callback func(TaskStateChangeEvent)) (unregister func()) {
+	return c.taskObservers.Register(callback)
+}
+
 // waitForTaskGeneric waits for a task to complete or until the timeout is reached
 func (c *RenegadeClient) waitForTaskGeneric(taskID uuid.UUID, direct bool) error {
 	log.Printf("waiting for task %s to complete", taskID)
 	deadline := time.Now().Add(taskTimeout)
+	previous := ""
 	for time.Now().Before(deadline) {
 		state, err := c.getTaskStatus(taskID, direct)
 		if err != nil {
 			return err
 		}
+		if state != previous {
+			c.taskObservers.Notify(TaskStateChangeEvent{TaskID: taskID, State: state, Previous: previous})
+			previous = state
+		}
 
 		// Check for completion or failure
 		state = strings.ToLower(state)
@@ -91,7 +133,7 @@ func (c *RenegadeClient) waitForTaskGeneric(taskID uuid.UUID, direct bool) error
 			return nil
 		} else if state == taskFailedStatus {
 			log.Printf("task %s failed", taskID)
-			return fmt.Errorf("task failed")
+			return ErrTaskFailed
 		}
 
 		time.Sleep(pollingInterval)
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+func TestOnTaskStateChange(t *testing.T) {
+	taskID := uuid.New()
+	key, _ := newTestWallet(t)
+	c := newTestClient(t, key, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != api_types.BuildTaskStatusPath(taskID) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(api_types.TaskResponse{
+			Status: api_types.ApiTaskStatus{ID: taskID, State: "Completed"},
+		})
+	})
+
+	var events []TaskStateChangeEvent
+	c.OnTaskStateChange(func(e TaskStateChangeEvent) { events = append(events, e) })
+
+	assert.NoError(t, c.waitForTaskDirect(taskID))
+	assert.Len(t, events, 1)
+	assert.Equal(t, taskID, events[0].TaskID)
+	assert.Equal(t, "Completed", events[0].State)
+	assert.Empty(t, events[0].Previous)
+	assert.True(t, events[0].Terminal())
+}
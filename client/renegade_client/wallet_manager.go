@@ -0,0 +1,84 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"io"
+	"math/big"
+
+	"github.com/google/uuid"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// WalletManager is the set of wallet operations implemented by
+// RenegadeClient. Applications should depend on this interface so that the
+// client can be swapped for a fake in tests, wrapped with instrumentation, or
+// composed with other backends
+type WalletManager interface {
+	// --- Wallet --- //
+
+	// GetWallet retrieves the current wallet state from the relayer
+	GetWallet() (*wallet.Wallet, error)
+	// GetBackOfQueueWallet retrieves the wallet state after all queued tasks
+	GetBackOfQueueWallet() (*wallet.Wallet, error)
+	// CheckWallet returns the wallet, looking it up if the relayer does not
+	// have it
+	CheckWallet() (*wallet.Wallet, error)
+	// LookupWallet looks up the wallet on-chain and indexes it in the relayer
+	LookupWallet() (*wallet.Wallet, error)
+	// RefreshWallet refreshes the relayer's copy of the wallet from on-chain
+	// state
+	RefreshWallet() (*wallet.Wallet, error)
+	// CreateWallet creates a new wallet in the relayer
+	CreateWallet() (*wallet.Wallet, error)
+	// EnsureWallet returns the wallet, looking it up or creating it as needed
+	EnsureWallet() (*wallet.Wallet, WalletSource, error)
+	// ExportWalletState writes a snapshot of the wallet's state, without its
+	// private keys
+	ExportWalletState(w io.Writer) error
+	// ImportWalletState reads a snapshot written by ExportWalletState
+	ImportWalletState(r io.Reader) (*wallet.Wallet, error)
+
+	// --- Orders --- //
+
+	// PlaceOrder places an order in the wallet
+	PlaceOrder(order *wallet.Order) (*wallet.Wallet, error)
+	// CancelOrder cancels the order with the given ID
+	CancelOrder(orderID uuid.UUID) (*wallet.Wallet, error)
+	// CancelAllOrders cancels every open order in the wallet
+	CancelAllOrders() (*wallet.Wallet, error)
+	// GetOrderStatus returns the relayer's record of the order with the given ID
+	GetOrderStatus(orderID uuid.UUID) (*api_types.ApiOrderMetadata, error)
+	// GetOrderHistory returns the relayer's record of the wallet's orders
+	GetOrderHistory() ([]api_types.ApiOrderMetadata, error)
+
+	// --- Balances --- //
+
+	// Deposit deposits the given amount of a token into the wallet
+	Deposit(mint string, amount *big.Int, ethPrivateKey *ecdsa.PrivateKey) (*wallet.Wallet, error)
+	// DepositAndPlaceOrder deposits a token and places an order selling it
+	DepositAndPlaceOrder(
+		mint string, amount *big.Int, order *wallet.Order, ethPrivateKey *ecdsa.PrivateKey,
+	) (*wallet.Wallet, error)
+	// Withdraw withdraws the given amount of a token to the wallet's owner
+	Withdraw(mint string, amount *big.Int) (*wallet.Wallet, error)
+	// WithdrawToAddress withdraws the given amount of a token to an address
+	WithdrawToAddress(mint string, amount *big.Int, destination string) (*wallet.Wallet, error)
+	// PayFees pays the wallet's outstanding fees
+	PayFees() (*wallet.Wallet, error)
+
+	// --- Tasks --- //
+
+	// GetTaskHistory returns the wallet's task history
+	GetTaskHistory() ([]api_types.ApiHistoricalTask, error)
+	// GetTaskHistoryPage returns a page of the wallet's task history
+	GetTaskHistoryPage(options *TaskHistoryOptions) (*api_types.TaskHistoryResponse, error)
+	// TaskHistoryIter returns an iterator over the wallet's task history
+	TaskHistoryIter(options *TaskHistoryOptions) *TaskHistoryIterator
+	// GetWalletJournal returns the wallet's event journal, built from its task
+	// history
+	GetWalletJournal() ([]WalletEvent, error)
+}
+
+var _ WalletManager = (*RenegadeClient)(nil)
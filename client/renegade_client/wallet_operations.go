@@ -37,9 +37,11 @@ func (c *RenegadeClient) getBackOfQueueWallet() (*wallet.Wallet, error) {
 	}
 
 	// Add the root key to the response, the relayer doesn't have it
-	rootKey := c.walletSecrets.Keychain.PrivateKeys.SkRoot.ToHexString()
 	w := &resp.Wallet
-	w.KeyChain.PrivateKeys.SkRoot = &rootKey
+	if !c.IsReadOnly() {
+		rootKey := c.walletSecrets.Keychain.PrivateKeys.SkRoot.ToHexString()
+		w.KeyChain.PrivateKeys.SkRoot = &rootKey
+	}
 
 	// Convert the ApiWallet to a Wallet
 	wallet, err := w.ToWallet()
@@ -63,6 +65,10 @@ func (c *RenegadeClient) getBackOfQueueWallet() (*wallet.Wallet, error) {
 // share seed, and private keychain (excluding the root key). It then sends a POST
 // request to the relayer and returns the response.
 func (c *RenegadeClient) lookupWallet(blocking bool) error {
+	if err := c.requireRootKey(); err != nil {
+		return err
+	}
+
 	walletID := c.walletSecrets.Id
 	path := api_types.LookupWalletPath
 
@@ -147,6 +153,10 @@ func (c *RenegadeClient) refreshWallet(blocking bool) error {
 // submits a creation request to the Renegade API, and returns the response.
 // This wallet can be used for private transactions within the Renegade network.
 func (c *RenegadeClient) createWallet(blocking bool) error {
+	if err := c.requireRootKey(); err != nil {
+		return err
+	}
+
 	// Create a new empty wallet from the base key
 	newWallet, err := wallet.NewEmptyWalletFromSecrets(c.walletSecrets)
 	if err != nil {
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// ExportWalletState writes a snapshot of the wallet's current state to the
+// given writer, see api_types.WalletSnapshot.
+//
+// The wallet's private keys are omitted, as they may be re-derived from the
+// client's Ethereum key; use ExportWalletStateWithSecrets to include them
+func (c *RenegadeClient) ExportWalletState(w io.Writer) error {
+	return c.exportWalletState(w, false /* includeSecrets */)
+}
+
+// ExportWalletStateWithSecrets writes a snapshot of the wallet's current state,
+// including its private keys, to the given writer
+func (c *RenegadeClient) ExportWalletStateWithSecrets(w io.Writer) error {
+	return c.exportWalletState(w, true /* includeSecrets */)
+}
+
+// exportWalletState fetches the wallet and writes a snapshot of it
+func (c *RenegadeClient) exportWalletState(w io.Writer, includeSecrets bool) error {
+	currentWallet, err := c.getWallet()
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := api_types.NewWalletSnapshot(currentWallet, includeSecrets)
+	if err != nil {
+		return err
+	}
+
+	return api_types.WriteWalletSnapshot(w, snapshot)
+}
+
+// ImportWalletState reads a wallet snapshot written by ExportWalletState from
+// the given reader.
+//
+// The snapshot must be of the client's wallet. The private keys of a snapshot
+// exported without secrets are restored from the client's keychain
+func (c *RenegadeClient) ImportWalletState(r io.Reader) (*wallet.Wallet, error) {
+	snapshot, err := api_types.ReadWalletSnapshot(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.Wallet.Id != c.walletSecrets.Id {
+		return nil, fmt.Errorf(
+			"wallet snapshot is of wallet %s, not %s", snapshot.Wallet.Id, c.walletSecrets.Id,
+		)
+	}
+
+	importedWallet, err := snapshot.ToWallet()
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.SecretsRedacted {
+		importedWallet.Keychain.PrivateKeys = c.walletSecrets.Keychain.PrivateKeys
+	}
+
+	return importedWallet, nil
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// defaultMaxUpdateRetries is the default number of times a wallet update that
+// conflicts with a concurrent update is rebased and retried
+const defaultMaxUpdateRetries = 3
+
+// lockWalletUpdate acquires the wallet update lock, returning a function that
+// releases it and may be called more than once.
+//
+// Each wallet update reads the back of the queue wallet, modifies it, and
+// signs the result. Updates from concurrent goroutines that interleave would
+// build on the same wallet, and all but one would be rejected by the relayer.
+// The lock is held from the read until the relayer enqueues the update, after
+// which the back of the queue reflects it, so callers release it before
+// waiting on the update's task
+func (c *RenegadeClient) lockWalletUpdate() func() {
+	c.walletUpdateMu.Lock()
+	var once sync.Once
+	return func() {
+		once.Do(c.walletUpdateMu.Unlock)
+	}
+}
+
+// SetMaxUpdateRetries sets the number of times a wallet update rejected for
+// conflicting with an update from another process is rebased onto the latest
+// back of the queue wallet and retried. Zero disables retries
+func (c *RenegadeClient) SetMaxUpdateRetries(retries int) {
+	unlock := c.lockWalletUpdate()
+	defer unlock()
+	c.maxUpdateRetries = retries
+}
+
+// updateWallet applies a change to the back of the queue wallet, reblinds and
+// signs the result, and submits it to the relayer, returning the ID of the
+// task enqueued for the update.
+//
+// The lock serializes updates within the process, but another process using
+// the same wallet may still update it between the read and the submission. The
+// relayer then rejects the update's commitment; the change is rebased onto a
+// fresh back of the queue wallet and retried, up to the retry cap
+func (c *RenegadeClient) updateWallet(
+	apply func(*wallet.Wallet) error,
+	submit func(*api_types.WalletUpdateAuthorization) (uuid.UUID, error),
+) (uuid.UUID, error) {
+	// A custom commitment signer authorizes updates without the root key
+	if c.commitmentSigner.Load() == nil {
+		if err := c.requireRootKey(); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	unlock := c.lockWalletUpdate()
+	defer unlock()
+
+	for attempt := 0; ; attempt++ {
+		taskID, err := c.tryUpdateWallet(apply, submit)
+		if err == nil || !isCommitmentConflict(err) || attempt >= c.maxUpdateRetries {
+			return taskID, err
+		}
+		log.Printf("wallet update conflicted with a concurrent update, rebasing (attempt %d): %v", attempt+1, err)
+	}
+}
+
+// tryUpdateWallet makes a single attempt at a wallet update
+func (c *RenegadeClient) tryUpdateWallet(
+	apply func(*wallet.Wallet) error,
+	submit func(*api_types.WalletUpdateAuthorization) (uuid.UUID, error),
+) (uuid.UUID, error) {
+	// Get the back of the queue wallet
+	backOfQueueWallet, err := c.GetBackOfQueueWallet()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	// Apply the change and reblind
+	if err := apply(backOfQueueWallet); err != nil {
+		return uuid.Nil, err
+	}
+	if err := backOfQueueWallet.Reblind(); err != nil {
+		return uuid.Nil, err
+	}
+
+	// Sign the commitment to the new wallet
+	auth, err := getWalletUpdateAuth(backOfQueueWallet, c.getCommitmentSigner(backOfQueueWallet))
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return submit(auth)
+}
+
+// isCommitmentConflict returns whether the relayer rejected an update because
+// it was built on a stale wallet. Other rejections, e.g. of a signature made
+// with the wrong key, would fail again on retry and are surfaced instead
+func isCommitmentConflict(err error) bool {
+	var statusErr *client.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	return statusErr.Code() == client.CodeStaleWallet
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// testRelayer is the state of the fake relayer created by newTestRelayer
+type testRelayer struct {
+	// maxInFlight is the most wallet updates seen in flight between a back of
+	// the queue read and the update's submission
+	maxInFlight atomic.Int32
+	// reads counts back of the queue reads
+	reads atomic.Int32
+	// rejections is the number of order submissions rejected with
+	// rejectStatus and rejectBody, e.g. as if another process had updated the
+	// wallet, before orders are accepted
+	rejections   int32
+	rejectStatus int
+	rejectBody   string
+}
+
+// newTestRelayer creates a client backed by a fake relayer that serves an
+// empty wallet and accepts orders, once the configured rejections are spent
+func newTestRelayer(t *testing.T, relayer *testRelayer) *RenegadeClient {
+	key, emptyWallet := newTestWallet(t)
+	apiWallet := toTestApiWallet(t, emptyWallet)
+
+	var inFlight, submissions atomic.Int32
+	return newTestClient(t, key, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/back-of-queue"):
+			relayer.reads.Add(1)
+			n := inFlight.Add(1)
+			for prev := relayer.maxInFlight.Load(); n > prev; prev = relayer.maxInFlight.Load() {
+				relayer.maxInFlight.CompareAndSwap(prev, n)
+			}
+			time.Sleep(5 * time.Millisecond)
+			_ = json.NewEncoder(w).Encode(api_types.GetWalletResponse{Wallet: *apiWallet})
+		case strings.HasSuffix(r.URL.Path, "/orders"):
+			inFlight.Add(-1)
+			if submissions.Add(1) <= relayer.rejections {
+				w.WriteHeader(relayer.rejectStatus)
+				_, _ = w.Write([]byte(relayer.rejectBody))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(api_types.CreateOrderResponse{TaskId: uuid.New()})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestConcurrentWalletUpdatesAreSerialized(t *testing.T) {
+	relayer := &testRelayer{}
+	c := newTestRelayer(t, relayer)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			order := wallet.NewOrderBuilder().
+				WithBaseMintHex("0x01").
+				WithQuoteMintHex("0x02").
+				WithSide(wallet.Buy).
+				WithAmountBigInt(big.NewInt(100)).
+				Build()
+			assert.NoError(t, c.placeOrder(&order, false /* blocking */))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), relayer.maxInFlight.Load())
+}
+
+func TestWalletUpdateConflictRetry(t *testing.T) {
+	order := wallet.NewOrderBuilder().
+		WithBaseMintHex("0x01").
+		WithQuoteMintHex("0x02").
+		WithSide(wallet.Buy).
+		WithAmountBigInt(big.NewInt(100)).
+		Build()
+	const conflict = "invalid wallet update: commitment does not match"
+	conflicting := func(rejections int32, body string) *testRelayer {
+		return &testRelayer{rejections: rejections, rejectStatus: http.StatusBadRequest, rejectBody: body}
+	}
+
+	// A conflicting update is rebased onto a fresh wallet and retried
+	relayer := conflicting(2, conflict)
+	c := newTestRelayer(t, relayer)
+	assert.NoError(t, c.placeOrder(&order, false /* blocking */))
+	assert.Equal(t, int32(3), relayer.reads.Load())
+
+	// Retries stop at the cap
+	relayer = conflicting(10, conflict)
+	c = newTestRelayer(t, relayer)
+	c.SetMaxUpdateRetries(1)
+	assert.Error(t, c.placeOrder(&order, false /* blocking */))
+	assert.Equal(t, int32(2), relayer.reads.Load())
+
+	// Other failures, including a signature the relayer rejects, are not
+	// retried
+	for _, body := range []string{"order amount too small", "invalid signature"} {
+		relayer = conflicting(1, body)
+		c = newTestRelayer(t, relayer)
+		assert.Error(t, c.placeOrder(&order, false /* blocking */))
+		assert.Equal(t, int32(1), relayer.reads.Load(), body)
+	}
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// WithdrawalProgress reports the outcome of one withdrawal in a WithdrawAll sweep
+type WithdrawalProgress struct {
+	// Mint is the erc20 address of the withdrawn asset
+	Mint string
+	// Amount is the amount withdrawn
+	Amount *big.Int
+	// Index is the zero-based position of this withdrawal in the sweep
+	Index int
+	// Total is the number of withdrawals in the sweep
+	Total int
+	// Err is the error encountered withdrawing this balance, if any
+	Err error
+}
+
+// WithdrawAll withdraws every non-zero balance in the wallet to the given
+// destination address.
+//
+// Outstanding fees are paid first, as the protocol requires, after which one
+// withdrawal is issued per mint. The optional progress callback is invoked
+// after each withdrawal. A failed withdrawal does not stop the sweep; all
+// failures are returned together once every mint has been attempted.
+func (c *RenegadeClient) WithdrawAll(
+	destination string, onProgress func(WithdrawalProgress),
+) (*wallet.Wallet, error) {
+	// Refuse the sweep before paying fees if the destination is not allowed
+	if err := c.checkWithdrawalDestination(destination); err != nil {
+		return nil, err
+	}
+
+	backOfQueueWallet, err := c.getBackOfQueueWallet()
+	if err != nil {
+		return nil, err
+	}
+
+	// Pay fees before withdrawing, waiting for the payments to settle
+	if walletHasFeesDue(backOfQueueWallet) {
+		if err := c.payFeesAndWait(); err != nil {
+			return nil, fmt.Errorf("failed to pay fees before withdrawal: %w", err)
+		}
+	}
+
+	// Withdraw each balance in turn
+	var balances []wallet.Balance
+	for _, balance := range backOfQueueWallet.Balances {
+		if !balance.Amount.IsZero() {
+			balances = append(balances, balance)
+		}
+	}
+
+	var errs []error
+	for i, balance := range balances {
+		mint := mintToAddress(balance.Mint)
+		amount := balance.Amount.ToBigInt()
+		err := c.withdrawToAddress(mint, amount, destination, true /* blocking */)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to withdraw %s: %w", mint, err))
+		}
+
+		if onProgress != nil {
+			onProgress(WithdrawalProgress{
+				Mint:   mint,
+				Amount: amount,
+				Index:  i,
+				Total:  len(balances),
+				Err:    err,
+			})
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return c.getWallet()
+}
+
+// payFeesAndWait pays the wallet's fees and waits for the payment tasks to complete
+func (c *RenegadeClient) payFeesAndWait() error {
+	taskIDs, err := c.payFeesWithTasks()
+	if err != nil {
+		return err
+	}
+
+	for _, taskID := range taskIDs {
+		if err := c.waitForTask(taskID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walletHasFeesDue returns whether any balance in the wallet owes fees
+func walletHasFeesDue(w *wallet.Wallet) bool {
+	for _, balance := range w.Balances {
+		if !balance.RelayerFeeBalance.IsZero() || !balance.ProtocolFeeBalance.IsZero() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mintToAddress formats a mint scalar as a checksummed, 0x-prefixed address
+func mintToAddress(mint wallet.Scalar) string {
+	return common.BigToAddress(mint.ToBigInt()).Hex()
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// DisallowedDestinationError is returned when a withdrawal targets an address
+// that the client's withdrawal policy does not allow
+type DisallowedDestinationError struct {
+	// Destination is the refused withdrawal destination
+	Destination string
+}
+
+// Error implements the error interface
+func (e *DisallowedDestinationError) Error() string {
+	return fmt.Sprintf("withdrawal destination %s is not in the allowlist", e.Destination)
+}
+
+// WithdrawalPolicy is an allowlist of withdrawal destinations. Once a policy is
+// set on a client, withdrawals to any address not in the allowlist are refused
+// with a *DisallowedDestinationError before anything is signed, guarding
+// automated systems against bugs or tampered configuration that would send
+// funds to an arbitrary address. Withdrawals to the wallet's own address are
+// always allowed.
+//
+// A WithdrawalPolicy is safe for concurrent use by multiple goroutines
+type WithdrawalPolicy struct {
+	mu      sync.RWMutex
+	allowed map[common.Address]struct{}
+}
+
+// NewWithdrawalPolicy creates a policy allowing the given hex addresses
+func NewWithdrawalPolicy(destinations ...string) (*WithdrawalPolicy, error) {
+	policy := &WithdrawalPolicy{allowed: make(map[common.Address]struct{})}
+	for _, destination := range destinations {
+		if err := policy.Allow(destination); err != nil {
+			return nil, err
+		}
+	}
+	return policy, nil
+}
+
+// Allow adds a hex address to the allowlist. Mixed-case addresses must carry
+// a valid EIP-55 checksum, and the zero address is rejected
+func (p *WithdrawalPolicy) Allow(destination string) error {
+	if _, err := api_types.NormalizeAddress(destination); err != nil {
+		return fmt.Errorf("invalid withdrawal destination: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.allowed[common.HexToAddress(destination)] = struct{}{}
+	return nil
+}
+
+// Revoke removes a hex address from the allowlist
+func (p *WithdrawalPolicy) Revoke(destination string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.allowed, common.HexToAddress(destination))
+}
+
+// IsAllowed returns whether the allowlist contains the given hex address
+func (p *WithdrawalPolicy) IsAllowed(destination string) bool {
+	if !common.IsHexAddress(destination) {
+		return false
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	_, ok := p.allowed[common.HexToAddress(destination)]
+	return ok
+}
+
+// SetWithdrawalPolicy sets the policy withdrawal destinations are checked
+// against; nil removes the policy, allowing any destination
+func (c *RenegadeClient) SetWithdrawalPolicy(policy *WithdrawalPolicy) {
+	c.withdrawalPolicy.Store(policy)
+}
+
+// checkWithdrawalDestination checks a withdrawal destination against the
+// client's policy, if one is set
+func (c *RenegadeClient) checkWithdrawalDestination(destination string) error {
+	policy := c.withdrawalPolicy.Load()
+	if policy == nil {
+		return nil
+	}
+
+	ownAddress := common.IsHexAddress(destination) &&
+		common.HexToAddress(destination) == common.HexToAddress(c.walletSecrets.Address)
+	if ownAddress || policy.IsAllowed(destination) {
+		return nil
+	}
+	return &DisallowedDestinationError{Destination: destination}
+}
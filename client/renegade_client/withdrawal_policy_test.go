@@ -0,0 +1,163 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+const (
+	allowedDestination    = "0xC5fE800A3D92112473e4E811296F194DA7b26BA7"
+	disallowedDestination = "0x9af58f1ff20ab22e819e40b57ffd784d115a9ef5"
+)
+
+func TestWithdrawalPolicyAllowlist(t *testing.T) {
+	policy, err := NewWithdrawalPolicy(allowedDestination)
+	assert.NoError(t, err)
+
+	// Addresses match regardless of case
+	assert.True(t, policy.IsAllowed(strings.ToLower(allowedDestination)))
+	assert.False(t, policy.IsAllowed(disallowedDestination))
+	assert.False(t, policy.IsAllowed("not an address"))
+
+	policy.Revoke(allowedDestination)
+	assert.False(t, policy.IsAllowed(allowedDestination))
+
+	_, err = NewWithdrawalPolicy("0x1234")
+	assert.Error(t, err)
+	_, err = NewWithdrawalPolicy("0x0000000000000000000000000000000000000000")
+	assert.True(t, errors.Is(err, api_types.ErrInvalidAddress))
+}
+
+func TestWithdrawalToMalformedDestinationRefused(t *testing.T) {
+	relayer := &testRelayer{}
+	c := newTestRelayer(t, relayer)
+
+	// A typo in a checksummed address and a truncated address are refused
+	// before the wallet is read
+	typo := strings.Replace(allowedDestination, "C5fE", "C5FE", 1)
+	for _, destination := range []string{typo, "0x1234"} {
+		_, err := c.WithdrawToAddress("0x01", big.NewInt(100), destination)
+		assert.True(t, errors.Is(err, api_types.ErrInvalidAddress), destination)
+	}
+	assert.Equal(t, int32(0), relayer.reads.Load())
+}
+
+func TestWithdrawalToDisallowedDestinationRefused(t *testing.T) {
+	relayer := &testRelayer{}
+	c := newTestRelayer(t, relayer)
+	policy, err := NewWithdrawalPolicy(allowedDestination)
+	assert.NoError(t, err)
+	c.SetWithdrawalPolicy(policy)
+
+	_, err = c.WithdrawToAddress("0x01", big.NewInt(100), disallowedDestination)
+	var destErr *DisallowedDestinationError
+	assert.True(t, errors.As(err, &destErr))
+	assert.Equal(t, disallowedDestination, destErr.Destination)
+
+	_, err = c.WithdrawAll(disallowedDestination, nil /* onProgress */)
+	assert.True(t, errors.As(err, &destErr))
+
+	// The refusal happens before the wallet is read
+	assert.Equal(t, int32(0), relayer.reads.Load())
+
+	// The wallet's own address and allowlisted addresses pass the check
+	assert.NoError(t, c.checkWithdrawalDestination(c.walletSecrets.Address))
+	assert.NoError(t, c.checkWithdrawalDestination(allowedDestination))
+
+	// Removing the policy allows any destination
+	c.SetWithdrawalPolicy(nil)
+	assert.NoError(t, c.checkWithdrawalDestination(disallowedDestination))
+}
+
+// newWithdrawAllRelayer creates a client backed by a fake relayer serving a
+// wallet with the given balances, recording the updates posted and failing
+// withdrawals of the given mint
+func newWithdrawAllRelayer(
+	t *testing.T, balances []wallet.Balance, failMint string, posted *[]string,
+) *RenegadeClient {
+	key, w := newTestWallet(t)
+	for _, balance := range balances {
+		require.NoError(t, w.AddBalance(balance))
+	}
+	apiWallet := toTestApiWallet(t, w)
+
+	var mu sync.Mutex
+	var tasks []api_types.ApiHistoricalTask
+	return newTestClient(t, key, func(rw http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/task-history"):
+			_ = json.NewEncoder(rw).Encode(api_types.TaskHistoryResponse{Tasks: tasks})
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(rw).Encode(api_types.GetWalletResponse{Wallet: *apiWallet})
+		case failMint != "" && strings.Contains(r.URL.Path, failMint):
+			http.Error(rw, "withdrawal failed", http.StatusInternalServerError)
+		default:
+			*posted = append(*posted, r.URL.Path)
+			taskID := uuid.New()
+			tasks = append(tasks, api_types.ApiHistoricalTask{Id: taskID, State: "Completed"})
+			if strings.HasSuffix(r.URL.Path, "/pay-fees") {
+				_ = json.NewEncoder(rw).Encode(api_types.PayFeesResponse{TaskIds: []uuid.UUID{taskID}})
+				return
+			}
+			_ = json.NewEncoder(rw).Encode(api_types.WithdrawResponse{TaskId: taskID})
+		}
+	})
+}
+
+func TestWithdrawAllPaysFeesThenWithdrawsEachBalance(t *testing.T) {
+	withFees := testBalance(1, 100)
+	withFees.RelayerFeeBalance = new(wallet.Scalar).FromBigInt(big.NewInt(5))
+	balances := []wallet.Balance{withFees, testBalance(2, 50)}
+
+	var posted []string
+	c := newWithdrawAllRelayer(t, balances, "" /* failMint */, &posted)
+	var progress []WithdrawalProgress
+	_, err := c.WithdrawAll(allowedDestination, func(p WithdrawalProgress) { progress = append(progress, p) })
+	require.NoError(t, err)
+
+	walletID := c.walletSecrets.Id
+	assert.Equal(t, []string{
+		api_types.BuildPayFeesPath(walletID),
+		api_types.BuildWithdrawPath(walletID, mintToAddress(balances[0].Mint)),
+		api_types.BuildWithdrawPath(walletID, mintToAddress(balances[1].Mint)),
+	}, posted)
+
+	require.Len(t, progress, 2)
+	assert.Equal(t, big.NewInt(100), progress[0].Amount)
+	assert.Equal(t, 1, progress[1].Index)
+	assert.Equal(t, 2, progress[1].Total)
+	assert.NoError(t, progress[1].Err)
+}
+
+func TestWithdrawAllContinuesPastFailures(t *testing.T) {
+	balances := []wallet.Balance{testBalance(1, 100), testBalance(2, 50)}
+	failMint := mintToAddress(balances[0].Mint)
+
+	var posted []string
+	c := newWithdrawAllRelayer(t, balances, failMint, &posted)
+	var progress []WithdrawalProgress
+	_, err := c.WithdrawAll(allowedDestination, func(p WithdrawalProgress) { progress = append(progress, p) })
+	assert.ErrorContains(t, err, failMint)
+
+	// No fees are owed, and the second balance is withdrawn regardless
+	walletID := c.walletSecrets.Id
+	assert.Equal(t, []string{api_types.BuildWithdrawPath(walletID, mintToAddress(balances[1].Mint))}, posted)
+	require.Len(t, progress, 2)
+	assert.Error(t, progress[0].Err)
+	assert.NoError(t, progress[1].Err)
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// requestIDHeader is the header carrying the per-request correlation ID
+const requestIDHeader = "X-Request-Id"
+
+// requestIDKey is the context key under which a request ID is stored
+type requestIDKey struct{}
+
+// NewRequestID generates a new request ID
+func NewRequestID() string {
+	return uuid.New().String()
+}
+
+// WithRequestID returns a copy of the context carrying the given request ID,
+// which the HttpClient sends in place of a freshly generated one
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID attached to the context, if any
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	return requestID, ok && requestID != ""
+}
+
+// requestIDForContext returns the context's request ID, generating one if unset
+func requestIDForContext(ctx context.Context) string {
+	if requestID, ok := RequestIDFromContext(ctx); ok {
+		return requestID
+	}
+	return NewRequestID()
+}
+
+// RequestError wraps an error with the ID of the request that produced it
+type RequestError struct {
+	// RequestID is the ID sent in the request's correlation header
+	RequestID string
+	// Err is the underlying error
+	Err error
+}
+
+// Error implements the error interface
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%v (request_id=%s)", e.Err, e.RequestID)
+}
+
+// Unwrap returns the underlying error
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// RequestIDFromError returns the request ID of the request that produced the
+// error, if the error chain contains one
+func RequestIDFromError(err error) (string, bool) {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.RequestID, true
+	}
+	return "", false
+}
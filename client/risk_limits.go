@@ -0,0 +1,290 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NotionalDecimals is the number of decimals risk limit notionals are
+// denominated in: a notional of 10^18 is one whole quote token, whatever the
+// decimals of the token itself
+const NotionalDecimals = 18
+
+// ErrUnknownQuoteDecimals is returned when a notional limit is set and a trade
+// is quoted in a token missing from the limits' QuoteDecimals
+var ErrUnknownQuoteDecimals = errors.New("quote token decimals unknown; notional cannot be checked against the risk limits")
+
+// RiskLimits are client side guardrails checked before a trade is sent to the
+// relayer. They are a last line of defense for automated traders, not a
+// substitute for checks in the strategy itself.
+//
+// Notional values are denominated in the trade's quote token with
+// NotionalDecimals decimals, so that one limit bounds trades quoted in tokens
+// of different decimals alike
+type RiskLimits struct {
+	// MaxNotionalPerTrade bounds the notional of a single trade; nil for no
+	// limit
+	MaxNotionalPerTrade *big.Int
+	// MaxDailyVolumePerPair bounds the notional traded per pair each UTC day;
+	// nil for no limit
+	MaxDailyVolumePerPair *big.Int
+	// MaxOpenOrders bounds the number of open orders in a wallet; zero for no
+	// limit. Only enforced by the wallet client
+	MaxOpenOrders int
+	// QuoteDecimals are the decimals of the tokens trades are quoted in, keyed
+	// by mint; a trade's notional in atoms is scaled by them to
+	// NotionalDecimals. While a notional limit is set, trades quoted in a token
+	// not listed are rejected with ErrUnknownQuoteDecimals
+	QuoteDecimals map[string]uint8
+	// Store persists the daily volume counters; nil keeps them in memory, in
+	// which case they reset when the process restarts. See
+	// NewStoreRiskCounterStore to keep them in a Store
+	Store RiskCounterStore
+}
+
+// RiskCounterStore persists the counters behind the daily volume limit, e.g.
+// so that they survive a restart or are shared by several processes
+type RiskCounterStore interface {
+	// Get returns the value of a counter, zero if it has never been set
+	Get(key string) (*big.Int, error)
+	// Add adds a delta to a counter and returns its new value
+	Add(key string, delta *big.Int) (*big.Int, error)
+}
+
+// RiskLimitError is returned when a trade would exceed a risk limit
+type RiskLimitError struct {
+	// Limit names the exceeded limit
+	Limit string
+	// Value is the value the trade would bring the limited quantity to
+	Value *big.Int
+	// Max is the limit's configured maximum
+	Max *big.Int
+}
+
+// Error implements the error interface
+func (e *RiskLimitError) Error() string {
+	return fmt.Sprintf("risk limit exceeded: %s would be %s, limit is %s", e.Limit, e.Value, e.Max)
+}
+
+// MemoryRiskCounterStore is an in-memory RiskCounterStore
+type MemoryRiskCounterStore struct {
+	mu       sync.Mutex
+	counters map[string]*big.Int
+}
+
+// NewMemoryRiskCounterStore creates an empty in-memory counter store
+func NewMemoryRiskCounterStore() *MemoryRiskCounterStore {
+	return &MemoryRiskCounterStore{counters: make(map[string]*big.Int)}
+}
+
+// Get implements RiskCounterStore
+func (s *MemoryRiskCounterStore) Get(key string) (*big.Int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if value, ok := s.counters[key]; ok {
+		return new(big.Int).Set(value), nil
+	}
+	return new(big.Int), nil
+}
+
+// Add implements RiskCounterStore
+func (s *MemoryRiskCounterStore) Add(key string, delta *big.Int) (*big.Int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.counters[key]
+	if !ok {
+		value = new(big.Int)
+		s.counters[key] = value
+	}
+	value.Add(value, delta)
+	return new(big.Int).Set(value), nil
+}
+
+// RiskGuard enforces a set of RiskLimits
+//
+// A RiskGuard is safe for concurrent use by multiple goroutines. Checks and
+// reservations are atomic within the process; a store shared by several
+// processes bounds their combined volume only up to their concurrent
+// reservations
+type RiskGuard struct {
+	limits RiskLimits
+	// quoteDecimals are the limits' quote token decimals, keyed by normalized
+	// mint
+	quoteDecimals map[string]uint8
+	// now returns the current time, overridden in tests
+	now func() time.Time
+
+	mu sync.Mutex
+}
+
+// NewRiskGuard creates a guard enforcing the given limits
+func NewRiskGuard(limits RiskLimits) *RiskGuard {
+	if limits.Store == nil {
+		limits.Store = NewMemoryRiskCounterStore()
+	}
+	quoteDecimals := make(map[string]uint8, len(limits.QuoteDecimals))
+	for mint, decimals := range limits.QuoteDecimals {
+		quoteDecimals[normalizeMint(mint)] = decimals
+	}
+	return &RiskGuard{limits: limits, quoteDecimals: quoteDecimals, now: time.Now}
+}
+
+// Limits returns the limits the guard enforces
+func (g *RiskGuard) Limits() RiskLimits {
+	return g.limits
+}
+
+// CheckTrade checks a trade of the given notional, in atoms of the quote
+// token, against the per-trade and daily volume limits without recording it
+func (g *RiskGuard) CheckTrade(baseMint, quoteMint string, notional *big.Int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	_, _, err := g.checkTrade(baseMint, quoteMint, notional)
+	return err
+}
+
+// ReserveTrade checks a trade against the limits and, if it passes, adds its
+// notional, in atoms of the quote token, to the pair's daily volume
+func (g *RiskGuard) ReserveTrade(baseMint, quoteMint string, notional *big.Int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	key, normalized, err := g.checkTrade(baseMint, quoteMint, notional)
+	if err != nil || g.limits.MaxDailyVolumePerPair == nil {
+		return err
+	}
+	if _, err := g.limits.Store.Add(key, normalized); err != nil {
+		return fmt.Errorf("failed to record trade volume: %w", err)
+	}
+	return nil
+}
+
+// ReleaseTrade returns a reserved trade's notional to the pair's daily volume,
+// e.g. when the trade is not executed after all
+func (g *RiskGuard) ReleaseTrade(baseMint, quoteMint string, notional *big.Int) error {
+	if g.limits.MaxDailyVolumePerPair == nil {
+		return nil
+	}
+
+	normalized, err := g.normalizeNotional(quoteMint, notional)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	key := g.dailyVolumeKey(baseMint, quoteMint)
+	if _, err := g.limits.Store.Add(key, new(big.Int).Neg(normalized)); err != nil {
+		return fmt.Errorf("failed to release trade volume: %w", err)
+	}
+	return nil
+}
+
+// DailyVolume returns the notional reserved for a pair on the current UTC day,
+// with NotionalDecimals decimals. Volume is only counted while a daily volume
+// limit is set
+func (g *RiskGuard) DailyVolume(baseMint, quoteMint string) (*big.Int, error) {
+	return g.limits.Store.Get(g.dailyVolumeKey(baseMint, quoteMint))
+}
+
+// CheckOpenOrders checks that a wallet with the given number of open orders
+// may place another
+func (g *RiskGuard) CheckOpenOrders(open int) error {
+	maxOrders := g.limits.MaxOpenOrders
+	if maxOrders > 0 && open+1 > maxOrders {
+		return &RiskLimitError{
+			Limit: "open orders",
+			Value: big.NewInt(int64(open + 1)),
+			Max:   big.NewInt(int64(maxOrders)),
+		}
+	}
+	return nil
+}
+
+// checkTrade checks a trade against the limits, returning the key of the
+// pair's daily volume counter and the trade's normalized notional, nil if no
+// notional limit is set. The guard's lock must be held
+func (g *RiskGuard) checkTrade(baseMint, quoteMint string, notional *big.Int) (string, *big.Int, error) {
+	key := g.dailyVolumeKey(baseMint, quoteMint)
+	maxNotional, maxVolume := g.limits.MaxNotionalPerTrade, g.limits.MaxDailyVolumePerPair
+	if maxNotional == nil && maxVolume == nil {
+		return key, nil, nil
+	}
+
+	normalized, err := g.normalizeNotional(quoteMint, notional)
+	if err != nil {
+		return "", nil, err
+	}
+	if maxNotional != nil && normalized.Cmp(maxNotional) > 0 {
+		return "", nil, &RiskLimitError{Limit: "trade notional", Value: normalized, Max: maxNotional}
+	}
+	if maxVolume == nil {
+		return key, normalized, nil
+	}
+
+	volume, err := g.limits.Store.Get(key)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read trade volume: %w", err)
+	}
+	if total := new(big.Int).Add(volume, normalized); total.Cmp(maxVolume) > 0 {
+		return "", nil, &RiskLimitError{Limit: "daily pair volume", Value: total, Max: maxVolume}
+	}
+	return key, normalized, nil
+}
+
+// normalizeNotional scales a notional in atoms of the quote token to
+// NotionalDecimals, rounding up
+func (g *RiskGuard) normalizeNotional(quoteMint string, notional *big.Int) (*big.Int, error) {
+	decimals, ok := g.quoteDecimals[normalizeMint(quoteMint)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownQuoteDecimals, quoteMint)
+	}
+
+	exponent := NotionalDecimals - int(decimals)
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exponent))), nil)
+	if exponent >= 0 {
+		return new(big.Int).Mul(notional, scale), nil
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(notional, scale, new(big.Int))
+	if remainder.Sign() > 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	return quotient, nil
+}
+
+// abs returns the absolute value of an integer
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// dailyVolumeKey returns the key of a pair's volume counter for the current
+// UTC day
+func (g *RiskGuard) dailyVolumeKey(baseMint, quoteMint string) string {
+	day := g.now().UTC().Format(time.DateOnly)
+	return fmt.Sprintf("volume:%s:%s:%s", normalizeMint(baseMint), normalizeMint(quoteMint), day)
+}
+
+// dailyVolumeKeyDay returns the UTC day of a daily volume counter's key, see
+// dailyVolumeKey
+func dailyVolumeKeyDay(key string) (time.Time, bool) {
+	if !strings.HasPrefix(key, "volume:") {
+		return time.Time{}, false
+	}
+	day, err := time.Parse(time.DateOnly, key[strings.LastIndex(key, ":")+1:])
+	return day, err == nil
+}
+
+// normalizeMint returns the canonical form of a hex mint address
+func normalizeMint(mint string) string {
+	return strings.ToLower(common.HexToAddress(mint).Hex())
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testBaseMint  = "0xc3414a7ef14aaaa9c4522dfc00a4e66e74e9c25a"
+	testQuoteMint = "0xdf8d259c04020562717557f2b5a3cf28e92707d1"
+)
+
+// testQuoteDecimals gives both test mints 18 decimals, so that notionals are
+// not rescaled
+var testQuoteDecimals = map[string]uint8{testBaseMint: 18, testQuoteMint: 18}
+
+func TestRiskLimitsTradeNotional(t *testing.T) {
+	guard := NewRiskGuard(RiskLimits{MaxNotionalPerTrade: big.NewInt(100), QuoteDecimals: testQuoteDecimals})
+
+	assert.NoError(t, guard.CheckTrade(testBaseMint, testQuoteMint, big.NewInt(100)))
+
+	err := guard.CheckTrade(testBaseMint, testQuoteMint, big.NewInt(101))
+	var limitErr *RiskLimitError
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, "trade notional", limitErr.Limit)
+}
+
+func TestRiskLimitsNormalizeQuoteDecimals(t *testing.T) {
+	// One whole quote token, whatever its decimals
+	limits := RiskLimits{
+		MaxNotionalPerTrade: new(big.Int).Exp(big.NewInt(10), big.NewInt(NotionalDecimals), nil),
+		QuoteDecimals:       map[string]uint8{testBaseMint: 6, testQuoteMint: 18},
+	}
+	guard := NewRiskGuard(limits)
+
+	// A million atoms are one token with 6 decimals, a millionth of one with 18
+	million := big.NewInt(1_000_000)
+	assert.NoError(t, guard.CheckTrade(testQuoteMint, testBaseMint, million))
+	assert.NoError(t, guard.CheckTrade(testBaseMint, testQuoteMint, million))
+	var limitErr *RiskLimitError
+	err := guard.CheckTrade(testQuoteMint, testBaseMint, big.NewInt(1_000_001))
+	assert.True(t, errors.As(err, &limitErr))
+
+	// Tokens with unknown decimals cannot be checked
+	err = guard.CheckTrade(testBaseMint, "0x01", million)
+	assert.ErrorIs(t, err, ErrUnknownQuoteDecimals)
+
+	// Without notional limits, no decimals are needed
+	assert.NoError(t, NewRiskGuard(RiskLimits{}).CheckTrade(testBaseMint, "0x01", million))
+}
+
+func TestRiskLimitsDailyVolume(t *testing.T) {
+	guard := NewRiskGuard(RiskLimits{MaxDailyVolumePerPair: big.NewInt(250), QuoteDecimals: testQuoteDecimals})
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	guard.now = func() time.Time { return now }
+
+	assert.NoError(t, guard.ReserveTrade(testBaseMint, testQuoteMint, big.NewInt(100)))
+	assert.NoError(t, guard.ReserveTrade(testBaseMint, testQuoteMint, big.NewInt(100)))
+
+	// The third trade would exceed the daily volume of the pair...
+	var limitErr *RiskLimitError
+	err := guard.ReserveTrade(testBaseMint, testQuoteMint, big.NewInt(100))
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, big.NewInt(300), limitErr.Value)
+
+	// ...but not of another pair, and mints match regardless of case or padding
+	assert.NoError(t, guard.ReserveTrade(testQuoteMint, testBaseMint, big.NewInt(100)))
+	volume, err := guard.DailyVolume("0x000000000000000000000000C3414A7EF14AAAA9C4522DFC00A4E66E74E9C25A", testQuoteMint)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(200), volume)
+
+	// Releasing a reservation frees its volume
+	assert.NoError(t, guard.ReleaseTrade(testBaseMint, testQuoteMint, big.NewInt(100)))
+	assert.NoError(t, guard.ReserveTrade(testBaseMint, testQuoteMint, big.NewInt(150)))
+
+	// The volume resets on the next UTC day
+	now = now.Add(2 * time.Hour)
+	assert.NoError(t, guard.ReserveTrade(testBaseMint, testQuoteMint, big.NewInt(250)))
+}
+
+func TestRiskLimitsSharedStore(t *testing.T) {
+	store := NewMemoryRiskCounterStore()
+	limits := RiskLimits{MaxDailyVolumePerPair: big.NewInt(100), QuoteDecimals: testQuoteDecimals, Store: store}
+
+	// A guard created later, e.g. after a restart, sees the stored volume
+	assert.NoError(t, NewRiskGuard(limits).ReserveTrade(testBaseMint, testQuoteMint, big.NewInt(80)))
+	err := NewRiskGuard(limits).ReserveTrade(testBaseMint, testQuoteMint, big.NewInt(80))
+	var limitErr *RiskLimitError
+	assert.True(t, errors.As(err, &limitErr))
+}
+
+func TestRiskLimitsOpenOrders(t *testing.T) {
+	guard := NewRiskGuard(RiskLimits{MaxOpenOrders: 2})
+	assert.NoError(t, guard.CheckOpenOrders(1))
+
+	var limitErr *RiskLimitError
+	assert.True(t, errors.As(guard.CheckOpenOrders(2), &limitErr))
+	assert.Equal(t, "open orders", limitErr.Limit)
+
+	assert.NoError(t, NewRiskGuard(RiskLimits{}).CheckOpenOrders(10))
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrClientClosed is returned for requests made after a client is closed or
+// begins shutting down
+var ErrClientClosed = errors.New("client is closed")
+
+// Flusher is a sink that buffers writes, e.g. an audit log wrapped in a
+// bufio.Writer, flushed when the client it is registered with closes
+type Flusher interface {
+	// Flush writes any buffered data to the underlying sink
+	Flush() error
+}
+
+// Flushers is a set of sinks flushed together when a client closes. The zero
+// value is an empty set; a set is safe for concurrent use
+type Flushers struct {
+	mu       sync.Mutex
+	flushers []Flusher
+}
+
+// Add registers a sink to be flushed
+func (f *Flushers) Add(flusher Flusher) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.flushers = append(f.flushers, flusher)
+}
+
+// Flush flushes every registered sink, returning their errors joined
+func (f *Flushers) Flush() error {
+	f.mu.Lock()
+	flushers := append([]Flusher(nil), f.flushers...)
+	f.mu.Unlock()
+
+	var errs []error
+	for _, flusher := range flushers {
+		if err := flusher.Flush(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops the client's background routines, rejects further requests and
+// closes idle connections. Requests in flight are not waited for, see Shutdown
+func (c *HttpClient) Close() {
+	c.markClosed()
+	c.StopKeepAlive()
+	c.httpClient.CloseIdleConnections()
+}
+
+// Shutdown stops the client's background routines and rejects further
+// requests, then waits for requests in flight to complete before closing idle
+// connections. If the context ends first, the context's error is returned and
+// the remaining requests are left to complete on their own
+func (c *HttpClient) Shutdown(ctx context.Context) error {
+	c.markClosed()
+	c.StopKeepAlive()
+
+	select {
+	case <-c.drained():
+	case <-ctx.Done():
+		return fmt.Errorf("failed to drain in-flight requests: %w", ctx.Err())
+	}
+
+	c.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// IsClosed returns whether the client is closed or shutting down
+func (c *HttpClient) IsClosed() bool {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	return c.closed
+}
+
+// beginRequest registers a request in flight, failing if the client is closed.
+// Every successful call must be paired with a call to endRequest
+func (c *HttpClient) beginRequest() error {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	if c.closed {
+		return ErrClientClosed
+	}
+
+	c.inFlight++
+	return nil
+}
+
+// endRequest unregisters a request in flight, signalling a pending shutdown
+// once none remain
+func (c *HttpClient) endRequest() {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	c.inFlight--
+	if c.inFlight == 0 && c.idle != nil {
+		close(c.idle)
+		c.idle = nil
+	}
+}
+
+// markClosed marks the client closed so that new requests are rejected
+func (c *HttpClient) markClosed() {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	c.closed = true
+}
+
+// drained returns a channel that is closed once no requests are in flight
+func (c *HttpClient) drained() <-chan struct{} {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	if c.inFlight == 0 {
+		done := make(chan struct{})
+		close(done)
+		return done
+	}
+
+	if c.idle == nil {
+		c.idle = make(chan struct{})
+	}
+	return c.idle
+}
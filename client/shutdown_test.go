@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdownDrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	client := NewHttpClient(server.URL, nil /* authKey */)
+
+	requestErr := make(chan error, 1)
+	go func() {
+		_, err := client.Get("/slow", nil /* body */)
+		requestErr <- err
+	}()
+	<-started
+
+	shutdownErr := make(chan error, 1)
+	go func() { shutdownErr <- client.Shutdown(context.Background()) }()
+
+	// New requests are rejected while the shutdown waits on the request in flight
+	assert.Eventually(t, client.IsClosed, time.Second, time.Millisecond)
+	_, err := client.Get("/other", nil /* body */)
+	assert.True(t, errors.Is(err, ErrClientClosed))
+	select {
+	case <-shutdownErr:
+		t.Fatal("shutdown returned before the request in flight completed")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	assert.NoError(t, <-requestErr)
+	assert.NoError(t, <-shutdownErr)
+}
+
+func TestShutdownRespectsContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+	client := NewHttpClient(server.URL, nil /* authKey */)
+
+	go func() { _, _ = client.Get("/slow", nil /* body */) }()
+	assert.Eventually(t, func() bool {
+		client.lifecycleMu.Lock()
+		defer client.lifecycleMu.Unlock()
+		return client.inFlight == 1
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := client.Shutdown(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestCloseRejectsRequests(t *testing.T) {
+	server := newTestServer(t, http.StatusOK, `{}`)
+	client := NewHttpClient(server.URL, nil /* authKey */)
+	client.Close()
+
+	_, err := client.Get("/", nil /* body */)
+	assert.True(t, errors.Is(err, ErrClientClosed))
+	assert.True(t, errors.Is(client.Warm(), ErrClientClosed))
+
+	// Shutting down a closed client with nothing in flight returns immediately
+	assert.NoError(t, client.Shutdown(context.Background()))
+}
+
+func TestFlushersJoinErrors(t *testing.T) {
+	var flushed int
+	errFlush := errors.New("disk full")
+	var flushers Flushers
+	flushers.Add(flusherFunc(func() error { flushed++; return errFlush }))
+	flushers.Add(flusherFunc(func() error { flushed++; return nil }))
+
+	// A failing sink does not stop the others from being flushed
+	assert.ErrorIs(t, flushers.Flush(), errFlush)
+	assert.Equal(t, 2, flushed)
+	assert.NoError(t, new(Flushers).Flush())
+}
+
+// flusherFunc adapts a function to a Flusher
+type flusherFunc func() error
+
+// Flush implements Flusher
+func (f flusherFunc) Flush() error {
+	return f()
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// SignedHeader is a header covered by a request's signature, as it appears in
+// the signature preimage
+type SignedHeader struct {
+	// Name is the lower cased header name
+	Name string
+	// Value is the header value
+	Value string
+}
+
+// SigningDebugInfo describes how a request was signed: the preimage the MAC is
+// computed over and the MAC sent. The preimage is the request path, followed
+// by each signed header's lower cased name and value in sorted order, followed
+// by the body as sent, compressed if request compression applies.
+//
+// The info includes the request body, which may hold wallet secrets, but never
+// the signing key
+type SigningDebugInfo struct {
+	// Method is the request method
+	Method string
+	// Path is the request path the preimage begins with
+	Path string
+	// RequestID is the request's correlation ID
+	RequestID string
+	// SignedHeaders are the headers in the preimage, in order
+	SignedHeaders []SignedHeader
+	// Body is the body in the preimage
+	Body []byte
+	// Preimage is the exact payload the MAC is computed over
+	Preimage []byte
+	// Signature is the base64 encoded HMAC-SHA256 sent in the auth header
+	Signature string
+}
+
+// String formats the info for a log line, with the preimage hex encoded
+func (i *SigningDebugInfo) String() string {
+	headers := make([]string, len(i.SignedHeaders))
+	for j, header := range i.SignedHeaders {
+		headers[j] = fmt.Sprintf("%s=%q", header.Name, header.Value)
+	}
+
+	return fmt.Sprintf(
+		"signed %s %s (request_id=%s): headers [%s], body %q, preimage %s, signature %s",
+		i.Method, i.Path, i.RequestID, strings.Join(headers, " "), i.Body,
+		hex.EncodeToString(i.Preimage), i.Signature,
+	)
+}
+
+// LogSigningDebug is a signing debug hook that logs every signed request, see
+// HttpClientOptions.WithSigningDebug
+func LogSigningDebug(info *SigningDebugInfo) {
+	log.Print(info.String())
+}
+
+// emitSigningDebug passes the signing details of a request to the debug hook
+func (c *HttpClient) emitSigningDebug(req *http.Request, body, preimage []byte, signature string) {
+	hook := c.options.SigningDebug
+	if hook == nil {
+		return
+	}
+
+	var headers []SignedHeader
+	for _, key := range signedHeaderKeys(req.Header) {
+		for _, value := range req.Header[key] {
+			headers = append(headers, SignedHeader{Name: strings.ToLower(key), Value: value})
+		}
+	}
+
+	hook(&SigningDebugInfo{
+		Method:        req.Method,
+		Path:          req.URL.Path,
+		RequestID:     req.Header.Get(requestIDHeader),
+		SignedHeaders: headers,
+		Body:          body,
+		Preimage:      preimage,
+		Signature:     signature,
+	})
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+func TestSigningDebugEmitsPreimage(t *testing.T) {
+	server := newTestServer(t, http.StatusUnauthorized, "signature invalid")
+	key := wallet.HmacKey{1, 2, 3}
+
+	var infos []*SigningDebugInfo
+	options := NewHttpClientOptions().WithSigningDebug(func(info *SigningDebugInfo) {
+		infos = append(infos, info)
+	})
+	client := NewHttpClientWithOptions(server.URL, &key, options)
+
+	headers := make(http.Header)
+	headers.Set("X-Renegade-Api-Key", "test-key")
+	headers.Set("X-Unsigned", "ignored")
+	err := client.PostWithAuthAndHeaders("/v0/path", &headers, map[string]int{"a": 1}, nil /* response */)
+	assert.Error(t, err)
+	assert.Len(t, infos, 1)
+
+	info := infos[0]
+	assert.Equal(t, http.MethodPost, info.Method)
+	assert.Equal(t, "/v0/path", info.Path)
+	assert.NotEmpty(t, info.RequestID)
+	assert.Equal(t, `{"a":1}`, string(info.Body))
+
+	// Only renegade headers other than the signature are signed, sorted
+	assert.Len(t, info.SignedHeaders, 2)
+	assert.Equal(t, "x-renegade-api-key", info.SignedHeaders[0].Name)
+	assert.Equal(t, expirationHeader, info.SignedHeaders[1].Name)
+
+	// The preimage is what the signature is computed over
+	assert.True(t, strings.HasPrefix(string(info.Preimage), "/v0/pathx-renegade-api-keytest-key"))
+	mac := hmac.New(sha256.New, key[:])
+	mac.Write(info.Preimage)
+	assert.Equal(t, base64.RawStdEncoding.EncodeToString(mac.Sum(nil)), info.Signature)
+	assert.Contains(t, info.String(), info.Signature)
+
+	// Unauthenticated requests are not signed
+	_, _ = client.Get("/", nil /* body */)
+	assert.Len(t, infos, 1)
+}
@@ -0,0 +1,392 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned by a Store for a key that has no value
+var ErrNotFound = errors.New("key not found")
+
+const (
+	// StoreNamespaceRisk is the namespace of the risk limit counters persisted
+	// by a StoreRiskCounterStore
+	StoreNamespaceRisk = "risk"
+	// RiskCounterRetention is how long a StoreRiskCounterStore keeps the
+	// daily volume counters of past days before removing them
+	RiskCounterRetention = 48 * time.Hour
+)
+
+// Store is a namespaced key-value store for execution bookkeeping, e.g. risk
+// counters and submitted settlements, so that it survives a process restart.
+// Namespaces keep the components sharing a store apart.
+//
+// Implementations must be safe for concurrent use
+type Store interface {
+	// Get returns the value of a key, or ErrNotFound if it has none
+	Get(namespace, key string) ([]byte, error)
+	// Put sets the value of a key
+	Put(namespace, key string, value []byte) error
+	// Delete removes a key; deleting a missing key is not an error
+	Delete(namespace, key string) error
+	// List returns every key and value in a namespace
+	List(namespace string) (map[string][]byte, error)
+}
+
+// MemoryStore is an in-memory Store, for tests and processes whose
+// bookkeeping need not outlive them
+type MemoryStore struct {
+	mu         sync.RWMutex
+	namespaces map[string]map[string][]byte
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{namespaces: make(map[string]map[string][]byte)}
+}
+
+// Get implements Store
+func (s *MemoryStore) Get(namespace, key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.namespaces[namespace][key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return cloneBytes(value), nil
+}
+
+// Put implements Store
+func (s *MemoryStore) Put(namespace, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, ok := s.namespaces[namespace]
+	if !ok {
+		entries = make(map[string][]byte)
+		s.namespaces[namespace] = entries
+	}
+	entries[key] = cloneBytes(value)
+	return nil
+}
+
+// Delete implements Store
+func (s *MemoryStore) Delete(namespace, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.namespaces[namespace], key)
+	return nil
+}
+
+// len returns the number of entries in the store
+func (s *MemoryStore) len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	n := 0
+	for _, entries := range s.namespaces {
+		n += len(entries)
+	}
+	return n
+}
+
+// List implements Store
+func (s *MemoryStore) List(namespace string) (map[string][]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := make(map[string][]byte, len(s.namespaces[namespace]))
+	for key, value := range s.namespaces[namespace] {
+		entries[key] = cloneBytes(value)
+	}
+	return entries, nil
+}
+
+// fileStoreCompactionMinRecords is the number of records below which a
+// FileStore's log is never compacted
+const fileStoreCompactionMinRecords = 1024
+
+// FileStore is a Store persisted to an append-only log file of JSON records,
+// one per write. A write appends its record and syncs the file; once most of
+// the log's records are superseded, it is compacted by rewriting the live
+// entries atomically. The file must not be shared by several processes
+type FileStore struct {
+	path string
+
+	// mu guards the in-memory copy of the file and writes to it
+	mu    sync.Mutex
+	store *MemoryStore
+	// records is the number of records in the log
+	records int
+}
+
+// fileStoreRecord is a write in a FileStore's log
+type fileStoreRecord struct {
+	// Delete is set if the record deletes the key
+	Delete    bool   `json:"delete,omitempty"`
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+	Value     []byte `json:"value,omitempty"`
+}
+
+// OpenFileStore opens the store persisted at the given path, creating it on
+// the first write if the file does not exist. A record torn by a crash while
+// it was appended is discarded
+func OpenFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, store: NewMemoryStore()}
+	contents, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return s, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read store: %w", err)
+	}
+
+	lines := bytes.Split(contents, []byte("\n"))
+	torn := false
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+
+		var record fileStoreRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			if i == len(lines)-1 {
+				torn = true
+				break
+			}
+			return nil, fmt.Errorf("failed to parse store %s: %w", path, err)
+		}
+		s.apply(record)
+		s.records++
+	}
+
+	if torn || s.shouldCompact() {
+		if err := s.compact(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Get implements Store
+func (s *FileStore) Get(namespace, key string) ([]byte, error) {
+	return s.store.Get(namespace, key)
+}
+
+// Put implements Store
+func (s *FileStore) Put(namespace, key string, value []byte) error {
+	return s.write(fileStoreRecord{Namespace: namespace, Key: key, Value: value})
+}
+
+// Delete implements Store
+func (s *FileStore) Delete(namespace, key string) error {
+	return s.write(fileStoreRecord{Delete: true, Namespace: namespace, Key: key})
+}
+
+// List implements Store
+func (s *FileStore) List(namespace string) (map[string][]byte, error) {
+	return s.store.List(namespace)
+}
+
+// write appends a record to the log and applies it, compacting the log if
+// most of it is superseded
+func (s *FileStore) write(record fileStoreRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode store record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.append(append(line, '\n')); err != nil {
+		return err
+	}
+	s.apply(record)
+	s.records++
+
+	if s.shouldCompact() {
+		return s.compact()
+	}
+	return nil
+}
+
+// append appends encoded records to the log and syncs it. The store's lock
+// must be held
+func (s *FileStore) append(records []byte) error {
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open store: %w", err)
+	}
+	if _, err := file.Write(records); err != nil {
+		file.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("failed to write store: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("failed to sync store: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write store: %w", err)
+	}
+	return nil
+}
+
+// apply applies a record to the in-memory copy of the log
+func (s *FileStore) apply(record fileStoreRecord) {
+	if record.Delete {
+		s.store.Delete(record.Namespace, record.Key) //nolint:errcheck,gosec
+		return
+	}
+	s.store.Put(record.Namespace, record.Key, record.Value) //nolint:errcheck,gosec
+}
+
+// shouldCompact returns whether most of the log's records are superseded. The
+// store's lock must be held, or the store not yet shared
+func (s *FileStore) shouldCompact() bool {
+	return s.records >= fileStoreCompactionMinRecords && s.records > 2*s.store.len()
+}
+
+// compact rewrites the log with one record per live entry to a temporary file
+// and renames it over the store's file, so that a crash never leaves a
+// partial log. The store's lock must be held, or the store not yet shared
+func (s *FileStore) compact() error {
+	s.store.mu.RLock()
+	var contents []byte
+	records := 0
+	for namespace, entries := range s.store.namespaces {
+		for key, value := range entries {
+			line, err := json.Marshal(fileStoreRecord{Namespace: namespace, Key: key, Value: value})
+			if err != nil {
+				s.store.mu.RUnlock()
+				return fmt.Errorf("failed to encode store record: %w", err)
+			}
+			contents = append(append(contents, line...), '\n')
+			records++
+		}
+	}
+	s.store.mu.RUnlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to compact store: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("failed to compact store: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("failed to sync store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to compact store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace store: %w", err)
+	}
+
+	s.records = records
+	return nil
+}
+
+// StoreRiskCounterStore is a RiskCounterStore keeping its counters in a Store,
+// under StoreNamespaceRisk, so that daily volumes survive a restart. Once a
+// day, daily volume counters older than RiskCounterRetention are removed
+type StoreRiskCounterStore struct {
+	store Store
+	// now returns the current time, overridden in tests
+	now func() time.Time
+
+	// mu makes additions atomic within the process
+	mu sync.Mutex
+	// prunedDay is the UTC day expired counters were last removed on
+	prunedDay string
+}
+
+// NewStoreRiskCounterStore creates a counter store backed by the given store
+func NewStoreRiskCounterStore(store Store) *StoreRiskCounterStore {
+	return &StoreRiskCounterStore{store: store, now: time.Now}
+}
+
+// Get implements RiskCounterStore
+func (s *StoreRiskCounterStore) Get(key string) (*big.Int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.get(key)
+}
+
+// Add implements RiskCounterStore
+func (s *StoreRiskCounterStore) Add(key string, delta *big.Int) (*big.Int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneExpired()
+
+	value, err := s.get(key)
+	if err != nil {
+		return nil, err
+	}
+	value.Add(value, delta)
+	if err := s.store.Put(StoreNamespaceRisk, key, []byte(value.String())); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// pruneExpired removes the daily volume counters older than the retention,
+// at most once per UTC day. The counters are bookkeeping only, so a failure is
+// logged rather than returned. The lock must be held
+func (s *StoreRiskCounterStore) pruneExpired() {
+	now := s.now().UTC()
+	today := now.Format(time.DateOnly)
+	if s.prunedDay == today {
+		return
+	}
+
+	counters, err := s.store.List(StoreNamespaceRisk)
+	if err != nil {
+		log.Printf("failed to list risk counters: %v", err)
+		return
+	}
+	cutoff := now.Add(-RiskCounterRetention)
+	for key := range counters {
+		day, ok := dailyVolumeKeyDay(key)
+		if !ok || !day.AddDate(0, 0, 1).Before(cutoff) {
+			continue
+		}
+		if err := s.store.Delete(StoreNamespaceRisk, key); err != nil {
+			log.Printf("failed to remove risk counter %s: %v", key, err)
+			return
+		}
+	}
+	s.prunedDay = today
+}
+
+// get reads a counter, zero if it has never been set. The lock must be held
+func (s *StoreRiskCounterStore) get(key string) (*big.Int, error) {
+	raw, err := s.store.Get(StoreNamespaceRisk, key)
+	if errors.Is(err, ErrNotFound) {
+		return new(big.Int), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := new(big.Int).SetString(string(raw), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid counter %s: %q", key, raw)
+	}
+	return value, nil
+}
+
+// cloneBytes copies a byte slice so that callers cannot alias stored values
+func cloneBytes(b []byte) []byte {
+	return append([]byte(nil), b...)
+}
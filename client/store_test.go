@@ -0,0 +1,147 @@
+package client
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+
+	_, err := store.Get("a", "key")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	value := []byte("value")
+	assert.NoError(t, store.Put("a", "key", value))
+	assert.NoError(t, store.Put("b", "key", []byte("other")))
+
+	// Stored values are copies and namespaces are separate
+	value[0] = 'X'
+	got, err := store.Get("a", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), got)
+
+	entries, err := store.List("b")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"key": []byte("other")}, entries)
+
+	assert.NoError(t, store.Delete("a", "key"))
+	assert.NoError(t, store.Delete("a", "missing"))
+	_, err = store.Get("a", "key")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFileStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	store, err := OpenFileStore(path)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Put("a", "kept", []byte("1")))
+	assert.NoError(t, store.Put("a", "deleted", []byte("2")))
+	assert.NoError(t, store.Delete("a", "deleted"))
+
+	reopened, err := OpenFileStore(path)
+	assert.NoError(t, err)
+	entries, err := reopened.List("a")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"kept": []byte("1")}, entries)
+}
+
+func TestFileStoreAppendsRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.log")
+	store, err := OpenFileStore(path)
+	assert.NoError(t, err)
+
+	// Each write appends one record
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, store.Put("a", "key", []byte{byte(i)}))
+	}
+	contents, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, bytes.Count(contents, []byte("\n")))
+
+	// A record torn by a crash is discarded, keeping the writes before it
+	assert.NoError(t, os.WriteFile(path, append(contents, []byte(`{"namespace":"a","ke`)...), 0o600))
+	reopened, err := OpenFileStore(path)
+	assert.NoError(t, err)
+	value, err := reopened.Get("a", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2}, value)
+	assert.NoError(t, reopened.Put("a", "other", []byte("1")))
+	_, err = OpenFileStore(path)
+	assert.NoError(t, err)
+}
+
+func TestFileStoreCompacts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.log")
+	store, err := OpenFileStore(path)
+	assert.NoError(t, err)
+
+	// Overwriting a key leaves the log bounded by its live entries
+	for i := 0; i < 3*fileStoreCompactionMinRecords; i++ {
+		assert.NoError(t, store.Put("a", "key", []byte(strconv.Itoa(i))))
+	}
+	assert.NoError(t, store.Put("a", "kept", []byte("1")))
+	contents, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Less(t, bytes.Count(contents, []byte("\n")), fileStoreCompactionMinRecords+1)
+
+	reopened, err := OpenFileStore(path)
+	assert.NoError(t, err)
+	entries, err := reopened.List("a")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"key":  []byte(strconv.Itoa(3*fileStoreCompactionMinRecords - 1)),
+		"kept": []byte("1"),
+	}, entries)
+}
+
+func TestRiskCountersExpire(t *testing.T) {
+	store := NewMemoryStore()
+	counters := NewStoreRiskCounterStore(store)
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	counters.now = func() time.Time { return now }
+
+	stale := "volume:0x01:0x02:2024-03-07"
+	recent := "volume:0x01:0x02:2024-03-09"
+	assert.NoError(t, store.Put(StoreNamespaceRisk, stale, []byte("5")))
+	assert.NoError(t, store.Put(StoreNamespaceRisk, recent, []byte("5")))
+	assert.NoError(t, store.Put(StoreNamespaceRisk, "other", []byte("5")))
+
+	// Counters of days past the retention are removed on the next addition
+	_, err := counters.Add("volume:0x01:0x02:2024-03-10", big.NewInt(1))
+	assert.NoError(t, err)
+	entries, err := store.List(StoreNamespaceRisk)
+	assert.NoError(t, err)
+	assert.NotContains(t, entries, stale)
+	assert.Contains(t, entries, recent)
+	assert.Contains(t, entries, "other")
+}
+
+func TestRiskCountersSurviveRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	store, err := OpenFileStore(path)
+	assert.NoError(t, err)
+	limits := RiskLimits{
+		MaxDailyVolumePerPair: big.NewInt(100),
+		QuoteDecimals:         testQuoteDecimals,
+		Store:                 NewStoreRiskCounterStore(store),
+	}
+	assert.NoError(t, NewRiskGuard(limits).ReserveTrade(testBaseMint, testQuoteMint, big.NewInt(80)))
+
+	// A guard created after a restart sees the volume already traded
+	reopened, err := OpenFileStore(path)
+	assert.NoError(t, err)
+	limits.Store = NewStoreRiskCounterStore(reopened)
+	guard := NewRiskGuard(limits)
+	volume, err := guard.DailyVolume(testBaseMint, testQuoteMint)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(80), volume)
+	assert.Error(t, guard.ReserveTrade(testBaseMint, testQuoteMint, big.NewInt(30)))
+}
@@ -0,0 +1,93 @@
+package crypto
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// CSPRNG is a stream of field elements derived deterministically from a seed
+type CSPRNG interface {
+	// Next returns the next element of the stream
+	Next() fr.Element
+	// NextN returns the next n elements of the stream
+	NextN(n int) []fr.Element
+	// Position returns the number of elements drawn since the stream was
+	// seeded
+	Position() uint64
+	// Reseed restarts the stream from the given seed at position zero
+	Reseed(seed fr.Element)
+	// Fork returns an independent copy of the stream at its current position;
+	// drawing from one does not advance the other
+	Fork() CSPRNG
+}
+
+// PoseidonCSPRNG is a CSPRNG based on the Poseidon2 permutation, matching the
+// relayer's share and blinder streams.
+//
+// The stream is a hash chain without further domain separation: with state
+// s_0 = seed, the i-th element (counting from one) is
+// s_i = Poseidon2Sponge.Hash([s_{i-1}]), a single-element absorb followed by a
+// single squeeze, and s_i becomes the new state. Each element therefore
+// determines every element after it, which is what lets a wallet's shares be
+// recovered from the last share seen on-chain, see ResumePoseidonCSPRNG. The
+// stream is forward secure only in the sense that earlier elements cannot be
+// computed from later ones; callers that need elements unlinkable to their
+// successors must Reseed from an independent seed
+type PoseidonCSPRNG struct {
+	state    fr.Element
+	position uint64
+}
+
+// NewPoseidonCSPRNG creates a new PoseidonCSPRNG instance
+func NewPoseidonCSPRNG(seed fr.Element) *PoseidonCSPRNG {
+	return &PoseidonCSPRNG{state: seed}
+}
+
+// ResumePoseidonCSPRNG resumes a stream after the element drawn at the given
+// position, without regenerating the stream from its seed. For example, a
+// wallet's next private shares are drawn from a stream seeded with its last
+// private share, so a recovery tool resumes from a share it already knows
+func ResumePoseidonCSPRNG(last fr.Element, position uint64) *PoseidonCSPRNG {
+	return &PoseidonCSPRNG{state: last, position: position}
+}
+
+// Next returns the next scalar in the CSPRNG
+func (p *PoseidonCSPRNG) Next() fr.Element {
+	p.state = NewPoseidon2Sponge().Hash([]fr.Element{p.state})
+	p.position++
+	return p.state
+}
+
+// NextN returns the next n scalars in the CSPRNG
+func (p *PoseidonCSPRNG) NextN(n int) []fr.Element {
+	result := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		result[i] = p.Next()
+	}
+
+	return result
+}
+
+// Position returns the number of elements drawn since the stream was seeded,
+// or the position it was resumed at plus those drawn since
+func (p *PoseidonCSPRNG) Position() uint64 {
+	return p.position
+}
+
+// Reseed restarts the stream from the given seed at position zero
+func (p *PoseidonCSPRNG) Reseed(seed fr.Element) {
+	p.state = seed
+	p.position = 0
+}
+
+// Fork returns an independent copy of the stream at its current position
+func (p *PoseidonCSPRNG) Fork() CSPRNG {
+	fork := *p
+	return &fork
+}
+
+// Skip advances the stream by n elements, discarding them
+func (p *PoseidonCSPRNG) Skip(n uint64) {
+	for i := uint64(0); i < n; i++ {
+		p.Next()
+	}
+}
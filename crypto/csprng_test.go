@@ -0,0 +1,50 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoseidonCSPRNGHashChain(t *testing.T) {
+	seed := fr.NewElement(42)
+	csprng := NewPoseidonCSPRNG(seed)
+
+	// Each element is the hash of the one before it
+	first := csprng.Next()
+	assert.Equal(t, NewPoseidon2Sponge().Hash([]fr.Element{seed}), first)
+	second := csprng.Next()
+	assert.Equal(t, NewPoseidon2Sponge().Hash([]fr.Element{first}), second)
+	assert.Equal(t, uint64(2), csprng.Position())
+}
+
+func TestPoseidonCSPRNGResume(t *testing.T) {
+	csprng := NewPoseidonCSPRNG(fr.NewElement(7))
+	elts := csprng.NextN(5)
+
+	// Resuming from the third element continues the original stream
+	resumed := ResumePoseidonCSPRNG(elts[2], 3 /* position */)
+	assert.Equal(t, elts[3:], resumed.NextN(2))
+	assert.Equal(t, csprng.Position(), resumed.Position())
+
+	skipped := NewPoseidonCSPRNG(fr.NewElement(7))
+	skipped.Skip(4)
+	assert.Equal(t, elts[4], skipped.Next())
+}
+
+func TestPoseidonCSPRNGForkAndReseed(t *testing.T) {
+	var csprng CSPRNG = NewPoseidonCSPRNG(fr.NewElement(1))
+	csprng.Next()
+
+	// A fork draws the same stream without advancing the original
+	fork := csprng.Fork()
+	expected := fork.NextN(3)
+	assert.Equal(t, uint64(4), fork.Position())
+	assert.Equal(t, uint64(1), csprng.Position())
+	assert.Equal(t, expected, csprng.NextN(3))
+
+	csprng.Reseed(fr.NewElement(1))
+	assert.Equal(t, uint64(0), csprng.Position())
+	assert.Equal(t, NewPoseidonCSPRNG(fr.NewElement(1)).Next(), csprng.Next())
+}
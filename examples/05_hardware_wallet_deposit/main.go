@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	renegade_client "github.com/renegade-fi/golang-sdk/client/renegade_client"
+	renegade_wallet "github.com/renegade-fi/golang-sdk/wallet"
+	"github.com/renegade-fi/golang-sdk/wallet/ledger"
+)
+
+const (
+	// baseUrl is the location at which to dial the relayer
+	baseUrl = "https://testnet.cluster0.renegade.fi:3000"
+
+	// wbtcMint and usdcMint are the testnet wBTC and USDC tokens
+	wbtcMint = "0xa91d929ea161688448f61cb3865a6d948d8bd904"
+	usdcMint = "0x404b26cd9055b35581c68ba9a2b878cca971b0a7"
+)
+
+// Funds a Renegade wallet from an account on a Ledger, then places an order.
+//
+// The Ledger signs the deposit's Permit2 permit, which is EIP-712 typed data.
+// The wallet itself is derived from, and its updates signed by, a separate
+// key held in software: wallet updates are authorized by signatures over raw
+// digests, which the Ledger Ethereum app does not produce.
+//
+// The Ledger account must already have approved the Permit2 contract to spend
+// the deposit, e.g. from Ledger Live or any wallet software.
+func main() {
+	device := flag.String("device", "/dev/hidraw0", "hidraw node of the ledger, with the Ethereum app open")
+	walletKey := flag.String("wallet-key", "", "hex encoded private key the Renegade wallet is derived from")
+	flag.Parse()
+
+	// Open the Ledger and look up its account
+	hid, err := ledger.OpenHIDRaw(*device)
+	if err != nil {
+		panic(err)
+	}
+	defer hid.Close()
+	ledgerDevice := ledger.NewDevice(ledger.NewHIDTransport(hid), ledger.DefaultDerivationPath)
+	from, err := ledgerDevice.Address()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Depositing from ledger account %s\n", from.Hex())
+
+	// Create a client for the wallet
+	privateKey, err := crypto.HexToECDSA(*walletKey)
+	if err != nil {
+		panic(err)
+	}
+	client, err := renegade_client.NewSepoliaRenegadeClient(baseUrl, privateKey)
+	if err != nil {
+		panic(err)
+	}
+	if _, err := client.CheckWallet(); err != nil {
+		panic(err)
+	}
+
+	// 1. Sign a deposit permit on the Ledger; the device displays the domain
+	// and message hashes to confirm
+	amount := big.NewInt(1_000_000) // 0.01 wBTC
+	permit, err := client.NewDepositPermit(wbtcMint, amount, from)
+	if err != nil {
+		panic(err)
+	}
+	domainSeparator, structHash := permit.EIP712Hashes()
+	fmt.Printf("Confirm on the device:\n  domain hash:  %s\n  message hash: %s\n", domainSeparator.Hex(), structHash.Hex())
+	signature, err := ledgerDevice.SignTypedDataHash(domainSeparator, structHash)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Depositing...")
+	wallet, err := client.DepositWithSignature(permit, signature)
+	if err != nil {
+		panic(err)
+	}
+
+	// 2. Place an order, a wallet update signed with the wallet's root key
+	balance, _ := wallet.GetBalance(wbtcMint)
+	order := renegade_wallet.NewOrderBuilder().
+		WithBaseMintHex(wbtcMint).
+		WithQuoteMintHex(usdcMint).
+		WithAmountBigInt(balance).
+		WithSide(renegade_wallet.OrderSide_SELL).
+		Build()
+
+	fmt.Println("Placing order...")
+	if _, err := client.PlaceOrder(&order); err != nil {
+		panic(err)
+	}
+	fmt.Println("Order placed")
+}
@@ -0,0 +1,273 @@
+// Package gassponsor reads the gas sponsorship contract that refunds the gas
+// of sponsored external matches: its remaining budget, the refunds recorded
+// in settlement receipts, and the refund options of sponsored settlements
+package gassponsor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/renegade-fi/golang-sdk/abis"
+)
+
+// sponsoredSettleMethod is the sponsor method that settles an external match
+// with refund options
+const sponsoredSettleMethod = "sponsorAtomicMatchSettleWithRefundOptions"
+
+var (
+	// ErrNotSponsoredSettlement is returned when calldata does not call the
+	// sponsor's settlement method
+	ErrNotSponsoredSettlement = errors.New("calldata is not a sponsored settlement")
+	// ErrRefundAddressMismatch matches every *RefundAddressMismatchError with
+	// errors.Is
+	ErrRefundAddressMismatch = errors.New("refund address mismatch")
+)
+
+// Backend is the chain access the sponsor reads need, e.g. an
+// *ethclient.Client
+type Backend interface {
+	bind.ContractCaller
+	// BalanceAt returns the wei balance of an account at a block, the latest
+	// if nil
+	BalanceAt(ctx context.Context, account geth_common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// Sponsor reads the gas sponsorship contract at an address
+type Sponsor struct {
+	backend  Backend
+	address  geth_common.Address
+	contract *abis.GasSponsorCaller
+}
+
+// NewSponsor creates a reader for the gas sponsorship contract at the given
+// address
+func NewSponsor(backend Backend, address geth_common.Address) (*Sponsor, error) {
+	contract, err := abis.NewGasSponsorCaller(address, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind gas sponsor: %w", err)
+	}
+	return &Sponsor{backend: backend, address: address, contract: contract}, nil
+}
+
+// Address returns the address of the contract
+func (s *Sponsor) Address() geth_common.Address {
+	return s.address
+}
+
+// Budget is what remains for the sponsor to refund
+type Budget struct {
+	// Paused is set if the sponsor is paused, in which case no match is
+	// refunded whatever its balances
+	Paused bool
+	// NativeBalance is the sponsor's wei balance, which pays native ETH
+	// refunds
+	NativeBalance *big.Int
+	// TokenBalances are the sponsor's balances of the requested tokens, in
+	// their smallest denomination, which pay in-kind refunds
+	TokenBalances map[geth_common.Address]*big.Int
+}
+
+// CanRefund returns whether the budget covers a refund of the given amount,
+// in native ETH if token is the zero address
+func (b *Budget) CanRefund(token geth_common.Address, amount *big.Int) bool {
+	if b.Paused {
+		return false
+	}
+
+	balance := b.NativeBalance
+	if token != (geth_common.Address{}) {
+		balance = b.TokenBalances[token]
+	}
+	return balance != nil && balance.Cmp(amount) >= 0
+}
+
+// RemainingBudget returns the sponsor's pause state, its native balance, and
+// its balances of the given tokens. A refund the sponsor cannot cover is
+// skipped rather than failing the settlement
+func (s *Sponsor) RemainingBudget(ctx context.Context, tokens ...geth_common.Address) (*Budget, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	paused, err := s.contract.Paused(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sponsor pause state: %w", err)
+	}
+	native, err := s.backend.BalanceAt(ctx, s.address, nil /* blockNumber */)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sponsor balance: %w", err)
+	}
+
+	budget := &Budget{
+		Paused:        paused,
+		NativeBalance: native,
+		TokenBalances: make(map[geth_common.Address]*big.Int, len(tokens)),
+	}
+	for _, token := range tokens {
+		erc20, err := abis.NewContractsCaller(token, s.backend)
+		if err != nil {
+			return nil, fmt.Errorf("failed to bind token %s: %w", token.Hex(), err)
+		}
+		balance, err := erc20.BalanceOf(opts, s.address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sponsor balance of %s: %w", token.Hex(), err)
+		}
+		budget.TokenBalances[token] = balance
+	}
+	return budget, nil
+}
+
+// IsNonceUsed returns whether a sponsorship nonce has been spent, i.e. the
+// sponsored quote it was issued for has settled
+func (s *Sponsor) IsNonceUsed(ctx context.Context, nonce *big.Int) (bool, error) {
+	return s.contract.IsNonceUsed(&bind.CallOpts{Context: ctx}, nonce)
+}
+
+// Refund is a refund the sponsor recorded in a settlement receipt
+type Refund struct {
+	// Nonce is the sponsorship nonce the refund was paid for
+	Nonce *big.Int
+	// Token is the token refunded, the zero address for native ETH
+	Token geth_common.Address
+	// Amount is the amount refunded, in wei for native ETH and otherwise in
+	// the token's smallest denomination; zero if skipped
+	Amount *big.Int
+	// Skipped is set if the sponsor's budget could not cover the refund, in
+	// which case the match settled without one
+	Skipped bool
+}
+
+// NativeEth returns whether the refund was paid in native ETH
+func (r *Refund) NativeEth() bool {
+	return r.Token == (geth_common.Address{})
+}
+
+// ParseRefunds returns the refunds the sponsor at the given address recorded
+// in a receipt, in log order. Logs of other contracts are ignored
+func ParseRefunds(receipt *types.Receipt, sponsor geth_common.Address) ([]Refund, error) {
+	filterer, err := abis.NewGasSponsorFilterer(sponsor, nil /* filterer */)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := abis.GasSponsorMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	sponsoredID := parsed.Events["SponsoredExternalMatch"].ID
+	insufficientID := parsed.Events["InsufficientSponsorBalance"].ID
+
+	var refunds []Refund
+	for _, log := range receipt.Logs {
+		if log.Address != sponsor || len(log.Topics) == 0 {
+			continue
+		}
+
+		switch log.Topics[0] {
+		case sponsoredID:
+			event, err := filterer.ParseSponsoredExternalMatch(*log)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse refund event: %w", err)
+			}
+			refunds = append(refunds, Refund{Nonce: event.Nonce, Token: event.Token, Amount: event.Amount})
+		case insufficientID:
+			event, err := filterer.ParseInsufficientSponsorBalance(*log)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse skipped refund event: %w", err)
+			}
+			refunds = append(refunds, Refund{Nonce: event.Nonce, Amount: new(big.Int), Skipped: true})
+		}
+	}
+	return refunds, nil
+}
+
+// SponsoredSettlement is the refund options of a sponsored settlement
+// transaction
+type SponsoredSettlement struct {
+	// Receiver is the address that receives the external party's output, the
+	// zero address for the sender
+	Receiver geth_common.Address
+	// RefundAddress is the address the refund is paid to, the zero address
+	// for the sender
+	RefundAddress geth_common.Address
+	// Nonce is the sponsorship nonce
+	Nonce *big.Int
+	// RefundNativeEth is set if the refund is paid in native ETH
+	RefundNativeEth bool
+	// RefundAmount is the refund the sponsorship was signed for
+	RefundAmount *big.Int
+}
+
+// DecodeSponsoredSettlement decodes the refund options of the calldata of a
+// sponsored settlement transaction.
+//
+// Returns ErrNotSponsoredSettlement if the calldata does not call the
+// sponsor's settlement method, e.g. because the match is settled directly
+// with the darkpool
+func DecodeSponsoredSettlement(data []byte) (*SponsoredSettlement, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("calldata too short: %d bytes", len(data))
+	}
+	parsed, err := abis.GasSponsorMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := parsed.MethodById(data[:4])
+	if err != nil || method.Name != sponsoredSettleMethod {
+		return nil, fmt.Errorf("%w: selector %x", ErrNotSponsoredSettlement, data[:4])
+	}
+
+	args := make(map[string]interface{})
+	if err := method.Inputs.UnpackIntoMap(args, data[4:]); err != nil {
+		return nil, fmt.Errorf("failed to decode %s calldata: %w", method.Name, err)
+	}
+
+	settlement := &SponsoredSettlement{}
+	settlement.Receiver, _ = args["receiver"].(geth_common.Address)
+	settlement.RefundAddress, _ = args["refund_address"].(geth_common.Address)
+	settlement.Nonce, _ = args["nonce"].(*big.Int)
+	settlement.RefundNativeEth, _ = args["refund_native_eth"].(bool)
+	settlement.RefundAmount, _ = args["refund_amount"].(*big.Int)
+	return settlement, nil
+}
+
+// RefundAddressMismatchError is returned when a sponsored settlement refunds
+// an address other than the one expected
+type RefundAddressMismatchError struct {
+	// Expected is the address expected to be refunded
+	Expected geth_common.Address
+	// Actual is the address the settlement refunds
+	Actual geth_common.Address
+}
+
+// Error implements the error interface
+func (e *RefundAddressMismatchError) Error() string {
+	return fmt.Sprintf("settlement refunds %s, expected %s", e.Actual.Hex(), e.Expected.Hex())
+}
+
+// Unwrap allows matching the error against ErrRefundAddressMismatch
+func (e *RefundAddressMismatchError) Unwrap() error {
+	return ErrRefundAddressMismatch
+}
+
+// VerifyRefundAddress checks that the sponsored settlement calldata, sent by
+// sender, refunds the expected address. A settlement that names no refund
+// address refunds its sender
+func VerifyRefundAddress(data []byte, sender, expected geth_common.Address) error {
+	settlement, err := DecodeSponsoredSettlement(data)
+	if err != nil {
+		return err
+	}
+
+	actual := settlement.RefundAddress
+	if actual == (geth_common.Address{}) {
+		actual = sender
+	}
+	if actual != expected {
+		return &RefundAddressMismatchError{Expected: expected, Actual: actual}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package gassponsor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/abis"
+)
+
+var (
+	testSponsor = geth_common.HexToAddress("0x5c7A38B6E2A1D0C3c4F5e6a7b8C9d0E1f2A3b4C5")
+	testUsdc    = geth_common.HexToAddress("0xaf88d065e77c8cC2239327C5EDb3A432268e5831")
+	testSender  = geth_common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testRefund  = geth_common.HexToAddress("0x2222222222222222222222222222222222222222")
+)
+
+// sponsorABI parses the sponsor's ABI
+func sponsorABI(t *testing.T) *abi.ABI {
+	parsed, err := abis.GasSponsorMetaData.GetAbi()
+	assert.NoError(t, err)
+	return parsed
+}
+
+// fakeBackend serves the sponsor's pause state and balances
+type fakeBackend struct {
+	t             *testing.T
+	paused        bool
+	native        int64
+	tokenBalances map[geth_common.Address]int64
+}
+
+func (f *fakeBackend) CodeAt(context.Context, geth_common.Address, *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (f *fakeBackend) CallContract(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	if *msg.To == testSponsor {
+		return sponsorABI(f.t).Methods["paused"].Outputs.Pack(f.paused)
+	}
+
+	erc20, err := abis.ContractsMetaData.GetAbi()
+	assert.NoError(f.t, err)
+	return erc20.Methods["balanceOf"].Outputs.Pack(big.NewInt(f.tokenBalances[*msg.To]))
+}
+
+func (f *fakeBackend) BalanceAt(context.Context, geth_common.Address, *big.Int) (*big.Int, error) {
+	return big.NewInt(f.native), nil
+}
+
+func TestRemainingBudget(t *testing.T) {
+	backend := &fakeBackend{t: t, native: 1000, tokenBalances: map[geth_common.Address]int64{testUsdc: 50}}
+	sponsor, err := NewSponsor(backend, testSponsor)
+	assert.NoError(t, err)
+
+	budget, err := sponsor.RemainingBudget(context.Background(), testUsdc)
+	assert.NoError(t, err)
+	assert.False(t, budget.Paused)
+	assert.Equal(t, big.NewInt(1000), budget.NativeBalance)
+	assert.Equal(t, big.NewInt(50), budget.TokenBalances[testUsdc])
+
+	assert.True(t, budget.CanRefund(geth_common.Address{}, big.NewInt(1000)))
+	assert.False(t, budget.CanRefund(geth_common.Address{}, big.NewInt(1001)))
+	assert.True(t, budget.CanRefund(testUsdc, big.NewInt(50)))
+	assert.False(t, budget.CanRefund(testSender, big.NewInt(1)))
+
+	// A paused sponsor refunds nothing
+	backend.paused = true
+	budget, err = sponsor.RemainingBudget(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, budget.CanRefund(geth_common.Address{}, big.NewInt(1)))
+}
+
+func TestParseRefunds(t *testing.T) {
+	parsed := sponsorABI(t)
+	sponsored := parsed.Events["SponsoredExternalMatch"]
+	data, err := sponsored.Inputs.NonIndexed().Pack(big.NewInt(42), testUsdc)
+	assert.NoError(t, err)
+	nonce := geth_common.BigToHash(big.NewInt(7))
+
+	receipt := &types.Receipt{Logs: []*types.Log{
+		// Logs of other contracts are ignored
+		{Address: testUsdc, Topics: []geth_common.Hash{sponsored.ID, nonce}, Data: data},
+		{Address: testSponsor, Topics: []geth_common.Hash{sponsored.ID, nonce}, Data: data},
+		{Address: testSponsor, Topics: []geth_common.Hash{
+			parsed.Events["InsufficientSponsorBalance"].ID, geth_common.BigToHash(big.NewInt(8)),
+		}},
+	}}
+
+	refunds, err := ParseRefunds(receipt, testSponsor)
+	assert.NoError(t, err)
+	assert.Len(t, refunds, 2)
+	assert.Equal(t, big.NewInt(7), refunds[0].Nonce)
+	assert.Equal(t, testUsdc, refunds[0].Token)
+	assert.Equal(t, big.NewInt(42), refunds[0].Amount)
+	assert.False(t, refunds[0].NativeEth())
+	assert.False(t, refunds[0].Skipped)
+	assert.Equal(t, big.NewInt(8), refunds[1].Nonce)
+	assert.True(t, refunds[1].Skipped)
+}
+
+func TestVerifyRefundAddress(t *testing.T) {
+	parsed := sponsorABI(t)
+	calldata := func(refundAddress geth_common.Address) []byte {
+		data, err := parsed.Pack(
+			sponsoredSettleMethod, testSender, []byte{1}, []byte{2}, []byte{3}, []byte{4},
+			refundAddress, big.NewInt(7), true /* refundNativeEth */, big.NewInt(42), []byte{5},
+		)
+		assert.NoError(t, err)
+		return data
+	}
+
+	settlement, err := DecodeSponsoredSettlement(calldata(testRefund))
+	assert.NoError(t, err)
+	assert.Equal(t, testRefund, settlement.RefundAddress)
+	assert.Equal(t, big.NewInt(7), settlement.Nonce)
+	assert.True(t, settlement.RefundNativeEth)
+	assert.Equal(t, big.NewInt(42), settlement.RefundAmount)
+
+	assert.NoError(t, VerifyRefundAddress(calldata(testRefund), testSender, testRefund))
+	err = VerifyRefundAddress(calldata(testRefund), testSender, testSender)
+	var mismatch *RefundAddressMismatchError
+	assert.True(t, errors.As(err, &mismatch))
+	assert.ErrorIs(t, err, ErrRefundAddressMismatch)
+	assert.Equal(t, testRefund, mismatch.Actual)
+
+	// A settlement naming no refund address refunds its sender
+	assert.NoError(t, VerifyRefundAddress(calldata(geth_common.Address{}), testSender, testSender))
+
+	// Calldata of other methods is not a sponsored settlement
+	paused, err := parsed.Pack("paused")
+	assert.NoError(t, err)
+	_, err = DecodeSponsoredSettlement(paused)
+	assert.ErrorIs(t, err, ErrNotSponsoredSettlement)
+}
@@ -0,0 +1,293 @@
+// Package apigen generates Go request/response types and endpoint paths from
+// the subset of an OpenAPI 3 specification used to describe the relayer's API
+package apigen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// refPrefix is the prefix of references to component schemas
+const refPrefix = "#/components/schemas/"
+
+// Spec is the subset of an OpenAPI 3 document understood by the generator
+type Spec struct {
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components struct {
+		Schemas map[string]Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Operation is an operation on a path
+type Operation struct {
+	OperationID string      `json:"operationId"`
+	Description string      `json:"description"`
+	Parameters  []Parameter `json:"parameters"`
+}
+
+// Parameter is a path or query parameter of an operation
+type Parameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+}
+
+// Schema is a JSON schema describing a type
+type Schema struct {
+	Ref         string            `json:"$ref"`
+	Type        string            `json:"type"`
+	Format      string            `json:"format"`
+	Description string            `json:"description"`
+	Properties  map[string]Schema `json:"properties"`
+	Required    []string          `json:"required"`
+	Items       *Schema           `json:"items"`
+	// GoType overrides the Go type generated for the schema
+	GoType string `json:"x-go-type"`
+}
+
+// Generate generates the Go source of a package with the given name from the
+// given OpenAPI specification. The spec name is recorded in the header
+func Generate(specJSON []byte, specName, pkg string) ([]byte, error) {
+	var spec Spec
+	if err := json.Unmarshal(specJSON, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	g := &generator{imports: make(map[string]struct{})}
+	if err := g.paths(&spec); err != nil {
+		return nil, err
+	}
+	if err := g.schemas(&spec); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by apigen from %s. DO NOT EDIT.\n\n", specName)
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	if len(g.imports) > 0 {
+		// Group the standard library imports before the others
+		var std, other []string
+		for _, imp := range sortedKeys(g.imports) {
+			if strings.Contains(strings.Split(imp, "/")[0], ".") {
+				other = append(other, imp)
+			} else {
+				std = append(std, imp)
+			}
+		}
+
+		out.WriteString("import (\n")
+		for _, imp := range std {
+			fmt.Fprintf(&out, "\t%q\n", imp)
+		}
+		if len(std) > 0 && len(other) > 0 {
+			out.WriteString("\n")
+		}
+		for _, imp := range other {
+			fmt.Fprintf(&out, "\t%q\n", imp)
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(g.body.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+// generator accumulates the generated declarations and their imports
+type generator struct {
+	body    bytes.Buffer
+	imports map[string]struct{}
+}
+
+// paths generates a path constant for each operation
+func (g *generator) paths(spec *Spec) error {
+	if len(spec.Paths) == 0 {
+		return nil
+	}
+
+	type entry struct {
+		name, method, path string
+		op                 Operation
+	}
+	var entries []entry
+	for path, methods := range spec.Paths {
+		for method, op := range methods {
+			if op.OperationID == "" {
+				return fmt.Errorf("%s %s has no operationId", strings.ToUpper(method), path)
+			}
+			entries = append(entries, entry{op.OperationID, strings.ToUpper(method), path, op})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	g.body.WriteString("// Paths of the relayer's endpoints, with each parameter replaced by a %s verb\n")
+	g.body.WriteString("const (\n")
+	for _, e := range entries {
+		fmt.Fprintf(&g.body, "\t// %sPath is the path of the %s operation, %s %s\n", e.name, e.name, e.method, e.path)
+		writeDescription(&g.body, "\t", e.op.Description)
+		fmt.Fprintf(&g.body, "\t%sPath = %q\n", e.name, formatPath(e.path, e.op.Parameters))
+	}
+	g.body.WriteString(")\n\n")
+
+	return nil
+}
+
+// formatPath replaces the path parameters of a path with %s verbs and appends
+// its required query parameters
+func formatPath(path string, params []Parameter) string {
+	var query []string
+	for _, param := range params {
+		switch param.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", "%s")
+		case "query":
+			if param.Required {
+				query = append(query, param.Name+"=%s")
+			}
+		}
+	}
+
+	if len(query) > 0 {
+		path += "?" + strings.Join(query, "&")
+	}
+	return path
+}
+
+// schemas generates a struct for each component schema
+func (g *generator) schemas(spec *Spec) error {
+	for _, name := range sortedKeys(spec.Components.Schemas) {
+		schema := spec.Components.Schemas[name]
+		if schema.Type != "object" {
+			return fmt.Errorf("schema %s: only object schemas may be components", name)
+		}
+
+		if schema.Description != "" {
+			writeDescription(&g.body, "", name+" is "+lowerFirst(schema.Description))
+		} else {
+			fmt.Fprintf(&g.body, "// %s is generated from the %s schema\n", name, name)
+		}
+		fmt.Fprintf(&g.body, "type %s struct {\n", name)
+
+		required := make(map[string]struct{}, len(schema.Required))
+		for _, prop := range schema.Required {
+			required[prop] = struct{}{}
+		}
+		for _, prop := range sortedKeys(schema.Properties) {
+			propSchema := schema.Properties[prop]
+			_, isRequired := required[prop]
+			goType, err := g.goType(&propSchema, !isRequired)
+			if err != nil {
+				return fmt.Errorf("schema %s, property %s: %w", name, prop, err)
+			}
+
+			tag := prop
+			if !isRequired {
+				tag += ",omitempty"
+			}
+			writeDescription(&g.body, "\t", propSchema.Description)
+			fmt.Fprintf(&g.body, "\t%s %s `json:%q`\n", exportedName(prop), goType, tag)
+		}
+		g.body.WriteString("}\n\n")
+	}
+
+	return nil
+}
+
+// goType returns the Go type of a schema, recording the imports it needs.
+// Optional scalars and structs are generated as pointers
+func (g *generator) goType(schema *Schema, optional bool) (string, error) {
+	pointer := ""
+	if optional {
+		pointer = "*"
+	}
+
+	if schema.GoType != "" {
+		return pointer + schema.GoType, nil
+	}
+	if schema.Ref != "" {
+		if !strings.HasPrefix(schema.Ref, refPrefix) {
+			return "", fmt.Errorf("unsupported reference %s", schema.Ref)
+		}
+		return pointer + strings.TrimPrefix(schema.Ref, refPrefix), nil
+	}
+
+	switch schema.Type {
+	case "string":
+		if schema.Format == "uuid" {
+			g.imports["github.com/google/uuid"] = struct{}{}
+			return pointer + "uuid.UUID", nil
+		}
+		return pointer + "string", nil
+	case "boolean":
+		return pointer + "bool", nil
+	case "number":
+		return pointer + "float64", nil
+	case "integer":
+		switch schema.Format {
+		case "uint64":
+			return pointer + "uint64", nil
+		case "uint128", "uint256":
+			g.imports["math/big"] = struct{}{}
+			return "*big.Int", nil
+		default:
+			return pointer + "int64", nil
+		}
+	case "array":
+		if schema.Items == nil {
+			return "", fmt.Errorf("array has no items schema")
+		}
+		item, err := g.goType(schema.Items, false /* optional */)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	}
+
+	return "", fmt.Errorf("unsupported schema type %q", schema.Type)
+}
+
+// exportedName converts a snake_case property name to an exported Go name
+func exportedName(prop string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(prop, "_") {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+// lowerFirst lowercases the first letter of a description so that it may
+// follow a declaration's name
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// writeDescription writes a description as a comment at the given indent
+func writeDescription(b *bytes.Buffer, indent, description string) {
+	for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		if line != "" {
+			fmt.Fprintf(b, "%s// %s\n", indent, strings.TrimSpace(line))
+		}
+	}
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,33 @@
+// Command apigen generates the relayer API layer from an OpenAPI spec, see
+// the apigen package
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/renegade-fi/golang-sdk/internal/apigen"
+)
+
+func main() {
+	specPath := flag.String("spec", "openapi.json", "path to the OpenAPI spec")
+	outPath := flag.String("out", "api.gen.go", "path of the generated file")
+	pkg := flag.String("package", "api", "name of the generated package")
+	flag.Parse()
+
+	spec, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatalf("failed to read spec: %v", err)
+	}
+
+	src, err := apigen.Generate(spec, filepath.Base(*specPath), *pkg)
+	if err != nil {
+		log.Fatalf("failed to generate API: %v", err)
+	}
+
+	if err := os.WriteFile(*outPath, src, 0o644); err != nil { //nolint:gosec
+		log.Fatalf("failed to write %s: %v", *outPath, err)
+	}
+}
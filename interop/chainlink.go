@@ -0,0 +1,130 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	geth_common "github.com/ethereum/go-ethereum/common"
+)
+
+// aggregatorV3ABI is the subset of Chainlink's AggregatorV3Interface used to
+// read a feed
+const aggregatorV3ABI = `[{
+	"name": "decimals",
+	"type": "function",
+	"stateMutability": "view",
+	"inputs": [],
+	"outputs": [{"name": "", "type": "uint8"}]
+}, {
+	"name": "latestRoundData",
+	"type": "function",
+	"stateMutability": "view",
+	"inputs": [],
+	"outputs": [
+		{"name": "roundId", "type": "uint80"},
+		{"name": "answer", "type": "int256"},
+		{"name": "startedAt", "type": "uint256"},
+		{"name": "updatedAt", "type": "uint256"},
+		{"name": "answeredInRound", "type": "uint80"}
+	]
+}]`
+
+// aggregatorV3 is the parsed AggregatorV3Interface ABI
+var aggregatorV3 = mustParseABI(aggregatorV3ABI)
+
+// ChainlinkFeed reads prices from a Chainlink aggregator, e.g. the ETH / USD
+// feed
+type ChainlinkFeed struct {
+	caller     bind.ContractCaller
+	aggregator geth_common.Address
+	maxAge     time.Duration
+	// decimals caches the aggregator's decimals, which never change
+	decimals atomic.Pointer[uint8]
+}
+
+// NewChainlinkFeed creates a feed reading the Chainlink aggregator at the
+// given address. Prices older than maxAge are rejected; zero uses
+// DefaultMaxPriceAge
+func NewChainlinkFeed(
+	caller bind.ContractCaller, aggregator geth_common.Address, maxAge time.Duration,
+) *ChainlinkFeed {
+	if maxAge <= 0 {
+		maxAge = DefaultMaxPriceAge
+	}
+	return &ChainlinkFeed{caller: caller, aggregator: aggregator, maxAge: maxAge}
+}
+
+// LatestPrice returns the aggregator's latest answer
+func (f *ChainlinkFeed) LatestPrice(ctx context.Context) (*OraclePrice, error) {
+	decimals, err := f.getDecimals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := f.call(ctx, "latestRoundData")
+	if err != nil {
+		return nil, err
+	}
+	answer, ok := outputs[1].(*big.Int)
+	updatedAt, ok2 := outputs[3].(*big.Int)
+	if !ok || !ok2 {
+		return nil, errors.New("unexpected latestRoundData output types")
+	}
+	if answer.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid chainlink answer: %s", answer)
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	price, _ := new(big.Rat).SetFrac(answer, scale).Float64()
+	oraclePrice := &OraclePrice{Price: price, UpdatedAt: time.Unix(updatedAt.Int64(), 0)}
+	if err := checkFreshness(oraclePrice, f.maxAge); err != nil {
+		return nil, err
+	}
+
+	return oraclePrice, nil
+}
+
+// getDecimals returns the aggregator's decimals, fetching them on first use
+func (f *ChainlinkFeed) getDecimals(ctx context.Context) (uint8, error) {
+	if decimals := f.decimals.Load(); decimals != nil {
+		return *decimals, nil
+	}
+
+	outputs, err := f.call(ctx, "decimals")
+	if err != nil {
+		return 0, err
+	}
+	decimals, ok := outputs[0].(uint8)
+	if !ok {
+		return 0, errors.New("unexpected decimals output type")
+	}
+
+	f.decimals.Store(&decimals)
+	return decimals, nil
+}
+
+// call calls a view method on the aggregator and decodes its outputs
+func (f *ChainlinkFeed) call(ctx context.Context, method string) ([]interface{}, error) {
+	data, err := aggregatorV3.Pack(method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s call: %w", method, err)
+	}
+
+	aggregator := f.aggregator
+	result, err := f.caller.CallContract(ctx, ethereum.CallMsg{To: &aggregator, Data: data}, nil /* blockNumber */)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+
+	outputs, err := aggregatorV3.Unpack(method, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", method, err)
+	}
+	return outputs, nil
+}
@@ -0,0 +1,106 @@
+// Package interop compares Renegade quotes against reference quotes from
+// other venues, such as Uniswap v3 or a 0x-style aggregator API, powering
+// policies like "only trade if better than the AMM". It also reads reference
+// prices from Chainlink and Pyth oracle feeds for validating quotes
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+// bpsPerUnit is the number of basis points in one unit
+const bpsPerUnit = 10_000
+
+// ErrNoReferenceQuote is returned when a reference venue cannot quote a trade,
+// e.g. because it has no liquidity for the pair
+var ErrNoReferenceQuote = errors.New("no reference quote available")
+
+// ReferenceQuoter quotes the amount of one token received for selling an
+// exact amount of another on a reference venue
+type ReferenceQuoter interface {
+	// QuoteExactInput returns the amount of buyToken received for selling
+	// sellAmount of sellToken, both in their smallest denomination
+	QuoteExactInput(
+		ctx context.Context, sellToken, buyToken geth_common.Address, sellAmount *big.Int,
+	) (*big.Int, error)
+}
+
+// Comparison compares what a Renegade quote and a reference venue pay out for
+// the same input
+type Comparison struct {
+	// SellToken is the token the external party sells
+	SellToken geth_common.Address
+	// BuyToken is the token the external party buys
+	BuyToken geth_common.Address
+	// SellAmount is the amount sold
+	SellAmount *big.Int
+	// RenegadeAmount is the amount received from Renegade, net of fees
+	RenegadeAmount *big.Int
+	// ReferenceAmount is the amount received from the reference venue
+	ReferenceAmount *big.Int
+	// ImprovementBps is Renegade's price improvement over the reference, in
+	// basis points; negative when the reference pays out more
+	ImprovementBps float64
+}
+
+// IsBetter returns whether Renegade improves on the reference by at least the
+// given number of basis points
+func (c *Comparison) IsBetter(minImprovementBps float64) bool {
+	return c.ImprovementBps >= minImprovementBps
+}
+
+// CompareQuote quotes the trade of a Renegade quote on the reference venue and
+// computes Renegade's price improvement
+func CompareQuote(
+	ctx context.Context, reference ReferenceQuoter, quote *api_types.ApiSignedQuote,
+) (*Comparison, error) {
+	send, receive := &quote.Quote.Send, &quote.Quote.Receive
+	if !geth_common.IsHexAddress(send.Mint) || !geth_common.IsHexAddress(receive.Mint) {
+		return nil, fmt.Errorf("invalid quote mints %q and %q", send.Mint, receive.Mint)
+	}
+
+	sellAmount := new(big.Int).Set((*big.Int)(&send.Amount))
+	if sellAmount.Sign() <= 0 {
+		return nil, errors.New("quote sends no tokens")
+	}
+
+	sellToken := geth_common.HexToAddress(send.Mint)
+	buyToken := geth_common.HexToAddress(receive.Mint)
+	referenceAmount, err := reference.QuoteExactInput(ctx, sellToken, buyToken, sellAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reference quote: %w", err)
+	}
+
+	comparison := &Comparison{
+		SellToken:       sellToken,
+		BuyToken:        buyToken,
+		SellAmount:      sellAmount,
+		RenegadeAmount:  new(big.Int).Set((*big.Int)(&receive.Amount)),
+		ReferenceAmount: referenceAmount,
+	}
+	comparison.ImprovementBps, err = improvementBps(comparison.RenegadeAmount, referenceAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	return comparison, nil
+}
+
+// improvementBps returns how much more one amount is than a reference amount,
+// in basis points of the reference
+func improvementBps(amount, reference *big.Int) (float64, error) {
+	if reference.Sign() <= 0 {
+		return 0, ErrNoReferenceQuote
+	}
+
+	diff := new(big.Int).Sub(amount, reference)
+	ratio, _ := new(big.Rat).SetFrac(diff, reference).Float64()
+	return ratio * bpsPerUnit, nil
+}
@@ -0,0 +1,122 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client/api_types"
+)
+
+var (
+	testWeth = geth_common.HexToAddress("0x82aF49447D8a07e3bd95BD0d56f35241523fBab1")
+	testUsdc = geth_common.HexToAddress("0xaf88d065e77c8cC2239327C5EDb3A432268e5831")
+)
+
+// fakeCaller serves QuoterV2 calls from a map of fee tier to amount out; tiers
+// missing from the map revert
+type fakeCaller struct {
+	amounts map[uint32]int64
+}
+
+func (f *fakeCaller) CodeAt(context.Context, geth_common.Address, *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (f *fakeCaller) CallContract(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	method := quoterV2.Methods["quoteExactInputSingle"]
+	args, err := method.Inputs.Unpack(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	params := args[0].(struct {
+		TokenIn           geth_common.Address `json:"tokenIn"`
+		TokenOut          geth_common.Address `json:"tokenOut"`
+		AmountIn          *big.Int            `json:"amountIn"`
+		Fee               *big.Int            `json:"fee"`
+		SqrtPriceLimitX96 *big.Int            `json:"sqrtPriceLimitX96"`
+	})
+
+	amount, ok := f.amounts[uint32(params.Fee.Uint64())]
+	if !ok {
+		return nil, errors.New("execution reverted")
+	}
+	return method.Outputs.Pack(big.NewInt(amount), new(big.Int), uint32(0), new(big.Int))
+}
+
+// testQuote builds a quote selling the given amount of WETH for USDC
+func testQuote(send, receive int64) *api_types.ApiSignedQuote {
+	return &api_types.ApiSignedQuote{Quote: api_types.ApiExternalQuote{
+		Send:    api_types.ApiExternalAssetTransfer{Mint: testWeth.Hex(), Amount: api_types.NewAmount(send)},
+		Receive: api_types.ApiExternalAssetTransfer{Mint: testUsdc.Hex(), Amount: api_types.NewAmount(receive)},
+	}}
+}
+
+func TestUniswapV3QuoterBestFeeTier(t *testing.T) {
+	caller := &fakeCaller{amounts: map[uint32]int64{500: 1990, 3000: 2000}}
+	quoter := NewUniswapV3Quoter(caller, ArbitrumUniswapV3QuoterV2)
+
+	amount, err := quoter.QuoteExactInput(context.Background(), testWeth, testUsdc, big.NewInt(1))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2000), amount.Int64())
+
+	// No pool quotes the pair
+	quoter = NewUniswapV3Quoter(&fakeCaller{}, ArbitrumUniswapV3QuoterV2, 500)
+	_, err = quoter.QuoteExactInput(context.Background(), testWeth, testUsdc, big.NewInt(1))
+	assert.ErrorIs(t, err, ErrNoReferenceQuote)
+}
+
+func TestZeroExQuoter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, zeroExPricePath, r.URL.Path)
+		assert.Equal(t, "test-key", r.Header.Get("0x-api-key"))
+		assert.Equal(t, "42161", r.URL.Query().Get("chainId"))
+		assert.Equal(t, "1000", r.URL.Query().Get("sellAmount"))
+
+		if r.URL.Query().Get("buyToken") == testUsdc.Hex() {
+			_, _ = w.Write([]byte(`{"liquidityAvailable":true,"buyAmount":"2000000"}`))
+		} else {
+			_, _ = w.Write([]byte(`{"liquidityAvailable":false}`))
+		}
+	}))
+	defer server.Close()
+
+	quoter := NewZeroExQuoter("test-key", 42161)
+	quoter.BaseURL = server.URL
+
+	amount, err := quoter.QuoteExactInput(context.Background(), testWeth, testUsdc, big.NewInt(1000))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2000000), amount.Int64())
+
+	_, err = quoter.QuoteExactInput(context.Background(), testUsdc, testWeth, big.NewInt(1000))
+	assert.ErrorIs(t, err, ErrNoReferenceQuote)
+}
+
+func TestCompareQuote(t *testing.T) {
+	reference := NewUniswapV3Quoter(&fakeCaller{amounts: map[uint32]int64{500: 2000}}, ArbitrumUniswapV3QuoterV2)
+
+	// Renegade pays out 2010 against the AMM's 2000, a 50 bps improvement
+	comparison, err := CompareQuote(context.Background(), reference, testQuote(1, 2010))
+	assert.NoError(t, err)
+	assert.Equal(t, testWeth, comparison.SellToken)
+	assert.Equal(t, int64(2000), comparison.ReferenceAmount.Int64())
+	assert.InDelta(t, 50, comparison.ImprovementBps, 1e-9)
+	assert.True(t, comparison.IsBetter(50))
+	assert.False(t, comparison.IsBetter(51))
+
+	// Renegade pays out less than the AMM
+	comparison, err = CompareQuote(context.Background(), reference, testQuote(1, 1990))
+	assert.NoError(t, err)
+	assert.InDelta(t, -50, comparison.ImprovementBps, 1e-9)
+	assert.False(t, comparison.IsBetter(0))
+
+	_, err = CompareQuote(context.Background(), reference, testQuote(0, 1990))
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/renegade-fi/golang-sdk/client/external_match_client"
+)
+
+// DefaultMaxPriceAge is the default age after which an oracle price is
+// considered stale
+const DefaultMaxPriceAge = time.Hour
+
+// ErrStalePrice is returned when an oracle's latest price is older than the
+// feed's maximum age
+var ErrStalePrice = errors.New("oracle price is stale")
+
+// OraclePrice is a price read from an oracle feed
+type OraclePrice struct {
+	// Price is the feed's price, e.g. in USD per token
+	Price float64
+	// UpdatedAt is the time at which the oracle last updated the price
+	UpdatedAt time.Time
+}
+
+// PriceFeed reads the latest price from an oracle feed
+type PriceFeed interface {
+	// LatestPrice returns the feed's latest price, or ErrStalePrice if it is
+	// older than the feed's maximum age
+	LatestPrice(ctx context.Context) (*OraclePrice, error)
+}
+
+// checkFreshness returns ErrStalePrice if a price is older than maxAge
+func checkFreshness(price *OraclePrice, maxAge time.Duration) error {
+	if age := time.Since(price.UpdatedAt); age > maxAge {
+		return fmt.Errorf("%w: updated %s ago", ErrStalePrice, age.Truncate(time.Second))
+	}
+	return nil
+}
+
+// MedianFeed combines several feeds for the same pair into the median of
+// their prices, so that no single feed is trusted
+type MedianFeed struct {
+	feeds []PriceFeed
+	// quorum is the minimum number of feeds that must return a price
+	quorum int
+}
+
+// NewMedianFeed creates a feed returning the median price of the given feeds,
+// failing if fewer than quorum of them return a fresh price
+func NewMedianFeed(quorum int, feeds ...PriceFeed) *MedianFeed {
+	return &MedianFeed{feeds: feeds, quorum: quorum}
+}
+
+// LatestPrice returns the median of the feeds' latest prices, timestamped
+// with the oldest update used
+func (f *MedianFeed) LatestPrice(ctx context.Context) (*OraclePrice, error) {
+	var prices []float64
+	var updatedAt time.Time
+	var errs []error
+	for _, feed := range f.feeds {
+		price, err := feed.LatestPrice(ctx)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		prices = append(prices, price.Price)
+		if updatedAt.IsZero() || price.UpdatedAt.Before(updatedAt) {
+			updatedAt = price.UpdatedAt
+		}
+	}
+
+	if len(prices) == 0 || len(prices) < f.quorum {
+		return nil, fmt.Errorf(
+			"only %d of %d feeds returned a price: %w", len(prices), len(f.feeds), errors.Join(errs...),
+		)
+	}
+
+	sort.Float64s(prices)
+	median := prices[len(prices)/2]
+	if len(prices)%2 == 0 {
+		median = (prices[len(prices)/2-1] + median) / 2
+	}
+
+	return &OraclePrice{Price: median, UpdatedAt: updatedAt}, nil
+}
+
+// OracleReferencePrice reads a token's USD price from an oracle feed and
+// converts it to units of a USD stablecoin quote token per unit of the token,
+// both in their smallest denomination, for use with
+// external_match_client.EstimatePriceImpact
+func OracleReferencePrice(
+	ctx context.Context, feed PriceFeed, baseDecimals, quoteDecimals uint8,
+) (float64, error) {
+	price, err := feed.LatestPrice(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if price.Price <= 0 || math.IsNaN(price.Price) || math.IsInf(price.Price, 0) {
+		return 0, fmt.Errorf("invalid oracle price: %v", price.Price)
+	}
+
+	return external_match_client.ReferencePrice(price.Price, baseDecimals, quoteDecimals), nil
+}
@@ -0,0 +1,118 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// oracleCaller serves view calls on a single contract from canned outputs,
+// keyed by method name
+type oracleCaller struct {
+	contract abi.ABI
+	outputs  map[string][]interface{}
+}
+
+func (c *oracleCaller) CodeAt(context.Context, geth_common.Address, *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c *oracleCaller) CallContract(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	method, err := c.contract.MethodById(msg.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	outputs, ok := c.outputs[method.Name]
+	if !ok {
+		return nil, errors.New("execution reverted")
+	}
+	return method.Outputs.Pack(outputs...)
+}
+
+// chainlinkCaller serves an aggregator with 8 decimals and the given answer
+func chainlinkCaller(answer int64, updatedAt time.Time) *oracleCaller {
+	return &oracleCaller{contract: aggregatorV3, outputs: map[string][]interface{}{
+		"decimals": {uint8(8)},
+		"latestRoundData": {
+			big.NewInt(1), big.NewInt(answer), big.NewInt(updatedAt.Unix()),
+			big.NewInt(updatedAt.Unix()), big.NewInt(1),
+		},
+	}}
+}
+
+// pythCaller serves a Pyth contract with the given price
+func pythCaller(price int64, expo int32, publishTime time.Time) *oracleCaller {
+	return &oracleCaller{contract: pyth, outputs: map[string][]interface{}{
+		"getPriceUnsafe": {pythPrice{price, 0, expo, big.NewInt(publishTime.Unix())}},
+	}}
+}
+
+// fixedFeed is a feed returning a fixed price or error
+type fixedFeed struct {
+	price float64
+	err   error
+}
+
+func (f fixedFeed) LatestPrice(context.Context) (*OraclePrice, error) {
+	return &OraclePrice{Price: f.price, UpdatedAt: time.Now()}, f.err
+}
+
+func TestChainlinkFeed(t *testing.T) {
+	ctx := context.Background()
+	feed := NewChainlinkFeed(chainlinkCaller(2500_12345678, time.Now()), geth_common.Address{}, 0)
+	price, err := feed.LatestPrice(ctx)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2500.12345678, price.Price, 1e-9)
+
+	feed = NewChainlinkFeed(chainlinkCaller(2500_00000000, time.Now().Add(-2*time.Hour)), geth_common.Address{}, 0)
+	_, err = feed.LatestPrice(ctx)
+	assert.ErrorIs(t, err, ErrStalePrice)
+}
+
+func TestPythFeed(t *testing.T) {
+	ctx := context.Background()
+	feed := NewPythFeed(pythCaller(250012345678, -8, time.Now()), ArbitrumPyth, geth_common.Hash{}, time.Minute)
+	price, err := feed.LatestPrice(ctx)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2500.12345678, price.Price, 1e-9)
+
+	feed = NewPythFeed(pythCaller(250012345678, -8, time.Now().Add(-time.Hour)), ArbitrumPyth, geth_common.Hash{}, time.Minute)
+	_, err = feed.LatestPrice(ctx)
+	assert.ErrorIs(t, err, ErrStalePrice)
+}
+
+func TestMedianFeed(t *testing.T) {
+	ctx := context.Background()
+	failing := fixedFeed{err: ErrStalePrice}
+
+	feed := NewMedianFeed(2, fixedFeed{price: 100}, fixedFeed{price: 102}, fixedFeed{price: 500}, failing)
+	price, err := feed.LatestPrice(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 102.0, price.Price)
+
+	feed = NewMedianFeed(2, fixedFeed{price: 100}, fixedFeed{price: 102})
+	price, err = feed.LatestPrice(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 101.0, price.Price)
+
+	feed = NewMedianFeed(2, fixedFeed{price: 100}, failing)
+	_, err = feed.LatestPrice(ctx)
+	assert.ErrorIs(t, err, ErrStalePrice)
+}
+
+func TestOracleReferencePrice(t *testing.T) {
+	// $2000 per 18-decimal token, quoted in a 6-decimal stablecoin
+	price, err := OracleReferencePrice(context.Background(), fixedFeed{price: 2000}, 18, 6)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2000e-12, price, 1e-21)
+
+	_, err = OracleReferencePrice(context.Background(), fixedFeed{price: 0}, 18, 6)
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	geth_common "github.com/ethereum/go-ethereum/common"
+)
+
+// ArbitrumPyth is the address of the Pyth price feed contract on Arbitrum One
+var ArbitrumPyth = geth_common.HexToAddress("0xff1a0f4744e8582DF1aE09D5611b887B6a12925C")
+
+// pythABI is the subset of the Pyth contract ABI used to read a feed
+const pythABI = `[{
+	"name": "getPriceUnsafe",
+	"type": "function",
+	"stateMutability": "view",
+	"inputs": [{"name": "id", "type": "bytes32"}],
+	"outputs": [{
+		"name": "price",
+		"type": "tuple",
+		"components": [
+			{"name": "price", "type": "int64"},
+			{"name": "conf", "type": "uint64"},
+			{"name": "expo", "type": "int32"},
+			{"name": "publishTime", "type": "uint256"}
+		]
+	}]
+}]`
+
+// pyth is the parsed Pyth contract ABI
+var pyth = mustParseABI(pythABI)
+
+// pythPrice is a price as stored by the Pyth contract, price * 10^expo
+type pythPrice struct {
+	Price       int64
+	Conf        uint64
+	Expo        int32
+	PublishTime *big.Int
+}
+
+// PythFeed reads prices for a single price feed ID from the Pyth contract
+type PythFeed struct {
+	caller   bind.ContractCaller
+	contract geth_common.Address
+	feedID   geth_common.Hash
+	maxAge   time.Duration
+}
+
+// NewPythFeed creates a feed reading the given price feed ID, e.g. the
+// ETH / USD feed, from the Pyth contract at the given address. Prices older
+// than maxAge are rejected; zero uses DefaultMaxPriceAge
+func NewPythFeed(
+	caller bind.ContractCaller, contract geth_common.Address, feedID geth_common.Hash, maxAge time.Duration,
+) *PythFeed {
+	if maxAge <= 0 {
+		maxAge = DefaultMaxPriceAge
+	}
+	return &PythFeed{caller: caller, contract: contract, feedID: feedID, maxAge: maxAge}
+}
+
+// LatestPrice returns the feed's latest price as stored on-chain
+func (f *PythFeed) LatestPrice(ctx context.Context) (*OraclePrice, error) {
+	data, err := pyth.Pack("getPriceUnsafe", f.feedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode getPriceUnsafe call: %w", err)
+	}
+
+	contract := f.contract
+	result, err := f.caller.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil /* blockNumber */)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call getPriceUnsafe: %w", err)
+	}
+
+	outputs, err := pyth.Unpack("getPriceUnsafe", result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pyth price: %w", err)
+	}
+	price := *abi.ConvertType(outputs[0], new(pythPrice)).(*pythPrice)
+	if price.Price <= 0 || price.PublishTime == nil {
+		return nil, errors.New("pyth feed has no valid price")
+	}
+
+	oraclePrice := &OraclePrice{
+		Price:     float64(price.Price) * math.Pow10(int(price.Expo)),
+		UpdatedAt: time.Unix(price.PublishTime.Int64(), 0),
+	}
+	if err := checkFreshness(oraclePrice, f.maxAge); err != nil {
+		return nil, err
+	}
+
+	return oraclePrice, nil
+}
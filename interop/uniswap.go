@@ -0,0 +1,144 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	geth_common "github.com/ethereum/go-ethereum/common"
+)
+
+// ArbitrumUniswapV3QuoterV2 is the address of Uniswap v3's QuoterV2 contract
+// on Arbitrum One
+var ArbitrumUniswapV3QuoterV2 = geth_common.HexToAddress("0x61fFE014bA17989E743c5F6cB21bF9697530B21e")
+
+// DefaultUniswapFeeTiers are the Uniswap v3 pool fee tiers quoted by default,
+// in hundredths of a basis point
+var DefaultUniswapFeeTiers = []uint32{100, 500, 3000, 10000}
+
+// quoterV2ABI is the subset of the QuoterV2 ABI used to quote single-pool swaps
+const quoterV2ABI = `[{
+	"name": "quoteExactInputSingle",
+	"type": "function",
+	"stateMutability": "nonpayable",
+	"inputs": [{
+		"name": "params",
+		"type": "tuple",
+		"components": [
+			{"name": "tokenIn", "type": "address"},
+			{"name": "tokenOut", "type": "address"},
+			{"name": "amountIn", "type": "uint256"},
+			{"name": "fee", "type": "uint24"},
+			{"name": "sqrtPriceLimitX96", "type": "uint160"}
+		]
+	}],
+	"outputs": [
+		{"name": "amountOut", "type": "uint256"},
+		{"name": "sqrtPriceX96After", "type": "uint160"},
+		{"name": "initializedTicksCrossed", "type": "uint32"},
+		{"name": "gasEstimate", "type": "uint256"}
+	]
+}]`
+
+// quoterV2 is the parsed QuoterV2 ABI
+var quoterV2 = mustParseABI(quoterV2ABI)
+
+// mustParseABI parses a contract ABI, panicking on an invalid ABI
+func mustParseABI(json string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(json))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// quoteExactInputSingleParams are the parameters of quoteExactInputSingle
+type quoteExactInputSingleParams struct {
+	TokenIn           geth_common.Address
+	TokenOut          geth_common.Address
+	AmountIn          *big.Int
+	Fee               *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+// UniswapV3Quoter quotes swaps against Uniswap v3 pools through the QuoterV2
+// contract, using an eth_call so that no transaction is sent
+type UniswapV3Quoter struct {
+	caller  bind.ContractCaller
+	quoter  geth_common.Address
+	feeTier []uint32
+}
+
+// NewUniswapV3Quoter creates a quoter that calls the QuoterV2 contract at the
+// given address through the given caller, e.g. an *ethclient.Client. The best
+// quote across the given fee tiers is returned; none quotes DefaultUniswapFeeTiers
+func NewUniswapV3Quoter(
+	caller bind.ContractCaller, quoter geth_common.Address, feeTiers ...uint32,
+) *UniswapV3Quoter {
+	if len(feeTiers) == 0 {
+		feeTiers = DefaultUniswapFeeTiers
+	}
+	return &UniswapV3Quoter{caller: caller, quoter: quoter, feeTier: feeTiers}
+}
+
+// QuoteExactInput returns the best amount of buyToken received for selling
+// sellAmount of sellToken in a single pool of any of the quoter's fee tiers
+func (q *UniswapV3Quoter) QuoteExactInput(
+	ctx context.Context, sellToken, buyToken geth_common.Address, sellAmount *big.Int,
+) (*big.Int, error) {
+	var best *big.Int
+	var errs []error
+	for _, fee := range q.feeTier {
+		amountOut, err := q.quoteFeeTier(ctx, sellToken, buyToken, sellAmount, fee)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fee tier %d: %w", fee, err))
+			continue
+		}
+		if best == nil || amountOut.Cmp(best) > 0 {
+			best = amountOut
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("%w: %w", ErrNoReferenceQuote, errors.Join(errs...))
+	}
+	return best, nil
+}
+
+// quoteFeeTier quotes a swap in the pool of the given fee tier
+func (q *UniswapV3Quoter) quoteFeeTier(
+	ctx context.Context, sellToken, buyToken geth_common.Address, sellAmount *big.Int, fee uint32,
+) (*big.Int, error) {
+	data, err := quoterV2.Pack("quoteExactInputSingle", quoteExactInputSingleParams{
+		TokenIn:           sellToken,
+		TokenOut:          buyToken,
+		AmountIn:          sellAmount,
+		Fee:               new(big.Int).SetUint64(uint64(fee)),
+		SqrtPriceLimitX96: new(big.Int),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode quote call: %w", err)
+	}
+
+	quoter := q.quoter
+	result, err := q.caller.CallContract(ctx, ethereum.CallMsg{To: &quoter, Data: data}, nil /* blockNumber */)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := quoterV2.Unpack("quoteExactInputSingle", result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode quote: %w", err)
+	}
+	amountOut, ok := outputs[0].(*big.Int)
+	if !ok {
+		return nil, errors.New("unexpected quote output type")
+	}
+
+	return amountOut, nil
+}
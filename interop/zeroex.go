@@ -0,0 +1,112 @@
+package interop
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// ZeroExBaseURL is the base URL of the 0x API
+	ZeroExBaseURL = "https://api.0x.org"
+	// zeroExPricePath is the path of the 0x indicative price endpoint
+	zeroExPricePath = "/swap/permit2/price"
+	// maxZeroExResponseBytes bounds the size of a price response
+	maxZeroExResponseBytes = 1 << 20
+)
+
+// ZeroExQuoter quotes swaps through a 0x-style aggregator HTTP API, using its
+// indicative price endpoint
+type ZeroExQuoter struct {
+	// BaseURL is the base URL of the API
+	BaseURL string
+	// PricePath is the path of the price endpoint
+	PricePath string
+	// ApiKey is sent in the 0x-api-key header, if set
+	ApiKey string //nolint:revive
+	// ChainID is the chain to quote on
+	ChainID uint64
+	// HttpClient is the client used for requests
+	HttpClient *http.Client //nolint:revive
+}
+
+// NewZeroExQuoter creates a quoter for the 0x API on the given chain
+func NewZeroExQuoter(apiKey string, chainID uint64) *ZeroExQuoter {
+	return &ZeroExQuoter{
+		BaseURL:    ZeroExBaseURL,
+		PricePath:  zeroExPricePath,
+		ApiKey:     apiKey,
+		ChainID:    chainID,
+		HttpClient: http.DefaultClient,
+	}
+}
+
+// zeroExPriceResponse is the subset of a price response used by the quoter
+type zeroExPriceResponse struct {
+	// LiquidityAvailable is false when no route exists for the trade
+	LiquidityAvailable *bool `json:"liquidityAvailable"`
+	// BuyAmount is the amount of the buy token received
+	BuyAmount string `json:"buyAmount"`
+}
+
+// QuoteExactInput returns the amount of buyToken received for selling
+// sellAmount of sellToken through the aggregator
+func (q *ZeroExQuoter) QuoteExactInput(
+	ctx context.Context, sellToken, buyToken geth_common.Address, sellAmount *big.Int,
+) (*big.Int, error) {
+	params := url.Values{}
+	params.Set("chainId", strconv.FormatUint(q.ChainID, 10))
+	params.Set("sellToken", sellToken.Hex())
+	params.Set("buyToken", buyToken.Hex())
+	params.Set("sellAmount", sellAmount.String())
+
+	reqURL := q.BaseURL + q.PricePath + "?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil /* body */)
+	if err != nil {
+		return nil, err
+	}
+	if q.ApiKey != "" {
+		req.Header.Set("0x-api-key", q.ApiKey)
+	}
+	req.Header.Set("0x-version", "v2")
+
+	httpClient := q.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxZeroExResponseBytes))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, body)
+	}
+
+	var price zeroExPriceResponse
+	if err := json.Unmarshal(body, &price); err != nil {
+		return nil, fmt.Errorf("failed to decode price response: %w", err)
+	}
+	if price.LiquidityAvailable != nil && !*price.LiquidityAvailable {
+		return nil, ErrNoReferenceQuote
+	}
+
+	buyAmount, ok := new(big.Int).SetString(price.BuyAmount, 10)
+	if !ok {
+		return nil, errors.New("invalid buy amount in price response")
+	}
+	return buyAmount, nil
+}
@@ -0,0 +1,227 @@
+// Package quickstart provides the helpers needed to go from API credentials to
+// a settled external match: configuring a client from the environment and
+// command line flags, resolving tokens, and submitting bundles on-chain
+package quickstart
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	external_match_client "github.com/renegade-fi/golang-sdk/client/external_match_client"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// The environment variables a Config is loaded from
+const (
+	// EnvApiKey holds the external match API key
+	EnvApiKey = "EXTERNAL_MATCH_KEY" //nolint:revive
+	// EnvApiSecret holds the base64 encoded external match API secret
+	EnvApiSecret = "EXTERNAL_MATCH_SECRET" //nolint:revive
+	// EnvNetwork holds the network to connect to, testnet or mainnet
+	EnvNetwork = "RENEGADE_NETWORK"
+	// EnvRpcUrl holds the URL of the RPC node bundles are submitted to
+	EnvRpcUrl = "RPC_URL" //nolint:revive
+	// EnvPrivateKey holds the hex encoded private key bundles are submitted with
+	EnvPrivateKey = "PKEY"
+	// EnvGasLimit holds the gas limit of submitted settlement transactions
+	EnvGasLimit = "GAS_LIMIT"
+	// EnvGasMarginBps holds the safety margin added to gas estimates, in
+	// basis points
+	EnvGasMarginBps = "GAS_MARGIN_BPS"
+	// EnvMulticallAddress holds the address of the multicall contract used to
+	// batch settlements
+	EnvMulticallAddress = "MULTICALL_ADDRESS"
+)
+
+// Network is a network a Renegade deployment runs on
+type Network string
+
+const (
+	// NetworkTestnet is the Arbitrum Sepolia testnet
+	NetworkTestnet Network = "testnet"
+	// NetworkMainnet is Arbitrum One
+	NetworkMainnet Network = "mainnet"
+)
+
+// ChainID returns the ID of the chain the network settles on
+func (n Network) ChainID() (uint64, error) {
+	switch n {
+	case NetworkTestnet:
+		return 421614, nil
+	case NetworkMainnet:
+		return 42161, nil
+	default:
+		return 0, fmt.Errorf("unknown network: %q", n)
+	}
+}
+
+// defaultGasLimit is the default gas limit of settlement transactions
+const defaultGasLimit = 10_000_000
+
+// defaultGasMarginBps is the default safety margin added to gas estimates, in
+// basis points
+const defaultGasMarginBps = 2_000
+
+// bpsPerUnit is the number of basis points in one unit
+const bpsPerUnit = 10_000
+
+// ErrMissingConfig is returned when a required configuration value is unset
+var ErrMissingConfig = errors.New("missing configuration")
+
+// Config configures the quickstart helpers
+type Config struct {
+	// Network is the network to connect to
+	Network Network
+	// ApiKey is the external match API key
+	ApiKey string //nolint:revive
+	// ApiSecret is the base64 encoded external match API secret
+	ApiSecret string //nolint:revive
+	// RpcUrl is the URL of the RPC node bundles are submitted to; only
+	// required to submit bundles
+	RpcUrl string //nolint:revive
+	// PrivateKey is the hex encoded private key bundles are submitted with;
+	// only required to submit bundles
+	PrivateKey string
+	// GasLimit is the gas limit of submitted settlement transactions that
+	// carry no gas estimate
+	GasLimit uint64
+	// GasMarginBps is the safety margin added to gas estimates, in basis
+	// points; estimates are requested with
+	// AssembleExternalMatchOptions.WithGasEstimation
+	GasMarginBps uint64
+	// MulticallAddress is the address of a Multicall3-compatible contract
+	// used by SubmitBundles to settle several bundles in one transaction;
+	// empty submits each bundle separately
+	MulticallAddress string
+}
+
+// LoadConfig loads a Config from the environment, overridden by any of the
+// given command line arguments, e.g. os.Args[1:]. The flags are named after
+// the Config fields: -network, -api-key, -api-secret, -rpc-url, -private-key,
+// -gas-limit, -gas-margin-bps, and -multicall-address
+func LoadConfig(args []string) (*Config, error) {
+	gasLimit := uint64(defaultGasLimit)
+	if raw := os.Getenv(EnvGasLimit); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvGasLimit, err)
+		}
+		gasLimit = parsed
+	}
+
+	gasMarginBps := uint64(defaultGasMarginBps)
+	if raw := os.Getenv(EnvGasMarginBps); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvGasMarginBps, err)
+		}
+		gasMarginBps = parsed
+	}
+
+	network := Network(os.Getenv(EnvNetwork))
+	if network == "" {
+		network = NetworkTestnet
+	}
+
+	config := &Config{
+		Network:          network,
+		ApiKey:           os.Getenv(EnvApiKey),
+		ApiSecret:        os.Getenv(EnvApiSecret),
+		RpcUrl:           os.Getenv(EnvRpcUrl),
+		PrivateKey:       os.Getenv(EnvPrivateKey),
+		GasLimit:         gasLimit,
+		GasMarginBps:     gasMarginBps,
+		MulticallAddress: os.Getenv(EnvMulticallAddress),
+	}
+
+	flags := flag.NewFlagSet("quickstart", flag.ContinueOnError)
+	flags.Func("network", "network to connect to, testnet or mainnet", func(value string) error {
+		config.Network = Network(value)
+		return nil
+	})
+	flags.StringVar(&config.ApiKey, "api-key", config.ApiKey, "external match API key")
+	flags.StringVar(&config.ApiSecret, "api-secret", config.ApiSecret, "base64 encoded external match API secret")
+	flags.StringVar(&config.RpcUrl, "rpc-url", config.RpcUrl, "URL of the RPC node bundles are submitted to")
+	flags.StringVar(&config.PrivateKey, "private-key", config.PrivateKey, "hex encoded private key to submit with")
+	flags.Uint64Var(&config.GasLimit, "gas-limit", config.GasLimit, "gas limit of settlement transactions")
+	flags.Uint64Var(&config.GasMarginBps, "gas-margin-bps", config.GasMarginBps, "margin added to gas estimates, in bps")
+	flags.StringVar(&config.MulticallAddress, "multicall-address", config.MulticallAddress, "multicall contract to batch settlements with")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate checks that the values required to create a client are set
+func (c *Config) Validate() error {
+	if _, err := c.Network.ChainID(); err != nil {
+		return err
+	}
+	if c.ApiKey == "" {
+		return fmt.Errorf("%w: %s", ErrMissingConfig, EnvApiKey)
+	}
+	if c.ApiSecret == "" {
+		return fmt.Errorf("%w: %s", ErrMissingConfig, EnvApiSecret)
+	}
+	if c.MulticallAddress != "" && !geth_common.IsHexAddress(c.MulticallAddress) {
+		return fmt.Errorf("invalid multicall address: %q", c.MulticallAddress)
+	}
+	return nil
+}
+
+// settlementGasLimit returns the gas limit of a settlement transaction: its
+// gas estimate plus the configured margin, or the configured gas limit if it
+// carries no estimate
+func (c *Config) settlementGasLimit(tx *external_match_client.SettlementTransaction) uint64 {
+	if tx == nil || tx.Gas == 0 {
+		return c.GasLimit
+	}
+	return c.withGasMargin(tx.Gas)
+}
+
+// withGasMargin adds the configured margin to a gas estimate
+func (c *Config) withGasMargin(estimate uint64) uint64 {
+	limit := new(big.Int).SetUint64(estimate)
+	limit.Mul(limit, new(big.Int).SetUint64(bpsPerUnit+c.GasMarginBps))
+	limit.Quo(limit, big.NewInt(bpsPerUnit))
+	if !limit.IsUint64() {
+		return math.MaxUint64
+	}
+	return limit.Uint64()
+}
+
+// apiSecretKey parses the API secret
+func (c *Config) apiSecretKey() (*wallet.HmacKey, error) {
+	key, err := new(wallet.HmacKey).FromBase64String(c.ApiSecret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API secret: %w", err)
+	}
+	return &key, nil
+}
+
+// privateKey parses the submission private key
+func (c *Config) privateKey() (*ecdsa.PrivateKey, error) {
+	if c.PrivateKey == "" {
+		return nil, fmt.Errorf("%w: %s", ErrMissingConfig, EnvPrivateKey)
+	}
+
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(c.PrivateKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return key, nil
+}
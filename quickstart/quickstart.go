@@ -0,0 +1,286 @@
+package quickstart
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	external_match_client "github.com/renegade-fi/golang-sdk/client/external_match_client"
+)
+
+// NewExternalMatchClient creates an external match client for the configured
+// network and credentials
+func NewExternalMatchClient(config *Config) (*external_match_client.ExternalMatchClient, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	apiSecret, err := config.apiSecretKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Network == NetworkMainnet {
+		return external_match_client.NewMainnetExternalMatchClient(config.ApiKey, apiSecret), nil
+	}
+	return external_match_client.NewTestnetExternalMatchClient(config.ApiKey, apiSecret), nil
+}
+
+// FindTokenAddr returns the address of the token with the given symbol
+func FindTokenAddr(client *external_match_client.ExternalMatchClient, symbol string) (string, error) {
+	return client.FindTokenAddr(symbol)
+}
+
+// SubmitBundle signs a bundle's settlement transaction with the configured
+// private key and sends it to the configured RPC node, returning the
+// transaction's hash. The gas limit is the relayer's gas estimate plus the
+// configured margin, or the configured gas limit if the bundle carries no
+// estimate. Sandbox bundles are rejected.
+//
+// Each call broadcasts a new transaction; use a SubmissionGuard to avoid
+// resubmitting a bundle that was already sent
+func SubmitBundle(
+	ctx context.Context,
+	config *Config,
+	bundle *external_match_client.ExternalMatchBundle,
+) (geth_common.Hash, error) {
+	if bundle.Sandbox {
+		return geth_common.Hash{}, external_match_client.ErrSandboxSettlement
+	}
+
+	sub, err := dialSubmitter(ctx, config)
+	if err != nil {
+		return geth_common.Hash{}, err
+	}
+	defer sub.close()
+
+	nonce, err := sub.pendingNonce(ctx)
+	if err != nil {
+		return geth_common.Hash{}, err
+	}
+	return sub.submit(ctx, bundle.SettlementTx, nonce, config.settlementGasLimit(bundle.SettlementTx))
+}
+
+// SubmitBundles settles several bundles, returning the hashes of the
+// transactions sent.
+//
+// If a multicall address is configured, the bundles are first aggregated into
+// a single transaction, see external_match_client.BuildBatchSettlementTx, with
+// a gas limit of the combined estimate; a single hash is returned. If the
+// batch cannot be built or its gas estimation fails, e.g. because the darkpool
+// rejects the multicall contract as the sender, each bundle is submitted in
+// its own transaction with consecutive nonces. Gas limits include the
+// configured margin, as in SubmitBundle
+func SubmitBundles(
+	ctx context.Context,
+	config *Config,
+	bundles []*external_match_client.ExternalMatchBundle,
+) ([]geth_common.Hash, error) {
+	for _, bundle := range bundles {
+		if bundle.Sandbox {
+			return nil, external_match_client.ErrSandboxSettlement
+		}
+	}
+
+	sub, err := dialSubmitter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.close()
+
+	return sub.submitAll(ctx, bundles, config)
+}
+
+// settlementBackend is the subset of an RPC client used to submit settlement
+// transactions, e.g. an *ethclient.Client
+type settlementBackend interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	PendingNonceAt(ctx context.Context, account geth_common.Address) (uint64, error)
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionByHash(ctx context.Context, hash geth_common.Hash) (*types.Transaction, bool, error)
+	NonceAt(ctx context.Context, account geth_common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// submitter signs and sends settlement transactions
+type submitter struct {
+	backend    settlementBackend
+	privateKey *ecdsa.PrivateKey
+	chainID    uint64
+	// closeFn releases the backend's connection, if any
+	closeFn func()
+}
+
+// dialSubmitter creates a submitter for the configured RPC node and key
+func dialSubmitter(ctx context.Context, config *Config) (*submitter, error) {
+	if config.RpcUrl == "" {
+		return nil, fmt.Errorf("%w: %s", ErrMissingConfig, EnvRpcUrl)
+	}
+
+	privateKey, err := config.privateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	chainID, err := config.Network.ChainID()
+	if err != nil {
+		return nil, err
+	}
+
+	ethClient, err := ethclient.DialContext(ctx, config.RpcUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to RPC node: %w", err)
+	}
+
+	return &submitter{
+		backend:    ethClient,
+		privateKey: privateKey,
+		chainID:    chainID,
+		closeFn:    ethClient.Close,
+	}, nil
+}
+
+// close releases the submitter's connection
+func (s *submitter) close() {
+	if s.closeFn != nil {
+		s.closeFn()
+	}
+}
+
+// sender returns the address transactions are sent from
+func (s *submitter) sender() geth_common.Address {
+	return crypto.PubkeyToAddress(s.privateKey.PublicKey)
+}
+
+// pendingNonce fetches the sender's next nonce
+func (s *submitter) pendingNonce(ctx context.Context) (uint64, error) {
+	nonce, err := s.backend.PendingNonceAt(ctx, s.sender())
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch nonce: %w", err)
+	}
+	return nonce, nil
+}
+
+// submitAll settles the bundles in a single multicall transaction if
+// configured and possible, falling back to one transaction per bundle
+func (s *submitter) submitAll(
+	ctx context.Context,
+	bundles []*external_match_client.ExternalMatchBundle,
+	config *Config,
+) ([]geth_common.Hash, error) {
+	nonce, err := s.pendingNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.MulticallAddress != "" && len(bundles) > 1 {
+		multicall := geth_common.HexToAddress(config.MulticallAddress)
+		hash, err := s.submitBatch(ctx, multicall, bundles, nonce, config)
+		if err == nil {
+			return []geth_common.Hash{hash}, nil
+		}
+		log.Printf("batch settlement unavailable, submitting bundles sequentially: %v", err)
+	}
+
+	hashes := make([]geth_common.Hash, 0, len(bundles))
+	for i, bundle := range bundles {
+		gasLimit := config.settlementGasLimit(bundle.SettlementTx)
+		hash, err := s.submit(ctx, bundle.SettlementTx, nonce+uint64(i), gasLimit)
+		if err != nil {
+			return hashes, fmt.Errorf("failed to submit bundle %d: %w", i, err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+// submitBatch aggregates the bundles into a multicall transaction and submits
+// it with a gas limit of its estimate plus the configured margin
+func (s *submitter) submitBatch(
+	ctx context.Context,
+	multicall geth_common.Address,
+	bundles []*external_match_client.ExternalMatchBundle,
+	nonce uint64,
+	config *Config,
+) (geth_common.Hash, error) {
+	batchTx, err := external_match_client.BuildBatchSettlementTx(multicall, bundles)
+	if err != nil {
+		return geth_common.Hash{}, err
+	}
+
+	estimate, err := s.backend.EstimateGas(ctx, ethereum.CallMsg{
+		From:  s.sender(),
+		To:    &batchTx.To,
+		Value: batchTx.Value,
+		Data:  batchTx.Data,
+	})
+	if err != nil {
+		return geth_common.Hash{}, fmt.Errorf("failed to estimate batch gas: %w", err)
+	}
+
+	return s.submit(ctx, batchTx, nonce, config.withGasMargin(estimate))
+}
+
+// submit signs and sends a settlement transaction with the given nonce and
+// gas limit
+func (s *submitter) submit(
+	ctx context.Context,
+	settlementTx *external_match_client.SettlementTransaction,
+	nonce uint64,
+	gasLimit uint64,
+) (geth_common.Hash, error) {
+	gasPrice, err := s.backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return geth_common.Hash{}, fmt.Errorf("failed to fetch gas price: %w", err)
+	}
+
+	tx, err := buildSettlementTx(settlementTx, s.chainID, nonce, gasPrice, gasLimit)
+	if err != nil {
+		return geth_common.Hash{}, err
+	}
+
+	signer := types.LatestSignerForChainID(new(big.Int).SetUint64(s.chainID))
+	signedTx, err := types.SignTx(tx, signer, s.privateKey)
+	if err != nil {
+		return geth_common.Hash{}, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	if err := s.backend.SendTransaction(ctx, signedTx); err != nil {
+		return geth_common.Hash{}, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	return signedTx.Hash(), nil
+}
+
+// buildSettlementTx builds an unsigned settlement transaction, tipping the
+// suggested gas price with a fee cap of twice that
+func buildSettlementTx(
+	settlementTx *external_match_client.SettlementTransaction,
+	chainID uint64,
+	nonce uint64,
+	gasPrice *big.Int,
+	gasLimit uint64,
+) (*types.Transaction, error) {
+	if settlementTx == nil {
+		return nil, fmt.Errorf("bundle has no settlement transaction")
+	}
+
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   new(big.Int).SetUint64(chainID),
+		Nonce:     nonce,
+		GasTipCap: gasPrice,
+		GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
+		Gas:       gasLimit,
+		To:        &settlementTx.To,
+		Value:     settlementTx.Value,
+		Data:      settlementTx.Data,
+	}), nil
+}
@@ -0,0 +1,233 @@
+package quickstart
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+
+	external_match_client "github.com/renegade-fi/golang-sdk/client/external_match_client"
+)
+
+// setTestEnv sets the credentials in the environment
+func setTestEnv(t *testing.T) {
+	t.Setenv(EnvApiKey, "test-key")
+	t.Setenv(EnvApiSecret, base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	t.Setenv(EnvNetwork, "")
+	t.Setenv(EnvRpcUrl, "")
+	t.Setenv(EnvPrivateKey, "")
+	t.Setenv(EnvGasLimit, "")
+	t.Setenv(EnvGasMarginBps, "")
+	t.Setenv(EnvMulticallAddress, "")
+}
+
+// fakeBackend records the transactions sent to it, failing gas estimation if
+// estimateErr is set. Transactions in known are returned by hash, pending if
+// minedNonce does not exceed their nonce
+type fakeBackend struct {
+	estimateErr error
+	sent        []*types.Transaction
+	known       map[geth_common.Hash]*types.Transaction
+	minedNonce  uint64
+}
+
+func (b *fakeBackend) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return big.NewInt(100), nil
+}
+
+func (b *fakeBackend) PendingNonceAt(context.Context, geth_common.Address) (uint64, error) {
+	return 3, nil
+}
+
+func (b *fakeBackend) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return 250_000, b.estimateErr
+}
+
+func (b *fakeBackend) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	b.sent = append(b.sent, tx)
+	return nil
+}
+
+func (b *fakeBackend) TransactionByHash(_ context.Context, hash geth_common.Hash) (*types.Transaction, bool, error) {
+	tx, ok := b.known[hash]
+	if !ok {
+		return nil, false, ethereum.NotFound
+	}
+	return tx, tx.Nonce() >= b.minedNonce, nil
+}
+
+func (b *fakeBackend) NonceAt(context.Context, geth_common.Address, *big.Int) (uint64, error) {
+	return b.minedNonce, nil
+}
+
+// newTestSubmitter creates a submitter sending to the given backend
+func newTestSubmitter(t *testing.T, backend settlementBackend) *submitter {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	return &submitter{backend: backend, privateKey: key, chainID: 421614}
+}
+
+// testBundles builds bundles settling against the darkpool
+func testBundles(n int) []*external_match_client.ExternalMatchBundle {
+	bundles := make([]*external_match_client.ExternalMatchBundle, n)
+	for i := range bundles {
+		bundles[i] = &external_match_client.ExternalMatchBundle{
+			SettlementTx: &external_match_client.SettlementTransaction{
+				To:    geth_common.HexToAddress("0x9af58f1ff20ab22e819e40b57ffd784d115a9ef5"),
+				Data:  []byte{byte(i)},
+				Value: big.NewInt(0),
+			},
+		}
+	}
+	return bundles
+}
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	setTestEnv(t)
+	t.Setenv(EnvGasLimit, "500000")
+
+	config, err := LoadConfig(nil /* args */)
+	assert.NoError(t, err)
+	assert.Equal(t, NetworkTestnet, config.Network)
+	assert.Equal(t, "test-key", config.ApiKey)
+	assert.Equal(t, uint64(500_000), config.GasLimit)
+	assert.Equal(t, uint64(defaultGasMarginBps), config.GasMarginBps)
+}
+
+func TestLoadConfigFlagsOverrideEnv(t *testing.T) {
+	setTestEnv(t)
+
+	config, err := LoadConfig([]string{"-network", "mainnet", "-api-key", "flag-key", "-gas-limit", "42"})
+	assert.NoError(t, err)
+	assert.Equal(t, NetworkMainnet, config.Network)
+	assert.Equal(t, "flag-key", config.ApiKey)
+	assert.Equal(t, uint64(42), config.GasLimit)
+
+	chainID, err := config.Network.ChainID()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42161), chainID)
+}
+
+func TestLoadConfigValidation(t *testing.T) {
+	setTestEnv(t)
+	t.Setenv(EnvApiKey, "")
+	_, err := LoadConfig(nil /* args */)
+	assert.True(t, errors.Is(err, ErrMissingConfig))
+
+	setTestEnv(t)
+	_, err = LoadConfig([]string{"-network", "devnet"})
+	assert.Error(t, err)
+
+	setTestEnv(t)
+	t.Setenv(EnvGasLimit, "lots")
+	_, err = LoadConfig(nil /* args */)
+	assert.Error(t, err)
+}
+
+func TestNewExternalMatchClient(t *testing.T) {
+	setTestEnv(t)
+	config, err := LoadConfig(nil /* args */)
+	assert.NoError(t, err)
+
+	client, err := NewExternalMatchClient(config)
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+
+	config.ApiSecret = "not base64"
+	_, err = NewExternalMatchClient(config)
+	assert.Error(t, err)
+}
+
+func TestSubmitBundleRejectsSandbox(t *testing.T) {
+	config := &Config{Network: NetworkTestnet, RpcUrl: "http://localhost:1"}
+	bundle := &external_match_client.ExternalMatchBundle{Sandbox: true}
+
+	_, err := SubmitBundle(context.Background(), config, bundle)
+	assert.True(t, errors.Is(err, external_match_client.ErrSandboxSettlement))
+}
+
+func TestSubmitBundleRequiresPrivateKey(t *testing.T) {
+	config := &Config{Network: NetworkTestnet, RpcUrl: "http://localhost:1"}
+	bundle := &external_match_client.ExternalMatchBundle{
+		SettlementTx: &external_match_client.SettlementTransaction{},
+	}
+
+	_, err := SubmitBundle(context.Background(), config, bundle)
+	assert.True(t, errors.Is(err, ErrMissingConfig))
+}
+
+func TestBuildSettlementTx(t *testing.T) {
+	to := geth_common.HexToAddress("0x9af58f1ff20ab22e819e40b57ffd784d115a9ef5")
+	bundle := &external_match_client.ExternalMatchBundle{
+		SettlementTx: &external_match_client.SettlementTransaction{
+			To:    to,
+			Data:  []byte{0x01, 0x02},
+			Value: big.NewInt(0),
+		},
+	}
+
+	tx, err := buildSettlementTx(bundle.SettlementTx, 421614, 7 /* nonce */, big.NewInt(100), 1_000_000)
+	assert.NoError(t, err)
+	assert.Equal(t, &to, tx.To())
+	assert.Equal(t, uint64(7), tx.Nonce())
+	assert.Equal(t, uint64(1_000_000), tx.Gas())
+	assert.Equal(t, big.NewInt(100), tx.GasTipCap())
+	assert.Equal(t, big.NewInt(200), tx.GasFeeCap())
+	assert.Equal(t, []byte{0x01, 0x02}, tx.Data())
+	assert.Equal(t, big.NewInt(421614), tx.ChainId())
+
+	_, err = buildSettlementTx(nil /* settlementTx */, 421614, 0, big.NewInt(1), 1)
+	assert.Error(t, err)
+}
+
+func TestSubmitBundlesBatched(t *testing.T) {
+	backend := &fakeBackend{}
+	config := &Config{GasLimit: 1_000_000, MulticallAddress: external_match_client.Multicall3Address.Hex()}
+
+	hashes, err := newTestSubmitter(t, backend).submitAll(context.Background(), testBundles(3), config)
+	assert.NoError(t, err)
+	assert.Len(t, hashes, 1)
+	assert.Len(t, backend.sent, 1)
+	assert.Equal(t, &external_match_client.Multicall3Address, backend.sent[0].To())
+	assert.Equal(t, uint64(250_000), backend.sent[0].Gas())
+
+	// The estimate is padded by the configured margin
+	config.GasMarginBps = 2_000
+	backend.sent = nil
+	_, err = newTestSubmitter(t, backend).submitAll(context.Background(), testBundles(2), config)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(300_000), backend.sent[0].Gas())
+	assert.Equal(t, uint64(3), backend.sent[0].Nonce())
+}
+
+func TestSubmitBundlesFallsBackToSequential(t *testing.T) {
+	backend := &fakeBackend{estimateErr: errors.New("execution reverted")}
+	config := &Config{GasLimit: 1_000_000, MulticallAddress: external_match_client.Multicall3Address.Hex()}
+
+	hashes, err := newTestSubmitter(t, backend).submitAll(context.Background(), testBundles(3), config)
+	assert.NoError(t, err)
+	assert.Len(t, hashes, 3)
+	for i, tx := range backend.sent {
+		assert.Equal(t, uint64(3+i), tx.Nonce())
+		assert.Equal(t, uint64(1_000_000), tx.Gas())
+		assert.Equal(t, []byte{byte(i)}, tx.Data())
+	}
+}
+
+func TestSettlementGasLimit(t *testing.T) {
+	config := &Config{GasLimit: 1_000_000, GasMarginBps: 2_500}
+
+	// Transactions without an estimate use the configured limit
+	assert.Equal(t, uint64(1_000_000), config.settlementGasLimit(&external_match_client.SettlementTransaction{}))
+	assert.Equal(t, uint64(1_000_000), config.settlementGasLimit(nil /* tx */))
+
+	estimated := &external_match_client.SettlementTransaction{Gas: 400_000}
+	assert.Equal(t, uint64(500_000), config.settlementGasLimit(estimated))
+}
@@ -0,0 +1,240 @@
+package quickstart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// minFeeBumpMultiplier is the smallest fee multiplier nodes accept for a
+	// replacement transaction
+	minFeeBumpMultiplier = 1.1
+	// defaultPendingTimeout is how long a settlement must have been pending
+	// before it is considered stuck
+	defaultPendingTimeout = 2 * time.Minute
+	// cancelGasLimit is the gas limit of a cancelling self-transfer
+	cancelGasLimit = 21_000
+)
+
+// ErrInsufficientFeeBump is returned when a rescue's gas multiplier is too
+// small for nodes to accept the replacement transaction
+var ErrInsufficientFeeBump = fmt.Errorf("gas multiplier must be at least %.1f", minFeeBumpMultiplier)
+
+// RescueAction is the action taken to rescue a settlement transaction
+type RescueAction int
+
+const (
+	// RescueNone indicates the transaction was not stuck: it was mined or
+	// has not yet been pending for the timeout
+	RescueNone RescueAction = iota
+	// RescueRebroadcast indicates the transaction was replaced by a copy with
+	// bumped fees
+	RescueRebroadcast
+	// RescueCancel indicates the transaction was replaced by a self-transfer
+	// with bumped fees, cancelling the settlement
+	RescueCancel
+)
+
+// String returns the name of the action
+func (a RescueAction) String() string {
+	switch a {
+	case RescueNone:
+		return "none"
+	case RescueRebroadcast:
+		return "rebroadcast"
+	case RescueCancel:
+		return "cancel"
+	default:
+		return fmt.Sprintf("RescueAction(%d)", int(a))
+	}
+}
+
+// RescueOptions configures the rescue of a stuck settlement
+type RescueOptions struct {
+	// SubmittedAt is the time the transaction was submitted; zero considers
+	// any pending transaction stuck
+	SubmittedAt time.Time
+	// PendingTimeout is how long a transaction must have been pending since
+	// it was submitted to be considered stuck
+	PendingTimeout time.Duration
+	// Deadline is the time after which the bundle can no longer settle, e.g.
+	// the quote's expiry; zero if unknown. A stuck settlement past its
+	// deadline is cancelled rather than rebroadcast
+	Deadline time.Time
+	// Cancel cancels a stuck settlement even if its deadline allows it to
+	// settle
+	Cancel bool
+}
+
+// NewRescueOptions creates a new RescueOptions with the default values
+func NewRescueOptions() *RescueOptions {
+	return &RescueOptions{PendingTimeout: defaultPendingTimeout}
+}
+
+// WithSubmittedAt sets the time the transaction was submitted
+func (o *RescueOptions) WithSubmittedAt(submittedAt time.Time) *RescueOptions {
+	o.SubmittedAt = submittedAt
+	return o
+}
+
+// WithPendingTimeout sets how long a transaction must be pending to be stuck
+func (o *RescueOptions) WithPendingTimeout(timeout time.Duration) *RescueOptions {
+	o.PendingTimeout = timeout
+	return o
+}
+
+// WithDeadline sets the time after which the bundle can no longer settle
+func (o *RescueOptions) WithDeadline(deadline time.Time) *RescueOptions {
+	o.Deadline = deadline
+	return o
+}
+
+// WithCancel sets whether a stuck settlement is always cancelled
+func (o *RescueOptions) WithCancel(cancel bool) *RescueOptions {
+	o.Cancel = cancel
+	return o
+}
+
+// RescueResult is the outcome of a settlement rescue
+type RescueResult struct {
+	// Action is the action taken
+	Action RescueAction
+	// Hash is the hash of the replacement transaction, zero if none was sent
+	Hash geth_common.Hash
+	// DeadlineAllowsSettlement indicates whether the bundle's deadline still
+	// allows it to settle; true if no deadline is set
+	DeadlineAllowsSettlement bool
+}
+
+// RescueSettlement rescues a settlement transaction submitted with the
+// configured private key that is stuck in the mempool. A transaction pending
+// for longer than the options' timeout is replaced, at the same nonce and
+// with its fees multiplied by newGasMultiplier, by either a rebroadcast of
+// itself or, if its deadline has passed or cancellation is requested, a
+// zero-value self-transfer.
+//
+// The options may be nil to use the defaults
+func RescueSettlement(
+	ctx context.Context,
+	config *Config,
+	txHash geth_common.Hash,
+	newGasMultiplier float64,
+	options *RescueOptions,
+) (*RescueResult, error) {
+	sub, err := dialSubmitter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.close()
+
+	return sub.rescue(ctx, txHash, newGasMultiplier, options)
+}
+
+// rescue replaces the transaction with the given hash if it is stuck
+func (s *submitter) rescue(
+	ctx context.Context,
+	txHash geth_common.Hash,
+	newGasMultiplier float64,
+	options *RescueOptions,
+) (*RescueResult, error) {
+	if options == nil {
+		options = NewRescueOptions()
+	}
+	if math.IsNaN(newGasMultiplier) || math.IsInf(newGasMultiplier, 0) || newGasMultiplier < minFeeBumpMultiplier {
+		return nil, ErrInsufficientFeeBump
+	}
+
+	result := &RescueResult{
+		Action:                   RescueNone,
+		DeadlineAllowsSettlement: options.Deadline.IsZero() || time.Now().Before(options.Deadline),
+	}
+
+	tx, pending, err := s.backend.TransactionByHash(ctx, txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, fmt.Errorf("transaction %s is not known to the node", txHash.Hex())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction: %w", err)
+	}
+	if !pending {
+		return result, nil
+	}
+
+	signer := types.LatestSignerForChainID(new(big.Int).SetUint64(s.chainID))
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover transaction sender: %w", err)
+	}
+	if from != s.sender() {
+		return nil, fmt.Errorf("transaction was sent by %s, not the configured key", from.Hex())
+	}
+
+	// A transaction whose nonce was consumed by another transaction is no
+	// longer replaceable
+	confirmed, err := s.backend.NonceAt(ctx, from, nil /* blockNumber */)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch nonce: %w", err)
+	}
+	if confirmed > tx.Nonce() {
+		return result, nil
+	}
+
+	if !options.SubmittedAt.IsZero() && time.Since(options.SubmittedAt) < options.PendingTimeout {
+		return result, nil
+	}
+
+	replacement := bumpFees(tx, newGasMultiplier)
+	result.Action = RescueRebroadcast
+	if options.Cancel || !result.DeadlineAllowsSettlement {
+		replacement.To = &from
+		replacement.Value = big.NewInt(0)
+		replacement.Data = nil
+		replacement.Gas = cancelGasLimit
+		result.Action = RescueCancel
+	}
+
+	signedTx, err := types.SignNewTx(s.privateKey, signer, replacement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign replacement transaction: %w", err)
+	}
+	if err := s.backend.SendTransaction(ctx, signedTx); err != nil {
+		return nil, fmt.Errorf("failed to send replacement transaction: %w", err)
+	}
+
+	result.Hash = signedTx.Hash()
+	return result, nil
+}
+
+// bumpFees copies a transaction at the same nonce with its tip and fee cap
+// multiplied by the given multiplier
+func bumpFees(tx *types.Transaction, multiplier float64) *types.DynamicFeeTx {
+	return &types.DynamicFeeTx{
+		ChainID:   tx.ChainId(),
+		Nonce:     tx.Nonce(),
+		GasTipCap: scaleWei(tx.GasTipCap(), multiplier),
+		GasFeeCap: scaleWei(tx.GasFeeCap(), multiplier),
+		Gas:       tx.Gas(),
+		To:        tx.To(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	}
+}
+
+// scaleWei multiplies an amount of wei by the given multiplier, rounding up
+// so that small amounts are still bumped
+func scaleWei(amount *big.Int, multiplier float64) *big.Int {
+	product := new(big.Float).Mul(new(big.Float).SetInt(amount), big.NewFloat(multiplier))
+	scaled, accuracy := product.Int(nil)
+	if accuracy == big.Below {
+		scaled.Add(scaled, big.NewInt(1))
+	}
+	return scaled
+}
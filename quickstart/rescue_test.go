@@ -0,0 +1,97 @@
+package quickstart
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// stuckSettlement submits a settlement through the submitter and returns its
+// hash, leaving it pending on the backend
+func stuckSettlement(t *testing.T, sub *submitter, backend *fakeBackend) geth_common.Hash {
+	hash, err := sub.submit(context.Background(), testBundles(1)[0].SettlementTx, 3 /* nonce */, 500_000)
+	assert.NoError(t, err)
+	backend.known = map[geth_common.Hash]*types.Transaction{hash: backend.sent[0]}
+	backend.sent = nil
+	return hash
+}
+
+func TestRescueSettlementRebroadcasts(t *testing.T) {
+	backend := &fakeBackend{}
+	sub := newTestSubmitter(t, backend)
+	hash := stuckSettlement(t, sub, backend)
+
+	options := NewRescueOptions().WithDeadline(time.Now().Add(time.Minute))
+	result, err := sub.rescue(context.Background(), hash, 1.25, options)
+	assert.NoError(t, err)
+	assert.Equal(t, RescueRebroadcast, result.Action)
+	assert.True(t, result.DeadlineAllowsSettlement)
+
+	assert.Len(t, backend.sent, 1)
+	replacement := backend.sent[0]
+	assert.Equal(t, result.Hash, replacement.Hash())
+	assert.Equal(t, uint64(3), replacement.Nonce())
+	assert.Equal(t, big.NewInt(125), replacement.GasTipCap())
+	assert.Equal(t, big.NewInt(250), replacement.GasFeeCap())
+	assert.Equal(t, backend.known[hash].Data(), replacement.Data())
+}
+
+func TestRescueSettlementCancelsPastDeadline(t *testing.T) {
+	backend := &fakeBackend{}
+	sub := newTestSubmitter(t, backend)
+	hash := stuckSettlement(t, sub, backend)
+
+	options := NewRescueOptions().WithDeadline(time.Now().Add(-time.Second))
+	result, err := sub.rescue(context.Background(), hash, 1.5, options)
+	assert.NoError(t, err)
+	assert.Equal(t, RescueCancel, result.Action)
+	assert.False(t, result.DeadlineAllowsSettlement)
+
+	replacement := backend.sent[0]
+	assert.Equal(t, sub.sender(), *replacement.To())
+	assert.Equal(t, uint64(3), replacement.Nonce())
+	assert.Equal(t, uint64(cancelGasLimit), replacement.Gas())
+	assert.Empty(t, replacement.Data())
+	assert.Equal(t, 0, replacement.Value().Sign())
+}
+
+func TestRescueSettlementSkipsHealthyTransactions(t *testing.T) {
+	backend := &fakeBackend{}
+	sub := newTestSubmitter(t, backend)
+	hash := stuckSettlement(t, sub, backend)
+
+	// A transaction that has not been pending for the timeout is left alone
+	options := NewRescueOptions().WithSubmittedAt(time.Now())
+	result, err := sub.rescue(context.Background(), hash, 1.25, options)
+	assert.NoError(t, err)
+	assert.Equal(t, RescueNone, result.Action)
+
+	// As is a mined transaction
+	backend.minedNonce = 4
+	result, err = sub.rescue(context.Background(), hash, 1.25, nil /* options */)
+	assert.NoError(t, err)
+	assert.Equal(t, RescueNone, result.Action)
+	assert.Empty(t, backend.sent)
+}
+
+func TestRescueSettlementValidation(t *testing.T) {
+	backend := &fakeBackend{}
+	sub := newTestSubmitter(t, backend)
+	hash := stuckSettlement(t, sub, backend)
+
+	_, err := sub.rescue(context.Background(), hash, 1.05, nil /* options */)
+	assert.True(t, errors.Is(err, ErrInsufficientFeeBump))
+
+	_, err = sub.rescue(context.Background(), geth_common.HexToHash("0x01"), 1.25, nil /* options */)
+	assert.Error(t, err)
+
+	// Transactions sent by another key cannot be replaced
+	_, err = newTestSubmitter(t, backend).rescue(context.Background(), hash, 1.25, nil /* options */)
+	assert.Error(t, err)
+}
@@ -0,0 +1,256 @@
+package quickstart
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+
+	external_match_client "github.com/renegade-fi/golang-sdk/client/external_match_client"
+)
+
+const (
+	// defaultSubmissionLatency is the initial estimate of the time taken to
+	// sign and send one settlement
+	defaultSubmissionLatency = 250 * time.Millisecond
+	// defaultInclusionTime is the default estimate of the time between a
+	// settlement's broadcast and its inclusion
+	defaultInclusionTime = time.Second
+	// latencySmoothing is the weight of each observed submission latency in
+	// the running estimate
+	latencySmoothing = 0.2
+)
+
+// ErrDeadlineUnmeetable is reported for scheduled bundles that were dropped
+// because they could not settle before their deadline
+var ErrDeadlineUnmeetable = errors.New("bundle cannot settle before its deadline")
+
+// SchedulerOptions configures a SubmissionScheduler
+type SchedulerOptions struct {
+	// SubmissionLatency is the initial estimate of the time taken to sign and
+	// send one settlement, refined as settlements are sent
+	SubmissionLatency time.Duration
+	// InclusionTime is the expected time between a settlement's broadcast and
+	// its inclusion at current gas prices
+	InclusionTime time.Duration
+	// Guard deduplicates the scheduler's submissions; nil to submit every
+	// scheduled bundle
+	Guard *SubmissionGuard
+}
+
+// NewSchedulerOptions creates a new SchedulerOptions with the default values
+func NewSchedulerOptions() *SchedulerOptions {
+	return &SchedulerOptions{
+		SubmissionLatency: defaultSubmissionLatency,
+		InclusionTime:     defaultInclusionTime,
+	}
+}
+
+// WithSubmissionLatency sets the initial estimate of the time taken to send
+// one settlement
+func (o *SchedulerOptions) WithSubmissionLatency(latency time.Duration) *SchedulerOptions {
+	o.SubmissionLatency = latency
+	return o
+}
+
+// WithInclusionTime sets the expected time between a settlement's broadcast
+// and its inclusion
+func (o *SchedulerOptions) WithInclusionTime(inclusionTime time.Duration) *SchedulerOptions {
+	o.InclusionTime = inclusionTime
+	return o
+}
+
+// WithSubmissionGuard sets the guard deduplicating the scheduler's submissions
+func (o *SchedulerOptions) WithSubmissionGuard(guard *SubmissionGuard) *SchedulerOptions {
+	o.Guard = guard
+	return o
+}
+
+// SubmissionReport is the outcome of a scheduled bundle's submission
+type SubmissionReport struct {
+	// Bundle is the scheduled bundle
+	Bundle *external_match_client.ExternalMatchBundle
+	// Deadline is the time by which the bundle had to settle
+	Deadline time.Time
+	// Hash is the hash of the settlement transaction, zero if none was sent
+	Hash geth_common.Hash
+	// Err is the submission's error; ErrDeadlineUnmeetable if the bundle was
+	// dropped
+	Err error
+}
+
+// SubmissionScheduler submits assembled bundles in order of urgency, i.e.
+// earliest deadline first. A bundle that cannot settle before its deadline,
+// given the backlog ahead of it and the expected inclusion time, is dropped
+// and reported instead of being broadcast
+type SubmissionScheduler struct {
+	config  *Config
+	options SchedulerOptions
+
+	// mu guards the fields below
+	mu sync.Mutex
+	// queue holds the scheduled bundles, ordered by deadline
+	queue scheduleQueue
+	// latency is the running estimate of the time taken to send a settlement
+	latency time.Duration
+}
+
+// NewSubmissionScheduler creates a scheduler submitting with the given config.
+// Nil options use the defaults, see NewSchedulerOptions
+func NewSubmissionScheduler(config *Config, options *SchedulerOptions) *SubmissionScheduler {
+	if options == nil {
+		options = NewSchedulerOptions()
+	}
+
+	return &SubmissionScheduler{
+		config:  config,
+		options: *options,
+		latency: options.SubmissionLatency,
+	}
+}
+
+// Schedule queues a bundle for submission before the given deadline, e.g. its
+// quote's expiry. Sandbox bundles are rejected
+func (s *SubmissionScheduler) Schedule(
+	bundle *external_match_client.ExternalMatchBundle, deadline time.Time,
+) error {
+	if bundle.Sandbox {
+		return external_match_client.ErrSandboxSettlement
+	}
+	if bundle.SettlementTx == nil {
+		return errors.New("bundle has no settlement transaction")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	heap.Push(&s.queue, scheduledBundle{bundle: bundle, deadline: deadline})
+	return nil
+}
+
+// Pending returns the number of bundles awaiting submission
+func (s *SubmissionScheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.queue.Len()
+}
+
+// Flush submits every scheduled bundle in order of urgency, returning a
+// report for each in the order they were handled. Bundles are deduplicated by
+// the options' guard, if any
+func (s *SubmissionScheduler) Flush(ctx context.Context) ([]SubmissionReport, error) {
+	if s.Pending() == 0 {
+		return nil, nil
+	}
+
+	sub, err := dialSubmitter(ctx, s.config)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.close()
+
+	return s.flush(ctx, sub)
+}
+
+// flush drains the queue through the given submitter, sending settlements
+// with consecutive nonces. If the context ends, the remaining bundles stay
+// queued
+func (s *SubmissionScheduler) flush(ctx context.Context, sub *submitter) ([]SubmissionReport, error) {
+	nonce, err := sub.pendingNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []SubmissionReport
+	for ctx.Err() == nil {
+		next, ok := s.pop()
+		if !ok {
+			return reports, nil
+		}
+		report := SubmissionReport{Bundle: next.bundle, Deadline: next.deadline}
+
+		// Drop the bundle if it cannot settle in time behind the backlog
+		// already sent
+		settleBy := time.Now().Add(s.expectedLatency() + s.options.InclusionTime)
+		if settleBy.After(next.deadline) {
+			late := settleBy.Sub(next.deadline)
+			report.Err = fmt.Errorf("%w: expected to settle %v late", ErrDeadlineUnmeetable, late)
+			reports = append(reports, report)
+			continue
+		}
+
+		sent := false
+		start := time.Now()
+		tx := next.bundle.SettlementTx
+		submit := func() (geth_common.Hash, error) {
+			sent = true
+			return sub.submit(ctx, tx, nonce, s.config.settlementGasLimit(tx))
+		}
+		if s.options.Guard != nil {
+			report.Hash, report.Err = s.options.Guard.do(settlementKey(next.bundle), submit)
+		} else {
+			report.Hash, report.Err = submit()
+		}
+		if sent {
+			s.observeLatency(time.Since(start))
+		}
+		if sent && report.Err == nil {
+			nonce++
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, ctx.Err()
+}
+
+// pop removes the most urgent bundle from the queue
+func (s *SubmissionScheduler) pop() (scheduledBundle, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.queue.Len() == 0 {
+		return scheduledBundle{}, false
+	}
+	return heap.Pop(&s.queue).(scheduledBundle), true //nolint:forcetypeassert
+}
+
+// expectedLatency returns the running estimate of the time taken to send a
+// settlement
+func (s *SubmissionScheduler) expectedLatency() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.latency
+}
+
+// observeLatency folds an observed submission latency into the estimate
+func (s *SubmissionScheduler) observeLatency(observed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = time.Duration((1-latencySmoothing)*float64(s.latency) + latencySmoothing*float64(observed))
+}
+
+// scheduledBundle is a bundle awaiting submission
+type scheduledBundle struct {
+	bundle   *external_match_client.ExternalMatchBundle
+	deadline time.Time
+}
+
+// scheduleQueue is a min-heap of scheduled bundles by deadline
+type scheduleQueue []scheduledBundle
+
+func (q scheduleQueue) Len() int           { return len(q) }
+func (q scheduleQueue) Less(i, j int) bool { return q[i].deadline.Before(q[j].deadline) }
+func (q scheduleQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *scheduleQueue) Push(x any) {
+	*q = append(*q, x.(scheduledBundle)) //nolint:forcetypeassert
+}
+
+func (q *scheduleQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
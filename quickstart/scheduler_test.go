@@ -0,0 +1,92 @@
+package quickstart
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	external_match_client "github.com/renegade-fi/golang-sdk/client/external_match_client"
+)
+
+func TestSchedulerSubmitsByUrgency(t *testing.T) {
+	backend := &fakeBackend{}
+	config := &Config{GasLimit: 1_000_000}
+	scheduler := NewSubmissionScheduler(config, NewSchedulerOptions())
+
+	// Schedule the bundles out of order of urgency
+	bundles := testBundles(3)
+	now := time.Now()
+	assert.NoError(t, scheduler.Schedule(bundles[0], now.Add(3*time.Minute)))
+	assert.NoError(t, scheduler.Schedule(bundles[1], now.Add(time.Minute)))
+	assert.NoError(t, scheduler.Schedule(bundles[2], now.Add(2*time.Minute)))
+	assert.Equal(t, 3, scheduler.Pending())
+
+	reports, err := scheduler.flush(context.Background(), newTestSubmitter(t, backend))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, scheduler.Pending())
+	assert.Len(t, reports, 3)
+	assert.Same(t, bundles[1], reports[0].Bundle)
+	assert.Same(t, bundles[2], reports[1].Bundle)
+	assert.Same(t, bundles[0], reports[2].Bundle)
+
+	// Settlements are sent with consecutive nonces in order of urgency
+	for i, tx := range backend.sent {
+		assert.NoError(t, reports[i].Err)
+		assert.Equal(t, tx.Hash(), reports[i].Hash)
+		assert.Equal(t, uint64(3+i), tx.Nonce())
+		assert.Equal(t, reports[i].Bundle.SettlementTx.Data, tx.Data())
+	}
+}
+
+func TestSchedulerDropsDoomedBundles(t *testing.T) {
+	backend := &fakeBackend{}
+	options := NewSchedulerOptions().WithInclusionTime(time.Second)
+	scheduler := NewSubmissionScheduler(&Config{GasLimit: 1_000_000}, options)
+
+	bundles := testBundles(2)
+	assert.NoError(t, scheduler.Schedule(bundles[0], time.Now().Add(500*time.Millisecond)))
+	assert.NoError(t, scheduler.Schedule(bundles[1], time.Now().Add(time.Minute)))
+
+	reports, err := scheduler.flush(context.Background(), newTestSubmitter(t, backend))
+	assert.NoError(t, err)
+	assert.Len(t, reports, 2)
+	assert.True(t, errors.Is(reports[0].Err, ErrDeadlineUnmeetable))
+	assert.Zero(t, reports[0].Hash)
+	assert.NoError(t, reports[1].Err)
+
+	// The dropped bundle does not consume a nonce
+	assert.Len(t, backend.sent, 1)
+	assert.Equal(t, uint64(3), backend.sent[0].Nonce())
+}
+
+func TestSchedulerRejectsSandboxBundles(t *testing.T) {
+	scheduler := NewSubmissionScheduler(&Config{}, NewSchedulerOptions())
+	err := scheduler.Schedule(&external_match_client.ExternalMatchBundle{Sandbox: true}, time.Now())
+	assert.True(t, errors.Is(err, external_match_client.ErrSandboxSettlement))
+	assert.Equal(t, 0, scheduler.Pending())
+}
+
+func TestSchedulerNilOptionsUseDefaults(t *testing.T) {
+	scheduler := NewSubmissionScheduler(&Config{}, nil /* options */)
+	assert.Equal(t, *NewSchedulerOptions(), scheduler.options)
+}
+
+func TestSchedulerDeduplicatesWithGuard(t *testing.T) {
+	backend := &fakeBackend{}
+	options := NewSchedulerOptions().WithSubmissionGuard(NewSubmissionGuard(time.Minute))
+	scheduler := NewSubmissionScheduler(&Config{GasLimit: 1_000_000}, options)
+
+	// The same settlement scheduled twice is sent once
+	bundle := testBundles(1)[0]
+	assert.NoError(t, scheduler.Schedule(bundle, time.Now().Add(time.Minute)))
+	assert.NoError(t, scheduler.Schedule(bundle, time.Now().Add(2*time.Minute)))
+
+	reports, err := scheduler.flush(context.Background(), newTestSubmitter(t, backend))
+	assert.NoError(t, err)
+	assert.Len(t, reports, 2)
+	assert.Len(t, backend.sent, 1)
+	assert.Equal(t, reports[0].Hash, reports[1].Hash)
+}
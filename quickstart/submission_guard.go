@@ -0,0 +1,400 @@
+package quickstart
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	external_match_client "github.com/renegade-fi/golang-sdk/client/external_match_client"
+)
+
+const (
+	// DefaultSubmissionRetention is a retention for NewSubmissionGuard
+	// comfortably longer than a quote's validity
+	DefaultSubmissionRetention = 10 * time.Minute
+	// StoreNamespaceSubmissions is the namespace of the submissions persisted
+	// by a SubmissionGuard with a store
+	StoreNamespaceSubmissions = "submissions"
+)
+
+// SubmissionGuard deduplicates bundle submissions by the hash of their
+// settlement transactions, see SettlementHash, so that retry logic submitting
+// the same bundle twice does not broadcast a duplicate transaction. A guard is
+// owned by the caller, who decides which submissions share it.
+//
+// A repeated submission returns the hash of the original transaction; one made
+// while the original is in flight waits for it. Failed submissions are not
+// remembered, so they may be retried
+type SubmissionGuard struct {
+	// retention is how long a successful submission is remembered
+	retention time.Duration
+	// store persists successful submissions, nil to remember them in memory
+	// only
+	store client.Store
+
+	// mu guards the submissions
+	mu sync.Mutex
+	// submissions are the remembered submissions, keyed by settlement hash
+	submissions map[string]*submission
+}
+
+// submission is a submission in flight or completed
+type submission struct {
+	// done is closed once the submission completes
+	done chan struct{}
+	// hash is the hash of the transaction sent, set once done
+	hash geth_common.Hash
+	// err is the submission's error, set once done
+	err error
+	// expiry is the time after which the submission is forgotten, set once done
+	expiry time.Time
+}
+
+// storedSubmission is the persisted form of a successful submission
+type storedSubmission struct {
+	Hash   geth_common.Hash `json:"hash"`
+	Expiry time.Time        `json:"expiry"`
+}
+
+// NewSubmissionGuard creates a guard that remembers successful submissions
+// for the given duration
+func NewSubmissionGuard(retention time.Duration) *SubmissionGuard {
+	return &SubmissionGuard{
+		retention:   retention,
+		submissions: make(map[string]*submission),
+	}
+}
+
+// NewSubmissionGuardWithStore creates a guard that remembers successful
+// submissions for the given duration in the given store, so that a bot
+// restarted with the same store does not resubmit them. Expired submissions
+// left in the store are removed
+func NewSubmissionGuardWithStore(retention time.Duration, store client.Store) (*SubmissionGuard, error) {
+	stored, err := store.List(StoreNamespaceSubmissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list submissions: %w", err)
+	}
+
+	now := time.Now()
+	for key, raw := range stored {
+		var entry storedSubmission
+		if err := json.Unmarshal(raw, &entry); err == nil && now.Before(entry.Expiry) {
+			continue
+		}
+		if err := store.Delete(StoreNamespaceSubmissions, key); err != nil {
+			return nil, fmt.Errorf("failed to remove expired submission: %w", err)
+		}
+	}
+
+	guard := NewSubmissionGuard(retention)
+	guard.store = store
+	return guard, nil
+}
+
+// SettlementHash returns the hash identifying a bundle's settlement
+// transaction, the keccak256 hash of its recipient, value and calldata. Unlike
+// the hash of the signed transaction, it does not depend on the nonce or gas
+// the settlement is sent with
+func SettlementHash(settlementTx *external_match_client.SettlementTransaction) geth_common.Hash {
+	value := new(big.Int)
+	if settlementTx.Value != nil {
+		value = settlementTx.Value
+	}
+	return crypto.Keccak256Hash(
+		settlementTx.To.Bytes(),
+		geth_common.LeftPadBytes(value.Bytes(), 32),
+		settlementTx.Data,
+	)
+}
+
+// SubmitBundle submits the bundle as the package level SubmitBundle does,
+// unless the same settlement was already submitted through the guard, in which
+// case the original transaction's hash is returned
+func (g *SubmissionGuard) SubmitBundle(
+	ctx context.Context,
+	config *Config,
+	bundle *external_match_client.ExternalMatchBundle,
+) (geth_common.Hash, error) {
+	return g.do(settlementKey(bundle), func() (geth_common.Hash, error) {
+		return SubmitBundle(ctx, config, bundle)
+	})
+}
+
+// SubmitBundles settles the bundles as the package level SubmitBundles does,
+// skipping those whose settlement was already submitted through the guard. It
+// returns, for each bundle, the hash of the transaction that settled it;
+// bundles settled in one multicall transaction share its hash
+func (g *SubmissionGuard) SubmitBundles(
+	ctx context.Context,
+	config *Config,
+	bundles []*external_match_client.ExternalMatchBundle,
+) ([]geth_common.Hash, error) {
+	for _, bundle := range bundles {
+		if bundle.Sandbox {
+			return nil, external_match_client.ErrSandboxSettlement
+		}
+	}
+
+	sub, err := dialSubmitter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.close()
+
+	return g.submitAll(ctx, sub, config, bundles)
+}
+
+// submitAll settles the bundles not yet submitted through the given
+// submitter, returning the hash settling each bundle
+func (g *SubmissionGuard) submitAll(
+	ctx context.Context,
+	sub *submitter,
+	config *Config,
+	bundles []*external_match_client.ExternalMatchBundle,
+) ([]geth_common.Hash, error) {
+	// Claim the bundles' settlements, leaving those claimed elsewhere to be
+	// awaited once ours complete
+	keys := make([]string, len(bundles))
+	claims := make([]*submission, len(bundles))
+	var owned []int
+	var pending []*external_match_client.ExternalMatchBundle
+	for i, bundle := range bundles {
+		keys[i] = settlementKey(bundle)
+		if keys[i] == "" {
+			return nil, fmt.Errorf("bundle %d has no settlement transaction", i)
+		}
+
+		var original bool
+		claims[i], original = g.claim(keys[i])
+		if original {
+			owned = append(owned, i)
+			pending = append(pending, bundle)
+		}
+	}
+
+	var submitErr error
+	if len(pending) > 0 {
+		var sent []geth_common.Hash
+		sent, submitErr = sub.submitAll(ctx, pending, config)
+		batched := submitErr == nil && len(sent) == 1 && len(pending) > 1
+		for j, i := range owned {
+			switch {
+			case batched:
+				g.complete(keys[i], claims[i], sent[0], nil)
+			case j < len(sent):
+				g.complete(keys[i], claims[i], sent[j], nil)
+			default:
+				g.complete(keys[i], claims[i], geth_common.Hash{}, submitErr)
+			}
+		}
+	}
+
+	hashes := make([]geth_common.Hash, len(bundles))
+	for i, claim := range claims {
+		<-claim.done
+		if claim.err != nil {
+			if submitErr != nil {
+				return hashes, submitErr
+			}
+
+			// The original submission failed elsewhere, so submit anew
+			hash, err := g.do(keys[i], func() (geth_common.Hash, error) {
+				nonce, err := sub.pendingNonce(ctx)
+				if err != nil {
+					return geth_common.Hash{}, err
+				}
+				tx := bundles[i].SettlementTx
+				return sub.submit(ctx, tx, nonce, config.settlementGasLimit(tx))
+			})
+			if err != nil {
+				return hashes, fmt.Errorf("failed to submit bundle %d: %w", i, err)
+			}
+			claim = &submission{hash: hash}
+		}
+		hashes[i] = claim.hash
+	}
+
+	return hashes, nil
+}
+
+// Submitted returns the hash of the transaction submitted for the bundle's
+// settlement, if one is remembered
+func (g *SubmissionGuard) Submitted(bundle *external_match_client.ExternalMatchBundle) (geth_common.Hash, bool) {
+	key := settlementKey(bundle)
+	if key == "" {
+		return geth_common.Hash{}, false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	sub, ok := g.lookup(key)
+	if !ok {
+		return geth_common.Hash{}, false
+	}
+	select {
+	case <-sub.done:
+	default:
+		return geth_common.Hash{}, false
+	}
+	if sub.err != nil || !time.Now().Before(sub.expiry) {
+		return geth_common.Hash{}, false
+	}
+	return sub.hash, true
+}
+
+// settlementKey returns the key a bundle's submission is remembered by, empty
+// if the bundle has no settlement transaction
+func settlementKey(bundle *external_match_client.ExternalMatchBundle) string {
+	if bundle.SettlementTx == nil {
+		return ""
+	}
+	return SettlementHash(bundle.SettlementTx).Hex()
+}
+
+// do runs the submission once per settlement key, returning the original
+// result for repeated submissions
+func (g *SubmissionGuard) do(
+	key string, submit func() (geth_common.Hash, error),
+) (geth_common.Hash, error) {
+	if key == "" {
+		return submit()
+	}
+
+	sub, original := g.claim(key)
+	if !original {
+		<-sub.done
+		if sub.err == nil {
+			log.Printf("settlement %s was already submitted in %s, not resubmitting",
+				abbreviate(key), sub.hash.Hex())
+			return sub.hash, nil
+		}
+
+		// The original submission failed and was forgotten, so submit anew
+		return g.do(key, submit)
+	}
+
+	hash, err := submit()
+	g.complete(key, sub, hash, err)
+	return hash, err
+}
+
+// claim returns the submission for the settlement key, and whether it was
+// created by this call. A newly created submission must be completed
+func (g *SubmissionGuard) claim(key string) (*submission, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	for stored, sub := range g.submissions {
+		select {
+		case <-sub.done:
+			if !now.Before(sub.expiry) {
+				delete(g.submissions, stored)
+				g.unpersist(stored)
+			}
+		default:
+		}
+	}
+
+	if sub, ok := g.lookup(key); ok {
+		return sub, false
+	}
+
+	sub := &submission{done: make(chan struct{})}
+	g.submissions[key] = sub
+	return sub, true
+}
+
+// complete records the result of a submission, forgetting it if it failed
+func (g *SubmissionGuard) complete(
+	key string, sub *submission, hash geth_common.Hash, err error,
+) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	sub.hash = hash
+	sub.err = err
+	sub.expiry = time.Now().Add(g.retention)
+	if err != nil {
+		delete(g.submissions, key)
+	} else {
+		g.persist(key, sub)
+	}
+	close(sub.done)
+}
+
+// lookup returns the submission for the settlement key, loading it from the
+// store if it is not in memory. The guard's lock must be held
+func (g *SubmissionGuard) lookup(key string) (*submission, bool) {
+	if sub, ok := g.submissions[key]; ok {
+		return sub, true
+	}
+	if g.store == nil {
+		return nil, false
+	}
+
+	raw, err := g.store.Get(StoreNamespaceSubmissions, key)
+	if err != nil {
+		if !errors.Is(err, client.ErrNotFound) {
+			log.Printf("failed to load submission for settlement %s: %v", abbreviate(key), err)
+		}
+		return nil, false
+	}
+	var entry storedSubmission
+	if err := json.Unmarshal(raw, &entry); err != nil || !time.Now().Before(entry.Expiry) {
+		g.unpersist(key)
+		return nil, false
+	}
+
+	sub := &submission{done: make(chan struct{}), hash: entry.Hash, expiry: entry.Expiry}
+	close(sub.done)
+	g.submissions[key] = sub
+	return sub, true
+}
+
+// persist records a successful submission in the store, if any. The
+// transaction is already broadcast, so a failure is logged rather than
+// returned. The guard's lock must be held
+func (g *SubmissionGuard) persist(key string, sub *submission) {
+	if g.store == nil {
+		return
+	}
+
+	raw, err := json.Marshal(storedSubmission{Hash: sub.hash, Expiry: sub.expiry})
+	if err == nil {
+		err = g.store.Put(StoreNamespaceSubmissions, key, raw)
+	}
+	if err != nil {
+		log.Printf("failed to persist submission for settlement %s: %v", abbreviate(key), err)
+	}
+}
+
+// unpersist removes an expired submission from the store, if any. The guard's
+// lock must be held
+func (g *SubmissionGuard) unpersist(key string) {
+	if g.store == nil {
+		return
+	}
+	if err := g.store.Delete(StoreNamespaceSubmissions, key); err != nil {
+		log.Printf("failed to remove submission for settlement %s: %v", abbreviate(key), err)
+	}
+}
+
+// abbreviate shortens a settlement key for logging
+func abbreviate(key string) string {
+	const maxLen = 16
+	if len(key) <= maxLen {
+		return key
+	}
+	return key[:maxLen] + "..."
+}
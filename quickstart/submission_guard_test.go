@@ -0,0 +1,214 @@
+package quickstart
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	geth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/renegade-fi/golang-sdk/client"
+	external_match_client "github.com/renegade-fi/golang-sdk/client/external_match_client"
+)
+
+// testBundle is the bundle the guard tests submit, and testKey its
+// settlement key
+var (
+	testBundle = testBundles(1)[0]
+	testKey    = settlementKey(testBundle)
+)
+
+func TestSettlementHash(t *testing.T) {
+	bundles := testBundles(2)
+	assert.Equal(t, SettlementHash(bundles[0].SettlementTx), SettlementHash(testBundle.SettlementTx))
+	assert.NotEqual(t, SettlementHash(bundles[0].SettlementTx), SettlementHash(bundles[1].SettlementTx))
+
+	// A nil value hashes as zero
+	noValue := *bundles[0].SettlementTx
+	noValue.Value = nil
+	assert.Equal(t, SettlementHash(bundles[0].SettlementTx), SettlementHash(&noValue))
+
+	// The gas estimate does not identify the settlement
+	estimated := *bundles[0].SettlementTx
+	estimated.Gas = 100_000
+	assert.Equal(t, SettlementHash(bundles[0].SettlementTx), SettlementHash(&estimated))
+}
+
+func TestSubmissionGuardDeduplicates(t *testing.T) {
+	guard := NewSubmissionGuard(time.Minute)
+	var sends atomic.Int32
+	submit := func() (geth_common.Hash, error) {
+		sends.Add(1)
+		return geth_common.HexToHash("0x01"), nil
+	}
+
+	first, err := guard.do(testKey, submit)
+	assert.NoError(t, err)
+	second, err := guard.do(testKey, submit)
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, int32(1), sends.Load())
+
+	hash, ok := guard.Submitted(testBundle)
+	assert.True(t, ok)
+	assert.Equal(t, first, hash)
+
+	// Other settlements and bundles without one are submitted
+	_, err = guard.do(settlementKey(testBundles(2)[1]), submit)
+	assert.NoError(t, err)
+	_, err = guard.do("", submit)
+	assert.NoError(t, err)
+	_, err = guard.do("", submit)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), sends.Load())
+}
+
+func TestSubmissionGuardConcurrentSubmissions(t *testing.T) {
+	guard := NewSubmissionGuard(time.Minute)
+	var sends atomic.Int32
+	release := make(chan struct{})
+	submit := func() (geth_common.Hash, error) {
+		sends.Add(1)
+		<-release
+		return geth_common.HexToHash("0x01"), nil
+	}
+
+	var wg sync.WaitGroup
+	hashes := make([]geth_common.Hash, 5)
+	for i := range hashes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			hashes[i], _ = guard.do(testKey, submit)
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), sends.Load())
+	for _, hash := range hashes {
+		assert.Equal(t, geth_common.HexToHash("0x01"), hash)
+	}
+}
+
+func TestSubmissionGuardRetriesFailures(t *testing.T) {
+	guard := NewSubmissionGuard(time.Minute)
+	_, err := guard.do(testKey, func() (geth_common.Hash, error) {
+		return geth_common.Hash{}, errors.New("rpc unavailable")
+	})
+	assert.Error(t, err)
+	_, ok := guard.Submitted(testBundle)
+	assert.False(t, ok)
+
+	hash, err := guard.do(testKey, func() (geth_common.Hash, error) {
+		return geth_common.HexToHash("0x02"), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, geth_common.HexToHash("0x02"), hash)
+}
+
+func TestSubmissionGuardRetention(t *testing.T) {
+	guard := NewSubmissionGuard(10 * time.Millisecond)
+	var sends atomic.Int32
+	submit := func() (geth_common.Hash, error) {
+		sends.Add(1)
+		return geth_common.HexToHash("0x01"), nil
+	}
+
+	_, err := guard.do(testKey, submit)
+	assert.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	_, ok := guard.Submitted(testBundle)
+	assert.False(t, ok)
+
+	_, err = guard.do(testKey, submit)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), sends.Load())
+}
+
+func TestSubmissionGuardWithStoreSurvivesRestart(t *testing.T) {
+	store := client.NewMemoryStore()
+	var sends atomic.Int32
+	submit := func() (geth_common.Hash, error) {
+		sends.Add(1)
+		return geth_common.HexToHash("0x01"), nil
+	}
+
+	guard, err := NewSubmissionGuardWithStore(time.Minute, store)
+	assert.NoError(t, err)
+	original, err := guard.do(testKey, submit)
+	assert.NoError(t, err)
+
+	// A guard created after a restart does not resubmit the bundle
+	restarted, err := NewSubmissionGuardWithStore(time.Minute, store)
+	assert.NoError(t, err)
+	hash, ok := restarted.Submitted(testBundle)
+	assert.True(t, ok)
+	assert.Equal(t, original, hash)
+	hash, err = restarted.do(testKey, submit)
+	assert.NoError(t, err)
+	assert.Equal(t, original, hash)
+	assert.Equal(t, int32(1), sends.Load())
+
+	// Expired submissions are removed from the store
+	expired, err := json.Marshal(storedSubmission{Hash: original, Expiry: time.Now().Add(-time.Second)})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Put(StoreNamespaceSubmissions, "old", expired))
+	_, err = NewSubmissionGuardWithStore(time.Minute, store)
+	assert.NoError(t, err)
+	_, err = store.Get(StoreNamespaceSubmissions, "old")
+	assert.ErrorIs(t, err, client.ErrNotFound)
+}
+
+func TestSubmissionGuardSubmitBundles(t *testing.T) {
+	guard := NewSubmissionGuard(time.Minute)
+	backend := &fakeBackend{}
+	config := &Config{GasLimit: 1_000_000}
+	bundles := testBundles(3)
+
+	// A bundle submitted alone is not resubmitted with the others
+	first, err := guard.submitAll(context.Background(), newTestSubmitter(t, backend), config, bundles[:1])
+	assert.NoError(t, err)
+	assert.Len(t, backend.sent, 1)
+
+	hashes, err := guard.submitAll(context.Background(), newTestSubmitter(t, backend), config, bundles)
+	assert.NoError(t, err)
+	assert.Len(t, hashes, 3)
+	assert.Len(t, backend.sent, 3)
+	assert.Equal(t, first[0], hashes[0])
+	assert.Equal(t, backend.sent[1].Hash(), hashes[1])
+	assert.Equal(t, backend.sent[2].Hash(), hashes[2])
+	for i, bundle := range bundles {
+		hash, ok := guard.Submitted(bundle)
+		assert.True(t, ok)
+		assert.Equal(t, hashes[i], hash)
+	}
+}
+
+func TestSubmissionGuardSubmitBundlesBatched(t *testing.T) {
+	guard := NewSubmissionGuard(time.Minute)
+	backend := &fakeBackend{}
+	config := &Config{GasLimit: 1_000_000, MulticallAddress: external_match_client.Multicall3Address.Hex()}
+	bundles := testBundles(3)
+
+	// Bundles settled in one batch share its hash, and a single bundle of the
+	// batch is not resubmitted
+	hashes, err := guard.submitAll(context.Background(), newTestSubmitter(t, backend), config, bundles)
+	assert.NoError(t, err)
+	assert.Len(t, backend.sent, 1)
+	assert.Equal(t, []geth_common.Hash{backend.sent[0].Hash(), backend.sent[0].Hash(), backend.sent[0].Hash()}, hashes)
+
+	hash, err := guard.do(settlementKey(bundles[1]), func() (geth_common.Hash, error) {
+		t.Fatal("settlement resubmitted")
+		return geth_common.Hash{}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, hashes[1], hash)
+}
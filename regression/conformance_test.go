@@ -0,0 +1,218 @@
+package regression
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+
+	renegade_crypto "github.com/renegade-fi/golang-sdk/crypto"
+	"github.com/renegade-fi/golang-sdk/wallet"
+)
+
+// vectorFile is the layout shared by every vector file
+type vectorFile[T any] struct {
+	// Source describes where the expected values come from
+	Source string `json:"source"`
+	// Vectors are the test vectors
+	Vectors []T `json:"vectors"`
+}
+
+// hashVector is a Poseidon2 hash of a sequence of field elements
+type hashVector struct {
+	Name   string   `json:"name"`
+	Input  []string `json:"input"`
+	Output string   `json:"output"`
+}
+
+// csprngVector is the stream of a Poseidon CSPRNG from a seed
+type csprngVector struct {
+	Name    string   `json:"name"`
+	Seed    string   `json:"seed"`
+	Outputs []string `json:"outputs"`
+}
+
+// derivationVector is the wallet derived from an Ethereum key
+type derivationVector struct {
+	Name          string `json:"name"`
+	EthPrivateKey string `json:"eth_private_key"`
+	ChainID       uint64 `json:"chain_id"`
+	Address       string `json:"address"`
+	WalletID      string `json:"wallet_id"`
+	PkRoot        string `json:"pk_root"`
+	PkMatch       string `json:"pk_match"`
+	SymmetricKey  string `json:"symmetric_key"`
+	BlinderSeed   string `json:"blinder_seed"`
+	ShareSeed     string `json:"share_seed"`
+	Blinder       string `json:"blinder"`
+	Commitment    string `json:"share_commitment"`
+}
+
+// shareBalance is a balance added to a wallet in a share vector
+type shareBalance struct {
+	Mint   string `json:"mint"`
+	Amount string `json:"amount"`
+}
+
+// shareOrder is an order added to a wallet in a share vector
+type shareOrder struct {
+	BaseMint  string `json:"base_mint"`
+	QuoteMint string `json:"quote_mint"`
+	Side      string `json:"side"`
+	Amount    string `json:"amount"`
+}
+
+// shareVector is the serialized shares of a wallet holding the given balances
+// and orders after one reblind
+type shareVector struct {
+	Name          string         `json:"name"`
+	EthPrivateKey string         `json:"eth_private_key"`
+	ChainID       uint64         `json:"chain_id"`
+	Balances      []shareBalance `json:"balances"`
+	Orders        []shareOrder   `json:"orders"`
+	PublicShares  []string       `json:"blinded_public_shares"`
+	PrivateShares []string       `json:"private_shares"`
+	Commitment    string         `json:"share_commitment"`
+}
+
+// loadVectors reads the named vector file from testdata
+func loadVectors[T any](t *testing.T, name string) []T {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+
+	var file vectorFile[T]
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("failed to parse %s: %v", name, err)
+	}
+	if file.Source == "" || len(file.Vectors) == 0 {
+		t.Fatalf("%s has no source or no vectors", name)
+	}
+	return file.Vectors
+}
+
+// parseElement parses a decimal field element
+func parseElement(t *testing.T, s string) fr.Element {
+	t.Helper()
+	var elt fr.Element
+	if _, err := elt.SetString(s); err != nil {
+		t.Fatalf("invalid field element %q: %v", s, err)
+	}
+	return elt
+}
+
+// parseScalar parses a hex scalar
+func parseScalar(t *testing.T, s string) wallet.Scalar {
+	t.Helper()
+	scalar, err := new(wallet.Scalar).FromHexString(s)
+	if err != nil {
+		t.Fatalf("invalid scalar %q: %v", s, err)
+	}
+	return scalar
+}
+
+// scalarsToHex hex encodes a wallet share
+func scalarsToHex(t *testing.T, share *wallet.WalletShare) []string {
+	t.Helper()
+	scalars, err := wallet.ToScalarsRecursive(share)
+	assert.NoError(t, err)
+	encoded := make([]string, len(scalars))
+	for i := range scalars {
+		encoded[i] = scalars[i].ToHexString()
+	}
+	return encoded
+}
+
+// walletFromKey derives a new wallet from a hex Ethereum key
+func walletFromKey(t *testing.T, ethPrivateKey string, chainID uint64) (*wallet.WalletSecrets, *wallet.Wallet) {
+	t.Helper()
+	key, err := crypto.HexToECDSA(ethPrivateKey)
+	if err != nil {
+		t.Fatalf("invalid key: %v", err)
+	}
+	secrets, err := wallet.DeriveWalletSecrets(key, chainID)
+	assert.NoError(t, err)
+	w, err := wallet.NewEmptyWalletFromSecrets(secrets)
+	assert.NoError(t, err)
+	return secrets, w
+}
+
+func TestPoseidon2Hash(t *testing.T) {
+	for _, v := range loadVectors[hashVector](t, "poseidon2_hash.json") {
+		t.Run(v.Name, func(t *testing.T) {
+			input := make([]fr.Element, len(v.Input))
+			for i, s := range v.Input {
+				input[i] = parseElement(t, s)
+			}
+
+			output := renegade_crypto.NewPoseidon2Sponge().Hash(input)
+			assert.Equal(t, v.Output, output.String())
+		})
+	}
+}
+
+func TestPoseidonCSPRNG(t *testing.T) {
+	for _, v := range loadVectors[csprngVector](t, "poseidon_csprng.json") {
+		t.Run(v.Name, func(t *testing.T) {
+			csprng := renegade_crypto.NewPoseidonCSPRNG(parseElement(t, v.Seed))
+			for i, expected := range v.Outputs {
+				output := csprng.Next()
+				assert.Equal(t, expected, output.String(), "output %d", i)
+			}
+		})
+	}
+}
+
+func TestWalletDerivation(t *testing.T) {
+	for _, v := range loadVectors[derivationVector](t, "wallet_derivation.json") {
+		t.Run(v.Name, func(t *testing.T) {
+			secrets, w := walletFromKey(t, v.EthPrivateKey, v.ChainID)
+			assert.Equal(t, v.Address, secrets.Address)
+			assert.Equal(t, v.WalletID, secrets.Id.String())
+			assert.Equal(t, v.PkRoot, secrets.Keychain.PublicKeys.PkRoot.ToHexString())
+			assert.Equal(t, v.PkMatch, secrets.Keychain.PublicKeys.PkMatch.ToHexString())
+			assert.Equal(t, v.SymmetricKey, secrets.Keychain.PrivateKeys.SymmetricKey.ToHexString())
+			assert.Equal(t, v.BlinderSeed, secrets.BlinderSeed.ToHexString())
+			assert.Equal(t, v.ShareSeed, secrets.ShareSeed.ToHexString())
+			assert.Equal(t, v.Blinder, w.Blinder.ToHexString())
+
+			commitment, err := w.GetShareCommitment()
+			assert.NoError(t, err)
+			assert.Equal(t, v.Commitment, commitment.ToHexString())
+		})
+	}
+}
+
+func TestWalletShareSerialization(t *testing.T) {
+	for _, v := range loadVectors[shareVector](t, "wallet_shares.json") {
+		t.Run(v.Name, func(t *testing.T) {
+			_, w := walletFromKey(t, v.EthPrivateKey, v.ChainID)
+			for _, b := range v.Balances {
+				balance := wallet.NewBalance(parseScalar(t, b.Mint), parseScalar(t, b.Amount))
+				assert.NoError(t, w.AddBalance(balance))
+			}
+			for _, o := range v.Orders {
+				order := wallet.NewOrderBuilder().
+					WithBaseMint(parseScalar(t, o.BaseMint)).
+					WithQuoteMint(parseScalar(t, o.QuoteMint)).
+					WithAmount(parseScalar(t, o.Amount)).
+					Build()
+				order.Side = parseScalar(t, o.Side)
+				assert.NoError(t, w.NewOrder(order))
+			}
+			assert.NoError(t, w.Reblind())
+
+			assert.Equal(t, v.PublicShares, scalarsToHex(t, &w.BlindedPublicShares))
+			assert.Equal(t, v.PrivateShares, scalarsToHex(t, &w.PrivateShares))
+			commitment, err := w.GetShareCommitment()
+			assert.NoError(t, err)
+			assert.Equal(t, v.Commitment, commitment.ToHexString())
+		})
+	}
+}
@@ -0,0 +1,12 @@
+// Package regression checks the SDK against deterministic test vectors pinned
+// as JSON, so that changes to its cryptography and wallet serialization are
+// caught without a running relayer.
+//
+// The vectors live in testdata, one file per primitive: Poseidon2 hashes,
+// Poseidon CSPRNG streams, wallet derivation and wallet share serialization.
+// Each file records the `source` of its expected values. Only the Poseidon2
+// vectors come from the relayer's Rust implementation; the rest pin this SDK's
+// own outputs and so guard against regressions, not against divergence from
+// the relayer. A vector is only regenerated from the SDK for an intended
+// change in behavior, never to make a failing test pass
+package regression
@@ -83,7 +83,7 @@ func (w *Wallet) AddBalance(balance Balance) error {
 	} else if len(w.Balances) < MaxBalances {
 		w.Balances = append(w.Balances, balance)
 	} else {
-		return fmt.Errorf("wallet already has the maximum number of balances")
+		return w.CheckBalanceCapacity(balance.Mint)
 	}
 
 	return nil
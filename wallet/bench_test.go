@@ -0,0 +1,56 @@
+package wallet
+
+import (
+	"math/big"
+	"testing"
+)
+
+// benchmarkFeeRate is a fee rate of 0.0002, as encoded by the relayer
+var benchmarkFeeRate = NewFixedPoint(new(Scalar).FromBigInt(big.NewInt(1844674407370955)))
+
+func BenchmarkFixedPointFromFloat(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = FixedPointFromFloat(3000.123456)
+	}
+}
+
+func BenchmarkFixedPointToFloat(b *testing.B) {
+	fp := FixedPointFromFloat(3000.123456)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = fp.ToFloat()
+	}
+}
+
+func BenchmarkFixedPointFloorMulInt(b *testing.B) {
+	amount := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = benchmarkFeeRate.FloorMulInt(amount)
+	}
+}
+
+func BenchmarkFixedPointDivInt(b *testing.B) {
+	fp := FixedPointFromFloat(3000.123456)
+	amount := big.NewInt(4_500_000_000)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = fp.DivInt(amount, RoundFloor)
+	}
+}
+
+func BenchmarkFixedPointReprString(b *testing.B) {
+	fp := FixedPointFromFloat(3000.123456)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var parsed FixedPoint
+		if _, err := parsed.FromReprDecimalString(fp.ToReprDecimalString()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrWalletFull is returned when a wallet has no free slot for a new order or
+// balance. Errors matching it are of type *CapacityError
+var ErrWalletFull = errors.New("wallet is full")
+
+// CapacityError is returned when adding an order or balance to a wallet that
+// already holds the maximum number of them
+type CapacityError struct {
+	// Resource is the kind of wallet entry that is full, "orders" or "balances"
+	Resource string
+	// Max is the maximum number of entries of the resource a wallet may hold
+	Max int
+	// Remedy suggests how to free a slot
+	Remedy string
+}
+
+// Error implements the error interface
+func (e *CapacityError) Error() string {
+	return fmt.Sprintf("wallet already has the maximum of %d %s; %s", e.Max, e.Resource, e.Remedy)
+}
+
+// Unwrap allows matching the error against ErrWalletFull
+func (e *CapacityError) Unwrap() error {
+	return ErrWalletFull
+}
+
+// RemainingOrderSlots returns the number of orders that may be added to the
+// wallet before it reaches MaxOrders
+func (w *Wallet) RemainingOrderSlots() int {
+	return max(MaxOrders-len(w.GetNonzeroOrders()), 0)
+}
+
+// RemainingBalanceSlots returns the number of balances in new mints that may
+// be added to the wallet before it reaches MaxBalances
+func (w *Wallet) RemainingBalanceSlots() int {
+	return max(MaxBalances-len(w.GetNonzeroBalances()), 0)
+}
+
+// CheckOrderCapacity returns a *CapacityError if the wallet has no free slot
+// for a new order
+func (w *Wallet) CheckOrderCapacity() error {
+	if w.RemainingOrderSlots() > 0 {
+		return nil
+	}
+
+	return &CapacityError{
+		Resource: "orders",
+		Max:      MaxOrders,
+		Remedy:   "cancel an open order to free a slot",
+	}
+}
+
+// CheckBalanceCapacity returns a *CapacityError if the wallet has no free slot
+// for a balance in the given mint. A mint the wallet already holds may always
+// be deposited
+func (w *Wallet) CheckBalanceCapacity(mint Scalar) error {
+	if idx := w.findMatchingBalance(mint); idx != -1 || w.RemainingBalanceSlots() > 0 {
+		return nil
+	}
+
+	return &CapacityError{
+		Resource: "balances",
+		Max:      MaxBalances,
+		Remedy:   "withdraw a balance in full, paying its fees, to free a slot",
+	}
+}
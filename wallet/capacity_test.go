@@ -0,0 +1,62 @@
+package wallet
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderCapacity(t *testing.T) {
+	w := &Wallet{}
+	for i := 0; i < MaxOrders; i++ {
+		assert.Equal(t, MaxOrders-i, w.RemainingOrderSlots())
+		order := NewOrderBuilder().WithSide(Buy).WithAmountBigInt(big.NewInt(1)).Build()
+		assert.NoError(t, w.NewOrder(order))
+	}
+	assert.Equal(t, 0, w.RemainingOrderSlots())
+
+	order := NewOrderBuilder().WithSide(Buy).WithAmountBigInt(big.NewInt(1)).Build()
+	err := w.NewOrder(order)
+	assert.ErrorIs(t, err, ErrWalletFull)
+	var capacityErr *CapacityError
+	assert.True(t, errors.As(err, &capacityErr))
+	assert.Equal(t, MaxOrders, capacityErr.Max)
+	assert.Contains(t, err.Error(), "cancel")
+
+	// Cancelling an order frees a slot
+	assert.NoError(t, w.CancelOrder(w.Orders[0].Id))
+	assert.Equal(t, 1, w.RemainingOrderSlots())
+	assert.NoError(t, w.NewOrder(order))
+
+	// Updating an order replaces it in its slot
+	order.Amount = new(Scalar).FromBigInt(big.NewInt(2))
+	assert.NoError(t, w.UpdateOrder(order))
+	assert.Equal(t, 0, w.RemainingOrderSlots())
+	assert.Equal(t, order, w.Orders[w.findOrder(order.Id)])
+	assert.Error(t, w.UpdateOrder(NewEmptyOrder()))
+}
+
+func TestBalanceCapacity(t *testing.T) {
+	w := &Wallet{}
+	for i := 0; i < MaxBalances; i++ {
+		balance := NewBalanceBuilder().
+			WithMint(*new(Scalar).SetUint64(uint64(i + 1))).
+			WithAmountBigInt(big.NewInt(1)).
+			Build()
+		assert.NoError(t, w.AddBalance(balance))
+	}
+	assert.Equal(t, 0, w.RemainingBalanceSlots())
+
+	// Depositing an existing mint is always allowed
+	existing := *new(Scalar).SetUint64(1)
+	assert.NoError(t, w.CheckBalanceCapacity(existing))
+
+	newMint := *new(Scalar).SetUint64(MaxBalances + 1)
+	assert.ErrorIs(t, w.CheckBalanceCapacity(newMint), ErrWalletFull)
+	balance := NewBalanceBuilder().WithMint(newMint).WithAmountBigInt(big.NewInt(1)).Build()
+	err := w.AddBalance(balance)
+	assert.ErrorIs(t, err, ErrWalletFull)
+	assert.Contains(t, err.Error(), "withdraw")
+}
@@ -0,0 +1,119 @@
+package wallet
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+
+	renegade_crypto "github.com/renegade-fi/golang-sdk/crypto"
+)
+
+// commitmentCacheSize bounds the number of private shares whose commitments
+// are cached; the cache is cleared once it is full
+const commitmentCacheSize = 64
+
+// commitmentCacheEntry caches the commitments of a wallet's shares
+type commitmentCacheEntry struct {
+	// privateShares are the private shares the entry was computed for
+	privateShares []Scalar
+	// privateCommitment is the commitment to the private shares
+	privateCommitment Scalar
+
+	// publicShares are the public shares last committed to alongside the
+	// private shares, nil if none have been
+	publicShares []Scalar
+	// shareCommitment is the full commitment to the shares above
+	shareCommitment Scalar
+	// checkpoints[j] is the sponge state after absorbing the first
+	// (j+1)*RATE elements of the full commitment's input
+	checkpoints []*renegade_crypto.Poseidon2Sponge
+}
+
+// commitmentCache caches share commitments keyed by the blinder's private
+// share. Reblinding samples new private shares, so the private commitment is
+// computed once per reblind; public shares change on their own, e.g. when a
+// match settles, so the full commitment resumes hashing from the last
+// unchanged prefix of its input
+var commitmentCache = struct {
+	mu      sync.Mutex
+	entries map[Scalar]*commitmentCacheEntry
+}{entries: make(map[Scalar]*commitmentCacheEntry)}
+
+// cachedPrivateShareCommitment returns the commitment to the given private
+// shares, computing and caching it if they are not cached
+func cachedPrivateShareCommitment(blinder Scalar, privateShares []Scalar) Scalar {
+	commitmentCache.mu.Lock()
+	defer commitmentCache.mu.Unlock()
+	return lookupPrivateShares(blinder, privateShares).privateCommitment
+}
+
+// cachedShareCommitment returns the commitment to the given private and public
+// shares, rehashing only the suffix of the public shares that changed since
+// they were last committed to with the same private shares
+func cachedShareCommitment(blinder Scalar, privateShares, publicShares []Scalar) Scalar {
+	commitmentCache.mu.Lock()
+	defer commitmentCache.mu.Unlock()
+	entry := lookupPrivateShares(blinder, privateShares)
+
+	// Find the first element of the hash input that changed; the input is the
+	// private commitment followed by the public shares
+	changed := 0
+	if entry.publicShares != nil && len(entry.publicShares) == len(publicShares) {
+		diff := 0
+		for diff < len(publicShares) && publicShares[diff] == entry.publicShares[diff] {
+			diff++
+		}
+		if diff == len(publicShares) {
+			return entry.shareCommitment
+		}
+		changed = diff + 1
+	}
+
+	// Resume from the last checkpoint before the change
+	resumeAt := changed / renegade_crypto.RATE
+	sponge := renegade_crypto.NewPoseidon2Sponge()
+	checkpoints := entry.checkpoints[:0]
+	if resumeAt > 0 {
+		sponge = entry.checkpoints[resumeAt-1].Clone()
+		checkpoints = entry.checkpoints[:resumeAt]
+	}
+
+	input := make([]fr.Element, 0, len(publicShares)+1)
+	input = append(input, fr.Element(entry.privateCommitment))
+	for _, share := range publicShares {
+		input = append(input, fr.Element(share))
+	}
+
+	for i := resumeAt * renegade_crypto.RATE; i < len(input); i++ {
+		//nolint:errcheck,gosec
+		sponge.Absorb(input[i])
+		if (i+1)%renegade_crypto.RATE == 0 {
+			checkpoints = append(checkpoints, sponge.Clone())
+		}
+	}
+
+	entry.publicShares = slices.Clone(publicShares)
+	entry.checkpoints = checkpoints
+	entry.shareCommitment = Scalar(sponge.Squeeze())
+	return entry.shareCommitment
+}
+
+// lookupPrivateShares returns the cache entry for the given private shares,
+// replacing any stale entry for the blinder. The cache lock must be held
+func lookupPrivateShares(blinder Scalar, privateShares []Scalar) *commitmentCacheEntry {
+	entry, ok := commitmentCache.entries[blinder]
+	if ok && slices.Equal(entry.privateShares, privateShares) {
+		return entry
+	}
+
+	if len(commitmentCache.entries) >= commitmentCacheSize {
+		clear(commitmentCache.entries)
+	}
+	entry = &commitmentCacheEntry{
+		privateShares:     slices.Clone(privateShares),
+		privateCommitment: HashScalars(privateShares),
+	}
+	commitmentCache.entries[blinder] = entry
+	return entry
+}
@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/stretchr/testify/assert"
+)
+
+// uncachedShareCommitment computes a wallet's share commitment from scratch
+func uncachedShareCommitment(t *testing.T, w *Wallet) Scalar {
+	privateShares, err := ToScalarsRecursive(&w.PrivateShares)
+	assert.NoError(t, err)
+	publicShares, err := ToScalarsRecursive(&w.BlindedPublicShares)
+	assert.NoError(t, err)
+
+	return HashScalars(append([]Scalar{HashScalars(privateShares)}, publicShares...))
+}
+
+func TestShareCommitmentCache(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	assert.NoError(t, err)
+	wallet, err := NewEmptyWallet(privateKey, 1 /* chainId */)
+	assert.NoError(t, err)
+
+	check := func() {
+		commitment, err := wallet.GetShareCommitment()
+		assert.NoError(t, err)
+		assert.Equal(t, uncachedShareCommitment(t, wallet), commitment)
+	}
+
+	// Cold and warm cache
+	check()
+	check()
+
+	// Public shares change without a reblind, e.g. when a match settles; a
+	// change at each position resumes from a different checkpoint
+	wallet.BlindedPublicShares.Blinder = Scalar{7}
+	check()
+	wallet.BlindedPublicShares.Balances[3].Amount = Scalar{9}
+	check()
+	wallet.BlindedPublicShares.Balances[0].Mint = Scalar{5}
+	check()
+
+	// A reblind samples new private shares
+	assert.NoError(t, wallet.Reblind())
+	check()
+	privateCommitment, err := wallet.GetPrivateShareCommitment()
+	assert.NoError(t, err)
+	privateShares, err := ToScalarsRecursive(&wallet.PrivateShares)
+	assert.NoError(t, err)
+	assert.Equal(t, HashScalars(privateShares), privateCommitment)
+
+	// Private shares modified without changing the blinder are not served
+	// from the cache
+	wallet.PrivateShares.Orders[0].Amount = Scalar{3}
+	check()
+}
@@ -0,0 +1,53 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CommitmentSigner signs wallet share commitments to authorize wallet
+// updates. The relayer verifies the signature against the wallet's public
+// root key, so a signer must hold the matching private key, e.g. in a custody
+// service or hardware module
+type CommitmentSigner interface {
+	// SignCommitment signs a commitment to a wallet's shares
+	SignCommitment(commitment Scalar) ([]byte, error)
+}
+
+// CommitmentSignerFunc adapts a function to a CommitmentSigner
+type CommitmentSignerFunc func(commitment Scalar) ([]byte, error)
+
+// SignCommitment calls f
+func (f CommitmentSignerFunc) SignCommitment(commitment Scalar) ([]byte, error) {
+	return f(commitment)
+}
+
+// Secp256k1CommitmentSigner signs commitments with a secp256k1 root key over
+// the keccak256 digest of the commitment's big-endian bytes, the scheme the
+// relayer verifies by default
+type Secp256k1CommitmentSigner struct {
+	key *PrivateSigningKey
+}
+
+// NewSecp256k1CommitmentSigner creates a signer for the given root key
+func NewSecp256k1CommitmentSigner(key *PrivateSigningKey) *Secp256k1CommitmentSigner {
+	return &Secp256k1CommitmentSigner{key: key}
+}
+
+// SignCommitment signs the commitment, returning a 65 byte [R || S || V]
+// signature
+func (s *Secp256k1CommitmentSigner) SignCommitment(commitment Scalar) ([]byte, error) {
+	if s.key == nil {
+		return nil, errors.New("no root key to sign with")
+	}
+	signKey := ecdsa.PrivateKey(*s.key)
+	if signKey.D == nil {
+		return nil, errors.New("no root key to sign with")
+	}
+
+	commBytes := commitment.ToBigInt().Bytes()
+	digest := crypto.Keccak256(commBytes)
+	return crypto.Sign(digest, &signKey)
+}
@@ -0,0 +1,51 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecp256k1CommitmentSigner(t *testing.T) {
+	key, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	assert.NoError(t, err)
+	w, err := NewEmptyWallet(key, 421614 /* chainID */)
+	assert.NoError(t, err)
+
+	commitment := *new(Scalar).SetUint64(12345)
+	sig, err := NewSecp256k1CommitmentSigner(w.Keychain.SkRoot()).SignCommitment(commitment)
+	assert.NoError(t, err)
+	assert.Len(t, sig, 65)
+
+	// The signature recovers to the wallet's root key
+	digest := crypto.Keccak256(commitment.ToBigInt().Bytes())
+	pubkey, err := crypto.SigToPub(digest, sig)
+	assert.NoError(t, err)
+	pkRoot := ecdsa.PublicKey(w.Keychain.PublicKeys.PkRoot)
+	assert.Equal(t, crypto.PubkeyToAddress(pkRoot), crypto.PubkeyToAddress(*pubkey))
+
+	// The wallet signs through the same signer
+	walletSig, err := w.SignCommitment(commitment)
+	assert.NoError(t, err)
+	assert.Equal(t, sig, walletSig)
+
+	_, err = NewSecp256k1CommitmentSigner(nil /* key */).SignCommitment(commitment)
+	assert.Error(t, err)
+	_, err = NewSecp256k1CommitmentSigner(&PrivateSigningKey{}).SignCommitment(commitment)
+	assert.Error(t, err)
+}
+
+func TestCommitmentSignerFunc(t *testing.T) {
+	errSign := errors.New("custody service unavailable")
+	var signer CommitmentSigner = CommitmentSignerFunc(func(Scalar) ([]byte, error) {
+		return nil, errSign
+	})
+
+	_, err := signer.SignCommitment(Scalar{})
+	assert.ErrorIs(t, err, errSign)
+}
@@ -10,6 +10,10 @@ import (
 // precisionBits is the number of bits of precision in the fixed point number
 const precisionBits = 63
 
+// maxReprDecimalDigits is the number of decimal digits in the largest 256-bit
+// integer, bounding the length of a valid repr string
+const maxReprDecimalDigits = 78
+
 // FixedPoint is a fixed point number with a scalar representation
 // The scalar represents the value `floor(repr >> 2^PRECISION)`
 // For our purposes, the precision is 63 bits
@@ -69,12 +73,71 @@ func (fp FixedPoint) ToReprDecimalString() string {
 
 // FromReprDecimalString creates a new fixed point number from a decimal string
 func (fp *FixedPoint) FromReprDecimalString(s string) (FixedPoint, error) {
+	if len(s) > maxReprDecimalDigits {
+		return FixedPoint{}, fmt.Errorf("fixed point repr exceeds %d digits", maxReprDecimalDigits)
+	}
+
 	reprBigint, ok := new(big.Int).SetString(s, 10)
 	if !ok {
 		return FixedPoint{}, fmt.Errorf("failed to convert decimal string to big.Int")
 	}
+	if reprBigint.Sign() < 0 {
+		return FixedPoint{}, fmt.Errorf("fixed point repr must be non-negative: %s", s)
+	}
 
 	repr := new(Scalar).FromBigInt(reprBigint)
 	fp.Repr = repr
 	return *fp, nil
 }
+
+// RoundingMode is the rounding applied when a fixed point product or quotient
+// is converted to an integer
+type RoundingMode int
+
+const (
+	// RoundFloor rounds toward zero. The relayer floors fee and price
+	// conversions, so this mode reproduces settled amounts
+	RoundFloor RoundingMode = iota
+	// RoundCeil rounds toward positive infinity
+	RoundCeil
+	// RoundNearest rounds to the nearest integer, with halves rounded up
+	RoundNearest
+)
+
+// MulInt multiplies the fixed point number by a non-negative integer,
+// rounding the product to an integer with the given mode
+func (fp FixedPoint) MulInt(i *big.Int, mode RoundingMode) *big.Int {
+	product := new(big.Int).Mul(fp.Repr.ToBigInt(), i)
+	return roundedQuo(product, new(big.Int).Lsh(big.NewInt(1), precisionBits), mode)
+}
+
+// FloorMulInt multiplies the fixed point number by a non-negative integer,
+// flooring the product as the relayer does
+func (fp FixedPoint) FloorMulInt(i *big.Int) *big.Int {
+	return fp.MulInt(i, RoundFloor)
+}
+
+// DivInt divides a non-negative integer by the fixed point number, rounding
+// the quotient to an integer with the given mode. The fixed point number must
+// be non-zero
+func (fp FixedPoint) DivInt(i *big.Int, mode RoundingMode) *big.Int {
+	shifted := new(big.Int).Lsh(i, precisionBits)
+	return roundedQuo(shifted, fp.Repr.ToBigInt(), mode)
+}
+
+// roundedQuo divides two non-negative integers, rounding with the given mode
+func roundedQuo(num, den *big.Int, mode RoundingMode) *big.Int {
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	switch mode {
+	case RoundCeil:
+		if rem.Sign() > 0 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	case RoundNearest:
+		if new(big.Int).Lsh(rem, 1).Cmp(den) >= 0 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	return quo
+}
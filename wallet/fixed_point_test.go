@@ -2,8 +2,11 @@ package wallet
 
 import (
 	"math"
+	"math/big"
 	"math/rand/v2"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 // precisionTolerance is the maximum allowed difference between the original and
@@ -27,3 +30,37 @@ func TestFixedPoint(t *testing.T) {
 		)
 	}
 }
+
+// TestFixedPointRounding checks integer conversions against vectors computed
+// with the relayer's fixed point arithmetic, `(repr * i) >> 63` for products
+func TestFixedPointRounding(t *testing.T) {
+	half := NewFixedPoint(new(Scalar).FromBigInt(big.NewInt(1 << 62)))
+	// A fee rate of 0.0002, as encoded by the relayer
+	feeRate := NewFixedPoint(new(Scalar).FromBigInt(big.NewInt(1844674407370955)))
+
+	testCases := []struct {
+		fp      FixedPoint
+		i       int64
+		floor   int64
+		ceil    int64
+		nearest int64
+	}{
+		{half, 3, 1, 2, 2},
+		{half, 4, 2, 2, 2},
+		{feeRate, 1_000_000, 199, 200, 200},
+		{feeRate, 0, 0, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		i := big.NewInt(tc.i)
+		assert.Equal(t, tc.floor, tc.fp.FloorMulInt(i).Int64())
+		assert.Equal(t, tc.ceil, tc.fp.MulInt(i, RoundCeil).Int64())
+		assert.Equal(t, tc.nearest, tc.fp.MulInt(i, RoundNearest).Int64())
+	}
+
+	// Dividing by one half doubles
+	assert.Equal(t, int64(6), half.DivInt(big.NewInt(3), RoundFloor).Int64())
+	// 1 / 0.0002 is just above 5000 due to the rate's truncated encoding
+	assert.Equal(t, int64(5000), feeRate.DivInt(big.NewInt(1), RoundFloor).Int64())
+	assert.Equal(t, int64(5001), feeRate.DivInt(big.NewInt(1), RoundCeil).Int64())
+}
@@ -0,0 +1,48 @@
+package wallet
+
+import (
+	"testing"
+)
+
+// FuzzScalarFromHexString checks that scalar hex parsing never panics
+func FuzzScalarFromHexString(f *testing.F) {
+	f.Add("0x01")
+	f.Add("30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001")
+	f.Add("0x" + "ff" + "30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001")
+	f.Fuzz(func(t *testing.T, s string) {
+		var scalar Scalar
+		//nolint:errcheck
+		scalar.FromHexString(s)
+	})
+}
+
+// FuzzFixedPointFromReprDecimalString checks that fixed point parsing never panics
+func FuzzFixedPointFromReprDecimalString(f *testing.F) {
+	f.Add("9223372036854775808")
+	f.Add("-1")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, s string) {
+		var fp FixedPoint
+		//nolint:errcheck
+		fp.FromReprDecimalString(s)
+	})
+}
+
+// FuzzScalarFromHexStringStrict checks that strictly parsed scalars round trip
+// through their hex encoding
+func FuzzScalarFromHexStringStrict(f *testing.F) {
+	f.Add("0x01")
+	f.Add("30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000")
+	f.Add("30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001")
+	f.Fuzz(func(t *testing.T, s string) {
+		var scalar Scalar
+		if _, err := scalar.FromHexStringStrict(s); err != nil {
+			return
+		}
+
+		var roundTripped Scalar
+		if _, err := roundTripped.FromHexString(scalar.ToHexString()); err != nil || roundTripped != scalar {
+			t.Fatalf("scalar %q does not round trip: %v", s, err)
+		}
+	})
+}
@@ -0,0 +1,374 @@
+package wallet
+
+import (
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+)
+
+// Signing keys export to the standard encodings external signers and HSMs
+// import: PKCS#8 and SubjectPublicKeyInfo PEM, as written by `openssl pkey`,
+// and JWK with the secp256k1 curve registered in RFC 8812. The standard
+// library's x509 package only supports the NIST curves, so the DER structures
+// are encoded here
+
+const (
+	// pemTypePrivateKey is the PEM block type of a PKCS#8 private key
+	pemTypePrivateKey = "PRIVATE KEY"
+	// pemTypeECPrivateKey is the PEM block type of a SEC 1 private key, as
+	// written by `openssl ecparam -genkey`
+	pemTypeECPrivateKey = "EC PRIVATE KEY"
+	// pemTypePublicKey is the PEM block type of a SubjectPublicKeyInfo
+	pemTypePublicKey = "PUBLIC KEY"
+
+	// jwkKeyType is the JWK key type of elliptic curve keys
+	jwkKeyType = "EC"
+	// jwkCurve is the JWK curve name of secp256k1
+	jwkCurve = "secp256k1"
+
+	// secp256k1ScalarBytes is the byte length of a secp256k1 scalar or
+	// coordinate
+	secp256k1ScalarBytes = 32
+)
+
+var (
+	// oidPublicKeyECDSA identifies elliptic curve public keys
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	// oidSecp256k1 identifies the secp256k1 curve
+	oidSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// algorithmIdentifier is an elliptic curve key's algorithm and named curve
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	NamedCurve asn1.ObjectIdentifier
+}
+
+// subjectPublicKeyInfo is the DER structure of a public key, RFC 5480
+type subjectPublicKeyInfo struct {
+	Algorithm algorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// pkcs8PrivateKey is the DER structure of a PKCS#8 private key, RFC 5208
+type pkcs8PrivateKey struct {
+	Version    int
+	Algorithm  algorithmIdentifier
+	PrivateKey []byte
+}
+
+// ecPrivateKey is the DER structure of a SEC 1 private key, RFC 5915
+type ecPrivateKey struct {
+	Version    int
+	PrivateKey []byte
+	NamedCurve asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey  asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// JWK is a JSON Web Key holding a secp256k1 key. Coordinates and the private
+// scalar are unpadded base64url encodings of their 32-byte big-endian forms
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	// D is the private scalar, empty for public keys
+	D string `json:"d,omitempty"`
+}
+
+// ToPEM encodes the public key as a SubjectPublicKeyInfo PEM block
+func (pk *PublicSigningKey) ToPEM() ([]byte, error) {
+	if pk.X == nil || pk.Y == nil {
+		return nil, errors.New("public key is not set")
+	}
+
+	der, err := asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: algorithmIdentifier{Algorithm: oidPublicKeyECDSA, NamedCurve: oidSecp256k1},
+		PublicKey: marshalPoint(pk.X, pk.Y),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePublicKey, Bytes: der}), nil
+}
+
+// FromPEM decodes a public key from a SubjectPublicKeyInfo PEM block
+func (pk *PublicSigningKey) FromPEM(data []byte) (PublicSigningKey, error) {
+	block, err := decodePEM(data, pemTypePublicKey)
+	if err != nil {
+		return PublicSigningKey{}, err
+	}
+
+	var info subjectPublicKeyInfo
+	if err := unmarshalDER(block.Bytes, &info); err != nil {
+		return PublicSigningKey{}, fmt.Errorf("invalid public key: %w", err)
+	}
+	if err := checkAlgorithm(info.Algorithm); err != nil {
+		return PublicSigningKey{}, err
+	}
+
+	x, y, err := unmarshalPoint(info.PublicKey)
+	if err != nil {
+		return PublicSigningKey{}, err
+	}
+	pk.Curve, pk.X, pk.Y = secp256k1.S256(), x, y
+	return *pk, nil
+}
+
+// ToJWK encodes the public key as a JWK
+func (pk *PublicSigningKey) ToJWK() ([]byte, error) {
+	if pk.X == nil || pk.Y == nil {
+		return nil, errors.New("public key is not set")
+	}
+	return json.Marshal(JWK{
+		Kty: jwkKeyType,
+		Crv: jwkCurve,
+		X:   encodeJWKInt(pk.X),
+		Y:   encodeJWKInt(pk.Y),
+	})
+}
+
+// FromJWK decodes a public key from a JWK. The private scalar, if present, is
+// ignored
+func (pk *PublicSigningKey) FromJWK(data []byte) (PublicSigningKey, error) {
+	var jwk JWK
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		return PublicSigningKey{}, fmt.Errorf("invalid JWK: %w", err)
+	}
+	x, y, err := jwk.point()
+	if err != nil {
+		return PublicSigningKey{}, err
+	}
+
+	pk.Curve, pk.X, pk.Y = secp256k1.S256(), x, y
+	return *pk, nil
+}
+
+// ToPEM encodes the private key as a PKCS#8 PEM block, including its
+// public key
+func (pk *PrivateSigningKey) ToPEM() ([]byte, error) {
+	if err := checkPrivateScalar(pk.D); err != nil {
+		return nil, err
+	}
+
+	x, y := secp256k1.S256().ScalarBaseMult(pk.D.FillBytes(make([]byte, secp256k1ScalarBytes)))
+	sec1, err := asn1.Marshal(ecPrivateKey{
+		Version:    1,
+		PrivateKey: pk.D.FillBytes(make([]byte, secp256k1ScalarBytes)),
+		PublicKey:  marshalPoint(x, y),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	der, err := asn1.Marshal(pkcs8PrivateKey{
+		Algorithm:  algorithmIdentifier{Algorithm: oidPublicKeyECDSA, NamedCurve: oidSecp256k1},
+		PrivateKey: sec1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: der}), nil
+}
+
+// FromPEM decodes a private key from a PKCS#8 PEM block, or from a SEC 1
+// ("EC PRIVATE KEY") block as generated by OpenSSL, and sets its public key
+func (pk *PrivateSigningKey) FromPEM(data []byte) (PrivateSigningKey, error) {
+	block, err := decodePEM(data, pemTypePrivateKey, pemTypeECPrivateKey)
+	if err != nil {
+		return PrivateSigningKey{}, err
+	}
+
+	sec1 := block.Bytes
+	if block.Type == pemTypePrivateKey {
+		var pkcs8 pkcs8PrivateKey
+		if err := unmarshalDER(block.Bytes, &pkcs8); err != nil {
+			return PrivateSigningKey{}, fmt.Errorf("invalid private key: %w", err)
+		}
+		if err := checkAlgorithm(pkcs8.Algorithm); err != nil {
+			return PrivateSigningKey{}, err
+		}
+		sec1 = pkcs8.PrivateKey
+	}
+
+	var key ecPrivateKey
+	if err := unmarshalDER(sec1, &key); err != nil {
+		return PrivateSigningKey{}, fmt.Errorf("invalid private key: %w", err)
+	}
+	if len(key.NamedCurve) > 0 && !key.NamedCurve.Equal(oidSecp256k1) {
+		return PrivateSigningKey{}, fmt.Errorf("private key is not on secp256k1: curve %s", key.NamedCurve)
+	}
+	if len(key.PrivateKey) > secp256k1ScalarBytes {
+		return PrivateSigningKey{}, errors.New("private key exceeds 32 bytes")
+	}
+
+	if err := pk.setScalar(new(big.Int).SetBytes(key.PrivateKey)); err != nil {
+		return PrivateSigningKey{}, err
+	}
+	return *pk, nil
+}
+
+// ToJWK encodes the private key as a JWK, including its public key
+func (pk *PrivateSigningKey) ToJWK() ([]byte, error) {
+	if err := checkPrivateScalar(pk.D); err != nil {
+		return nil, err
+	}
+
+	x, y := secp256k1.S256().ScalarBaseMult(pk.D.FillBytes(make([]byte, secp256k1ScalarBytes)))
+	return json.Marshal(JWK{
+		Kty: jwkKeyType,
+		Crv: jwkCurve,
+		X:   encodeJWKInt(x),
+		Y:   encodeJWKInt(y),
+		D:   encodeJWKInt(pk.D),
+	})
+}
+
+// FromJWK decodes a private key from a JWK, checking that its public
+// coordinates belong to the private scalar
+func (pk *PrivateSigningKey) FromJWK(data []byte) (PrivateSigningKey, error) {
+	var jwk JWK
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		return PrivateSigningKey{}, fmt.Errorf("invalid JWK: %w", err)
+	}
+	x, y, err := jwk.point()
+	if err != nil {
+		return PrivateSigningKey{}, err
+	}
+	if jwk.D == "" {
+		return PrivateSigningKey{}, errors.New("JWK holds no private key")
+	}
+	d, err := decodeJWKInt("d", jwk.D)
+	if err != nil {
+		return PrivateSigningKey{}, err
+	}
+
+	var key PrivateSigningKey
+	if err := key.setScalar(d); err != nil {
+		return PrivateSigningKey{}, err
+	}
+	if key.X.Cmp(x) != 0 || key.Y.Cmp(y) != 0 {
+		return PrivateSigningKey{}, errors.New("JWK public key does not match its private key")
+	}
+
+	*pk = key
+	return *pk, nil
+}
+
+// setScalar sets the private scalar and derives the public key from it
+func (pk *PrivateSigningKey) setScalar(d *big.Int) error {
+	if err := checkPrivateScalar(d); err != nil {
+		return err
+	}
+
+	curve := secp256k1.S256()
+	pk.D = d
+	pk.Curve = curve
+	pk.X, pk.Y = curve.ScalarBaseMult(d.FillBytes(make([]byte, secp256k1ScalarBytes)))
+	return nil
+}
+
+// point decodes and validates the JWK's public coordinates
+func (k *JWK) point() (x, y *big.Int, err error) {
+	if k.Kty != jwkKeyType || k.Crv != jwkCurve {
+		return nil, nil, fmt.Errorf("JWK is not a secp256k1 key: kty %q, crv %q", k.Kty, k.Crv)
+	}
+	if x, err = decodeJWKInt("x", k.X); err != nil {
+		return nil, nil, err
+	}
+	if y, err = decodeJWKInt("y", k.Y); err != nil {
+		return nil, nil, err
+	}
+	if !secp256k1.S256().IsOnCurve(x, y) {
+		return nil, nil, errors.New("JWK public key is not a point on secp256k1")
+	}
+	return x, y, nil
+}
+
+// checkPrivateScalar checks that a private scalar is a valid secp256k1 key
+func checkPrivateScalar(d *big.Int) error {
+	if d == nil {
+		return errors.New("private key is not set")
+	}
+	if d.Sign() <= 0 || d.Cmp(secp256k1.S256().N) >= 0 {
+		return errors.New("private key is out of range for secp256k1")
+	}
+	return nil
+}
+
+// checkAlgorithm checks that a key's algorithm is ECDSA over secp256k1
+func checkAlgorithm(algorithm algorithmIdentifier) error {
+	if !algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return fmt.Errorf("key is not an elliptic curve key: algorithm %s", algorithm.Algorithm)
+	}
+	if !algorithm.NamedCurve.Equal(oidSecp256k1) {
+		return fmt.Errorf("key is not on secp256k1: curve %s", algorithm.NamedCurve)
+	}
+	return nil
+}
+
+// decodePEM decodes the first PEM block in the data, which must be of one of
+// the given types
+func decodePEM(data []byte, types ...string) (*pem.Block, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	for _, t := range types {
+		if block.Type == t {
+			return block, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected PEM block type %q", block.Type)
+}
+
+// unmarshalDER decodes a DER structure, rejecting trailing data
+func unmarshalDER(der []byte, out interface{}) error {
+	rest, err := asn1.Unmarshal(der, out)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return errors.New("trailing data after key")
+	}
+	return nil
+}
+
+// marshalPoint encodes a point in uncompressed form as a bit string
+func marshalPoint(x, y *big.Int) asn1.BitString {
+	bytes := secp256k1.S256().Marshal(x, y)
+	return asn1.BitString{Bytes: bytes, BitLength: 8 * len(bytes)}
+}
+
+// unmarshalPoint decodes an uncompressed point from a bit string
+func unmarshalPoint(bits asn1.BitString) (x, y *big.Int, err error) {
+	x, y = secp256k1.S256().Unmarshal(bits.RightAlign())
+	if x == nil || !secp256k1.S256().IsOnCurve(x, y) {
+		return nil, nil, errors.New("public key is not an uncompressed point on secp256k1")
+	}
+	return x, y, nil
+}
+
+// encodeJWKInt encodes an integer as an unpadded base64url string of its
+// 32-byte big-endian form
+func encodeJWKInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.FillBytes(make([]byte, secp256k1ScalarBytes)))
+}
+
+// decodeJWKInt decodes a JWK integer member, which must be 32 bytes
+func decodeJWKInt(name, s string) (*big.Int, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWK %s: %w", name, err)
+	}
+	if len(bytes) != secp256k1ScalarBytes {
+		return nil, fmt.Errorf("JWK %s must be 32 bytes, got %d", name, len(bytes))
+	}
+	return new(big.Int).SetBytes(bytes), nil
+}
@@ -147,7 +147,8 @@ func (pk *PublicSigningKey) ToHexString() string {
 	return hex.EncodeToString(bytes)
 }
 
-// FromHexString converts a hex string to a public key
+// FromHexString converts a hex string to a public key. The point is not
+// validated; see FromHexStringStrict
 func (pk *PublicSigningKey) FromHexString(hexString string) (PublicSigningKey, error) {
 	hexString = preprocessHexString(hexString)
 	bytes, err := hex.DecodeString(hexString)
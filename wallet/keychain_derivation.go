@@ -36,10 +36,32 @@ const (
 	// walletIdMessage is the message used to derive the wallet ID
 	walletIDMessage = "wallet id"
 
+	// accountKeyMessage is the message that is signed, suffixed with the
+	// account index, to derive an account's Ethereum key
+	accountKeyMessage = "renegade account:"
+
 	// walletIdNumBytes is the number of bytes in the wallet ID
 	walletIDNumBytes = 16
 )
 
+// DeriveAccountKey derives the Ethereum key of the account with the given
+// index from a master key, so that many wallets can be managed from, and
+// recovered with, one key. Account zero is the master key itself, i.e. the
+// wallet a single-wallet client derives from the same key
+func DeriveAccountKey(masterKey *ecdsa.PrivateKey, index uint32) (*ecdsa.PrivateKey, error) {
+	if index == 0 {
+		return masterKey, nil
+	}
+
+	message := []byte(fmt.Sprintf("%s%d", accountKeyMessage, index))
+	keyBytes, err := getExtendedSigBytes(message, masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return secpKeyFromBytes(keyBytes)
+}
+
 // DeriveKeychain derives the keychain from the private key
 func DeriveKeychain(pkey *ecdsa.PrivateKey, chainID uint64) (*Keychain, error) {
 	// Create the derivation key
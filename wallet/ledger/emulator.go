@@ -0,0 +1,136 @@
+package ledger
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Emulator is a Transport that answers the Ethereum app's instructions with
+// an in-memory key, in place of a device. It lets code written against a
+// Device be tested, and its signatures checked, without hardware. Every
+// derivation path signs with the same key
+type Emulator struct {
+	key *ecdsa.PrivateKey
+	// Reject makes the emulator reject every signing request, as a user
+	// declining on the device would
+	Reject bool
+
+	// message is the personal message being received in chunks
+	message []byte
+	// messageLength is the length of the personal message being received
+	messageLength int
+}
+
+// NewEmulator creates an emulator signing with the given key
+func NewEmulator(key *ecdsa.PrivateKey) *Emulator {
+	return &Emulator{key: key}
+}
+
+// Exchange implements Transport
+func (e *Emulator) Exchange(apdu []byte) ([]byte, error) {
+	if len(apdu) < 5 || len(apdu) != 5+int(apdu[4]) {
+		return nil, errors.New("malformed apdu")
+	}
+	if apdu[0] != claEthereum {
+		return nil, fmt.Errorf("unsupported instruction class 0x%02x", apdu[0])
+	}
+	ins, p1, data := apdu[1], apdu[2], apdu[5:]
+
+	var reply []byte
+	var err error
+	switch ins {
+	case insGetAddress:
+		reply, err = e.address(data)
+	case insSignPersonalMessage:
+		reply, err = e.signPersonalMessage(p1, data)
+	case insSignEIP712Hashed:
+		reply, err = e.signEIP712Hashed(data)
+	default:
+		return nil, fmt.Errorf("unsupported instruction 0x%02x", ins)
+	}
+	if errors.Is(err, ErrRejected) {
+		return binary.BigEndian.AppendUint16(nil, statusRejected), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return binary.BigEndian.AppendUint16(reply, statusOK), nil
+}
+
+// address answers an address request
+func (e *Emulator) address(data []byte) ([]byte, error) {
+	if _, err := decodePath(data); err != nil {
+		return nil, err
+	}
+
+	pubkey := crypto.FromECDSAPub(&e.key.PublicKey)
+	address := strings.TrimPrefix(crypto.PubkeyToAddress(e.key.PublicKey).Hex(), "0x")
+	reply := append([]byte{byte(len(pubkey))}, pubkey...)
+	reply = append(reply, byte(len(address)))
+	return append(reply, address...), nil
+}
+
+// signPersonalMessage receives a chunk of a personal message, signing it once
+// complete
+func (e *Emulator) signPersonalMessage(p1 byte, data []byte) ([]byte, error) {
+	if p1 == p1FirstChunk {
+		rest, err := decodePath(data)
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) < 4 {
+			return nil, errors.New("missing message length")
+		}
+		e.messageLength = int(binary.BigEndian.Uint32(rest))
+		e.message = append([]byte{}, rest[4:]...)
+	} else {
+		e.message = append(e.message, data...)
+	}
+
+	// Acknowledge chunks until the message is complete
+	if len(e.message) < e.messageLength {
+		return nil, nil
+	}
+	return e.sign(accounts.TextHash(e.message))
+}
+
+// signEIP712Hashed signs typed data given its domain separator and struct
+// hash
+func (e *Emulator) signEIP712Hashed(data []byte) ([]byte, error) {
+	rest, err := decodePath(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 64 {
+		return nil, errors.New("expected a domain separator and struct hash")
+	}
+	return e.sign(crypto.Keccak256([]byte("\x19\x01"), rest[:32], rest[32:]))
+}
+
+// sign signs a digest, replying with a [V || R || S] signature as the device
+// does
+func (e *Emulator) sign(digest []byte) ([]byte, error) {
+	if e.Reject {
+		return nil, ErrRejected
+	}
+
+	sig, err := crypto.Sign(digest, e.key)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{sig[crypto.RecoveryIDOffset] + 27}, sig[:crypto.RecoveryIDOffset]...), nil
+}
+
+// decodePath strips an encoded derivation path from the front of the data
+func decodePath(data []byte) ([]byte, error) {
+	if len(data) < 1 || len(data) < 1+4*int(data[0]) {
+		return nil, errors.New("malformed derivation path")
+	}
+	return data[1+4*int(data[0]):], nil
+}
@@ -0,0 +1,121 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	// hidReportSize is the size of a Ledger HID report
+	hidReportSize = 64
+	// hidChannel is the channel Ledger devices communicate on
+	hidChannel = 0x0101
+	// hidTagAPDU tags reports carrying APDUs
+	hidTagAPDU = 0x05
+	// hidHeaderSize is the size of a report's channel, tag and sequence
+	// number
+	hidHeaderSize = 5
+)
+
+// HIDTransport exchanges APDUs with a Ledger over USB HID, framing them into
+// 64 byte reports. It works over any HID device handle that reads and writes
+// whole reports, e.g. one opened with a HID library, or OpenHIDRaw on Linux
+type HIDTransport struct {
+	device io.ReadWriter
+}
+
+// NewHIDTransport creates a transport over a HID device handle
+func NewHIDTransport(device io.ReadWriter) *HIDTransport {
+	return &HIDTransport{device: device}
+}
+
+// Exchange implements Transport
+func (t *HIDTransport) Exchange(apdu []byte) ([]byte, error) {
+	if err := t.write(apdu); err != nil {
+		return nil, err
+	}
+	return t.read()
+}
+
+// write frames an APDU, prefixed with its length, into reports
+func (t *HIDTransport) write(apdu []byte) error {
+	payload := binary.BigEndian.AppendUint16(nil, uint16(len(apdu))) //nolint:gosec
+	payload = append(payload, apdu...)
+
+	for seq := uint16(0); len(payload) > 0; seq++ {
+		report := make([]byte, hidReportSize)
+		binary.BigEndian.PutUint16(report, hidChannel)
+		report[2] = hidTagAPDU
+		binary.BigEndian.PutUint16(report[3:], seq)
+		n := copy(report[hidHeaderSize:], payload)
+		payload = payload[n:]
+
+		if _, err := t.device.Write(report); err != nil {
+			return fmt.Errorf("failed to write hid report: %w", err)
+		}
+	}
+	return nil
+}
+
+// read reassembles a response APDU from reports
+func (t *HIDTransport) read() ([]byte, error) {
+	var reply []byte
+	length := -1
+	report := make([]byte, hidReportSize)
+	for seq := uint16(0); length < 0 || len(reply) < length; seq++ {
+		if _, err := io.ReadFull(t.device, report); err != nil {
+			return nil, fmt.Errorf("failed to read hid report: %w", err)
+		}
+		if binary.BigEndian.Uint16(report) != hidChannel || report[2] != hidTagAPDU ||
+			binary.BigEndian.Uint16(report[3:]) != seq {
+			return nil, errors.New("unexpected hid report header")
+		}
+
+		data := report[hidHeaderSize:]
+		if length < 0 {
+			length = int(binary.BigEndian.Uint16(data))
+			data = data[2:]
+		}
+		reply = append(reply, data[:min(len(data), length-len(reply))]...)
+	}
+	return reply, nil
+}
+
+// OpenHIDRaw opens a Ledger's Linux hidraw device, e.g. /dev/hidraw0, as a
+// HID device handle. The Ethereum app must be open on the device, and the
+// user needs read and write access to the device node, usually granted by
+// Ledger's udev rules
+func OpenHIDRaw(path string) (io.ReadWriteCloser, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open hid device: %w", err)
+	}
+	return &hidrawDevice{file: file}, nil
+}
+
+// hidrawDevice writes reports to a hidraw node, which expects each to be
+// prefixed with its report number, zero for Ledger devices
+type hidrawDevice struct {
+	file *os.File
+}
+
+// Read reads a report
+func (d *hidrawDevice) Read(p []byte) (int, error) {
+	return d.file.Read(p)
+}
+
+// Write writes a report behind a zero report number
+func (d *hidrawDevice) Write(p []byte) (int, error) {
+	if _, err := d.file.Write(append([]byte{0x00}, p...)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the device
+func (d *hidrawDevice) Close() error {
+	return d.file.Close()
+}
@@ -0,0 +1,186 @@
+// Package ledger signs with keys held on a Ledger device running the Ethereum
+// app, speaking the app's APDU protocol over a Transport.
+//
+// The Ethereum app signs EIP-191 personal messages and EIP-712 typed data,
+// but never raw digests. Renegade deposit permits are EIP-712 typed data, so a
+// Ledger account can fund a wallet. Wallet update commitments, and the
+// signatures a wallet's keychain is derived from, are signatures over raw
+// keccak256 digests, so the wallet's root key cannot be held on a Ledger; it
+// must be held in software or by a CommitmentSigner able to sign raw digests,
+// e.g. an HSM
+package ledger
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// claEthereum is the instruction class of the Ethereum app
+	claEthereum = 0xe0
+	// insGetAddress returns the public key and address of a derivation path
+	insGetAddress = 0x02
+	// insSignPersonalMessage signs an EIP-191 personal message
+	insSignPersonalMessage = 0x08
+	// insSignEIP712Hashed signs EIP-712 typed data given its domain
+	// separator and struct hash
+	insSignEIP712Hashed = 0x0c
+
+	// p1FirstChunk marks the first chunk of a chunked instruction
+	p1FirstChunk = 0x00
+	// p1NextChunk marks a subsequent chunk of a chunked instruction
+	p1NextChunk = 0x80
+
+	// maxChunkSize is the largest data payload of a single APDU
+	maxChunkSize = 255
+
+	// statusOK is the status word of a successful instruction
+	statusOK = 0x9000
+	// statusRejected is the status word of an instruction the user rejected
+	statusRejected = 0x6985
+)
+
+// DefaultDerivationPath is the derivation path of the first account in
+// Ledger Live, m/44'/60'/0'/0/0
+var DefaultDerivationPath = accounts.DefaultBaseDerivationPath
+
+// ErrRejected is returned when the user rejects a request on the device
+var ErrRejected = errors.New("request rejected on the ledger device")
+
+// StatusError is returned when the device responds with an error status word
+type StatusError struct {
+	// Status is the status word
+	Status uint16
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("ledger device returned status 0x%04x", e.Status)
+}
+
+// Is matches ErrRejected for the rejection status word
+func (e *StatusError) Is(target error) bool {
+	return target == ErrRejected && e.Status == statusRejected
+}
+
+// Transport exchanges APDUs with a device
+type Transport interface {
+	// Exchange sends a command APDU and returns the response APDU, including
+	// its trailing status word
+	Exchange(apdu []byte) ([]byte, error)
+}
+
+// Device is a Ledger running the Ethereum app, signing with the key at a
+// derivation path
+type Device struct {
+	transport Transport
+	path      accounts.DerivationPath
+}
+
+// NewDevice creates a device signing with the key at the given path
+func NewDevice(transport Transport, path accounts.DerivationPath) *Device {
+	return &Device{transport: transport, path: path}
+}
+
+// Address returns the address of the device's key
+func (d *Device) Address() (common.Address, error) {
+	reply, err := d.exchange(insGetAddress, p1FirstChunk, encodePath(d.path))
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	// The reply is the length-prefixed public key then the length-prefixed
+	// hex address
+	if len(reply) < 1 || len(reply) < 1+int(reply[0])+1 {
+		return common.Address{}, errors.New("malformed address reply")
+	}
+	reply = reply[1+int(reply[0]):]
+	if len(reply) < 1+int(reply[0]) || reply[0] != 2*common.AddressLength {
+		return common.Address{}, errors.New("malformed address reply")
+	}
+	return common.HexToAddress(string(reply[1 : 1+int(reply[0])])), nil
+}
+
+// SignPersonalMessage signs an EIP-191 personal message, returning a 65 byte
+// [R || S || V] signature with a recovery id of 27 or 28
+func (d *Device) SignPersonalMessage(message []byte) ([]byte, error) {
+	data := encodePath(d.path)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(message))) //nolint:gosec
+	data = append(data, message...)
+
+	var reply []byte
+	for p1 := byte(p1FirstChunk); len(data) > 0; p1 = p1NextChunk {
+		chunk := data[:min(len(data), maxChunkSize)]
+		data = data[len(chunk):]
+
+		var err error
+		if reply, err = d.exchange(insSignPersonalMessage, p1, chunk); err != nil {
+			return nil, err
+		}
+	}
+	return decodeSignature(reply)
+}
+
+// SignTypedDataHash signs EIP-712 typed data given its domain separator and
+// struct hash, returning a 65 byte [R || S || V] signature with a recovery id
+// of 27 or 28. The device displays both hashes for the user to check
+func (d *Device) SignTypedDataHash(domainSeparator, structHash common.Hash) ([]byte, error) {
+	data := encodePath(d.path)
+	data = append(data, domainSeparator.Bytes()...)
+	data = append(data, structHash.Bytes()...)
+
+	reply, err := d.exchange(insSignEIP712Hashed, p1FirstChunk, data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSignature(reply)
+}
+
+// exchange sends an instruction and returns its reply data, checking its
+// status word
+func (d *Device) exchange(ins, p1 byte, data []byte) ([]byte, error) {
+	if len(data) > maxChunkSize {
+		return nil, fmt.Errorf("apdu data exceeds %d bytes", maxChunkSize)
+	}
+	apdu := append([]byte{claEthereum, ins, p1, 0x00, byte(len(data))}, data...)
+
+	reply, err := d.transport.Exchange(apdu)
+	if err != nil {
+		return nil, fmt.Errorf("ledger exchange failed: %w", err)
+	}
+	if len(reply) < 2 {
+		return nil, errors.New("ledger reply is missing its status word")
+	}
+
+	status := binary.BigEndian.Uint16(reply[len(reply)-2:])
+	if status != statusOK {
+		return nil, &StatusError{Status: status}
+	}
+	return reply[:len(reply)-2], nil
+}
+
+// encodePath encodes a derivation path as the app expects: its length, then
+// each component as a big-endian uint32
+func encodePath(path accounts.DerivationPath) []byte {
+	encoded := []byte{byte(len(path))}
+	for _, component := range path {
+		encoded = binary.BigEndian.AppendUint32(encoded, component)
+	}
+	return encoded
+}
+
+// decodeSignature converts a [V || R || S] signature reply into [R || S || V]
+func decodeSignature(reply []byte) ([]byte, error) {
+	if len(reply) != crypto.SignatureLength {
+		return nil, fmt.Errorf("expected a %d byte signature, got %d bytes", crypto.SignatureLength, len(reply))
+	}
+
+	sig := make([]byte, 0, crypto.SignatureLength)
+	sig = append(sig, reply[1:]...)
+	return append(sig, reply[0]), nil
+}
@@ -0,0 +1,121 @@
+package ledger
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestDevice creates a device backed by an emulator with a fresh key
+func newTestDevice(t *testing.T) (*Device, *Emulator, *ecdsa.PrivateKey) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	emulator := NewEmulator(key)
+	return NewDevice(emulator, DefaultDerivationPath), emulator, key
+}
+
+// recoverSigner recovers the address that signed a digest with an
+// [R || S || V] signature whose recovery id is offset by 27
+func recoverSigner(t *testing.T, digest, sig []byte) common.Address {
+	assert.Len(t, sig, crypto.SignatureLength)
+	normalized := append([]byte{}, sig...)
+	normalized[crypto.RecoveryIDOffset] -= 27
+	pubkey, err := crypto.SigToPub(digest, normalized)
+	assert.NoError(t, err)
+	return crypto.PubkeyToAddress(*pubkey)
+}
+
+func TestDeviceSigning(t *testing.T) {
+	device, emulator, key := newTestDevice(t)
+	expected := crypto.PubkeyToAddress(key.PublicKey)
+
+	address, err := device.Address()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, address)
+
+	// Messages longer than an APDU are sent in chunks
+	for _, message := range [][]byte{[]byte("hello"), []byte(strings.Repeat("renegade", 100))} {
+		sig, err := device.SignPersonalMessage(message)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, recoverSigner(t, accounts.TextHash(message), sig))
+	}
+
+	domainSeparator := crypto.Keccak256Hash([]byte("domain"))
+	structHash := crypto.Keccak256Hash([]byte("struct"))
+	sig, err := device.SignTypedDataHash(domainSeparator, structHash)
+	assert.NoError(t, err)
+	digest := crypto.Keccak256([]byte("\x19\x01"), domainSeparator.Bytes(), structHash.Bytes())
+	assert.Equal(t, expected, recoverSigner(t, digest, sig))
+
+	// A rejection on the device surfaces as ErrRejected
+	emulator.Reject = true
+	_, err = device.SignTypedDataHash(domainSeparator, structHash)
+	assert.True(t, errors.Is(err, ErrRejected))
+	var statusErr *StatusError
+	assert.True(t, errors.As(err, &statusErr))
+}
+
+// hidLoopback is a HID device handle that deframes written reports, passes
+// each complete APDU to a transport, and frames its reply for reading
+type hidLoopback struct {
+	transport Transport
+	pending   []byte
+	replies   bytes.Buffer
+}
+
+func (l *hidLoopback) Write(report []byte) (int, error) {
+	if len(report) != hidReportSize {
+		return 0, errors.New("short report")
+	}
+	l.pending = append(l.pending, report[hidHeaderSize:]...)
+	length := int(binary.BigEndian.Uint16(l.pending))
+	if len(l.pending)-2 < length {
+		return len(report), nil
+	}
+
+	reply, err := l.transport.Exchange(l.pending[2 : 2+length])
+	l.pending = nil
+	if err != nil {
+		return 0, err
+	}
+
+	payload := binary.BigEndian.AppendUint16(nil, uint16(len(reply))) //nolint:gosec
+	payload = append(payload, reply...)
+	for seq := uint16(0); len(payload) > 0; seq++ {
+		out := make([]byte, hidReportSize)
+		binary.BigEndian.PutUint16(out, hidChannel)
+		out[2] = hidTagAPDU
+		binary.BigEndian.PutUint16(out[3:], seq)
+		payload = payload[copy(out[hidHeaderSize:], payload):]
+		l.replies.Write(out)
+	}
+	return len(report), nil
+}
+
+func (l *hidLoopback) Read(p []byte) (int, error) {
+	return l.replies.Read(p)
+}
+
+func TestHIDTransport(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	device := NewDevice(NewHIDTransport(&hidLoopback{transport: NewEmulator(key)}), DefaultDerivationPath)
+
+	address, err := device.Address()
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), address)
+
+	// Messages spanning several reports and APDUs are framed and reassembled
+	message := []byte(strings.Repeat("renegade", 100))
+	sig, err := device.SignPersonalMessage(message)
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), recoverSigner(t, accounts.TextHash(message), sig))
+}
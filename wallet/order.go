@@ -66,6 +66,9 @@ type Order struct {
 	Amount Scalar
 	// WorstCasePrice is the worst case price of the order
 	WorstCasePrice FixedPoint
+	// MatchingPool is the relayer matching pool the order is placed in, empty
+	// for the global pool. It is relayer state and not part of the wallet
+	MatchingPool string `scalar_serialize:"skip"`
 }
 
 // OrderBuilder is a builder for Order
@@ -141,6 +144,14 @@ func (ob *OrderBuilder) WithWorstCasePrice(price FixedPoint) *OrderBuilder {
 	return ob
 }
 
+// WithMatchingPool sets the MatchingPool. The relayer places orders in a
+// matching pool only through its admin API, so a client placing the order
+// needs an admin client
+func (ob *OrderBuilder) WithMatchingPool(pool string) *OrderBuilder {
+	ob.order.MatchingPool = pool
+	return ob
+}
+
 // Build returns the constructed Order
 func (ob *OrderBuilder) Build() Order {
 	return ob.order
@@ -202,7 +213,7 @@ func (w *Wallet) NewOrder(order Order) error {
 	} else if len(w.Orders) < MaxOrders {
 		w.Orders = append(w.Orders, order)
 	} else {
-		return fmt.Errorf("wallet already has the maximum number of orders")
+		return w.CheckOrderCapacity()
 	}
 
 	return nil
@@ -233,6 +244,17 @@ func (w *Wallet) CancelOrder(orderID uuid.UUID) error {
 	return nil
 }
 
+// UpdateOrder replaces the order with the same ID in place
+func (w *Wallet) UpdateOrder(order Order) error {
+	idx := w.findOrder(order.Id)
+	if idx == -1 {
+		return fmt.Errorf("order not found")
+	}
+
+	w.Orders[idx] = order
+	return nil
+}
+
 // findOrder finds the index of an order with the given ID, or -1 if no order has the given ID
 func (w *Wallet) findOrder(orderID uuid.UUID) int {
 	for i, order := range w.Orders {
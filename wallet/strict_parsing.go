@@ -0,0 +1,192 @@
+package wallet
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+)
+
+// The lengths of strictly parsed encodings, in bytes
+const (
+	// hmacKeyLength is the length of an HMAC key
+	hmacKeyLength = 32
+	// publicSigningKeyLength is the length of an uncompressed secp256k1 point
+	publicSigningKeyLength = 65
+	// privateSigningKeyLength is the maximum length of a secp256k1 scalar
+	privateSigningKeyLength = 32
+	// feeEncryptionKeyLength is the length of a fee encryption key
+	feeEncryptionKeyLength = 2 * fr.Bytes
+)
+
+// ErrMalformedEncoding is returned by the strict parsers when their input is
+// not the canonical encoding of a value. Errors never include the input, which
+// may be secret
+var ErrMalformedEncoding = errors.New("malformed encoding")
+
+// decodeHexStrict decodes a hex string with an optional 0x prefix, requiring
+// an even, non-zero number of digits encoding at most maxBytes bytes, or
+// exactly maxBytes if exact is set
+func decodeHexStrict(kind, hexString string, maxBytes int, exact bool) ([]byte, error) {
+	digits := strings.TrimPrefix(hexString, "0x")
+	switch {
+	case len(digits) == 0:
+		return nil, fmt.Errorf("%w: %s hex string is empty", ErrMalformedEncoding, kind)
+	case len(digits) > 2*maxBytes:
+		return nil, fmt.Errorf(
+			"%w: %s hex string has %d digits, at most %d allowed",
+			ErrMalformedEncoding, kind, len(digits), 2*maxBytes,
+		)
+	case exact && len(digits) != 2*maxBytes:
+		return nil, fmt.Errorf(
+			"%w: %s hex string has %d digits, %d required",
+			ErrMalformedEncoding, kind, len(digits), 2*maxBytes,
+		)
+	case len(digits)%2 != 0:
+		return nil, fmt.Errorf(
+			"%w: %s hex string has an odd number of digits (%d)", ErrMalformedEncoding, kind, len(digits),
+		)
+	}
+
+	bytes, err := hex.DecodeString(digits)
+	if err != nil {
+		var invalidByte hex.InvalidByteError
+		if errors.As(err, &invalidByte) {
+			offset := strings.IndexByte(digits, byte(invalidByte))
+			return nil, fmt.Errorf(
+				"%w: %s hex string has a non-hex character at offset %d", ErrMalformedEncoding, kind, offset,
+			)
+		}
+		return nil, fmt.Errorf("%w: %s hex string: %v", ErrMalformedEncoding, kind, err)
+	}
+	return bytes, nil
+}
+
+// FromHexStringStrict sets the scalar from a hex string, rejecting empty
+// input, odd-length input, input longer than 32 bytes, and values that are not
+// reduced modulo the scalar field, rather than padding or reducing them
+func (s *Scalar) FromHexStringStrict(hexString string) (Scalar, error) {
+	bytes, err := decodeHexStrict("scalar", hexString, fr.Bytes, false /* exact */)
+	if err != nil {
+		return Scalar{}, err
+	}
+	if new(big.Int).SetBytes(bytes).Cmp(fr.Modulus()) >= 0 {
+		return Scalar{}, fmt.Errorf("%w: scalar is not less than the field modulus", ErrMalformedEncoding)
+	}
+
+	var fixedBytes [fr.Bytes]byte
+	copy(fixedBytes[fr.Bytes-len(bytes):], bytes)
+	s.FromBytes(fixedBytes)
+	return *s, nil
+}
+
+// FromHexStringStrict converts a hex string of exactly 32 bytes to an HMAC key
+func (k *HmacKey) FromHexStringStrict(hexString string) (HmacKey, error) {
+	bytes, err := decodeHexStrict("HMAC key", hexString, hmacKeyLength, true /* exact */)
+	if err != nil {
+		return HmacKey{}, err
+	}
+
+	copy(k[:], bytes)
+	return *k, nil
+}
+
+// FromBase64StringStrict converts a padded standard base64 string to an HMAC
+// key, rejecting whitespace, non-zero padding bits, and other non-canonical
+// encodings
+func (k *HmacKey) FromBase64StringStrict(b64String string) (HmacKey, error) {
+	encoding := base64.StdEncoding.Strict()
+	if expected := encoding.EncodedLen(hmacKeyLength); len(b64String) != expected {
+		return HmacKey{}, fmt.Errorf(
+			"%w: HMAC key base64 string has %d characters, %d required",
+			ErrMalformedEncoding, len(b64String), expected,
+		)
+	}
+	if strings.ContainsAny(b64String, "\r\n") {
+		return HmacKey{}, fmt.Errorf("%w: HMAC key base64 string contains a line break", ErrMalformedEncoding)
+	}
+
+	bytes, err := encoding.DecodeString(b64String)
+	if err != nil {
+		return HmacKey{}, fmt.Errorf("%w: HMAC key base64 string: %v", ErrMalformedEncoding, err)
+	}
+	if len(bytes) != hmacKeyLength {
+		return HmacKey{}, fmt.Errorf(
+			"%w: HMAC key is %d bytes, %d required", ErrMalformedEncoding, len(bytes), hmacKeyLength,
+		)
+	}
+
+	copy(k[:], bytes)
+	return *k, nil
+}
+
+// FromHexStringStrict converts the hex encoding of an uncompressed secp256k1
+// point to a public key, rejecting points not on the curve
+func (pk *PublicSigningKey) FromHexStringStrict(hexString string) (PublicSigningKey, error) {
+	bytes, err := decodeHexStrict("public key", hexString, publicSigningKeyLength, true /* exact */)
+	if err != nil {
+		return PublicSigningKey{}, err
+	}
+
+	x, y := secp256k1.S256().Unmarshal(bytes)
+	if x == nil || !secp256k1.S256().IsOnCurve(x, y) {
+		return PublicSigningKey{}, fmt.Errorf(
+			"%w: public key is not an uncompressed point on secp256k1", ErrMalformedEncoding,
+		)
+	}
+
+	pk.X = x
+	pk.Y = y
+	pk.Curve = secp256k1.S256()
+	return *pk, nil
+}
+
+// FromHexStringStrict converts a hex string to a private key, rejecting zero
+// and values that are not less than the secp256k1 group order
+func (pk *PrivateSigningKey) FromHexStringStrict(hexString string) (PrivateSigningKey, error) {
+	bytes, err := decodeHexStrict("private key", hexString, privateSigningKeyLength, false /* exact */)
+	if err != nil {
+		return PrivateSigningKey{}, err
+	}
+
+	d := new(big.Int).SetBytes(bytes)
+	if d.Sign() == 0 || d.Cmp(secp256k1.S256().Params().N) >= 0 {
+		return PrivateSigningKey{}, fmt.Errorf(
+			"%w: private key is not in the range [1, n) of the secp256k1 group order", ErrMalformedEncoding,
+		)
+	}
+
+	pk.D = d
+	return *pk, nil
+}
+
+// FromHexStringStrict converts a hex string of exactly 64 bytes to a fee
+// encryption key, rejecting coordinates that are not reduced modulo the
+// scalar field
+func (pk *FeeEncryptionKey) FromHexStringStrict(hexString string) error {
+	bytes, err := decodeHexStrict("fee encryption key", hexString, feeEncryptionKeyLength, true /* exact */)
+	if err != nil {
+		return err
+	}
+
+	var xBytes, yBytes [fr.Bytes]byte
+	copy(xBytes[:], bytes[:fr.Bytes])
+	copy(yBytes[:], bytes[fr.Bytes:])
+
+	var x, y Scalar
+	if _, err = x.FromLittleEndianBytes(xBytes); err != nil {
+		return fmt.Errorf("%w: fee encryption key x coordinate: %v", ErrMalformedEncoding, err)
+	}
+	if _, err = y.FromLittleEndianBytes(yBytes); err != nil {
+		return fmt.Errorf("%w: fee encryption key y coordinate: %v", ErrMalformedEncoding, err)
+	}
+
+	pk.X = x
+	pk.Y = y
+	return nil
+}
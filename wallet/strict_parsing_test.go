@@ -0,0 +1,108 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScalarFromHexStringStrict(t *testing.T) {
+	const modulus = "30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001"
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"prefixed", "0x01", true},
+		{"largest", "30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000", true},
+		{"empty", "0x", false},
+		{"odd length", "0x1", false},
+		{"non-hex", "0x0g", false},
+		{"modulus", modulus, false},
+		{"too long", "00" + modulus, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := new(Scalar).FromHexStringStrict(tt.input)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, ErrMalformedEncoding), "got %v", err)
+			}
+		})
+	}
+
+	// The lenient parser pads and reduces the same inputs
+	lenient, err := new(Scalar).FromHexString(modulus)
+	assert.NoError(t, err)
+	assert.True(t, lenient.IsZero())
+}
+
+func TestHmacKeyStrictParsing(t *testing.T) {
+	key := HmacKey{1, 2, 3}
+	b64 := key.ToBase64String()
+
+	parsed, err := new(HmacKey).FromBase64StringStrict(b64)
+	assert.NoError(t, err)
+	assert.Equal(t, key, parsed)
+	parsed, err = new(HmacKey).FromHexStringStrict("0x" + key.ToHexString())
+	assert.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	// Line breaks, which the lenient parser skips, and truncated input
+	_, err = new(HmacKey).FromBase64StringStrict(b64[:20] + "\n" + b64[21:])
+	assert.True(t, errors.Is(err, ErrMalformedEncoding))
+	_, err = new(HmacKey).FromBase64StringStrict(b64[:40])
+	assert.True(t, errors.Is(err, ErrMalformedEncoding))
+	_, err = new(HmacKey).FromHexStringStrict(key.ToHexString()[:62])
+	assert.True(t, errors.Is(err, ErrMalformedEncoding))
+
+	// Errors do not leak the key
+	_, err = new(HmacKey).FromHexStringStrict(key.ToHexString() + "zz")
+	assert.NotContains(t, err.Error(), key.ToHexString())
+}
+
+func TestSigningKeyStrictParsing(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	assert.NoError(t, err)
+	sk := PrivateSigningKey(*ecdsaKey)
+	pk := PublicSigningKey(ecdsaKey.PublicKey)
+
+	parsedSk, err := new(PrivateSigningKey).FromHexStringStrict(hex.EncodeToString(ecdsaKey.D.FillBytes(make([]byte, 32))))
+	assert.NoError(t, err)
+	assert.Equal(t, sk.D, parsedSk.D)
+	parsedPk, err := new(PublicSigningKey).FromHexStringStrict(pk.ToHexString())
+	assert.NoError(t, err)
+	assert.Equal(t, pk.X, parsedPk.X)
+
+	// Zero and out of range private keys
+	_, err = new(PrivateSigningKey).FromHexStringStrict("00")
+	assert.True(t, errors.Is(err, ErrMalformedEncoding))
+	_, err = new(PrivateSigningKey).FromHexStringStrict(strings.Repeat("ff", 32))
+	assert.True(t, errors.Is(err, ErrMalformedEncoding))
+
+	// Points not on the curve are rejected by the strict parser only; the
+	// lenient parser keeps its original behavior
+	offCurve := "04" + strings.Repeat("01", 64)
+	_, err = new(PublicSigningKey).FromHexStringStrict(offCurve)
+	assert.True(t, errors.Is(err, ErrMalformedEncoding))
+	_, err = new(PublicSigningKey).FromHexString(offCurve)
+	assert.NoError(t, err)
+}
+
+func TestFeeEncryptionKeyStrictParsing(t *testing.T) {
+	key := FeeEncryptionKey{X: Scalar{1}, Y: Scalar{2}}
+	var parsed FeeEncryptionKey
+	assert.NoError(t, parsed.FromHexStringStrict(key.ToHexString()))
+	assert.Equal(t, key, parsed)
+
+	// A coordinate above the field modulus
+	assert.True(t, errors.Is(parsed.FromHexStringStrict(strings.Repeat("ff", 64)), ErrMalformedEncoding))
+}
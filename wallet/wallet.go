@@ -137,6 +137,10 @@ func (s *Scalar) FromHexString(hexString string) (Scalar, error) {
 		return Scalar{}, err
 	}
 
+	if len(bytes) > fr.Bytes {
+		return Scalar{}, fmt.Errorf("scalar hex string exceeds %d bytes", fr.Bytes)
+	}
+
 	var fixedBytes [fr.Bytes]byte
 	copy(fixedBytes[fr.Bytes-len(bytes):], bytes)
 	s.FromBytes(fixedBytes)
@@ -400,9 +404,10 @@ func walletBlinderFromSeed(seed Scalar) (Scalar, Scalar) {
 	return blinder, blinderPrivateShare
 }
 
-// GetShareCommitment returns a Poseidon hash commitment of the wallet's shares
+// GetShareCommitment returns a Poseidon hash commitment of the wallet's shares.
+// Commitments are cached, see commitmentCache
 func (w *Wallet) GetShareCommitment() (Scalar, error) {
-	privateCommitment, err := w.GetPrivateShareCommitment()
+	privateShares, err := ToScalarsRecursive(&w.PrivateShares)
 	if err != nil {
 		return Scalar{}, err
 	}
@@ -413,9 +418,7 @@ func (w *Wallet) GetShareCommitment() (Scalar, error) {
 		return Scalar{}, err
 	}
 
-	// Create a hash input that is the privateCommitment concatenated with the publicShares
-	hashInput := append([]Scalar{privateCommitment}, publicShares...)
-	return HashScalars(hashInput), nil
+	return cachedShareCommitment(w.PrivateShares.Blinder, privateShares, publicShares), nil
 }
 
 // GetPrivateShareCommitment returns a Poseidon hash commitment of the wallet's private share
@@ -425,22 +428,13 @@ func (w *Wallet) GetPrivateShareCommitment() (Scalar, error) {
 		return Scalar{}, err
 	}
 
-	return HashScalars(privateShares), nil
+	return cachedPrivateShareCommitment(w.PrivateShares.Blinder, privateShares), nil
 }
 
-// SignCommitment signs the given commitment using the private root key
+// SignCommitment signs the given commitment using the private root key, see
+// Secp256k1CommitmentSigner
 func (w *Wallet) SignCommitment(commitment Scalar) ([]byte, error) {
-	privateRootKey := w.Keychain.SkRoot()
-	signKey := ecdsa.PrivateKey(*privateRootKey)
-
-	commBytes := commitment.ToBigInt().Bytes()
-	digest := crypto.Keccak256(commBytes)
-	sig, err := crypto.Sign(digest, &signKey)
-	if err != nil {
-		return nil, err
-	}
-
-	return sig, nil
+	return NewSecp256k1CommitmentSigner(w.Keychain.SkRoot()).SignCommitment(commitment)
 }
 
 // Reblind reblinds the wallet, sampling new secret shares and blinders from the CSPRNGs
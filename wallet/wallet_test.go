@@ -3,6 +3,7 @@ package wallet
 import (
 	"crypto/ecdsa"
 	"crypto/rand"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
@@ -24,6 +25,12 @@ func TestScalarToFromHex(t *testing.T) {
 	assert.Equal(t, randomScalar, newScalar)
 }
 
+func TestScalarFromHexStringTooLong(t *testing.T) {
+	var scalar Scalar
+	_, err := scalar.FromHexString("0x" + strings.Repeat("ff", 33))
+	assert.Error(t, err)
+}
+
 func TestScalarToBigIntAndBack(t *testing.T) {
 	// Generate a random scalar
 	randomScalar, err := RandomScalar()